@@ -13,12 +13,16 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/api"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/bars"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/config"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/database"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/instrument"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/replies"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/session"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/telemetry"
+	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/instrument/v1/instrumentv1connect"
 	marketdatav1connect "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1/marketdatav1connect"
 	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1/orderv1connect"
 	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/portfolio/v1/portfoliov1connect"
@@ -31,6 +35,7 @@ const (
 	shutdownTimeout     = 10 * time.Second
 	otelShutdownTimeout = 5 * time.Second
 	sessionTTL          = 24 * time.Hour
+	accountsCacheTTL    = 5 * time.Minute
 )
 
 func main() {
@@ -65,7 +70,39 @@ func main() {
 	logger.Info("Database initialized successfully")
 
 	// Initialize IBKR client.
-	ibkrClient := ibkr.NewClient(cfg.IBKRGatewayURL, cfg.IBKRAccountID)
+	confirmPolicy := ibkr.ConfirmMessageIDs(cfg.IBKRAutoConfirmMessageIDs...)
+	if cfg.IBKRAutoConfirmAll {
+		confirmPolicy = ibkr.ConfirmAll
+	}
+
+	ibkrClient := ibkr.NewClient(cfg.IBKRGatewayURL,
+		ibkr.WithLogger(logger),
+		ibkr.WithConfirmPolicy(confirmPolicy),
+		ibkr.WithRetryPolicy(ibkr.RetryPolicy{
+			MaxAttempts:    cfg.IBKRRetryMaxAttempts,
+			InitialBackoff: cfg.IBKRRetryInitialBackoff,
+			MaxBackoff:     cfg.IBKRRetryMaxBackoff,
+		}),
+		ibkr.WithRateLimits(ibkr.RateLimits{
+			Global:   cfg.IBKRRateLimitGlobal,
+			Snapshot: cfg.IBKRRateLimitSnapshot,
+			History:  cfg.IBKRRateLimitHistory,
+			Orders:   cfg.IBKRRateLimitOrders,
+			Tickle:   cfg.IBKRRateLimitTickle,
+		}),
+		ibkr.WithSnapshotPolicy(ibkr.SnapshotPolicy{
+			MaxWait:      cfg.IBKRSnapshotMaxWait,
+			PollInterval: cfg.IBKRSnapshotPollInterval,
+		}),
+	)
+
+	// Keep the Gateway brokerage session alive.
+	gatewaySession := ibkr.NewSupervisor(ibkrClient, ibkr.SupervisorConfig{
+		TickleInterval:       cfg.IBKRTickleInterval,
+		ReauthInitialBackoff: cfg.IBKRReauthInitialBackoff,
+		ReauthMaxBackoff:     cfg.IBKRReauthMaxBackoff,
+	}, logger)
+	gatewaySession.Start(ctx)
 
 	// Initialize session service (24 hour TTL).
 	sessionService := session.NewService(db.Queries, cfg.EncryptionKey, sessionTTL)
@@ -73,7 +110,7 @@ func main() {
 	logger.Info("Services initialized successfully")
 
 	// Create and start HTTP server.
-	server, err := setupServer(cfg, db, ibkrClient, sessionService)
+	server, err := setupServer(cfg, db, ibkrClient, sessionService, gatewaySession)
 	if err != nil {
 		logger.Error("Failed to setup server", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -83,6 +120,9 @@ func main() {
 
 	// Wait for shutdown signal.
 	waitForShutdown(server, logger)
+
+	// Stop the Gateway session supervisor.
+	gatewaySession.Stop()
 }
 
 func setupLogger(cfg *config.Config) *slog.Logger {
@@ -142,11 +182,67 @@ func setupInterceptors(cfg *config.Config, sessionService *session.Service, logg
 	)
 }
 
+// setupContractResolver resolves contracts through the instruments table when a database
+// is available, and directly at the Gateway otherwise.
+func setupContractResolver(
+	cfg *config.Config,
+	db *database.DB,
+	ibkrClient *ibkr.Client,
+	logger *slog.Logger,
+) ibkr.ContractResolver {
+	resolver := ibkr.NewResolver(ibkrClient, ibkr.WithRollRules(ibkr.RollRules{
+		Days:         cfg.FuturesRollDays,
+		DaysBySymbol: cfg.FuturesRollDaysBySymbol,
+	}))
+	if db == nil || db.Queries == nil {
+		return resolver
+	}
+
+	return instrument.NewCache(db.Queries, resolver, cfg.InstrumentCacheTTL, logger)
+}
+
+// setupOrderOptions configures the order service, keeping confirmation questions in the
+// database when one is available so that any replica can answer them.
+func setupOrderOptions(db *database.DB) []api.OrderOption {
+	if db == nil || db.Queries == nil {
+		return nil
+	}
+
+	return []api.OrderOption{api.WithReplyStore(replies.NewStore(db.Queries, replies.DefaultTTL))}
+}
+
+// setupMarketDataOptions configures the market data service, caching historical bars
+// when a database is available.
+func setupMarketDataOptions(
+	cfg *config.Config,
+	db *database.DB,
+	ibkrClient *ibkr.Client,
+	logger *slog.Logger,
+) []api.MarketDataOption {
+	opts := []api.MarketDataOption{
+		api.WithQuoteStreamConfig(api.QuoteStreamConfig{
+			MinInterval:       cfg.QuoteStreamMinInterval,
+			MaxInterval:       cfg.QuoteStreamMaxInterval,
+			Interval:          cfg.QuoteStreamInterval,
+			HeartbeatInterval: cfg.QuoteStreamHeartbeatInterval,
+			SnapshotInterval:  cfg.QuoteStreamSnapshotInterval,
+		}),
+		api.WithAdminAccounts(cfg.AdminAccountIDs...),
+	}
+
+	if db != nil && db.Queries != nil {
+		opts = append(opts, api.WithHistoryCache(bars.NewCache(db.Queries, ibkrClient, cfg.BarCacheSettleDelay, logger)))
+	}
+
+	return opts
+}
+
 func setupServer(
 	cfg *config.Config,
 	db *database.DB,
 	ibkrClient *ibkr.Client,
 	sessionService *session.Service,
+	gatewaySession *ibkr.Supervisor,
 ) (*http.Server, error) {
 	logger := slog.Default()
 	mux := http.NewServeMux()
@@ -155,9 +251,14 @@ func setupServer(
 	interceptors := setupInterceptors(cfg, sessionService, logger)
 
 	// Create service handlers.
-	orderHandler := api.NewOrderServiceHandler(ibkrClient)
-	portfolioHandler := api.NewPortfolioServiceHandler(ibkrClient)
-	marketDataHandler := api.NewMarketDataServiceHandler(ibkrClient)
+	accounts := api.NewAccountResolver(ibkrClient, accountsCacheTTL)
+	contracts := setupContractResolver(cfg, db, ibkrClient, logger)
+	orderHandler := api.NewOrderServiceHandler(ibkrClient, accounts, contracts, setupOrderOptions(db)...)
+	portfolioHandler := api.NewPortfolioServiceHandler(ibkrClient, accounts)
+	marketDataHandler := api.NewMarketDataServiceHandler(ibkrClient, contracts,
+		setupMarketDataOptions(cfg, db, ibkrClient, logger)...,
+	)
+	instrumentHandler := api.NewInstrumentServiceHandler(ibkrClient)
 
 	// Register service handlers.
 	path, handler := orderv1connect.NewOrderServiceHandler(orderHandler, interceptors)
@@ -169,6 +270,9 @@ func setupServer(
 	path, handler = marketdatav1connect.NewMarketDataServiceHandler(marketDataHandler, interceptors)
 	mux.Handle(path, handler)
 
+	path, handler = instrumentv1connect.NewInstrumentServiceHandler(instrumentHandler, interceptors)
+	mux.Handle(path, handler)
+
 	logger.Info("Service handlers registered")
 
 	// Health check endpoint.
@@ -179,6 +283,21 @@ func setupServer(
 
 	// Readiness check endpoint.
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		// Check the Gateway brokerage session.
+		if gatewaySession != nil && !gatewaySession.Ready() {
+			state := gatewaySession.State()
+			logger.Warn("Gateway session not ready",
+				slog.Bool("authenticated", state.Authenticated),
+				slog.Bool("connected", state.Connected),
+				slog.Bool("competing", state.Competing),
+				slog.String("error", state.LastError),
+			)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, "Gateway session not ready")
+
+			return
+		}
+
 		// Check database health.
 		if err := db.Health(r.Context()); err != nil {
 			logger.Error("Database health check failed", slog.String("error", err.Error()))
@@ -199,6 +318,10 @@ func setupServer(
 		Handler: h2c.NewHandler(mux, &http2.Server{}),
 	}
 
+	// End the open quote streams on shutdown; they would otherwise keep the server
+	// waiting for them, and the Gateway polled for them, until the process exits.
+	server.RegisterOnShutdown(marketDataHandler.Close)
+
 	// Configure TLS if enabled.
 	if cfg.MTLSEnabled {
 		if err := configureTLS(server, cfg); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/config"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/database"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
 )
 
 func TestSetupServer(t *testing.T) {
@@ -18,7 +19,7 @@ func TestSetupServer(t *testing.T) {
 		MTLSEnabled: false,
 	}
 
-	server, err := setupServer(cfg, nil, nil, nil)
+	server, err := setupServer(cfg, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("setupServer() error = %v", err)
 	}
@@ -45,7 +46,7 @@ func TestSetupServerWithMTLS(t *testing.T) {
 	}
 
 	// This will fail to configure TLS due to missing files
-	server, err := setupServer(cfg, nil, nil, nil)
+	server, err := setupServer(cfg, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatal("setupServer() expected error due to missing certs")
 	}
@@ -117,7 +118,7 @@ func TestStartServer(t *testing.T) {
 
 func TestHealthCheck(t *testing.T) {
 	cfg := &config.Config{HTTPPort: 8080}
-	server, _ := setupServer(cfg, nil, nil, nil)
+	server, _ := setupServer(cfg, nil, nil, nil, nil)
 
 	req := httptest.NewRequest("GET", "/healthz", nil)
 	w := httptest.NewRecorder()
@@ -132,7 +133,7 @@ func TestHealthCheck(t *testing.T) {
 func TestReadinessCheck_DatabaseUnhealthy(t *testing.T) {
 	cfg := &config.Config{HTTPPort: 8080}
 	db := &database.DB{} // Pool is nil, Health() should return error
-	server, _ := setupServer(cfg, db, nil, nil)
+	server, _ := setupServer(cfg, db, nil, nil, nil)
 
 	req := httptest.NewRequest("GET", "/readyz", nil)
 	w := httptest.NewRecorder()
@@ -143,3 +144,21 @@ func TestReadinessCheck_DatabaseUnhealthy(t *testing.T) {
 		t.Errorf("Status = %v, want %v", w.Code, http.StatusServiceUnavailable)
 	}
 }
+
+func TestReadinessCheck_GatewaySessionNotReady(t *testing.T) {
+	cfg := &config.Config{HTTPPort: 8080}
+	gatewaySession := ibkr.NewSupervisor(nil, ibkr.SupervisorConfig{}, slog.Default()) // Never checked, so not ready.
+	server, _ := setupServer(cfg, &database.DB{}, nil, nil, gatewaySession)
+
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	server.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Status = %v, want %v", w.Code, http.StatusServiceUnavailable)
+	}
+	if w.Body.String() != "Gateway session not ready" {
+		t.Errorf("Body = %q, want %q", w.Body.String(), "Gateway session not ready")
+	}
+}
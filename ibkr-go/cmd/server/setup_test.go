@@ -15,7 +15,7 @@ func TestSetupServer_AllPaths(t *testing.T) {
 		MTLSEnabled: false,
 	}
 
-	srv, err := setupServer(cfg, nil, nil, nil)
+	srv, err := setupServer(cfg, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("setupServer error = %v", err)
 	}
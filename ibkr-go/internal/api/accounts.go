@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+)
+
+// AccountResolver selects the brokerage account a request operates on and checks
+// that the caller is entitled to it.
+//
+// The accounts available through the Gateway session are discovered with
+// GetAccounts and cached for a while. A caller is entitled to its own session
+// account and, when that account is an advisor or master account, to the
+// accounts linked beneath it.
+type AccountResolver struct {
+	client ibkr.AccountClient
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	accounts  map[string]ibkr.Account
+	fetchedAt time.Time
+}
+
+// NewAccountResolver creates a new AccountResolver that caches discovered accounts for ttl.
+func NewAccountResolver(client ibkr.AccountClient, ttl time.Duration) *AccountResolver {
+	return &AccountResolver{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// Resolve returns the account a request operates on. An empty requested account
+// selects the caller's session account. The returned error is a connect error.
+func (r *AccountResolver) Resolve(ctx context.Context, requested string) (string, error) {
+	callerID, ok := middleware.GetAccountIDFromContext(ctx)
+	if !ok {
+		return "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	}
+
+	if requested == "" {
+		requested = callerID
+	}
+
+	accounts, err := r.discover(ctx)
+	if err != nil {
+		return "", gatewayError("failed to get accounts", err)
+	}
+
+	account, ok := accounts[requested]
+	if !ok {
+		return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("account not found: %s", requested))
+	}
+
+	if requested != callerID && account.Parent.AccountID != callerID {
+		return "", connect.NewError(
+			connect.CodePermissionDenied,
+			fmt.Errorf("account %s is not accessible to %s", requested, callerID),
+		)
+	}
+
+	return requested, nil
+}
+
+// discover returns the accounts available through the Gateway, keyed by account ID.
+// The Gateway is asked without holding the lock, so that a slow or hung GetAccounts
+// does not stall requests while the cached accounts are still fresh.
+func (r *AccountResolver) discover(ctx context.Context) (map[string]ibkr.Account, error) {
+	r.mu.Lock()
+	cached, fetchedAt := r.accounts, r.fetchedAt
+	r.mu.Unlock()
+
+	if cached != nil && time.Since(fetchedAt) < r.ttl {
+		return cached, nil
+	}
+
+	started := time.Now()
+
+	accounts, err := r.client.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := make(map[string]ibkr.Account, len(accounts))
+	for _, account := range accounts {
+		id := account.AccountID
+		if id == "" {
+			id = account.ID
+		}
+
+		discovered[id] = account
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A concurrent discovery that started later has the more recent accounts.
+	if r.accounts != nil && r.fetchedAt.After(started) {
+		return r.accounts, nil
+	}
+
+	r.accounts = discovered
+	r.fetchedAt = started
+
+	return discovered, nil
+}
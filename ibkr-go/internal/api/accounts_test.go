@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestAccountResolver returns a resolver that knows the single account U12345.
+func newTestAccountResolver() *AccountResolver {
+	accountClient := new(MockAccountClient)
+	accountClient.On("GetAccounts", mock.Anything).Return([]ibkr.Account{{AccountID: "U12345"}}, nil)
+
+	return NewAccountResolver(accountClient, time.Minute)
+}
+
+func advisorAccounts() []ibkr.Account {
+	subAccount := ibkr.Account{AccountID: "U22222"}
+	subAccount.Parent.AccountID = "F11111"
+
+	return []ibkr.Account{
+		{AccountID: "F11111"},
+		subAccount,
+		{AccountID: "U33333"},
+	}
+}
+
+func TestAccountResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		caller    string
+		requested string
+		want      string
+		wantCode  connect.Code
+	}{
+		{name: "defaults to caller account", caller: "F11111", requested: "", want: "F11111"},
+		{name: "own account", caller: "U33333", requested: "U33333", want: "U33333"},
+		{name: "advisor sub-account", caller: "F11111", requested: "U22222", want: "U22222"},
+		{name: "unrelated account", caller: "F11111", requested: "U33333", wantCode: connect.CodePermissionDenied},
+		{name: "sub-account cannot reach parent", caller: "U22222", requested: "F11111", wantCode: connect.CodePermissionDenied},
+		{name: "unknown account", caller: "F11111", requested: "U99999", wantCode: connect.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountClient := new(MockAccountClient)
+			accountClient.On("GetAccounts", mock.Anything).Return(advisorAccounts(), nil)
+
+			resolver := NewAccountResolver(accountClient, time.Minute)
+			ctx := middleware.SetAccountIDInContext(context.Background(), tt.caller)
+
+			got, err := resolver.Resolve(ctx, tt.requested)
+			if tt.wantCode != 0 {
+				if connect.CodeOf(err) != tt.wantCode {
+					t.Fatalf("Resolve() code = %v, want %v (err = %v)", connect.CodeOf(err), tt.wantCode, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountResolver_NoAccountInContext(t *testing.T) {
+	resolver := NewAccountResolver(new(MockAccountClient), time.Minute)
+
+	_, err := resolver.Resolve(context.Background(), "U12345")
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Code = %v, want Unauthenticated", connect.CodeOf(err))
+	}
+}
+
+func TestAccountResolver_CachesAccounts(t *testing.T) {
+	accountClient := new(MockAccountClient)
+	accountClient.On("GetAccounts", mock.Anything).Return(advisorAccounts(), nil).Once()
+
+	resolver := NewAccountResolver(accountClient, time.Minute)
+	ctx := middleware.SetAccountIDInContext(context.Background(), "F11111")
+
+	for _, requested := range []string{"F11111", "U22222", ""} {
+		if _, err := resolver.Resolve(ctx, requested); err != nil {
+			t.Fatalf("Resolve(%q) error = %v", requested, err)
+		}
+	}
+
+	accountClient.AssertNumberOfCalls(t, "GetAccounts", 1)
+}
+
+func TestAccountResolver_DiscoveryError(t *testing.T) {
+	accountClient := new(MockAccountClient)
+	accountClient.On("GetAccounts", mock.Anything).Return(nil, &ibkr.APIError{StatusCode: 401})
+
+	resolver := NewAccountResolver(accountClient, time.Minute)
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	_, err := resolver.Resolve(ctx, "")
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Code = %v, want Unauthenticated", connect.CodeOf(err))
+	}
+
+	var apiErr *ibkr.APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("Expected wrapped *ibkr.APIError, got %T", err)
+	}
+}
+
+func TestAccountResolver_SlowDiscoveryDoesNotBlock(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	slowCtx := middleware.SetAccountIDInContext(parent, "F11111")
+	ctx := middleware.SetAccountIDInContext(context.Background(), "F11111")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	accountClient := new(MockAccountClient)
+	accountClient.On("GetAccounts", slowCtx).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(nil, context.DeadlineExceeded)
+	accountClient.On("GetAccounts", ctx).Return(advisorAccounts(), nil)
+
+	resolver := NewAccountResolver(accountClient, time.Minute)
+
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := resolver.Resolve(slowCtx, "")
+		slowDone <- err
+	}()
+
+	// Wait for the slow discovery to be in flight.
+	<-started
+
+	if _, err := resolver.Resolve(ctx, "U22222"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	close(release)
+
+	if err := <-slowDone; err == nil {
+		t.Error("slow Resolve() error = nil, want error")
+	}
+
+	// The failed discovery does not replace the accounts discovered meanwhile.
+	if _, err := resolver.Resolve(ctx, "U22222"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	accountClient.AssertNumberOfCalls(t, "GetAccounts", 2)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// resolveInstrument resolves the contract of an instrument given either by contract ID or
+// by the query. A contract ID is used as is, without searching the Gateway, so only the
+// ConID of the contract is set.
+func resolveInstrument(
+	ctx context.Context,
+	contracts ibkr.ContractResolver,
+	conID int64,
+	query ibkr.ContractQuery,
+) (*ibkr.ResolvedContract, error) {
+	if conID > 0 {
+		return &ibkr.ResolvedContract{ConID: int(conID)}, nil
+	}
+
+	return contracts.ResolveContract(ctx, query)
+}
+
+// instrumentName names an instrument in errors by its symbol, or by its contract ID when
+// it was given by contract ID.
+func instrumentName(symbol string, conID int) string {
+	if symbol != "" {
+		return "symbol: " + symbol
+	}
+
+	return "conid: " + strconv.Itoa(conID)
+}
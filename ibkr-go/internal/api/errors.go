@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// gatewayError wraps an error returned by the IBKR Gateway client in a connect error
+// whose code reflects the upstream failure, so callers can tell a bad request apart
+// from an expired session or an unreachable Gateway.
+func gatewayError(msg string, err error) *connect.Error {
+	return connect.NewError(gatewayErrorCode(err), fmt.Errorf("%s: %w", msg, err))
+}
+
+// gatewayErrorCode maps a Gateway client error to a connect code.
+func gatewayErrorCode(err error) connect.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.CodeDeadlineExceeded
+	}
+
+	var apiErr *ibkr.APIError
+	if errors.As(err, &apiErr) {
+		return codeFromStatus(apiErr.StatusCode)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return connect.CodeDeadlineExceeded
+		}
+
+		return connect.CodeUnavailable
+	}
+
+	return connect.CodeInternal
+}
+
+// codeFromStatus maps a Gateway HTTP status code to a connect code.
+func codeFromStatus(statusCode int) connect.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return connect.CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case http.StatusNotFound:
+		return connect.CodeNotFound
+	case http.StatusConflict:
+		return connect.CodeAborted
+	case http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case http.StatusNotImplemented:
+		return connect.CodeUnimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return connect.CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return connect.CodeDeadlineExceeded
+	default:
+		return connect.CodeInternal
+	}
+}
+
+// contractError converts a contract resolution failure into a connect error.
+func contractError(err error) *connect.Error {
+	var ambiguous *ibkr.AmbiguousContractError
+
+	switch {
+	case errors.As(err, &ambiguous), errors.Is(err, ibkr.ErrUnsupportedSecType):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, ibkr.ErrContractNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	default:
+		return gatewayError("failed to resolve contract", err)
+	}
+}
+
+// historyError maps a historical data error to a connect error.
+func historyError(err error) *connect.Error {
+	if errors.Is(err, ibkr.ErrInvalidHistoryRequest) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return gatewayError("failed to get historical data", err)
+}
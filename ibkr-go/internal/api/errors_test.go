@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
+)
+
+func TestGatewayErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{"bad request", &ibkr.APIError{StatusCode: http.StatusBadRequest}, connect.CodeInvalidArgument},
+		{"unauthorized", &ibkr.APIError{StatusCode: http.StatusUnauthorized}, connect.CodeUnauthenticated},
+		{"forbidden", &ibkr.APIError{StatusCode: http.StatusForbidden}, connect.CodePermissionDenied},
+		{"not found", &ibkr.APIError{StatusCode: http.StatusNotFound}, connect.CodeNotFound},
+		{"rate limited", &ibkr.APIError{StatusCode: http.StatusTooManyRequests}, connect.CodeResourceExhausted},
+		{"unavailable", &ibkr.APIError{StatusCode: http.StatusServiceUnavailable}, connect.CodeUnavailable},
+		{"bad gateway", &ibkr.APIError{StatusCode: http.StatusBadGateway}, connect.CodeUnavailable},
+		{"gateway timeout", &ibkr.APIError{StatusCode: http.StatusGatewayTimeout}, connect.CodeDeadlineExceeded},
+		{"server error", &ibkr.APIError{StatusCode: http.StatusInternalServerError}, connect.CodeInternal},
+		{"wrapped api error", fmt.Errorf("wrapped: %w", &ibkr.APIError{StatusCode: 404}), connect.CodeNotFound},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, connect.CodeUnavailable},
+		{"deadline", context.DeadlineExceeded, connect.CodeDeadlineExceeded},
+		{"canceled", context.Canceled, connect.CodeCanceled},
+		{"unknown", errors.New("boom"), connect.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gatewayErrorCode(tt.err); got != tt.want {
+				t.Errorf("gatewayErrorCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetQuote_GatewayErrorCode(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockClient.On("SearchContracts", ctx, "AAPL").
+		Return(nil, &ibkr.APIError{StatusCode: http.StatusUnauthorized, Message: "not authenticated"})
+
+	_, err := handler.GetQuote(ctx, connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "AAPL"},
+	}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Expected CodeUnauthenticated, got %v", connect.CodeOf(err))
+	}
+}
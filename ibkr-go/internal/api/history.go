@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// defaultHistoricalPageSize is the page size of GetHistoricalData requests without a limit.
+const defaultHistoricalPageSize = 10000
+
+// errInvalidPageToken is returned for page tokens that were not issued by GetHistoricalData.
+var errInvalidPageToken = errors.New("invalid page token")
+
+// HistoryCache serves historical bars from a cache in front of the Gateway. *bars.Cache
+// implements it.
+type HistoryCache interface {
+	GetHistory(ctx context.Context, req ibkr.HistoryRequest) (*ibkr.History, error)
+	// Invalidate removes the cached bars of a contract and returns how many were removed.
+	Invalidate(ctx context.Context, conID int) (int64, error)
+}
+
+// WithHistoryCache serves GetHistoricalData through a bar cache.
+func WithHistoryCache(cache HistoryCache) MarketDataOption {
+	return func(h *MarketDataServiceHandler) {
+		h.historyCache = cache
+	}
+}
+
+// WithAdminAccounts sets the session accounts allowed to call InvalidateHistoricalCache.
+func WithAdminAccounts(accountIDs ...string) MarketDataOption {
+	return func(h *MarketDataServiceHandler) {
+		h.adminAccounts = make(map[string]bool, len(accountIDs))
+		for _, id := range accountIDs {
+			h.adminAccounts[id] = true
+		}
+	}
+}
+
+// historyPageToken is the range of the bars left to page through: from the start of the
+// requested period up to, excluding, the oldest bar returned so far.
+type historyPageToken struct {
+	start time.Time
+	end   time.Time
+}
+
+// encode returns the opaque form of the token.
+func (t historyPageToken) encode() string {
+	raw := fmt.Sprintf("%d:%d", t.start.UnixMilli(), t.end.UnixMilli())
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeHistoryPageToken parses a token returned by encode.
+func decodeHistoryPageToken(token string) (historyPageToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return historyPageToken{}, errInvalidPageToken
+	}
+
+	startMs, endMs, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return historyPageToken{}, errInvalidPageToken
+	}
+
+	start, err := strconv.ParseInt(startMs, 10, 64)
+	if err != nil {
+		return historyPageToken{}, errInvalidPageToken
+	}
+
+	end, err := strconv.ParseInt(endMs, 10, 64)
+	if err != nil || end <= start {
+		return historyPageToken{}, errInvalidPageToken
+	}
+
+	return historyPageToken{start: time.UnixMilli(start), end: time.UnixMilli(end)}, nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/indicators"
+	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxIndicatorSourceBars bounds the bars fetched for GetIndicators, the most recent
+	// of the period first.
+	maxIndicatorSourceBars = 20000
+	// maxResampleBaseSize is the largest bar size bars are resampled from. Weekly and
+	// monthly bars are not aligned to the resampling buckets.
+	maxResampleBaseSize = 24 * time.Hour
+	// vwapSession is the interval VWAP restarts at for intraday bars.
+	vwapSession = 24 * time.Hour
+
+	// Default indicator parameters.
+	defaultMovingAveragePeriod = 20
+	defaultRSIPeriod           = 14
+	defaultATRPeriod           = 14
+	defaultMACDFastPeriod      = 12
+	defaultMACDSlowPeriod      = 26
+	defaultMACDSignalPeriod    = 9
+	defaultBollingerStdDev     = 2
+)
+
+// errInvalidIndicator is returned for indicators with inconsistent parameters.
+var errInvalidIndicator = errors.New("invalid indicator")
+
+// indicatorBarSize returns the bar size to fetch for the requested one and, when the
+// Gateway does not serve the requested size, the size to resample the bars to: the
+// largest bar size it serves that divides the requested one.
+func indicatorBarSize(barSize string) (string, time.Duration, error) {
+	if slices.Contains(ibkr.HistoryBarSizes, barSize) {
+		return barSize, 0, nil
+	}
+
+	size, err := ibkr.ParseHistoryDuration(barSize)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, base := range slices.Backward(ibkr.HistoryBarSizes) {
+		baseSize, err := ibkr.ParseHistoryDuration(base)
+		if err != nil || baseSize > maxResampleBaseSize {
+			continue
+		}
+
+		if size%baseSize == 0 {
+			return base, size, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: bar size %q cannot be resampled", ibkr.ErrInvalidHistoryRequest, barSize)
+}
+
+// toIndicatorBars converts Gateway bars to indicator bars.
+func toIndicatorBars(data []ibkr.HistoricalBar) []indicators.Bar {
+	bars := make([]indicators.Bar, len(data))
+
+	for i, bar := range data {
+		bars[i] = indicators.Bar{
+			Time:   time.UnixMilli(bar.Time).UTC(),
+			Open:   bar.Open,
+			High:   bar.High,
+			Low:    bar.Low,
+			Close:  bar.Close,
+			Volume: float64(bar.Volume),
+		}
+	}
+
+	return bars
+}
+
+func mapIndicatorBarToProto(bar indicators.Bar) *marketdatav1.Bar {
+	return &marketdatav1.Bar{
+		OpenedAt: timestamppb.New(bar.Time),
+		Open:     bar.Open,
+		High:     bar.High,
+		Low:      bar.Low,
+		Close:    bar.Close,
+		Volume:   int64(math.Round(bar.Volume)),
+	}
+}
+
+// computeIndicator computes an indicator over the bars, restarting VWAP every session
+// when the bars are intraday.
+func computeIndicator(
+	spec *marketdatav1.IndicatorSpec,
+	bars []indicators.Bar,
+	intraday bool,
+) (*marketdatav1.Indicator, error) {
+	spec = withIndicatorDefaults(spec)
+	closes := indicators.Closes(bars)
+	period := int(spec.GetPeriod())
+
+	var series []*marketdatav1.IndicatorSeries
+
+	switch spec.Type {
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_SMA:
+		series = append(series, indicatorSeries("sma", indicators.SMA(closes, period)))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_EMA:
+		series = append(series, indicatorSeries("ema", indicators.EMA(closes, period)))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_RSI:
+		series = append(series, indicatorSeries("rsi", indicators.RSI(closes, period)))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_MACD:
+		// Either period may have been defaulted, so they are checked again.
+		if spec.GetFastPeriod() >= spec.GetSlowPeriod() {
+			return nil, fmt.Errorf("%w: MACD fast period %d is not less than slow period %d",
+				errInvalidIndicator, spec.GetFastPeriod(), spec.GetSlowPeriod())
+		}
+
+		macd := indicators.MACD(closes, int(spec.GetFastPeriod()), int(spec.GetSlowPeriod()), int(spec.GetSignalPeriod()))
+		series = append(series,
+			indicatorSeries("macd", macd.MACD),
+			indicatorSeries("signal", macd.Signal),
+			indicatorSeries("histogram", macd.Histogram),
+		)
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_ATR:
+		series = append(series, indicatorSeries("atr", indicators.ATR(bars, period)))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_BOLLINGER:
+		bands := indicators.Bollinger(closes, period, spec.GetStdDev())
+		series = append(series,
+			indicatorSeries("middle", bands.Middle),
+			indicatorSeries("upper", bands.Upper),
+			indicatorSeries("lower", bands.Lower),
+		)
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_VWAP:
+		var anchor time.Duration
+		if intraday {
+			anchor = vwapSession
+		}
+
+		series = append(series, indicatorSeries("vwap", indicators.VWAP(bars, anchor)))
+	}
+
+	return &marketdatav1.Indicator{Spec: spec, Series: series}, nil
+}
+
+// withIndicatorDefaults returns a copy of the spec with the defaults of the parameters
+// its indicator takes.
+func withIndicatorDefaults(spec *marketdatav1.IndicatorSpec) *marketdatav1.IndicatorSpec {
+	spec = proto.CloneOf(spec)
+
+	switch spec.Type {
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_SMA,
+		marketdatav1.IndicatorType_INDICATOR_TYPE_EMA:
+		spec.Period = proto.Int32(cmp.Or(spec.GetPeriod(), defaultMovingAveragePeriod))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_RSI:
+		spec.Period = proto.Int32(cmp.Or(spec.GetPeriod(), defaultRSIPeriod))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_ATR:
+		spec.Period = proto.Int32(cmp.Or(spec.GetPeriod(), defaultATRPeriod))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_MACD:
+		spec.FastPeriod = proto.Int32(cmp.Or(spec.GetFastPeriod(), defaultMACDFastPeriod))
+		spec.SlowPeriod = proto.Int32(cmp.Or(spec.GetSlowPeriod(), defaultMACDSlowPeriod))
+		spec.SignalPeriod = proto.Int32(cmp.Or(spec.GetSignalPeriod(), defaultMACDSignalPeriod))
+	case marketdatav1.IndicatorType_INDICATOR_TYPE_BOLLINGER:
+		spec.Period = proto.Int32(cmp.Or(spec.GetPeriod(), defaultMovingAveragePeriod))
+		spec.StdDev = proto.Float64(cmp.Or(spec.GetStdDev(), defaultBollingerStdDev))
+	}
+
+	return spec
+}
+
+func indicatorSeries(name string, values []float64) *marketdatav1.IndicatorSeries {
+	return &marketdatav1.IndicatorSeries{Name: name, Values: values}
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	instrumentv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/instrument/v1"
+	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/instrument/v1/instrumentv1connect"
+)
+
+// InstrumentServiceHandler implements the InstrumentService ConnectRPC service.
+type InstrumentServiceHandler struct {
+	ibkrClient ibkr.InstrumentClient
+}
+
+// NewInstrumentServiceHandler creates a new InstrumentService handler.
+func NewInstrumentServiceHandler(ibkrClient ibkr.InstrumentClient) instrumentv1connect.InstrumentServiceHandler {
+	return &InstrumentServiceHandler{
+		ibkrClient: ibkrClient,
+	}
+}
+
+// SearchInstruments searches for instruments by symbol or company name.
+func (h *InstrumentServiceHandler) SearchInstruments(
+	ctx context.Context,
+	req *connect.Request[instrumentv1.SearchInstrumentsRequest],
+) (*connect.Response[instrumentv1.SearchInstrumentsResponse], error) {
+	contracts, err := h.ibkrClient.SearchInstruments(ctx, ibkr.InstrumentSearch{
+		Query:   req.Msg.Query,
+		Name:    req.Msg.Name,
+		SecType: req.Msg.GetSecType(),
+	})
+	if err != nil {
+		return nil, gatewayError("failed to search instruments", err)
+	}
+
+	instruments := make([]*instrumentv1.Instrument, 0, len(contracts))
+	for i := range contracts {
+		instruments = append(instruments, mapContractToInstrument(&contracts[i]))
+	}
+
+	return connect.NewResponse(&instrumentv1.SearchInstrumentsResponse{
+		Instruments: instruments,
+	}), nil
+}
+
+// GetContractDetails retrieves the details of a contract, optionally with its trading rules.
+func (h *InstrumentServiceHandler) GetContractDetails(
+	ctx context.Context,
+	req *connect.Request[instrumentv1.GetContractDetailsRequest],
+) (*connect.Response[instrumentv1.GetContractDetailsResponse], error) {
+	conID := int(req.Msg.Conid)
+
+	if req.Msg.IncludeRules {
+		rules, err := h.ibkrClient.GetContractRules(ctx, conID, true)
+		if err != nil {
+			return nil, gatewayError("failed to get contract rules", err)
+		}
+
+		details := mapContractDetailsToProto(&rules.ContractDetails)
+		details.Rules = mapTradingRulesToProto(&rules.Rules)
+
+		return connect.NewResponse(&instrumentv1.GetContractDetailsResponse{
+			Contract: details,
+		}), nil
+	}
+
+	details, err := h.ibkrClient.GetContractDetails(ctx, conID)
+	if err != nil {
+		return nil, gatewayError("failed to get contract details", err)
+	}
+
+	return connect.NewResponse(&instrumentv1.GetContractDetailsResponse{
+		Contract: mapContractDetailsToProto(details),
+	}), nil
+}
+
+// GetTradingRules retrieves the trading rules of a contract.
+func (h *InstrumentServiceHandler) GetTradingRules(
+	ctx context.Context,
+	req *connect.Request[instrumentv1.GetTradingRulesRequest],
+) (*connect.Response[instrumentv1.GetTradingRulesResponse], error) {
+	rules, err := h.ibkrClient.GetContractRules(ctx, int(req.Msg.Conid), !req.Msg.Sell)
+	if err != nil {
+		return nil, gatewayError("failed to get trading rules", err)
+	}
+
+	return connect.NewResponse(&instrumentv1.GetTradingRulesResponse{
+		Rules: mapTradingRulesToProto(&rules.Rules),
+	}), nil
+}
+
+func mapContractToInstrument(contract *ibkr.Contract) *instrumentv1.Instrument {
+	instrument := &instrumentv1.Instrument{
+		Conid:       int64(contract.ConID),
+		Symbol:      contract.Symbol,
+		CompanyName: contract.CompanyName,
+		Description: contract.Description,
+		Sections:    make([]*instrumentv1.InstrumentSection, 0, len(contract.Sections)),
+	}
+
+	for _, section := range contract.Sections {
+		instrument.Sections = append(instrument.Sections, &instrumentv1.InstrumentSection{
+			SecType:  section.SecType,
+			Exchange: section.Exchange,
+			Months:   contract.Months(section.SecType),
+		})
+	}
+
+	return instrument
+}
+
+func mapContractDetailsToProto(details *ibkr.ContractDetails) *instrumentv1.ContractDetails {
+	return &instrumentv1.ContractDetails{
+		Conid:           int64(details.ConID),
+		Symbol:          details.Symbol,
+		LocalSymbol:     details.LocalSymbol,
+		CompanyName:     details.CompanyName,
+		SecType:         details.InstrumentType,
+		Exchange:        details.Exchange,
+		ValidExchanges:  splitList(details.ValidExchanges),
+		Currency:        details.Currency,
+		TradingClass:    details.TradingClass,
+		Multiplier:      string(details.Multiplier),
+		Industry:        details.Industry,
+		Category:        details.Category,
+		Cusip:           details.Cusip,
+		MaturityDate:    details.MaturityDate,
+		ContractMonth:   details.ContractMonth,
+		UnderlyingConid: int64(details.UnderlyingConID),
+	}
+}
+
+func mapTradingRulesToProto(rules *ibkr.TradingRules) *instrumentv1.TradingRules {
+	out := &instrumentv1.TradingRules{
+		PriceIncrement:       rules.Increment,
+		PriceIncrements:      make([]*instrumentv1.PriceIncrement, 0, len(rules.IncrementRules)),
+		PriceDecimals:        int32(rules.IncrementDigits), //nolint:gosec // A handful of digits.
+		SizeIncrement:        rules.SizeIncrement,
+		MinSize:              rules.SizeIncrement,
+		DefaultSize:          rules.DefaultSize,
+		OrderTypes:           rules.OrderTypes,
+		OrderTypesOutsideRth: rules.OrderTypesOutside,
+		TimeInForce:          make([]string, 0, len(rules.TIFTypes)),
+		Error:                rules.Error,
+	}
+
+	for _, rule := range rules.IncrementRules {
+		out.PriceIncrements = append(out.PriceIncrements, &instrumentv1.PriceIncrement{
+			LowerEdge: rule.LowerEdge,
+			Increment: rule.Increment,
+		})
+	}
+
+	// Each time in force is followed by the order types it applies to, e.g. "GTC/o,a".
+	for _, tif := range rules.TIFTypes {
+		tif, _, _ = strings.Cut(tif, "/")
+		if tif != "" {
+			out.TimeInForce = append(out.TimeInForce, tif)
+		}
+	}
+
+	return out
+}
+
+// splitList splits a comma separated Gateway list, dropping empty items.
+func splitList(list string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
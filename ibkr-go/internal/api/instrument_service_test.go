@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	instrumentv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/instrument/v1"
+)
+
+func TestSearchInstruments(t *testing.T) {
+	mockClient := new(MockInstrumentClient)
+	handler := NewInstrumentServiceHandler(mockClient)
+
+	ctx := context.Background()
+	secType := "STK"
+	req := connect.NewRequest(&instrumentv1.SearchInstrumentsRequest{Query: "Apple", Name: true, SecType: &secType})
+
+	contracts := []ibkr.Contract{{
+		ConID:       265598,
+		Symbol:      "AAPL",
+		CompanyName: "APPLE INC",
+		Description: "NASDAQ",
+		Opt:         "JAN24;FEB24",
+		Sections: []ibkr.ContractSection{
+			{SecType: "STK"},
+			{SecType: "OPT", Exchange: "SMART;CBOE"},
+		},
+	}}
+	mockClient.On("SearchInstruments", ctx, ibkr.InstrumentSearch{Query: "Apple", Name: true, SecType: "STK"}).
+		Return(contracts, nil)
+
+	resp, err := handler.SearchInstruments(ctx, req)
+	if err != nil {
+		t.Fatalf("SearchInstruments() error = %v", err)
+	}
+
+	if len(resp.Msg.Instruments) != 1 {
+		t.Fatalf("Expected 1 instrument, got %d", len(resp.Msg.Instruments))
+	}
+
+	instrument := resp.Msg.Instruments[0]
+	if instrument.Conid != 265598 || instrument.Symbol != "AAPL" || instrument.CompanyName != "APPLE INC" {
+		t.Errorf("Unexpected instrument %v", instrument)
+	}
+
+	if len(instrument.Sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(instrument.Sections))
+	}
+
+	if months := instrument.Sections[1].Months; !slices.Equal(months, []string{"JAN24", "FEB24"}) {
+		t.Errorf("OPT months = %v, want [JAN24 FEB24]", months)
+	}
+
+	if months := instrument.Sections[0].Months; len(months) != 0 {
+		t.Errorf("STK months = %v, want none", months)
+	}
+}
+
+func TestGetContractDetails(t *testing.T) {
+	mockClient := new(MockInstrumentClient)
+	handler := NewInstrumentServiceHandler(mockClient)
+
+	ctx := context.Background()
+	req := connect.NewRequest(&instrumentv1.GetContractDetailsRequest{Conid: 265598})
+
+	mockClient.On("GetContractDetails", ctx, 265598).Return(&ibkr.ContractDetails{
+		ConID:          265598,
+		Symbol:         "AAPL",
+		InstrumentType: "STK",
+		Exchange:       "NASDAQ",
+		ValidExchanges: "SMART,AMEX,NYSE,",
+		Currency:       "USD",
+		Multiplier:     "1",
+	}, nil)
+
+	resp, err := handler.GetContractDetails(ctx, req)
+	if err != nil {
+		t.Fatalf("GetContractDetails() error = %v", err)
+	}
+
+	contract := resp.Msg.Contract
+	if contract.Conid != 265598 || contract.SecType != "STK" || contract.Multiplier != "1" {
+		t.Errorf("Unexpected contract %v", contract)
+	}
+
+	if !slices.Equal(contract.ValidExchanges, []string{"SMART", "AMEX", "NYSE"}) {
+		t.Errorf("ValidExchanges = %v, want [SMART AMEX NYSE]", contract.ValidExchanges)
+	}
+
+	if contract.Rules != nil {
+		t.Errorf("Expected no rules unless requested, got %v", contract.Rules)
+	}
+
+	mockClient.AssertNotCalled(t, "GetContractRules")
+}
+
+func TestGetContractDetails_IncludeRules(t *testing.T) {
+	mockClient := new(MockInstrumentClient)
+	handler := NewInstrumentServiceHandler(mockClient)
+
+	ctx := context.Background()
+	req := connect.NewRequest(&instrumentv1.GetContractDetailsRequest{Conid: 265598, IncludeRules: true})
+
+	mockClient.On("GetContractRules", ctx, 265598, true).Return(&ibkr.ContractRules{
+		ContractDetails: ibkr.ContractDetails{ConID: 265598, Symbol: "AAPL"},
+		Rules:           ibkr.TradingRules{Increment: 0.01, SizeIncrement: 1},
+	}, nil)
+
+	resp, err := handler.GetContractDetails(ctx, req)
+	if err != nil {
+		t.Fatalf("GetContractDetails() error = %v", err)
+	}
+
+	if resp.Msg.Contract.Symbol != "AAPL" || resp.Msg.Contract.GetRules().GetPriceIncrement() != 0.01 {
+		t.Errorf("Unexpected contract %v", resp.Msg.Contract)
+	}
+}
+
+func TestGetTradingRules(t *testing.T) {
+	mockClient := new(MockInstrumentClient)
+	handler := NewInstrumentServiceHandler(mockClient)
+
+	ctx := context.Background()
+	req := connect.NewRequest(&instrumentv1.GetTradingRulesRequest{Conid: 265598, Sell: true})
+
+	mockClient.On("GetContractRules", ctx, 265598, false).Return(&ibkr.ContractRules{
+		Rules: ibkr.TradingRules{
+			OrderTypes:        []string{"limit", "market", "stop"},
+			OrderTypesOutside: []string{"limit"},
+			DefaultSize:       100,
+			SizeIncrement:     1,
+			TIFTypes:          []string{"DAY/o,a", "GTC/o,a", "IOC/LMT,MKT"},
+			Increment:         0.01,
+			IncrementDigits:   2,
+			IncrementRules:    []ibkr.IncrementRule{{LowerEdge: 0, Increment: 0.01}},
+		},
+	}, nil)
+
+	resp, err := handler.GetTradingRules(ctx, req)
+	if err != nil {
+		t.Fatalf("GetTradingRules() error = %v", err)
+	}
+
+	rules := resp.Msg.Rules
+	if rules.PriceIncrement != 0.01 || rules.PriceDecimals != 2 || rules.MinSize != 1 || rules.DefaultSize != 100 {
+		t.Errorf("Unexpected rules %v", rules)
+	}
+
+	if !slices.Equal(rules.TimeInForce, []string{"DAY", "GTC", "IOC"}) {
+		t.Errorf("TimeInForce = %v, want [DAY GTC IOC]", rules.TimeInForce)
+	}
+
+	if !slices.Equal(rules.OrderTypesOutsideRth, []string{"limit"}) {
+		t.Errorf("OrderTypesOutsideRth = %v, want [limit]", rules.OrderTypesOutsideRth)
+	}
+
+	if len(rules.PriceIncrements) != 1 || rules.PriceIncrements[0].Increment != 0.01 {
+		t.Errorf("PriceIncrements = %v, want one band of 0.01", rules.PriceIncrements)
+	}
+}
+
+func TestGetTradingRules_GatewayError(t *testing.T) {
+	mockClient := new(MockInstrumentClient)
+	handler := NewInstrumentServiceHandler(mockClient)
+
+	ctx := context.Background()
+	req := connect.NewRequest(&instrumentv1.GetTradingRulesRequest{Conid: 1})
+
+	mockClient.On("GetContractRules", ctx, 1, true).
+		Return(nil, &ibkr.APIError{StatusCode: http.StatusNotFound, Message: "contract not found"})
+
+	_, err := handler.GetTradingRules(ctx, req)
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Expected CodeNotFound, got %v", err)
+	}
+}
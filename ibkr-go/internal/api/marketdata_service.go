@@ -1,32 +1,93 @@
 package api
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/indicators"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/quotes"
 	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
 	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1/marketdatav1connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	streamInterval = 5 * time.Second
-	maxStreamCount = 100
+	// optionSecType is the security type of the contracts in an option chain.
+	optionSecType = "OPT"
+	// optionExchange is the exchange option strikes and contracts are listed on.
+	optionExchange = "SMART"
+	// maxOptionChainStrikes bounds the strikes of a chain, as each one costs a contract lookup per right.
+	maxOptionChainStrikes = 50
+	// snapshotBatchSize is the maximum number of contracts per market data snapshot request.
+	snapshotBatchSize = 100
+	// quoteResolveConcurrency bounds the contract resolutions of GetQuotes running at once.
+	quoteResolveConcurrency = 8
 )
 
+// optionRights are the option rights, in the order contracts of the same strike are returned.
+var optionRights = []string{"C", "P"}
+
+// optionChainFields are the snapshot fields requested for the contracts of an option chain.
+var optionChainFields = []string{
+	ibkr.FieldLastPrice,
+	ibkr.FieldBid,
+	ibkr.FieldAsk,
+	ibkr.FieldImpliedVolatility,
+	ibkr.FieldDelta,
+	ibkr.FieldGamma,
+	ibkr.FieldTheta,
+	ibkr.FieldVega,
+}
+
 // MarketDataServiceHandler implements the MarketDataService ConnectRPC service.
 type MarketDataServiceHandler struct {
-	ibkrClient ibkr.MarketDataClient
+	ibkrClient   ibkr.MarketDataClient
+	contracts    ibkr.ContractResolver
+	quotes       *quotes.Hub
+	streamConfig QuoteStreamConfig
+	historyCache HistoryCache
+	logger       *slog.Logger
+	// adminAccounts are the session accounts allowed to call administrative RPCs.
+	adminAccounts map[string]bool
 }
 
+var _ marketdatav1connect.MarketDataServiceHandler = (*MarketDataServiceHandler)(nil)
+
 // NewMarketDataServiceHandler creates a new MarketDataService handler.
-func NewMarketDataServiceHandler(ibkrClient ibkr.MarketDataClient) marketdatav1connect.MarketDataServiceHandler {
-	return &MarketDataServiceHandler{
+func NewMarketDataServiceHandler(
+	ibkrClient ibkr.MarketDataClient,
+	contracts ibkr.ContractResolver,
+	opts ...MarketDataOption,
+) *MarketDataServiceHandler {
+	h := &MarketDataServiceHandler{
 		ibkrClient: ibkrClient,
+		contracts:  contracts,
+		logger:     slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
 	}
+
+	h.streamConfig = h.streamConfig.withDefaults()
+	h.quotes = quotes.NewHub(ibkrClient, quotes.Config{Interval: h.streamConfig.Interval}, h.logger)
+
+	return h
+}
+
+// Close ends the open quote streams and stops polling the Gateway for them.
+func (h *MarketDataServiceHandler) Close() {
+	h.quotes.Close()
 }
 
 // GetQuote retrieves a market data quote for a symbol.
@@ -40,34 +101,42 @@ func (h *MarketDataServiceHandler) GetQuote(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
 	}
 
-	// Search for contract by symbol.
-	contracts, err := h.ibkrClient.SearchContracts(ctx, req.Msg.Symbol)
+	// Resolve the contract of the instrument.
+	contract, err := resolveInstrument(ctx, h.contracts, req.Msg.GetConid(), ibkr.ContractQuery{
+		Symbol:   req.Msg.GetSymbol(),
+		SecType:  req.Msg.GetSecType(),
+		Exchange: req.Msg.GetExchange(),
+		Currency: req.Msg.GetCurrency(),
+		Expiry:   req.Msg.GetExpiry(),
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search contracts: %w", err))
+		return nil, contractError(err)
 	}
 
-	if len(contracts) == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("symbol not found: %s", req.Msg.Symbol))
-	}
+	conID := contract.ConID
 
-	// Use the first contract found.
-	conID := contracts[0].ConID
+	// Pick the snapshot fields: the default quote fields plus the requested ones.
+	fields, err := quoteFields(req.Msg.Fields)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
 	// Get market data snapshot.
-	snapshots, err := h.ibkrClient.GetMarketData(ctx, []int{conID}, nil)
+	snapshots, err := h.ibkrClient.GetMarketData(ctx, []int{conID}, fields)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get market data: %w", err))
+		return nil, gatewayError("failed to get market data", err)
 	}
 
 	if len(snapshots) == 0 {
 		return nil, connect.NewError(
 			connect.CodeNotFound,
-			fmt.Errorf("no market data available for symbol: %s", req.Msg.Symbol),
+			fmt.Errorf("no market data available for %s", instrumentName(req.Msg.GetSymbol(), conID)),
 		)
 	}
 
 	// Map to proto quote.
-	quote := mapSnapshotToQuote(&snapshots[0], req.Msg.Symbol)
+	quote := mapSnapshotToQuote(&snapshots[0], req.Msg.GetSymbol())
+	quote.Fields = mapSnapshotFields(&snapshots[0], req.Msg.Fields)
 
 	_ = accountID
 
@@ -76,6 +145,141 @@ func (h *MarketDataServiceHandler) GetQuote(
 	}), nil
 }
 
+// GetQuotes retrieves market data quotes for many instruments. Contracts are resolved
+// concurrently and quoted with batched snapshot requests; an instrument that cannot be
+// resolved or quoted gets an error result rather than failing the call.
+func (h *MarketDataServiceHandler) GetQuotes(
+	ctx context.Context,
+	req *connect.Request[marketdatav1.GetQuotesRequest],
+) (*connect.Response[marketdatav1.GetQuotesResponse], error) {
+	// Get account ID from context.
+	if _, ok := middleware.GetAccountIDFromContext(ctx); !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	}
+
+	// Pick the snapshot fields: the default quote fields plus the requested ones.
+	fields, err := quoteFields(req.Msg.Fields)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	instruments := req.Msg.Instruments
+	contracts, errs := h.resolveInstruments(ctx, instruments)
+
+	// A cancelled call fails as a whole rather than with an error per instrument.
+	if err := ctx.Err(); err != nil {
+		return nil, gatewayError("failed to resolve contracts", err)
+	}
+
+	// Quote every distinct contract once, in batches.
+	conIDs := make([]int, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract != nil && !slices.Contains(conIDs, contract.ConID) {
+			conIDs = append(conIDs, contract.ConID)
+		}
+	}
+
+	snapshots := make(map[int]*ibkr.MarketDataSnapshot, len(conIDs))
+	batchErrs := make(map[int]*connect.Error)
+
+	for batch := range slices.Chunk(conIDs, snapshotBatchSize) {
+		batchSnapshots, err := h.ibkrClient.GetMarketData(ctx, batch, fields)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, gatewayError("failed to get market data", err)
+			}
+
+			for _, conID := range batch {
+				batchErrs[conID] = gatewayError("failed to get market data", err)
+			}
+
+			continue
+		}
+
+		for i := range batchSnapshots {
+			snapshots[batchSnapshots[i].ConID] = &batchSnapshots[i]
+		}
+	}
+
+	results := make([]*marketdatav1.QuoteResult, 0, len(instruments))
+
+	for i, instrument := range instruments {
+		result := &marketdatav1.QuoteResult{Symbol: instrument.GetSymbol(), Conid: instrument.GetConid()}
+
+		switch {
+		case errs[i] != nil:
+			result.Result = quoteErrorResult(errs[i])
+		case batchErrs[contracts[i].ConID] != nil:
+			result.Result = quoteErrorResult(batchErrs[contracts[i].ConID])
+		case snapshots[contracts[i].ConID] == nil:
+			result.Result = quoteErrorResult(connect.NewError(
+				connect.CodeNotFound,
+				fmt.Errorf("no market data available for %s", instrumentName(instrument.GetSymbol(), contracts[i].ConID)),
+			))
+		default:
+			snapshot := snapshots[contracts[i].ConID]
+			quote := mapSnapshotToQuote(snapshot, instrument.GetSymbol())
+			quote.Fields = mapSnapshotFields(snapshot, req.Msg.Fields)
+			result.Result = &marketdatav1.QuoteResult_Quote{Quote: quote}
+		}
+
+		results = append(results, result)
+	}
+
+	return connect.NewResponse(&marketdatav1.GetQuotesResponse{
+		Results: results,
+	}), nil
+}
+
+// resolveInstruments resolves the contracts of the instruments, at most
+// quoteResolveConcurrency at a time. Each instrument gets either a contract or an error.
+func (h *MarketDataServiceHandler) resolveInstruments(
+	ctx context.Context,
+	instruments []*marketdatav1.QuoteInstrument,
+) ([]*ibkr.ResolvedContract, []*connect.Error) {
+	contracts := make([]*ibkr.ResolvedContract, len(instruments))
+	errs := make([]*connect.Error, len(instruments))
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, quoteResolveConcurrency)
+
+	for i, instrument := range instruments {
+		sem <- struct{}{}
+
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			contract, err := resolveInstrument(ctx, h.contracts, instrument.GetConid(), ibkr.ContractQuery{
+				Symbol:   instrument.GetSymbol(),
+				SecType:  instrument.GetSecType(),
+				Exchange: instrument.GetExchange(),
+				Currency: instrument.GetCurrency(),
+				Expiry:   instrument.GetExpiry(),
+			})
+			if err != nil {
+				errs[i] = contractError(err)
+
+				return
+			}
+
+			contracts[i] = contract
+		})
+	}
+
+	wg.Wait()
+
+	return contracts, errs
+}
+
+// quoteErrorResult returns the error result of an instrument that could not be quoted.
+func quoteErrorResult(err *connect.Error) *marketdatav1.QuoteResult_Error {
+	return &marketdatav1.QuoteResult_Error{Error: &marketdatav1.QuoteError{
+		Code:    err.Code().String(),
+		Message: err.Message(),
+	}}
+}
+
 // GetHistoricalData retrieves historical market data for a symbol.
 func (h *MarketDataServiceHandler) GetHistoricalData(
 	ctx context.Context,
@@ -87,145 +291,550 @@ func (h *MarketDataServiceHandler) GetHistoricalData(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
 	}
 
-	// Search for contract by symbol.
-	contracts, err := h.ibkrClient.SearchContracts(ctx, req.Msg.Symbol)
+	// Resolve the contract of the instrument.
+	contract, err := resolveInstrument(ctx, h.contracts, req.Msg.GetConid(), ibkr.ContractQuery{
+		Symbol:   req.Msg.GetSymbol(),
+		SecType:  req.Msg.GetSecType(),
+		Exchange: req.Msg.GetExchange(),
+		Currency: req.Msg.GetCurrency(),
+		Expiry:   req.Msg.GetExpiry(),
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search contracts: %w", err))
+		return nil, contractError(err)
 	}
 
-	if len(contracts) == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("symbol not found: %s", req.Msg.Symbol))
+	limit := defaultHistoricalPageSize
+	if req.Msg.Limit != nil {
+		limit = int(req.Msg.GetLimit())
 	}
 
-	// Use the first contract found.
-	conID := contracts[0].ConID
+	histReq := ibkr.HistoryRequest{
+		ConID:      contract.ConID,
+		Period:     req.Msg.Period,
+		BarSize:    req.Msg.BarSize,
+		Limit:      limit,
+		OutsideRTH: req.Msg.OutsideRth,
+	}
+
+	// Later pages continue from the oldest bar of the previous one.
+	if req.Msg.GetPageToken() != "" {
+		token, err := decodeHistoryPageToken(req.Msg.GetPageToken())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		histReq.Start, histReq.End = token.start, token.end
+	}
+
+	// Get historical data, through the bar cache when there is one.
+	getHistory := h.ibkrClient.GetHistory
+	if h.historyCache != nil {
+		getHistory = h.historyCache.GetHistory
+	}
 
-	// Get historical data.
-	histData, err := h.ibkrClient.GetHistoricalData(ctx, conID, req.Msg.Period, req.Msg.BarSize)
+	history, err := getHistory(ctx, histReq)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get historical data: %w", err))
+		return nil, historyError(err)
 	}
 
 	// Map to proto bars.
-	bars := make([]*marketdatav1.Bar, 0, len(histData.Data))
-	for i := range histData.Data {
-		bar := mapHistoricalBarToProto(&histData.Data[i])
+	bars := make([]*marketdatav1.Bar, 0, len(history.Data))
+	for i := range history.Data {
+		bar := mapHistoricalBarToProto(&history.Data[i])
 		bars = append(bars, bar)
 	}
 
+	resp := &marketdatav1.GetHistoricalDataResponse{
+		Bars:       bars,
+		Delayed:    history.Delayed(),
+		OutsideRth: history.OutsideRth || histReq.OutsideRTH,
+	}
+
+	if history.More && len(history.Data) > 0 {
+		resp.NextPageToken = historyPageToken{
+			start: history.Start,
+			end:   time.UnixMilli(history.Data[0].Time),
+		}.encode()
+	}
+
 	_ = accountID
 
-	return connect.NewResponse(&marketdatav1.GetHistoricalDataResponse{
-		Bars: bars,
+	return connect.NewResponse(resp), nil
+}
+
+// GetIndicators computes technical indicators over the historical bars of a symbol.
+func (h *MarketDataServiceHandler) GetIndicators(
+	ctx context.Context,
+	req *connect.Request[marketdatav1.GetIndicatorsRequest],
+) (*connect.Response[marketdatav1.GetIndicatorsResponse], error) {
+	if _, ok := middleware.GetAccountIDFromContext(ctx); !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	}
+
+	size, err := ibkr.ParseHistoryDuration(req.Msg.BarSize)
+	if err != nil {
+		return nil, historyError(err)
+	}
+
+	// Bar sizes the Gateway does not serve are resampled from smaller bars.
+	barSize, resampleSize, err := indicatorBarSize(req.Msg.BarSize)
+	if err != nil {
+		return nil, historyError(err)
+	}
+
+	contract, err := resolveInstrument(ctx, h.contracts, req.Msg.GetConid(), ibkr.ContractQuery{
+		Symbol:   req.Msg.GetSymbol(),
+		SecType:  req.Msg.GetSecType(),
+		Exchange: req.Msg.GetExchange(),
+		Currency: req.Msg.GetCurrency(),
+		Expiry:   req.Msg.GetExpiry(),
+	})
+	if err != nil {
+		return nil, contractError(err)
+	}
+
+	getHistory := h.ibkrClient.GetHistory
+	if h.historyCache != nil {
+		getHistory = h.historyCache.GetHistory
+	}
+
+	history, err := getHistory(ctx, ibkr.HistoryRequest{
+		ConID:      contract.ConID,
+		Period:     req.Msg.Period,
+		BarSize:    barSize,
+		Limit:      maxIndicatorSourceBars,
+		OutsideRTH: req.Msg.OutsideRth,
+	})
+	if err != nil {
+		return nil, historyError(err)
+	}
+
+	bars := toIndicatorBars(history.Data)
+	if resampleSize > 0 {
+		bars = indicators.Resample(bars, resampleSize)
+	}
+
+	resp := &marketdatav1.GetIndicatorsResponse{
+		Bars:       make([]*marketdatav1.Bar, 0, len(bars)),
+		Indicators: make([]*marketdatav1.Indicator, 0, len(req.Msg.Indicators)),
+		Delayed:    history.Delayed(),
+	}
+
+	for _, bar := range bars {
+		resp.Bars = append(resp.Bars, mapIndicatorBarToProto(bar))
+	}
+
+	for _, spec := range req.Msg.Indicators {
+		indicator, err := computeIndicator(spec, bars, size < vwapSession)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		resp.Indicators = append(resp.Indicators, indicator)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// InvalidateHistoricalCache removes the cached historical bars of a contract. The cache
+// is shared by all callers, so only admin accounts may invalidate it.
+func (h *MarketDataServiceHandler) InvalidateHistoricalCache(
+	ctx context.Context,
+	req *connect.Request[marketdatav1.InvalidateHistoricalCacheRequest],
+) (*connect.Response[marketdatav1.InvalidateHistoricalCacheResponse], error) {
+	accountID, ok := middleware.GetAccountIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	}
+
+	if !h.adminAccounts[accountID] {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("account %s is not an admin account", accountID))
+	}
+
+	if h.historyCache == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("historical bar cache is not enabled"))
+	}
+
+	deleted, err := h.historyCache.Invalidate(ctx, int(req.Msg.Conid))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to invalidate historical cache: %w", err))
+	}
+
+	return connect.NewResponse(&marketdatav1.InvalidateHistoricalCacheResponse{
+		DeletedBars: deleted,
 	}), nil
 }
 
-// StreamQuotes streams real-time quotes for a symbol.
+// StreamQuotes streams real-time quotes for one or more instruments. The upstream polls
+// of the contracts are shared with the other streams of them.
 func (h *MarketDataServiceHandler) StreamQuotes(
 	ctx context.Context,
 	req *connect.Request[marketdatav1.StreamQuotesRequest],
 	stream *connect.ServerStream[marketdatav1.StreamQuotesResponse],
 ) error {
 	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
+	if _, ok := middleware.GetAccountIDFromContext(ctx); !ok {
 		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
 	}
 
-	_ = accountID
+	// Resolve the contracts of the instruments; the stream needs all of them.
+	instruments := streamInstruments(req.Msg)
+	contracts, errs := h.resolveInstruments(ctx, instruments)
 
-	// Search for contract by symbol.
-	contracts, err := h.ibkrClient.SearchContracts(ctx, req.Msg.Symbol)
+	symbols := make(map[int][]string, len(contracts))
+
+	for i, contract := range contracts {
+		if errs[i] != nil {
+			return errs[i]
+		}
+
+		if !slices.Contains(symbols[contract.ConID], instruments[i].GetSymbol()) {
+			symbols[contract.ConID] = append(symbols[contract.ConID], instruments[i].GetSymbol())
+		}
+	}
+
+	// The contracts are polled at least as often as the stream sends their quotes.
+	interval := h.streamConfig.interval(req.Msg.IntervalMs)
+
+	sub, err := h.quotes.Subscribe(interval, slices.Collect(maps.Keys(symbols))...)
 	if err != nil {
-		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search contracts: %w", err))
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	defer sub.Close()
+
+	streamer := newQuoteStreamer(symbols, req.Msg.Delta, stream.Send)
+
+	return h.streamQuotes(ctx, sub, streamer, interval)
+}
+
+// GetOptionChain retrieves the option contracts of an underlying for an expiration month.
+func (h *MarketDataServiceHandler) GetOptionChain(
+	ctx context.Context,
+	req *connect.Request[marketdatav1.GetOptionChainRequest],
+) (*connect.Response[marketdatav1.GetOptionChainResponse], error) {
+	// Get account ID from context.
+	if _, ok := middleware.GetAccountIDFromContext(ctx); !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
 	}
 
-	if len(contracts) == 0 {
-		return connect.NewError(connect.CodeNotFound, fmt.Errorf("symbol not found: %s", req.Msg.Symbol))
+	msg := req.Msg
+	if msg.MinStrike != nil && msg.MaxStrike != nil && msg.GetMinStrike() > msg.GetMaxStrike() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("min_strike must not exceed max_strike"))
 	}
 
-	// Use the first contract found.
-	conID := contracts[0].ConID
+	// Resolve the contract of the underlying.
+	underlying, err := h.contracts.ResolveContract(ctx, ibkr.ContractQuery{
+		Symbol:   msg.Symbol,
+		SecType:  msg.GetSecType(),
+		Exchange: msg.GetExchange(),
+		Currency: msg.GetCurrency(),
+	})
+	if err != nil {
+		return nil, contractError(err)
+	}
+
+	months, err := h.optionMonths(ctx, underlying)
+	if err != nil {
+		return nil, err
+	}
+
+	month := msg.GetMonth()
+
+	switch {
+	case len(months) == 0:
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no options listed for %s", underlying.Symbol))
+	case month == "":
+		month = months[0]
+	case !slices.Contains(months, month):
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("no options expiring in %s for %s, available months: %s", month, underlying.Symbol, strings.Join(months, ", ")),
+		)
+	}
+
+	strikes, err := h.ibkrClient.GetStrikes(ctx, ibkr.StrikesRequest{
+		ConID:    underlying.ConID,
+		SecType:  optionSecType,
+		Month:    month,
+		Exchange: optionExchange,
+	})
+	if err != nil {
+		return nil, gatewayError("failed to get option strikes", err)
+	}
 
-	return h.streamQuotesLoop(ctx, conID, req.Msg.Symbol, stream)
+	strikesByRight := filterStrikes(msg, strikes)
+
+	chainStrikes := make(map[float64]bool)
+	for _, rightStrikes := range strikesByRight {
+		for _, strike := range rightStrikes {
+			chainStrikes[strike] = true
+		}
+	}
+
+	if len(chainStrikes) > maxOptionChainStrikes {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf(
+			"option chain of %s for %s has %d strikes, narrow it to at most %d with min_strike and max_strike",
+			underlying.Symbol, month, len(chainStrikes), maxOptionChainStrikes,
+		))
+	}
+
+	contracts, err := h.optionContracts(ctx, underlying.ConID, month, msg.GetExpiration(), strikesByRight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.addOptionMarketData(ctx, contracts); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&marketdatav1.GetOptionChainResponse{
+		Symbol:          underlying.Symbol,
+		UnderlyingConid: int64(underlying.ConID),
+		Months:          months,
+		Month:           month,
+		Expirations:     uniqueSorted(contracts, func(c *marketdatav1.OptionContract) string { return c.Expiration }),
+		Strikes:         uniqueSorted(contracts, func(c *marketdatav1.OptionContract) float64 { return c.Strike }),
+		Contracts:       contracts,
+	}), nil
+}
+
+// optionMonths returns the option expiration months listed for the underlying.
+func (h *MarketDataServiceHandler) optionMonths(ctx context.Context, underlying *ibkr.ResolvedContract) ([]string, error) {
+	results, err := h.ibkrClient.SearchContracts(ctx, underlying.Symbol)
+	if err != nil {
+		return nil, gatewayError("failed to search contracts", err)
+	}
+
+	for _, result := range results {
+		if result.ConID == underlying.ConID {
+			return result.Months(optionSecType), nil
+		}
+	}
+
+	return nil, nil
 }
 
-// streamQuotesLoop handles the streaming loop for quotes.
-func (h *MarketDataServiceHandler) streamQuotesLoop(
+// optionContracts looks up the contracts of every strike and right of an expiration month.
+// A non-empty expiration keeps only the contracts expiring on that date.
+func (h *MarketDataServiceHandler) optionContracts(
 	ctx context.Context,
-	conID int,
-	symbol string,
-	stream *connect.ServerStream[marketdatav1.StreamQuotesResponse],
-) error {
-	ticker := time.NewTicker(streamInterval)
-	defer ticker.Stop()
-
-	count := 0
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-ticker.C:
-			if err := h.sendQuoteSnapshot(ctx, conID, symbol, stream); err != nil {
-				return err
+	underlyingConID int,
+	month, expiration string,
+	strikesByRight map[string][]float64,
+) ([]*marketdatav1.OptionContract, error) {
+	var contracts []*marketdatav1.OptionContract
+
+	for _, right := range optionRights {
+		for _, strike := range strikesByRight[right] {
+			infos, err := h.ibkrClient.GetContractInfo(ctx, ibkr.ContractInfoRequest{
+				ConID:    underlyingConID,
+				SecType:  optionSecType,
+				Month:    month,
+				Exchange: optionExchange,
+				Strike:   strike,
+				Right:    right,
+			})
+			if err != nil {
+				return nil, gatewayError("failed to get option contract info", err)
 			}
 
-			count++
-			if count >= maxStreamCount {
-				return nil
+			for i := range infos {
+				if expiration != "" && infos[i].MaturityDate != expiration {
+					continue
+				}
+
+				contracts = append(contracts, mapContractInfoToOption(&infos[i], strike, right))
 			}
 		}
 	}
+
+	slices.SortFunc(contracts, func(a, b *marketdatav1.OptionContract) int {
+		return cmp.Or(
+			cmp.Compare(a.Expiration, b.Expiration),
+			cmp.Compare(a.Strike, b.Strike),
+			cmp.Compare(a.Right, b.Right),
+		)
+	})
+
+	return contracts, nil
 }
 
-// sendQuoteSnapshot fetches and sends a single quote snapshot.
-func (h *MarketDataServiceHandler) sendQuoteSnapshot(
-	ctx context.Context,
-	conID int,
-	symbol string,
-	stream *connect.ServerStream[marketdatav1.StreamQuotesResponse],
-) error {
-	snapshots, err := h.ibkrClient.GetMarketData(ctx, []int{conID}, nil)
-	if err != nil {
-		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get market data: %w", err))
+// addOptionMarketData fills in the quotes, implied volatility and greeks of the contracts.
+func (h *MarketDataServiceHandler) addOptionMarketData(ctx context.Context, contracts []*marketdatav1.OptionContract) error {
+	byConID := make(map[int]*marketdatav1.OptionContract, len(contracts))
+	conIDs := make([]int, 0, len(contracts))
+
+	for _, contract := range contracts {
+		byConID[int(contract.Conid)] = contract
+		conIDs = append(conIDs, int(contract.Conid))
 	}
 
-	if len(snapshots) == 0 {
-		return nil
+	for batch := range slices.Chunk(conIDs, snapshotBatchSize) {
+		snapshots, err := h.ibkrClient.GetMarketData(ctx, batch, optionChainFields)
+		if err != nil {
+			return gatewayError("failed to get option market data", err)
+		}
+
+		for i := range snapshots {
+			if contract, ok := byConID[snapshots[i].ConID]; ok {
+				applyOptionSnapshot(contract, &snapshots[i])
+			}
+		}
 	}
 
-	quote := mapSnapshotToQuote(&snapshots[0], symbol)
+	return nil
+}
 
-	return stream.Send(&marketdatav1.StreamQuotesResponse{
-		Quote: quote,
-	})
+// filterStrikes returns the strikes of each requested right within the requested strike range.
+func filterStrikes(msg *marketdatav1.GetOptionChainRequest, strikes *ibkr.Strikes) map[string][]float64 {
+	all := map[string][]float64{"C": strikes.Call, "P": strikes.Put}
+	filtered := make(map[string][]float64, len(all))
+
+	for right, rightStrikes := range all {
+		if msg.Right != nil && msg.GetRight() != right {
+			continue
+		}
+
+		for _, strike := range rightStrikes {
+			if (msg.MinStrike != nil && strike < msg.GetMinStrike()) ||
+				(msg.MaxStrike != nil && strike > msg.GetMaxStrike()) {
+				continue
+			}
+
+			filtered[right] = append(filtered[right], strike)
+		}
+	}
+
+	return filtered
+}
+
+// uniqueSorted returns the distinct values of a contract attribute in ascending order.
+func uniqueSorted[T cmp.Ordered](contracts []*marketdatav1.OptionContract, value func(*marketdatav1.OptionContract) T) []T {
+	values := make([]T, 0, len(contracts))
+	for _, contract := range contracts {
+		values = append(values, value(contract))
+	}
+
+	slices.Sort(values)
+
+	return slices.Compact(values)
+}
+
+// quoteFields returns the snapshot field IDs of a quote with the named additional fields,
+// or nil for the client's default fields when none are requested.
+func quoteFields(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ids, err := ibkr.FieldIDs(names)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := slices.Clone(ibkr.DefaultSnapshotFields)
+	for _, id := range ids {
+		if !slices.Contains(fields, id) {
+			fields = append(fields, id)
+		}
+	}
+
+	return fields, nil
 }
 
 // Helper functions for mapping IBKR types to proto types.
 
 func mapSnapshotToQuote(snapshot *ibkr.MarketDataSnapshot, symbol string) *marketdatav1.Quote {
 	quote := &marketdatav1.Quote{
-		Symbol: symbol,
+		Symbol: cmp.Or(symbol, snapshot.Symbol),
+		Conid:  int64(snapshot.ConID),
 		Last:   snapshot.LastPrice,
 		Bid:    snapshot.Bid,
 		Ask:    snapshot.Ask,
 		Volume: snapshot.Volume,
 		High:   snapshot.High,
 		Low:    snapshot.Low,
+		Open:   snapshot.Open,
+		Close:  snapshot.Close,
+	}
+
+	if !snapshot.Updated.IsZero() {
+		quote.UpdatedAt = timestamppb.New(snapshot.Updated)
 	}
 
 	return quote
 }
 
+// mapSnapshotFields returns the raw values of the named fields present in the snapshot.
+func mapSnapshotFields(snapshot *ibkr.MarketDataSnapshot, names []string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]string, len(names))
+
+	for _, name := range names {
+		field, ok := ibkr.LookupField(name)
+		if !ok {
+			continue
+		}
+
+		if value, ok := snapshot.Fields[field.ID]; ok {
+			fields[name] = value
+		}
+	}
+
+	return fields
+}
+
+func mapContractInfoToOption(info *ibkr.ContractInfo, strike float64, right string) *marketdatav1.OptionContract {
+	contract := &marketdatav1.OptionContract{
+		Conid:        int64(info.ConID),
+		Symbol:       info.Symbol,
+		Right:        right,
+		Strike:       strike,
+		Expiration:   info.MaturityDate,
+		Multiplier:   info.Multiplier,
+		TradingClass: info.TradingClass,
+	}
+
+	if info.Strike != 0 {
+		contract.Strike = info.Strike
+	}
+
+	return contract
+}
+
+// applyOptionSnapshot sets the market data fields of an option contract. Fields the
+// Gateway did not return are left unset.
+func applyOptionSnapshot(contract *marketdatav1.OptionContract, snapshot *ibkr.MarketDataSnapshot) {
+	contract.Bid = nonZero(snapshot.Bid)
+	contract.Ask = nonZero(snapshot.Ask)
+	contract.Last = nonZero(snapshot.LastPrice)
+	contract.ImpliedVolatility = nonZero(snapshot.ImpliedVolatility)
+	contract.Delta = nonZero(snapshot.Delta)
+	contract.Gamma = nonZero(snapshot.Gamma)
+	contract.Theta = nonZero(snapshot.Theta)
+	contract.Vega = nonZero(snapshot.Vega)
+}
+
+// nonZero returns a pointer to the value, or nil for a zero value.
+func nonZero(value float64) *float64 {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
+
 func mapHistoricalBarToProto(bar *ibkr.HistoricalBar) *marketdatav1.Bar {
 	protoBar := &marketdatav1.Bar{
-		Timestamp: fmt.Sprintf("%d", bar.Time),
-		Open:      bar.Open,
-		High:      bar.High,
-		Low:       bar.Low,
-		Close:     bar.Close,
-		Volume:    bar.Volume,
+		OpenedAt: timestamppb.New(time.UnixMilli(bar.Time)),
+		Open:     bar.Open,
+		High:     bar.High,
+		Low:      bar.Low,
+		Close:    bar.Close,
+		Volume:   bar.Volume,
 	}
 
 	return protoBar
@@ -2,26 +2,52 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
 	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
+	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1/marketdatav1connect"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 )
 
+// mockAAPLContract sets up contract resolution of AAPL to conid 12345.
+func mockAAPLContract(ctx context.Context, mockClient *MockMarketDataClient) {
+	contracts := []ibkr.Contract{{
+		ConID:       12345,
+		Symbol:      "AAPL",
+		Description: "NASDAQ",
+		Sections:    []ibkr.ContractSection{{SecType: "STK"}},
+	}}
+	mockClient.On("SearchContracts", ctx, "AAPL").Return(contracts, nil)
+
+	infos := []ibkr.ContractInfo{{ConID: 12345, Symbol: "AAPL", ListingExchange: "NASDAQ", Currency: "USD"}}
+	mockClient.On("GetContractInfo", ctx, ibkr.ContractInfoRequest{ConID: 12345, SecType: "STK"}).Return(infos, nil)
+}
+
 func TestGetQuote(t *testing.T) {
 	mockClient := new(MockMarketDataClient)
-	handler := NewMarketDataServiceHandler(mockClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
-	req := connect.NewRequest(&marketdatav1.GetQuoteRequest{Symbol: "AAPL"})
+	req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "AAPL"},
+	})
 
 	// Mocks
-	contracts := []ibkr.Contract{{ConID: 12345, Symbol: "AAPL"}}
-	mockClient.On("SearchContracts", ctx, "AAPL").Return(contracts, nil)
+	mockAAPLContract(ctx, mockClient)
 
-	snapshots := []ibkr.MarketDataSnapshot{{LastPrice: 150.0}}
+	updated := time.UnixMilli(1702334859712)
+	snapshots := []ibkr.MarketDataSnapshot{{LastPrice: 150.0, Updated: updated}}
 	mockClient.On("GetMarketData", ctx, []int{12345}, []string(nil)).Return(snapshots, nil)
 
 	resp, err := handler.GetQuote(ctx, req)
@@ -32,30 +58,355 @@ func TestGetQuote(t *testing.T) {
 	if resp.Msg.Quote.Last != 150.0 {
 		t.Errorf("Last = %v, want 150.0", resp.Msg.Quote.Last)
 	}
+
+	if !resp.Msg.Quote.UpdatedAt.AsTime().Equal(updated) {
+		t.Errorf("UpdatedAt = %v, want %v", resp.Msg.Quote.UpdatedAt.AsTime(), updated)
+	}
+}
+
+// contractResolverFunc resolves contracts with a function.
+type contractResolverFunc func(ctx context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error)
+
+func (f contractResolverFunc) ResolveContract(ctx context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+	return f(ctx, query)
+}
+
+// symbolInstrument returns an instrument to quote by symbol.
+func symbolInstrument(symbol string) *marketdatav1.QuoteInstrument {
+	return &marketdatav1.QuoteInstrument{Instrument: &marketdatav1.QuoteInstrument_Symbol{Symbol: symbol}}
+}
+
+func TestGetQuote_Conid(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	contracts := new(MockContractResolver)
+	handler := NewMarketDataServiceHandler(mockClient, contracts)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Conid{Conid: 72063691},
+	})
+
+	snapshots := []ibkr.MarketDataSnapshot{{ConID: 72063691, Symbol: "BRK B", LastPrice: 410}}
+	mockClient.On("GetMarketData", ctx, []int{72063691}, []string(nil)).Return(snapshots, nil)
+
+	resp, err := handler.GetQuote(ctx, req)
+	if err != nil {
+		t.Fatalf("GetQuote() error = %v", err)
+	}
+
+	// The contract ID is used as is, without a search, and the symbol is the snapshot's.
+	contracts.AssertNotCalled(t, "ResolveContract", mock.Anything, mock.Anything)
+
+	quote := resp.Msg.Quote
+	if quote.Symbol != "BRK B" || quote.Conid != 72063691 || quote.Last != 410 {
+		t.Errorf("Unexpected quote %v", quote)
+	}
+}
+
+func TestGetQuotes(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	conIDs := map[string]int{"AAPL": 1, "MSFT": 2, "IBM": 3}
+	contracts := contractResolverFunc(func(_ context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+		conID, ok := conIDs[query.Symbol]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ibkr.ErrContractNotFound, query)
+		}
+
+		return &ibkr.ResolvedContract{ConID: conID, Symbol: query.Symbol, SecType: "STK"}, nil
+	})
+
+	mockClient := new(MockMarketDataClient)
+	mockClient.On("GetMarketData", ctx, []int{1, 2, 3}, []string(nil)).Return([]ibkr.MarketDataSnapshot{
+		{ConID: 2, LastPrice: 410.5},
+		{ConID: 1, LastPrice: 193.18},
+	}, nil)
+
+	handler := NewMarketDataServiceHandler(mockClient, contracts)
+
+	resp, err := handler.GetQuotes(ctx, connect.NewRequest(&marketdatav1.GetQuotesRequest{
+		Instruments: []*marketdatav1.QuoteInstrument{
+			symbolInstrument("AAPL"), symbolInstrument("NOPE"), symbolInstrument("MSFT"),
+			symbolInstrument("IBM"), symbolInstrument("AAPL"),
+		},
+	}))
+	if err != nil {
+		t.Fatalf("GetQuotes() error = %v", err)
+	}
+
+	results := resp.Msg.Results
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(results))
+	}
+
+	for i, want := range []float64{193.18, 0, 410.5, 0, 193.18} {
+		if got := results[i].GetQuote().GetLast(); got != want {
+			t.Errorf("results[%d] last = %v, want %v", i, got, want)
+		}
+	}
+
+	if results[1].GetError().GetCode() != connect.CodeNotFound.String() || results[1].Symbol != "NOPE" {
+		t.Errorf("Expected a not found error for NOPE, got %v", results[1])
+	}
+
+	if results[3].GetError().GetCode() != connect.CodeNotFound.String() {
+		t.Errorf("Expected a not found error for IBM without market data, got %v", results[3])
+	}
+
+	// Duplicate instruments are quoted once, in a single snapshot request.
+	mockClient.AssertNumberOfCalls(t, "GetMarketData", 1)
+}
+
+func TestGetQuotes_Conid(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	mockClient := new(MockMarketDataClient)
+	mockClient.On("GetMarketData", ctx, []int{12087792, 1}, []string(nil)).Return([]ibkr.MarketDataSnapshot{
+		{ConID: 12087792, Symbol: "EUR", LastPrice: 1.08},
+	}, nil)
+
+	contracts := new(MockContractResolver)
+	handler := NewMarketDataServiceHandler(mockClient, contracts)
+
+	resp, err := handler.GetQuotes(ctx, connect.NewRequest(&marketdatav1.GetQuotesRequest{
+		Instruments: []*marketdatav1.QuoteInstrument{
+			{Instrument: &marketdatav1.QuoteInstrument_Conid{Conid: 12087792}},
+			{Instrument: &marketdatav1.QuoteInstrument_Conid{Conid: 1}},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("GetQuotes() error = %v", err)
+	}
+
+	contracts.AssertNotCalled(t, "ResolveContract", mock.Anything, mock.Anything)
+
+	results := resp.Msg.Results
+	if results[0].Conid != 12087792 || results[0].GetQuote().GetLast() != 1.08 {
+		t.Errorf("Unexpected result %v", results[0])
+	}
+
+	if results[1].Conid != 1 || results[1].GetError().GetMessage() != "no market data available for conid: 1" {
+		t.Errorf("Expected a not found error for conid 1, got %v", results[1])
+	}
+}
+
+func TestGetQuotes_Batches(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	contracts := contractResolverFunc(func(_ context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+		conID, err := strconv.Atoi(strings.TrimPrefix(query.Symbol, "S"))
+
+		return &ibkr.ResolvedContract{ConID: conID, Symbol: query.Symbol}, err
+	})
+
+	instruments := make([]*marketdatav1.QuoteInstrument, 0, snapshotBatchSize+10)
+	for i := 1; i <= snapshotBatchSize+10; i++ {
+		instruments = append(instruments, symbolInstrument(fmt.Sprintf("S%d", i)))
+	}
+
+	mockClient := new(MockMarketDataClient)
+	mockClient.On("GetMarketData", ctx, mock.MatchedBy(func(conIDs []int) bool { return len(conIDs) == snapshotBatchSize }), mock.Anything).
+		Return(nil, &ibkr.APIError{StatusCode: http.StatusServiceUnavailable})
+
+	// The second batch holds the last ten contracts.
+	snapshots := make([]ibkr.MarketDataSnapshot, 0, 10)
+	for conID := snapshotBatchSize + 1; conID <= snapshotBatchSize+10; conID++ {
+		snapshots = append(snapshots, ibkr.MarketDataSnapshot{ConID: conID, LastPrice: float64(conID)})
+	}
+
+	mockClient.On("GetMarketData", ctx, mock.MatchedBy(func(conIDs []int) bool { return len(conIDs) == 10 }), mock.Anything).
+		Return(snapshots, nil)
+
+	handler := NewMarketDataServiceHandler(mockClient, contracts)
+
+	resp, err := handler.GetQuotes(ctx, connect.NewRequest(&marketdatav1.GetQuotesRequest{Instruments: instruments}))
+	if err != nil {
+		t.Fatalf("GetQuotes() error = %v", err)
+	}
+
+	for i, result := range resp.Msg.Results {
+		if i < snapshotBatchSize {
+			if result.GetError().GetCode() != connect.CodeUnavailable.String() {
+				t.Errorf("results[%d] = %v, want an unavailable error", i, result)
+			}
+
+			continue
+		}
+
+		if result.GetQuote().GetLast() != float64(i+1) {
+			t.Errorf("results[%d] = %v, want last %d", i, result, i+1)
+		}
+	}
+
+	mockClient.AssertNumberOfCalls(t, "GetMarketData", 2)
 }
 
 func TestGetHistoricalData(t *testing.T) {
 	mockClient := new(MockMarketDataClient)
-	handler := NewMarketDataServiceHandler(mockClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&marketdatav1.GetHistoricalDataRequest{
-		Symbol:  "AAPL",
-		Period:  "1d",
-		BarSize: "1h",
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1d",
+		BarSize:    "1h",
 	})
 
-	// Mock SearchContracts first
-	contracts := []ibkr.Contract{{ConID: 12345, Symbol: "AAPL"}}
-	mockClient.On("SearchContracts", ctx, "AAPL").Return(contracts, nil)
+	// Mock contract resolution first
+	mockAAPLContract(ctx, mockClient)
 
-	// Mock GetHistoricalData
-	histData := &ibkr.HistoricalDataResponse{
-		Data: []ibkr.HistoricalBar{
-			{Time: 1000, Open: 100, High: 110, Low: 90, Close: 105, Volume: 1000},
+	// Mock GetHistory
+	histData := &ibkr.History{
+		HistoricalDataResponse: ibkr.HistoricalDataResponse{
+			MdAvailability: "DpB",
+			OutsideRth:     true,
+			Data: []ibkr.HistoricalBar{
+				{Time: 1704067200000, Open: 100, High: 110, Low: 90, Close: 105, Volume: 1000},
+			},
 		},
 	}
-	mockClient.On("GetHistoricalData", ctx, 12345, "1d", "1h").Return(histData, nil)
+	histReq := ibkr.HistoryRequest{ConID: 12345, Period: "1d", BarSize: "1h", Limit: defaultHistoricalPageSize}
+	mockClient.On("GetHistory", ctx, histReq).Return(histData, nil)
+
+	resp, err := handler.GetHistoricalData(ctx, req)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	if len(resp.Msg.Bars) != 1 {
+		t.Fatalf("Bars count = %v, want 1", len(resp.Msg.Bars))
+	}
+
+	if got, want := resp.Msg.Bars[0].OpenedAt.AsTime(), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("OpenedAt = %v, want %v", got, want)
+	}
+
+	if !resp.Msg.Delayed || !resp.Msg.OutsideRth {
+		t.Errorf("Expected delayed bars outside regular trading hours, got delayed %v, outside RTH %v",
+			resp.Msg.Delayed, resp.Msg.OutsideRth)
+	}
+
+	if resp.Msg.NextPageToken != "" {
+		t.Errorf("NextPageToken = %q, want none", resp.Msg.NextPageToken)
+	}
+}
+
+func TestGetHistoricalData_Pages(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	start := time.UnixMilli(1000)
+	limit := int32(2)
+
+	firstReq := ibkr.HistoryRequest{ConID: 12345, Period: "1y", BarSize: "1d", Limit: 2}
+	mockClient.On("GetHistory", ctx, firstReq).Return(&ibkr.History{
+		HistoricalDataResponse: ibkr.HistoricalDataResponse{
+			Data: []ibkr.HistoricalBar{{Time: 5000}, {Time: 6000}},
+		},
+		Start: start,
+		More:  true,
+	}, nil)
+
+	// The next page covers the bars from the start of the period up to the oldest bar returned.
+	secondReq := ibkr.HistoryRequest{
+		ConID:   12345,
+		Period:  "1y",
+		BarSize: "1d",
+		Limit:   2,
+		Start:   start,
+		End:     time.UnixMilli(5000),
+	}
+	mockClient.On("GetHistory", ctx, secondReq).Return(&ibkr.History{
+		HistoricalDataResponse: ibkr.HistoricalDataResponse{
+			Data: []ibkr.HistoricalBar{{Time: 4000}},
+		},
+		Start: start,
+	}, nil)
+
+	msg := &marketdatav1.GetHistoricalDataRequest{
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1y",
+		BarSize:    "1d",
+		Limit:      &limit,
+	}
+
+	first, err := handler.GetHistoricalData(ctx, connect.NewRequest(msg))
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	if len(first.Msg.Bars) != 2 || first.Msg.NextPageToken == "" {
+		t.Fatalf("Expected a first page of 2 bars with a next page, got %v", first.Msg)
+	}
+
+	msg.PageToken = &first.Msg.NextPageToken
+
+	second, err := handler.GetHistoricalData(ctx, connect.NewRequest(msg))
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	if len(second.Msg.Bars) != 1 || second.Msg.Bars[0].OpenedAt.AsTime().UnixMilli() != 4000 || second.Msg.NextPageToken != "" {
+		t.Errorf("Expected a last page with the older bar, got %v", second.Msg)
+	}
+
+	badToken := "not a token"
+	msg.PageToken = &badToken
+
+	if _, err := handler.GetHistoricalData(ctx, connect.NewRequest(msg)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument for a malformed page token", connect.CodeOf(err))
+	}
+}
+
+func TestGetHistoricalData_InvalidBarSize(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	mockClient.On("GetHistory", ctx, mock.Anything).
+		Return(nil, fmt.Errorf("%w: malformed duration", ibkr.ErrInvalidHistoryRequest))
+
+	req := connect.NewRequest(&marketdatav1.GetHistoricalDataRequest{
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1d",
+		BarSize:    "hourly",
+	})
+
+	if _, err := handler.GetHistoricalData(ctx, req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+	}
+}
+
+func TestGetHistoricalData_HistoryCache(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	cache := new(MockHistoryCache)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient), WithHistoryCache(cache))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	histReq := ibkr.HistoryRequest{
+		ConID:      12345,
+		Period:     "1w",
+		BarSize:    "1h",
+		Limit:      defaultHistoricalPageSize,
+		OutsideRTH: true,
+	}
+	cache.On("GetHistory", ctx, histReq).Return(&ibkr.History{
+		HistoricalDataResponse: ibkr.HistoricalDataResponse{Data: []ibkr.HistoricalBar{{Time: 1000}}},
+	}, nil)
+
+	req := connect.NewRequest(&marketdatav1.GetHistoricalDataRequest{
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1w",
+		BarSize:    "1h",
+		OutsideRth: true,
+	})
 
 	resp, err := handler.GetHistoricalData(ctx, req)
 	if err != nil {
@@ -65,4 +416,409 @@ func TestGetHistoricalData(t *testing.T) {
 	if len(resp.Msg.Bars) != 1 {
 		t.Errorf("Bars count = %v, want 1", len(resp.Msg.Bars))
 	}
+
+	// The Gateway is not asked directly.
+	mockClient.AssertNotCalled(t, "GetHistory", mock.Anything, mock.Anything)
+}
+
+func TestInvalidateHistoricalCache(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&marketdatav1.InvalidateHistoricalCacheRequest{Conid: 265598})
+
+	mockClient := new(MockMarketDataClient)
+
+	uncached := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient), WithAdminAccounts("U12345"))
+	if _, err := uncached.InvalidateHistoricalCache(ctx, req); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("Code = %v, want FailedPrecondition without a cache", connect.CodeOf(err))
+	}
+
+	cache := new(MockHistoryCache)
+	cache.On("Invalidate", ctx, 265598).Return(int64(42), nil)
+
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient),
+		WithHistoryCache(cache), WithAdminAccounts("U12345"))
+
+	resp, err := handler.InvalidateHistoricalCache(ctx, req)
+	if err != nil {
+		t.Fatalf("InvalidateHistoricalCache() error = %v", err)
+	}
+
+	if resp.Msg.DeletedBars != 42 {
+		t.Errorf("DeletedBars = %d, want 42", resp.Msg.DeletedBars)
+	}
+}
+
+func TestInvalidateHistoricalCache_NotAdmin(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U99999")
+	req := connect.NewRequest(&marketdatav1.InvalidateHistoricalCacheRequest{Conid: 265598})
+
+	mockClient := new(MockMarketDataClient)
+	cache := new(MockHistoryCache)
+
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient),
+		WithHistoryCache(cache), WithAdminAccounts("U12345"))
+
+	if _, err := handler.InvalidateHistoricalCache(ctx, req); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("Code = %v, want PermissionDenied for a non-admin account", connect.CodeOf(err))
+	}
+
+	cache.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything)
+}
+
+func TestGetIndicators(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	// Eight hourly bars from 14:00, rising by one every hour, resampled to four 2h bars.
+	start := time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC)
+
+	var data []ibkr.HistoricalBar
+	for i := range 8 {
+		price := float64(100 + i)
+		data = append(data, ibkr.HistoricalBar{
+			Time:   start.Add(time.Duration(i) * time.Hour).UnixMilli(),
+			Open:   price,
+			High:   price + 1,
+			Low:    price - 1,
+			Close:  price,
+			Volume: 10,
+		})
+	}
+
+	histReq := ibkr.HistoryRequest{ConID: 12345, Period: "1d", BarSize: "1h", Limit: maxIndicatorSourceBars}
+	mockClient.On("GetHistory", ctx, histReq).Return(&ibkr.History{
+		HistoricalDataResponse: ibkr.HistoricalDataResponse{Data: data},
+	}, nil)
+
+	req := connect.NewRequest(&marketdatav1.GetIndicatorsRequest{
+		Instrument: &marketdatav1.GetIndicatorsRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1d",
+		BarSize:    "2h",
+		Indicators: []*marketdatav1.IndicatorSpec{
+			{Type: marketdatav1.IndicatorType_INDICATOR_TYPE_SMA, Period: proto.Int32(2)},
+			{Type: marketdatav1.IndicatorType_INDICATOR_TYPE_MACD},
+		},
+	})
+
+	resp, err := handler.GetIndicators(ctx, req)
+	if err != nil {
+		t.Fatalf("GetIndicators() error = %v", err)
+	}
+
+	if len(resp.Msg.Bars) != 4 {
+		t.Fatalf("Expected 4 resampled bars, got %d", len(resp.Msg.Bars))
+	}
+
+	if bar := resp.Msg.Bars[1]; bar.Open != 102 || bar.Close != 103 || bar.High != 104 || bar.Volume != 20 ||
+		!bar.OpenedAt.AsTime().Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Unexpected resampled bar %v", bar)
+	}
+
+	if len(resp.Msg.Indicators) != 2 {
+		t.Fatalf("Expected 2 indicators, got %d", len(resp.Msg.Indicators))
+	}
+
+	sma := resp.Msg.Indicators[0].Series
+	if len(sma) != 1 || sma[0].Name != "sma" || !math.IsNaN(sma[0].Values[0]) || sma[0].Values[1] != 102 {
+		t.Errorf("Unexpected SMA series %v", sma)
+	}
+
+	// MACD gets its default periods and its three series, all warming up over 4 bars.
+	macd := resp.Msg.Indicators[1]
+	if macd.Spec.GetFastPeriod() != 12 || macd.Spec.GetSlowPeriod() != 26 || macd.Spec.GetSignalPeriod() != 9 {
+		t.Errorf("Expected the default MACD periods, got %v", macd.Spec)
+	}
+
+	if len(macd.Series) != 3 || len(macd.Series[2].Values) != 4 || macd.Series[2].Name != "histogram" {
+		t.Errorf("Unexpected MACD series %v", macd.Series)
+	}
+}
+
+func TestGetIndicators_InvalidMACD(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	mockClient.On("GetHistory", ctx, mock.Anything).Return(&ibkr.History{}, nil)
+
+	// The slow period defaults to 26, below the fast period.
+	req := connect.NewRequest(&marketdatav1.GetIndicatorsRequest{
+		Instrument: &marketdatav1.GetIndicatorsRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1d",
+		BarSize:    "1h",
+		Indicators: []*marketdatav1.IndicatorSpec{
+			{Type: marketdatav1.IndicatorType_INDICATOR_TYPE_MACD, FastPeriod: proto.Int32(30)},
+		},
+	})
+
+	if _, err := handler.GetIndicators(ctx, req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+	}
+}
+
+func TestIndicatorBarSize(t *testing.T) {
+	tests := []struct {
+		barSize      string
+		wantBarSize  string
+		wantResample time.Duration
+	}{
+		{barSize: "5min", wantBarSize: "5min"},
+		{barSize: "1d", wantBarSize: "1d"},
+		{barSize: "2h", wantBarSize: "1h", wantResample: 2 * time.Hour},
+		{barSize: "45min", wantBarSize: "15min", wantResample: 45 * time.Minute},
+		{barSize: "3d", wantBarSize: "1d", wantResample: 72 * time.Hour},
+		{barSize: "2w", wantBarSize: "1d", wantResample: 14 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		barSize, resample, err := indicatorBarSize(tt.barSize)
+		if err != nil || barSize != tt.wantBarSize || resample != tt.wantResample {
+			t.Errorf("indicatorBarSize(%q) = %q, %v, %v, want %q, %v",
+				tt.barSize, barSize, resample, err, tt.wantBarSize, tt.wantResample)
+		}
+	}
+}
+
+func TestGetQuote_ContractResolution(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	// SHOP is listed on NYSE and NASDAQ in USD, and on TSE in CAD.
+	listings := []ibkr.ContractInfo{
+		{ConID: 1, Symbol: "SHOP", ListingExchange: "NYSE", Currency: "USD"},
+		{ConID: 2, Symbol: "SHOP", ListingExchange: "TSE", Currency: "CAD"},
+		{ConID: 3, Symbol: "SHOP", ListingExchange: "NASDAQ", Currency: "USD"},
+	}
+
+	newHandler := func() (*MockMarketDataClient, marketdatav1connect.MarketDataServiceHandler) {
+		mockClient := new(MockMarketDataClient)
+
+		contracts := make([]ibkr.Contract, 0, len(listings))
+		for _, listing := range listings {
+			contracts = append(contracts, ibkr.Contract{
+				ConID:       listing.ConID,
+				Symbol:      listing.Symbol,
+				Description: listing.ListingExchange,
+				Sections:    []ibkr.ContractSection{{SecType: "STK"}},
+			})
+			mockClient.On("GetContractInfo", ctx, ibkr.ContractInfoRequest{ConID: listing.ConID, SecType: "STK"}).
+				Return([]ibkr.ContractInfo{listing}, nil)
+		}
+
+		mockClient.On("SearchContracts", ctx, "SHOP").Return(contracts, nil)
+
+		return mockClient, NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+	}
+
+	t.Run("currency selects the listing", func(t *testing.T) {
+		mockClient, handler := newHandler()
+		mockClient.On("GetMarketData", ctx, []int{2}, []string(nil)).
+			Return([]ibkr.MarketDataSnapshot{{LastPrice: 100.0}}, nil)
+
+		currency := "CAD"
+		req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
+			Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "SHOP"},
+			Currency:   &currency,
+		})
+
+		if _, err := handler.GetQuote(ctx, req); err != nil {
+			t.Fatalf("GetQuote() error = %v", err)
+		}
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no matching listing", func(t *testing.T) {
+		_, handler := newHandler()
+
+		exchange := "LSE"
+		req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
+			Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "SHOP"},
+			Exchange:   &exchange,
+		})
+
+		_, err := handler.GetQuote(ctx, req)
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			t.Errorf("Code = %v, want NotFound", connect.CodeOf(err))
+		}
+	})
+
+	t.Run("ambiguous listing", func(t *testing.T) {
+		_, handler := newHandler()
+
+		_, err := handler.GetQuote(ctx, connect.NewRequest(&marketdatav1.GetQuoteRequest{
+			Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "SHOP"},
+		}))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+		}
+	})
+}
+
+func TestGetOptionChain(t *testing.T) {
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	newHandler := func() (*MockMarketDataClient, marketdatav1connect.MarketDataServiceHandler) {
+		mockClient := new(MockMarketDataClient)
+
+		contracts := []ibkr.Contract{{
+			ConID:       12345,
+			Symbol:      "AAPL",
+			Description: "NASDAQ",
+			Sections:    []ibkr.ContractSection{{SecType: "STK"}, {SecType: "OPT", Months: "JAN24;FEB24"}},
+		}}
+		mockClient.On("SearchContracts", ctx, "AAPL").Return(contracts, nil)
+
+		infos := []ibkr.ContractInfo{{ConID: 12345, Symbol: "AAPL", ListingExchange: "NASDAQ", Currency: "USD"}}
+		mockClient.On("GetContractInfo", ctx, ibkr.ContractInfoRequest{ConID: 12345, SecType: "STK"}).Return(infos, nil)
+
+		mockClient.On("GetStrikes", ctx, ibkr.StrikesRequest{ConID: 12345, SecType: "OPT", Month: "JAN24", Exchange: "SMART"}).
+			Return(&ibkr.Strikes{Call: []float64{185, 190, 195}, Put: []float64{185, 190}}, nil)
+
+		return mockClient, NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+	}
+
+	optionInfo := func(conID int, strike float64, right string, maturity string) []ibkr.ContractInfo {
+		return []ibkr.ContractInfo{{
+			ConID:        conID,
+			Symbol:       "AAPL",
+			SecType:      "OPT",
+			Right:        right,
+			Strike:       strike,
+			MaturityDate: maturity,
+			Multiplier:   "100",
+			TradingClass: "AAPL",
+		}}
+	}
+
+	mockOptionInfo := func(mockClient *MockMarketDataClient, strike float64, right string, infos []ibkr.ContractInfo) {
+		mockClient.On("GetContractInfo", ctx, ibkr.ContractInfoRequest{
+			ConID:    12345,
+			SecType:  "OPT",
+			Month:    "JAN24",
+			Exchange: "SMART",
+			Strike:   strike,
+			Right:    right,
+		}).Return(infos, nil)
+	}
+
+	t.Run("chain with greeks", func(t *testing.T) {
+		mockClient, handler := newHandler()
+
+		mockOptionInfo(mockClient, 190, "C", append(optionInfo(101, 190, "C", "20240119"), optionInfo(102, 190, "C", "20240105")...))
+		mockOptionInfo(mockClient, 195, "C", optionInfo(103, 195, "C", "20240119"))
+		mockOptionInfo(mockClient, 190, "P", optionInfo(104, 190, "P", "20240119"))
+
+		snapshots := []ibkr.MarketDataSnapshot{
+			{ConID: 101, Bid: 4.1, Ask: 4.3, ImpliedVolatility: 24.5, Delta: 0.52, Gamma: 0.04, Theta: -0.11, Vega: 0.2},
+			{ConID: 104, Bid: 3.9},
+		}
+		mockClient.On("GetMarketData", ctx, []int{102, 101, 104, 103}, optionChainFields).Return(snapshots, nil)
+
+		minStrike := 186.0
+		resp, err := handler.GetOptionChain(ctx, connect.NewRequest(&marketdatav1.GetOptionChainRequest{
+			Symbol:    "AAPL",
+			MinStrike: &minStrike,
+		}))
+		if err != nil {
+			t.Fatalf("GetOptionChain() error = %v", err)
+		}
+
+		msg := resp.Msg
+		if msg.UnderlyingConid != 12345 || msg.Month != "JAN24" || len(msg.Months) != 2 {
+			t.Errorf("Unexpected chain: %+v", msg)
+		}
+
+		if len(msg.Expirations) != 2 || msg.Expirations[0] != "20240105" {
+			t.Errorf("Expirations = %v, want [20240105 20240119]", msg.Expirations)
+		}
+
+		if len(msg.Strikes) != 2 || msg.Strikes[0] != 190 || msg.Strikes[1] != 195 {
+			t.Errorf("Strikes = %v, want [190 195]", msg.Strikes)
+		}
+
+		if len(msg.Contracts) != 4 {
+			t.Fatalf("Contracts count = %v, want 4", len(msg.Contracts))
+		}
+
+		call := msg.Contracts[1]
+		if call.Conid != 101 || call.Right != "C" || call.GetDelta() != 0.52 || call.GetImpliedVolatility() != 24.5 {
+			t.Errorf("Unexpected call contract: %+v", call)
+		}
+
+		put := msg.Contracts[2]
+		if put.Conid != 104 || put.GetBid() != 3.9 || put.Delta != nil || put.Ask != nil {
+			t.Errorf("Unexpected put contract: %+v", put)
+		}
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("unlisted month", func(t *testing.T) {
+		_, handler := newHandler()
+
+		month := "MAR24"
+		_, err := handler.GetOptionChain(ctx, connect.NewRequest(&marketdatav1.GetOptionChainRequest{
+			Symbol: "AAPL",
+			Month:  &month,
+		}))
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			t.Errorf("Code = %v, want NotFound", connect.CodeOf(err))
+		}
+	})
+
+	t.Run("invalid strike range", func(t *testing.T) {
+		_, handler := newHandler()
+
+		minStrike, maxStrike := 200.0, 100.0
+		_, err := handler.GetOptionChain(ctx, connect.NewRequest(&marketdatav1.GetOptionChainRequest{
+			Symbol:    "AAPL",
+			MinStrike: &minStrike,
+			MaxStrike: &maxStrike,
+		}))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+		}
+	})
+}
+
+func TestGetQuote_Fields(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	mockAAPLContract(ctx, mockClient)
+
+	fields := append(slices.Clone(ibkr.DefaultSnapshotFields), ibkr.FieldBidSize, ibkr.FieldMarketDataAvailability)
+	snapshots := []ibkr.MarketDataSnapshot{{
+		ConID:     12345,
+		LastPrice: 150.0,
+		Fields:    map[string]string{ibkr.FieldLastPrice: "150.0", ibkr.FieldBidSize: "1,200"},
+	}}
+	mockClient.On("GetMarketData", ctx, []int{12345}, fields).Return(snapshots, nil)
+
+	resp, err := handler.GetQuote(ctx, connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "AAPL"},
+		Fields:     []string{"bid_size", "market_data_availability", "last"},
+	}))
+	if err != nil {
+		t.Fatalf("GetQuote() error = %v", err)
+	}
+
+	got := resp.Msg.Quote.Fields
+	if len(got) != 2 || got["bid_size"] != "1,200" || got["last"] != "150.0" {
+		t.Errorf("Fields = %v, want bid_size and last", got)
+	}
+
+	_, err = handler.GetQuote(ctx, connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "AAPL"},
+		Fields:     []string{"nope"},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+	}
 }
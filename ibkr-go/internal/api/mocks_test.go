@@ -10,29 +10,37 @@ type MockOrderClient struct {
 	mock.Mock
 }
 
-func (m *MockOrderClient) PlaceOrder(ctx context.Context, req *ibkr.PlaceOrderRequest) (*ibkr.OrderResponse, error) {
-	args := m.Called(ctx, req)
+func (m *MockOrderClient) PlaceOrder(ctx context.Context, accountID string, orders ...ibkr.PlaceOrderRequest) ([]ibkr.OrderResponse, error) {
+	args := m.Called(ctx, accountID, orders)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*ibkr.OrderResponse), args.Error(1)
+	return args.Get(0).([]ibkr.OrderResponse), args.Error(1)
 }
 
-func (m *MockOrderClient) ModifyOrder(ctx context.Context, orderID string, req *ibkr.ModifyOrderRequest) (*ibkr.OrderResponse, error) {
-	args := m.Called(ctx, orderID, req)
+func (m *MockOrderClient) ModifyOrder(ctx context.Context, accountID, orderID string, req *ibkr.ModifyOrderRequest) (*ibkr.OrderResponse, error) {
+	args := m.Called(ctx, accountID, orderID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*ibkr.OrderResponse), args.Error(1)
 }
 
-func (m *MockOrderClient) CancelOrder(ctx context.Context, orderID string) error {
-	args := m.Called(ctx, orderID)
+func (m *MockOrderClient) CancelOrder(ctx context.Context, accountID, orderID string) error {
+	args := m.Called(ctx, accountID, orderID)
 	return args.Error(0)
 }
 
-func (m *MockOrderClient) GetLiveOrders(ctx context.Context) ([]ibkr.Order, error) {
-	args := m.Called(ctx)
+func (m *MockOrderClient) ReplyOrder(ctx context.Context, replyID string, confirmed bool) ([]ibkr.OrderResponse, error) {
+	args := m.Called(ctx, replyID, confirmed)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ibkr.OrderResponse), args.Error(1)
+}
+
+func (m *MockOrderClient) GetLiveOrders(ctx context.Context, accountID string) ([]ibkr.Order, error) {
+	args := m.Called(ctx, accountID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -59,6 +67,14 @@ func (m *MockMarketDataClient) GetHistoricalData(ctx context.Context, conID int,
 	return args.Get(0).(*ibkr.HistoricalDataResponse), args.Error(1)
 }
 
+func (m *MockMarketDataClient) GetHistory(ctx context.Context, req ibkr.HistoryRequest) (*ibkr.History, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.History), args.Error(1)
+}
+
 func (m *MockMarketDataClient) SearchContracts(ctx context.Context, symbol string) ([]ibkr.Contract, error) {
 	args := m.Called(ctx, symbol)
 	if args.Get(0) == nil {
@@ -67,22 +83,131 @@ func (m *MockMarketDataClient) SearchContracts(ctx context.Context, symbol strin
 	return args.Get(0).([]ibkr.Contract), args.Error(1)
 }
 
+func (m *MockMarketDataClient) GetContractInfo(ctx context.Context, req ibkr.ContractInfoRequest) ([]ibkr.ContractInfo, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ibkr.ContractInfo), args.Error(1)
+}
+
+func (m *MockMarketDataClient) GetStrikes(ctx context.Context, req ibkr.StrikesRequest) (*ibkr.Strikes, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.Strikes), args.Error(1)
+}
+
+func (m *MockMarketDataClient) GetSecDefs(ctx context.Context, conIDs []int) ([]ibkr.SecDef, error) {
+	args := m.Called(ctx, conIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ibkr.SecDef), args.Error(1)
+}
+
+func (m *MockMarketDataClient) GetFutures(ctx context.Context, symbols []string) (map[string][]ibkr.FutureContract, error) {
+	args := m.Called(ctx, symbols)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]ibkr.FutureContract), args.Error(1)
+}
+
+type MockInstrumentClient struct {
+	mock.Mock
+}
+
+func (m *MockInstrumentClient) SearchInstruments(ctx context.Context, search ibkr.InstrumentSearch) ([]ibkr.Contract, error) {
+	args := m.Called(ctx, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ibkr.Contract), args.Error(1)
+}
+
+func (m *MockInstrumentClient) GetContractDetails(ctx context.Context, conID int) (*ibkr.ContractDetails, error) {
+	args := m.Called(ctx, conID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.ContractDetails), args.Error(1)
+}
+
+func (m *MockInstrumentClient) GetContractRules(ctx context.Context, conID int, isBuy bool) (*ibkr.ContractRules, error) {
+	args := m.Called(ctx, conID, isBuy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.ContractRules), args.Error(1)
+}
+
+type MockHistoryCache struct {
+	mock.Mock
+}
+
+func (m *MockHistoryCache) GetHistory(ctx context.Context, req ibkr.HistoryRequest) (*ibkr.History, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.History), args.Error(1)
+}
+
+func (m *MockHistoryCache) Invalidate(ctx context.Context, conID int) (int64, error) {
+	args := m.Called(ctx, conID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockContractResolver struct {
+	mock.Mock
+}
+
+func (m *MockContractResolver) ResolveContract(ctx context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ibkr.ResolvedContract), args.Error(1)
+}
+
+// newTestContractResolver returns a resolver that resolves any query to AAPL, conid 265598.
+func newTestContractResolver() *MockContractResolver {
+	contracts := new(MockContractResolver)
+	contracts.On("ResolveContract", mock.Anything, mock.Anything).
+		Return(&ibkr.ResolvedContract{ConID: 265598, Symbol: "AAPL", SecType: "STK", Exchange: "NASDAQ", Currency: "USD"}, nil)
+	return contracts
+}
+
 type MockPortfolioClient struct {
 	mock.Mock
 }
 
-func (m *MockPortfolioClient) GetPortfolio(ctx context.Context) ([]ibkr.Position, error) {
-	args := m.Called(ctx)
+func (m *MockPortfolioClient) GetPortfolio(ctx context.Context, accountID string) ([]ibkr.Position, error) {
+	args := m.Called(ctx, accountID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]ibkr.Position), args.Error(1)
 }
 
-func (m *MockPortfolioClient) GetAccountSummary(ctx context.Context) (*ibkr.AccountSummary, error) {
-	args := m.Called(ctx)
+func (m *MockPortfolioClient) GetAccountSummary(ctx context.Context, accountID string) (*ibkr.AccountSummary, error) {
+	args := m.Called(ctx, accountID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*ibkr.AccountSummary), args.Error(1)
 }
+
+type MockAccountClient struct {
+	mock.Mock
+}
+
+func (m *MockAccountClient) GetAccounts(ctx context.Context) ([]ibkr.Account, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ibkr.Account), args.Error(1)
+}
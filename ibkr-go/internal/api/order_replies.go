@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// replyTTL is how long a confirmation question of the Gateway can be answered
+// through ConfirmOrder after it was asked.
+const replyTTL = 5 * time.Minute
+
+// ReplyStore remembers the account each Gateway confirmation question was asked for, so
+// that a question can only be answered by the account whose order raised it.
+// *replies.Store implements it on the database.
+type ReplyStore interface {
+	// Issue records a question as asked for an account about orders of the given
+	// Gateway types, in the order they were sent.
+	Issue(ctx context.Context, accountID, replyID string, orderTypes []string) error
+	// Issued reports whether a question was asked for an account and has not expired,
+	// and returns the Gateway types of the orders it was asked about.
+	Issued(ctx context.Context, accountID, replyID string) ([]string, bool, error)
+	// Forget removes an answered question.
+	Forget(ctx context.Context, replyID string) error
+}
+
+// OrderOption configures an OrderServiceHandler.
+type OrderOption func(*OrderServiceHandler)
+
+// WithReplyStore keeps the confirmation questions of the Gateway in a store shared by
+// all server replicas, instead of in the memory of this one.
+func WithReplyStore(store ReplyStore) OrderOption {
+	return func(h *OrderServiceHandler) {
+		h.replies = store
+	}
+}
+
+// issueReply records the question of a Gateway response, if it asks one.
+func (h *OrderServiceHandler) issueReply(
+	ctx context.Context,
+	accountID string,
+	resp *ibkr.OrderResponse,
+	orderTypes []string,
+) error {
+	if !resp.NeedsConfirmation() {
+		return nil
+	}
+
+	if err := h.replies.Issue(ctx, accountID, resp.ReplyID, orderTypes); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record confirmation: %w", err))
+	}
+
+	return nil
+}
+
+// replyTracker is the ReplyStore used without a database. It only knows the questions
+// asked through this process, so a question must be answered through the replica that
+// returned it.
+type replyTracker struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	replies map[string]issuedReply
+}
+
+// issuedReply is a confirmation question asked for an account.
+type issuedReply struct {
+	accountID  string
+	orderTypes []string
+	expiresAt  time.Time
+}
+
+// newReplyTracker creates a replyTracker that forgets questions after ttl.
+func newReplyTracker(ttl time.Duration) *replyTracker {
+	return &replyTracker{
+		ttl:     ttl,
+		replies: make(map[string]issuedReply),
+	}
+}
+
+// Issue implements ReplyStore.
+func (t *replyTracker) Issue(_ context.Context, accountID, replyID string, orderTypes []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+
+	// Drop expired questions so that unanswered ones do not accumulate.
+	for id, reply := range t.replies {
+		if now.After(reply.expiresAt) {
+			delete(t.replies, id)
+		}
+	}
+
+	t.replies[replyID] = issuedReply{
+		accountID:  accountID,
+		orderTypes: orderTypes,
+		expiresAt:  now.Add(t.ttl),
+	}
+
+	return nil
+}
+
+// Issued implements ReplyStore.
+func (t *replyTracker) Issued(_ context.Context, accountID, replyID string) ([]string, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reply, ok := t.replies[replyID]
+	if !ok || reply.accountID != accountID || !time.Now().Before(reply.expiresAt) {
+		return nil, false, nil
+	}
+
+	return reply.orderTypes, true, nil
+}
+
+// Forget implements ReplyStore.
+func (t *replyTracker) Forget(_ context.Context, replyID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.replies, replyID)
+
+	return nil
+}
@@ -1,22 +1,36 @@
 package api
 
 import (
+	"cmp"
 	"context"
+	"crypto/rand"
 	"fmt"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
-	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
 	orderv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1"
 	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1/orderv1connect"
 )
 
 const (
 	// IBKR order type constants.
-	ibkrOrderTypeMarket    = "MKT"
-	ibkrOrderTypeLimit     = "LMT"
-	ibkrOrderTypeStop      = "STP"
-	ibkrOrderTypeStopLimit = "STP LMT"
+	ibkrOrderTypeMarket          = "MKT"
+	ibkrOrderTypeLimit           = "LMT"
+	ibkrOrderTypeStop            = "STP"
+	ibkrOrderTypeStopLimit       = "STP LMT"
+	ibkrOrderTypeTrail           = "TRAIL"
+	ibkrOrderTypeTrailLimit      = "TRAILLMT"
+	ibkrOrderTypeMarketOnClose   = "MOC"
+	ibkrOrderTypeLimitOnClose    = "LOC"
+	ibkrOrderTypeMarketIfTouched = "MIT"
+	ibkrOrderTypeLimitIfTouched  = "LIT"
+	ibkrOrderTypeRelative        = "REL"
+	ibkrOrderTypeMidprice        = "MIDPRICE"
+
+	// IBKR trailing type constants.
+	ibkrTrailingTypeAmount  = "amt"
+	ibkrTrailingTypePercent = "%"
 
 	// IBKR order side constants.
 	ibkrOrderSideBuy  = "BUY"
@@ -32,13 +46,32 @@ const (
 // OrderServiceHandler implements the OrderService ConnectRPC service.
 type OrderServiceHandler struct {
 	ibkrClient ibkr.OrderClient
+	accounts   *AccountResolver
+	contracts  ibkr.ContractResolver
+	replies    ReplyStore
+	orderTypes *orderTypeCache
 }
 
 // NewOrderServiceHandler creates a new OrderService handler.
-func NewOrderServiceHandler(ibkrClient ibkr.OrderClient) orderv1connect.OrderServiceHandler {
-	return &OrderServiceHandler{
+func NewOrderServiceHandler(
+	ibkrClient ibkr.OrderClient,
+	accounts *AccountResolver,
+	contracts ibkr.ContractResolver,
+	opts ...OrderOption,
+) orderv1connect.OrderServiceHandler {
+	h := &OrderServiceHandler{
 		ibkrClient: ibkrClient,
+		accounts:   accounts,
+		contracts:  contracts,
+		replies:    newReplyTracker(replyTTL),
+		orderTypes: newOrderTypeCache(orderTypeTTL),
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 // PlaceOrder places a new order.
@@ -46,42 +79,38 @@ func (h *OrderServiceHandler) PlaceOrder(
 	ctx context.Context,
 	req *connect.Request[orderv1.PlaceOrderRequest],
 ) (*connect.Response[orderv1.PlaceOrderResponse], error) {
-	// Get account ID from context (set by session middleware).
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Map proto request to IBKR request.
-	ibkrReq := &ibkr.PlaceOrderRequest{
-		SecType:   "STK", // Default to stock, could be enhanced to support other types.
-		OrderType: mapOrderType(req.Msg.Type),
-		Side:      mapOrderSide(req.Msg.Side),
-		Quantity:  req.Msg.Quantity,
-		Tif:       mapTimeInForce(req.Msg.TimeInForce),
-		Ticker:    req.Msg.Symbol,
-	}
-
-	// Set price fields based on order type.
-	if req.Msg.LimitPrice != nil {
-		ibkrReq.Price = *req.Msg.LimitPrice
+	ibkrReq, err := h.gatewayOrder(ctx, req.Msg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Place order via IBKR Gateway.
-	resp, err := h.ibkrClient.PlaceOrder(ctx, ibkrReq)
+	responses, err := h.ibkrClient.PlaceOrder(ctx, accountID, ibkrReq)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to place order: %w", err))
+		return nil, gatewayError("failed to place order", err)
+	}
+
+	if err := h.trackPlacedOrders(ctx, accountID, responses, ibkrReq); err != nil {
+		return nil, err
 	}
 
+	resp := &responses[0]
+
 	// Map IBKR response to proto response.
 	protoResp := &orderv1.PlaceOrderResponse{
-		OrderId: resp.OrderID,
-		Status:  mapOrderStatus(resp.OrderStatus),
-		Message: formatMessages(resp.Message),
+		OrderId:      resp.OrderID,
+		Status:       mapOrderResponseStatus(resp),
+		Message:      formatMessages(resp.Message),
+		Confirmation: mapOrderConfirmation(resp),
 	}
 
-	_ = accountID // Will be used for logging/auditing.
-
 	return connect.NewResponse(protoResp), nil
 }
 
@@ -90,10 +119,10 @@ func (h *OrderServiceHandler) ModifyOrder(
 	ctx context.Context,
 	req *connect.Request[orderv1.ModifyOrderRequest],
 ) (*connect.Response[orderv1.ModifyOrderResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Map proto request to IBKR request.
@@ -103,25 +132,36 @@ func (h *OrderServiceHandler) ModifyOrder(
 		ibkrReq.Quantity = *req.Msg.Quantity
 	}
 
-	if req.Msg.LimitPrice != nil {
-		ibkrReq.Price = *req.Msg.LimitPrice
+	// The Gateway fields the prices go in depend on the order type.
+	if modifiesPrices(req.Msg) {
+		order, err := h.modifiedOrder(ctx, accountID, req.Msg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := setModifiedPrices(ibkrReq, order, req.Msg); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 	}
 
 	// Modify order via IBKR Gateway.
-	resp, err := h.ibkrClient.ModifyOrder(ctx, req.Msg.OrderId, ibkrReq)
+	resp, err := h.ibkrClient.ModifyOrder(ctx, accountID, req.Msg.OrderId, ibkrReq)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to modify order: %w", err))
+		return nil, gatewayError("failed to modify order", err)
+	}
+
+	if err := h.issueReply(ctx, accountID, resp, nil); err != nil {
+		return nil, err
 	}
 
 	// Map IBKR response to proto response.
 	protoResp := &orderv1.ModifyOrderResponse{
-		OrderId: resp.OrderID,
-		Status:  mapOrderStatus(resp.OrderStatus),
-		Message: formatMessages(resp.Message),
+		OrderId:      resp.OrderID,
+		Status:       mapOrderResponseStatus(resp),
+		Message:      formatMessages(resp.Message),
+		Confirmation: mapOrderConfirmation(resp),
 	}
 
-	_ = accountID
-
 	return connect.NewResponse(protoResp), nil
 }
 
@@ -130,15 +170,15 @@ func (h *OrderServiceHandler) CancelOrder(
 	ctx context.Context,
 	req *connect.Request[orderv1.CancelOrderRequest],
 ) (*connect.Response[orderv1.CancelOrderResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Cancel order via IBKR Gateway.
-	if err := h.ibkrClient.CancelOrder(ctx, req.Msg.OrderId); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel order: %w", err))
+	if err := h.ibkrClient.CancelOrder(ctx, accountID, req.Msg.OrderId); err != nil {
+		return nil, gatewayError("failed to cancel order", err)
 	}
 
 	// Return success response.
@@ -148,66 +188,356 @@ func (h *OrderServiceHandler) CancelOrder(
 		Message: "Order cancelled successfully",
 	}
 
-	_ = accountID
+	return connect.NewResponse(protoResp), nil
+}
+
+// ConfirmOrder answers a confirmation question returned by PlaceOrder or ModifyOrder.
+func (h *OrderServiceHandler) ConfirmOrder(
+	ctx context.Context,
+	req *connect.Request[orderv1.ConfirmOrderRequest],
+) (*connect.Response[orderv1.ConfirmOrderResponse], error) {
+	// Check that the caller may use the account the order was placed for.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only questions asked for the orders of the account may be answered.
+	orderTypes, ok, err := h.replies.Issued(ctx, accountID, req.Msg.ReplyId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to look up confirmation: %w", err))
+	}
+
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("confirmation not found: %s", req.Msg.ReplyId))
+	}
+
+	// Reply to the question via IBKR Gateway.
+	responses, err := h.ibkrClient.ReplyOrder(ctx, req.Msg.ReplyId, req.Msg.Confirmed)
+	if err != nil {
+		return nil, gatewayError("failed to confirm order", err)
+	}
+
+	// The question is answered at the Gateway; one that cannot be removed expires.
+	_ = h.replies.Forget(ctx, req.Msg.ReplyId)
+
+	// The Gateway may ask a further question instead of placing the order.
+	if err := h.trackOrders(ctx, accountID, responses, orderTypes); err != nil {
+		return nil, err
+	}
+
+	resp := &responses[0]
+
+	// Map IBKR response to proto response.
+	protoResp := &orderv1.ConfirmOrderResponse{
+		OrderId:      resp.OrderID,
+		Status:       mapOrderResponseStatus(resp),
+		Message:      formatMessages(resp.Message),
+		Confirmation: mapOrderConfirmation(resp),
+	}
+
+	// Only the orders of a bracket or a group are listed.
+	if len(responses) > 1 {
+		protoResp.OrderIds = orderIDs(responses)
+	}
 
 	return connect.NewResponse(protoResp), nil
 }
 
+// PlaceBracketOrder places an entry order with a take profit and a stop loss order attached.
+func (h *OrderServiceHandler) PlaceBracketOrder(
+	ctx context.Context,
+	req *connect.Request[orderv1.PlaceBracketOrderRequest],
+) (*connect.Response[orderv1.PlaceBracketOrderResponse], error) {
+	entry := req.Msg.Entry
+
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, entry.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the entry order, which the exit orders are attached to by its client order ID.
+	parent, err := h.gatewayOrder(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	parent.COID = newClientOrderID()
+
+	exit := ibkr.PlaceOrderRequest{
+		ConID:    parent.ConID,
+		SecType:  parent.SecType,
+		Side:     mapOrderSide(oppositeSide(entry.Side)),
+		Quantity: parent.Quantity,
+		Tif:      mapTimeInForce(cmp.Or(req.Msg.ExitTimeInForce, entry.TimeInForce)),
+		Ticker:   parent.Ticker,
+		ParentID: parent.COID,
+	}
+
+	takeProfit := exit
+	takeProfit.OrderType = ibkrOrderTypeLimit
+	takeProfit.Price = req.Msg.TakeProfitPrice
+
+	stopLoss := exit
+	stopLoss.OrderType = ibkrOrderTypeStop
+	stopLoss.Price = req.Msg.StopLossPrice
+
+	// Place the orders in one request via IBKR Gateway.
+	responses, err := h.ibkrClient.PlaceOrder(ctx, accountID, parent, takeProfit, stopLoss)
+	if err != nil {
+		return nil, gatewayError("failed to place bracket order", err)
+	}
+
+	if err := h.trackPlacedOrders(ctx, accountID, responses, parent, takeProfit, stopLoss); err != nil {
+		return nil, err
+	}
+
+	resp := &responses[0]
+
+	return connect.NewResponse(&orderv1.PlaceBracketOrderResponse{
+		OrderIds:     orderIDs(responses),
+		Status:       mapOrderResponseStatus(resp),
+		Message:      formatMessages(resp.Message),
+		Confirmation: mapOrderConfirmation(resp),
+	}), nil
+}
+
+// PlaceOrderGroup places a one-cancels-all group of orders.
+func (h *OrderServiceHandler) PlaceOrderGroup(
+	ctx context.Context,
+	req *connect.Request[orderv1.PlaceOrderGroupRequest],
+) (*connect.Response[orderv1.PlaceOrderGroupResponse], error) {
+	// Resolve the account and check that the caller may use it. The orders of a group are
+	// all for the same account.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.Orders[0].AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	group := req.Msg.GetOcaGroup()
+	if group == "" {
+		group = newClientOrderID()
+	}
+
+	// Map the orders, marking them as orders of the group.
+	orders := make([]ibkr.PlaceOrderRequest, 0, len(req.Msg.Orders))
+
+	for _, msg := range req.Msg.Orders {
+		order, err := h.gatewayOrder(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		order.OCAGroup = group
+		order.IsSingleGroup = true
+		orders = append(orders, order)
+	}
+
+	// Place the orders in one request via IBKR Gateway.
+	responses, err := h.ibkrClient.PlaceOrder(ctx, accountID, orders...)
+	if err != nil {
+		return nil, gatewayError("failed to place order group", err)
+	}
+
+	if err := h.trackPlacedOrders(ctx, accountID, responses, orders...); err != nil {
+		return nil, err
+	}
+
+	resp := &responses[0]
+
+	return connect.NewResponse(&orderv1.PlaceOrderGroupResponse{
+		OrderIds:     orderIDs(responses),
+		Status:       mapOrderResponseStatus(resp),
+		Message:      formatMessages(resp.Message),
+		Confirmation: mapOrderConfirmation(resp),
+		OcaGroup:     group,
+	}), nil
+}
+
+// gatewayOrder resolves the contract of an order and maps the order to a Gateway order.
+// The returned error is a connect error.
+func (h *OrderServiceHandler) gatewayOrder(
+	ctx context.Context,
+	msg *orderv1.PlaceOrderRequest,
+) (ibkr.PlaceOrderRequest, error) {
+	// Resolve the contract of the instrument.
+	contract, err := resolveInstrument(ctx, h.contracts, msg.GetConid(), ibkr.ContractQuery{
+		Symbol:   msg.GetSymbol(),
+		SecType:  msg.GetSecType(),
+		Exchange: msg.GetExchange(),
+		Currency: msg.GetCurrency(),
+		Expiry:   msg.GetExpiry(),
+	})
+	if err != nil {
+		return ibkr.PlaceOrderRequest{}, contractError(err)
+	}
+
+	order := ibkr.PlaceOrderRequest{
+		ConID:     contract.ConID,
+		SecType:   contract.SecType,
+		OrderType: mapOrderType(msg.Type),
+		Side:      mapOrderSide(msg.Side),
+		Quantity:  msg.Quantity,
+		Tif:       mapTimeInForce(msg.TimeInForce),
+		Ticker:    contract.Symbol,
+	}
+
+	// Set price fields based on order type.
+	setOrderPrices(&order, msg)
+
+	return order, nil
+}
+
+// newClientOrderID generates a client order ID, also used to name one-cancels-all groups.
+func newClientOrderID() string {
+	return rand.Text()
+}
+
+// orderIDs lists the IDs of the orders placed, or returns nil if the Gateway asked a
+// question instead.
+func orderIDs(responses []ibkr.OrderResponse) []string {
+	if responses[0].NeedsConfirmation() {
+		return nil
+	}
+
+	ids := make([]string, 0, len(responses))
+	for i := range responses {
+		ids = append(ids, responses[i].OrderID)
+	}
+
+	return ids
+}
+
+// trackPlacedOrders remembers the types of orders placed via the Gateway, see trackOrders.
+func (h *OrderServiceHandler) trackPlacedOrders(
+	ctx context.Context,
+	accountID string,
+	responses []ibkr.OrderResponse,
+	orders ...ibkr.PlaceOrderRequest,
+) error {
+	orderTypes := make([]string, 0, len(orders))
+	for i := range orders {
+		orderTypes = append(orderTypes, orders[i].OrderType)
+	}
+
+	return h.trackOrders(ctx, accountID, responses, orderTypes)
+}
+
+// trackOrders remembers the types of the orders of the Gateway responses, or records the
+// question the Gateway asked instead so that it can be confirmed by the account. The
+// Gateway returns the orders of a request in the order they were sent.
+func (h *OrderServiceHandler) trackOrders(
+	ctx context.Context,
+	accountID string,
+	responses []ibkr.OrderResponse,
+	orderTypes []string,
+) error {
+	if responses[0].NeedsConfirmation() {
+		return h.issueReply(ctx, accountID, &responses[0], orderTypes)
+	}
+
+	if len(responses) != len(orderTypes) {
+		return nil
+	}
+
+	for i := range responses {
+		if responses[i].OrderID != "" {
+			h.orderTypes.set(accountID, responses[i].OrderID, mapOrderTypeFromString(orderTypes[i]))
+		}
+	}
+
+	return nil
+}
+
 // GetOrder retrieves order details.
 func (h *OrderServiceHandler) GetOrder(
 	ctx context.Context,
 	req *connect.Request[orderv1.GetOrderRequest],
 ) (*connect.Response[orderv1.GetOrderResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
+	// Find the requested order.
+	order, err := h.findOrder(ctx, accountID, req.Msg.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&orderv1.GetOrderResponse{
+		Order: mapIBKROrderToProto(order),
+	}), nil
+}
+
+// findOrder finds a live order of an account.
+func (h *OrderServiceHandler) findOrder(ctx context.Context, accountID, orderID string) (*ibkr.Order, error) {
 	// Get live orders from IBKR Gateway.
-	orders, err := h.ibkrClient.GetLiveOrders(ctx)
+	orders, err := h.ibkrClient.GetLiveOrders(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get orders: %w", err))
+		return nil, gatewayError("failed to get orders", err)
 	}
 
-	// Find the requested order.
-	for _, order := range orders {
-		if order.OrderID == req.Msg.OrderId {
-			protoOrder := mapIBKROrderToProto(&order)
+	h.orderTypes.setListed(accountID, orders)
 
-			return connect.NewResponse(&orderv1.GetOrderResponse{
-				Order: protoOrder,
-			}), nil
+	for i := range orders {
+		if orders[i].OrderID == orderID {
+			return &orders[i], nil
 		}
 	}
 
-	_ = accountID
-
 	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("order not found"))
 }
 
+// modifiedOrder returns what ModifyOrder needs to know of an order to map its prices: its
+// type and, when it takes both prices but only one is modified, its current prices. The
+// type is taken from the cache when known, to spare listing the live orders; orders missing
+// from the cache are looked up among the live orders, which fills the cache. Only orders
+// the Gateway lists with a type that is not mapped come back as ORDER_TYPE_UNSPECIFIED.
+func (h *OrderServiceHandler) modifiedOrder(
+	ctx context.Context,
+	accountID string,
+	msg *orderv1.ModifyOrderRequest,
+) (*orderv1.Order, error) {
+	if orderType, ok := h.orderTypes.get(accountID, msg.OrderId); ok {
+		takesLimit, takesStop := orderPrices(orderType)
+		if !takesLimit || !takesStop || (msg.LimitPrice != nil && msg.StopPrice != nil) {
+			return &orderv1.Order{OrderId: msg.OrderId, Type: orderType}, nil
+		}
+	}
+
+	order, err := h.findOrder(ctx, accountID, msg.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapIBKROrderToProto(order), nil
+}
+
 // ListOrders lists orders for an account.
 func (h *OrderServiceHandler) ListOrders(
 	ctx context.Context,
 	req *connect.Request[orderv1.ListOrdersRequest],
 ) (*connect.Response[orderv1.ListOrdersResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get live orders from IBKR Gateway.
-	orders, err := h.ibkrClient.GetLiveOrders(ctx)
+	orders, err := h.ibkrClient.GetLiveOrders(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get orders: %w", err))
+		return nil, gatewayError("failed to get orders", err)
 	}
 
+	h.orderTypes.setListed(accountID, orders)
+
 	// Filter and map orders.
 	protoOrders := filterAndMapOrders(orders, req.Msg.StatusFilter, req.Msg.Limit)
 
-	_ = accountID
-
 	return connect.NewResponse(&orderv1.ListOrdersResponse{
 		Orders: protoOrders,
 	}), nil
@@ -251,11 +581,176 @@ func mapOrderType(protoType orderv1.OrderType) string {
 		return ibkrOrderTypeStop
 	case orderv1.OrderType_ORDER_TYPE_STOP_LIMIT:
 		return ibkrOrderTypeStopLimit
+	case orderv1.OrderType_ORDER_TYPE_TRAIL:
+		return ibkrOrderTypeTrail
+	case orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT:
+		return ibkrOrderTypeTrailLimit
+	case orderv1.OrderType_ORDER_TYPE_MOC:
+		return ibkrOrderTypeMarketOnClose
+	case orderv1.OrderType_ORDER_TYPE_LOC:
+		return ibkrOrderTypeLimitOnClose
+	case orderv1.OrderType_ORDER_TYPE_MIT:
+		return ibkrOrderTypeMarketIfTouched
+	case orderv1.OrderType_ORDER_TYPE_LIT:
+		return ibkrOrderTypeLimitIfTouched
+	case orderv1.OrderType_ORDER_TYPE_REL:
+		return ibkrOrderTypeRelative
+	case orderv1.OrderType_ORDER_TYPE_MIDPRICE:
+		return ibkrOrderTypeMidprice
 	default:
 		return ibkrOrderTypeMarket
 	}
 }
 
+func mapTrailingType(protoType orderv1.TrailingType) string {
+	switch protoType {
+	case orderv1.TrailingType_TRAILING_TYPE_AMOUNT:
+		return ibkrTrailingTypeAmount
+	case orderv1.TrailingType_TRAILING_TYPE_PERCENT:
+		return ibkrTrailingTypePercent
+	case orderv1.TrailingType_TRAILING_TYPE_UNSPECIFIED:
+		return ""
+	default:
+		return ""
+	}
+}
+
+// setOrderPrices sets the prices of a Gateway order from the prices of the request that
+// its order type takes, see ibkr.PlaceOrderRequest.
+func setOrderPrices(ibkrReq *ibkr.PlaceOrderRequest, msg *orderv1.PlaceOrderRequest) {
+	ibkrReq.Price, ibkrReq.AuxPrice = gatewayPrices(msg.Type, msg.GetLimitPrice(), msg.GetStopPrice())
+
+	// The offset of relative orders is their auxiliary price.
+	if msg.Offset != nil {
+		ibkrReq.AuxPrice = *msg.Offset
+	}
+
+	if msg.TrailingAmount != nil {
+		ibkrReq.TrailingAmt = *msg.TrailingAmount
+		ibkrReq.TrailingType = mapTrailingType(msg.TrailingType)
+	}
+}
+
+// orderPrices reports whether orders of a type take a limit price and a stop price. The
+// stop price is the trigger price of if touched orders.
+func orderPrices(orderType orderv1.OrderType) (limit, stop bool) {
+	switch orderType {
+	case orderv1.OrderType_ORDER_TYPE_LIMIT,
+		orderv1.OrderType_ORDER_TYPE_LOC,
+		orderv1.OrderType_ORDER_TYPE_REL,
+		orderv1.OrderType_ORDER_TYPE_MIDPRICE:
+		return true, false
+	case orderv1.OrderType_ORDER_TYPE_STOP,
+		orderv1.OrderType_ORDER_TYPE_TRAIL,
+		orderv1.OrderType_ORDER_TYPE_MIT:
+		return false, true
+	case orderv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+		orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT,
+		orderv1.OrderType_ORDER_TYPE_LIT:
+		return true, true
+	case orderv1.OrderType_ORDER_TYPE_UNSPECIFIED,
+		orderv1.OrderType_ORDER_TYPE_MARKET,
+		orderv1.OrderType_ORDER_TYPE_MOC:
+		return false, false
+	default:
+		return false, false
+	}
+}
+
+// gatewayPrices maps the limit and stop prices of an order to the Gateway's price and
+// auxiliary price: orders with a single price send it as the price, orders with both send
+// the limit price as the price and the stop price as the auxiliary price.
+func gatewayPrices(orderType orderv1.OrderType, limitPrice, stopPrice float64) (price, auxPrice float64) {
+	switch limit, stop := orderPrices(orderType); {
+	case limit && stop:
+		return limitPrice, stopPrice
+	case stop:
+		return stopPrice, 0
+	default:
+		return limitPrice, 0
+	}
+}
+
+// modifiesPrices reports whether a modification changes any of the prices of an order,
+// including the offset of relative orders and the trailing amount of trailing orders.
+func modifiesPrices(msg *orderv1.ModifyOrderRequest) bool {
+	return msg.LimitPrice != nil || msg.StopPrice != nil || msg.Offset != nil || msg.TrailingAmount != nil
+}
+
+// setModifiedPrices sets the prices of a modified Gateway order. A price that is not
+// modified keeps its current value, as the Gateway takes both prices of an order together.
+// The prices of orders of types that are not mapped are sent as given: the limit price as
+// the price and the stop price as the auxiliary price, with the offset and trailing amount
+// forwarded as for placed orders.
+func setModifiedPrices(ibkrReq *ibkr.ModifyOrderRequest, order *orderv1.Order, msg *orderv1.ModifyOrderRequest) error {
+	if order.Type == orderv1.OrderType_ORDER_TYPE_UNSPECIFIED {
+		ibkrReq.Price, ibkrReq.AuxPrice = msg.GetLimitPrice(), msg.GetStopPrice()
+		setModifiedOffsets(ibkrReq, msg)
+
+		return nil
+	}
+
+	trailing := order.Type == orderv1.OrderType_ORDER_TYPE_TRAIL || order.Type == orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT
+	if msg.TrailingAmount != nil && !trailing {
+		return fmt.Errorf("%s orders do not take a trailing amount", order.Type)
+	}
+
+	if msg.Offset != nil && order.Type != orderv1.OrderType_ORDER_TYPE_REL {
+		return fmt.Errorf("%s orders do not take an offset", order.Type)
+	}
+
+	takesLimit, takesStop := orderPrices(order.Type)
+	if msg.LimitPrice != nil && !takesLimit {
+		return fmt.Errorf("%s orders do not take a limit price", order.Type)
+	}
+
+	if msg.StopPrice != nil && !takesStop {
+		return fmt.Errorf("%s orders do not take a stop price", order.Type)
+	}
+
+	limitPrice := order.GetLimitPrice()
+	if msg.LimitPrice != nil {
+		limitPrice = *msg.LimitPrice
+	}
+
+	stopPrice := order.GetStopPrice()
+	if msg.StopPrice != nil {
+		stopPrice = *msg.StopPrice
+	}
+
+	ibkrReq.Price, ibkrReq.AuxPrice = gatewayPrices(order.Type, limitPrice, stopPrice)
+	setModifiedOffsets(ibkrReq, msg)
+
+	return nil
+}
+
+// setModifiedOffsets sets the offset and trailing amount of a modified Gateway order, as
+// setOrderPrices does for placed orders.
+func setModifiedOffsets(ibkrReq *ibkr.ModifyOrderRequest, msg *orderv1.ModifyOrderRequest) {
+	// The offset of relative orders is their auxiliary price.
+	if msg.Offset != nil {
+		ibkrReq.AuxPrice = *msg.Offset
+	}
+
+	if msg.TrailingAmount != nil {
+		ibkrReq.TrailingAmt = *msg.TrailingAmount
+		ibkrReq.TrailingType = mapTrailingType(msg.TrailingType)
+	}
+}
+
+func oppositeSide(side orderv1.OrderSide) orderv1.OrderSide {
+	switch side {
+	case orderv1.OrderSide_ORDER_SIDE_BUY:
+		return orderv1.OrderSide_ORDER_SIDE_SELL
+	case orderv1.OrderSide_ORDER_SIDE_SELL:
+		return orderv1.OrderSide_ORDER_SIDE_BUY
+	case orderv1.OrderSide_ORDER_SIDE_UNSPECIFIED:
+		return orderv1.OrderSide_ORDER_SIDE_UNSPECIFIED
+	default:
+		return orderv1.OrderSide_ORDER_SIDE_UNSPECIFIED
+	}
+}
+
 func mapOrderSide(protoSide orderv1.OrderSide) string {
 	switch protoSide {
 	case orderv1.OrderSide_ORDER_SIDE_UNSPECIFIED:
@@ -303,6 +798,28 @@ func mapOrderStatus(ibkrStatus string) orderv1.OrderStatus {
 	}
 }
 
+// mapOrderResponseStatus maps the status of an order response, including unanswered questions.
+func mapOrderResponseStatus(resp *ibkr.OrderResponse) orderv1.OrderStatus {
+	if resp.NeedsConfirmation() {
+		return orderv1.OrderStatus_ORDER_STATUS_CONFIRMATION_REQUIRED
+	}
+
+	return mapOrderStatus(resp.OrderStatus)
+}
+
+// mapOrderConfirmation maps an unanswered Gateway question, or returns nil if there is none.
+func mapOrderConfirmation(resp *ibkr.OrderResponse) *orderv1.OrderConfirmation {
+	if !resp.NeedsConfirmation() {
+		return nil
+	}
+
+	return &orderv1.OrderConfirmation{
+		ReplyId:    resp.ReplyID,
+		Messages:   resp.Message,
+		MessageIds: resp.MessageIDs,
+	}
+}
+
 func mapOrderStatusFromString(status string) orderv1.OrderStatus {
 	return mapOrderStatus(status)
 }
@@ -333,8 +850,24 @@ func mapIBKROrderToProto(ibkrOrder *ibkr.Order) *orderv1.Order {
 		TimeInForce:    orderv1.TimeInForce_TIME_IN_FORCE_DAY, // Default, IBKR doesn't return this.
 	}
 
-	if ibkrOrder.Price > 0 {
-		order.LimitPrice = &ibkrOrder.Price
+	// Orders with a single stop price may list it as either price.
+	var limitPrice, stopPrice float64
+
+	switch takesLimit, takesStop := orderPrices(order.Type); {
+	case takesLimit && takesStop:
+		limitPrice, stopPrice = ibkrOrder.Price, ibkrOrder.AuxPrice
+	case takesStop:
+		stopPrice = cmp.Or(ibkrOrder.AuxPrice, ibkrOrder.Price)
+	default:
+		limitPrice = ibkrOrder.Price
+	}
+
+	if limitPrice > 0 {
+		order.LimitPrice = &limitPrice
+	}
+
+	if stopPrice > 0 {
+		order.StopPrice = &stopPrice
 	}
 
 	return order
@@ -351,16 +884,34 @@ func mapOrderSideFromString(side string) orderv1.OrderSide {
 	}
 }
 
+// mapOrderTypeFromString maps a Gateway order type, either the code orders are placed
+// with, e.g. "STP LMT", or the name orders are listed with, e.g. "Stop Limit".
 func mapOrderTypeFromString(orderType string) orderv1.OrderType {
-	switch orderType {
-	case ibkrOrderTypeMarket:
+	switch strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(orderType)), "_", " ") {
+	case ibkrOrderTypeMarket, "MARKET":
 		return orderv1.OrderType_ORDER_TYPE_MARKET
-	case ibkrOrderTypeLimit:
+	case ibkrOrderTypeLimit, "LIMIT":
 		return orderv1.OrderType_ORDER_TYPE_LIMIT
-	case ibkrOrderTypeStop:
+	case ibkrOrderTypeStop, "STOP":
 		return orderv1.OrderType_ORDER_TYPE_STOP
-	case ibkrOrderTypeStopLimit:
+	case ibkrOrderTypeStopLimit, "STOP LIMIT":
 		return orderv1.OrderType_ORDER_TYPE_STOP_LIMIT
+	case ibkrOrderTypeTrail, "TRAILING STOP":
+		return orderv1.OrderType_ORDER_TYPE_TRAIL
+	case ibkrOrderTypeTrailLimit, "TRAIL LIMIT", "TRAILING STOP LIMIT":
+		return orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT
+	case ibkrOrderTypeMarketOnClose, "MARKET ON CLOSE":
+		return orderv1.OrderType_ORDER_TYPE_MOC
+	case ibkrOrderTypeLimitOnClose, "LIMIT ON CLOSE":
+		return orderv1.OrderType_ORDER_TYPE_LOC
+	case ibkrOrderTypeMarketIfTouched, "MARKET IF TOUCHED":
+		return orderv1.OrderType_ORDER_TYPE_MIT
+	case ibkrOrderTypeLimitIfTouched, "LIMIT IF TOUCHED":
+		return orderv1.OrderType_ORDER_TYPE_LIT
+	case ibkrOrderTypeRelative, "RELATIVE":
+		return orderv1.OrderType_ORDER_TYPE_REL
+	case ibkrOrderTypeMidprice:
+		return orderv1.OrderType_ORDER_TYPE_MIDPRICE
 	default:
 		return orderv1.OrderType_ORDER_TYPE_UNSPECIFIED
 	}
@@ -2,25 +2,31 @@ package api
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
+	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
 	orderv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestPlaceOrder(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	// Setup context with account ID
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 
 	// Setup request
 	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
-		Symbol:      "AAPL",
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
 		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
 		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
 		Quantity:    10,
@@ -28,10 +34,10 @@ func TestPlaceOrder(t *testing.T) {
 	})
 
 	// Mock behavior
-	mockClient.On("PlaceOrder", ctx, mock.Anything).Return(&ibkr.OrderResponse{
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.Anything).Return([]ibkr.OrderResponse{{
 		OrderID:     "1001",
 		OrderStatus: "Submitted",
-	}, nil)
+	}}, nil)
 
 	resp, err := handler.PlaceOrder(ctx, req)
 	if err != nil {
@@ -45,7 +51,7 @@ func TestPlaceOrder(t *testing.T) {
 
 func TestPlaceOrder_NoAccount(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	ctx := context.Background() // No account ID
 	req := connect.NewRequest(&orderv1.PlaceOrderRequest{})
@@ -61,7 +67,7 @@ func TestPlaceOrder_NoAccount(t *testing.T) {
 
 func TestGetOrder(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&orderv1.GetOrderRequest{OrderId: "1001"})
@@ -70,7 +76,7 @@ func TestGetOrder(t *testing.T) {
 		{OrderID: "1001", Status: "Submitted", Ticker: "AAPL"},
 	}
 
-	mockClient.On("GetLiveOrders", ctx).Return(orders, nil)
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return(orders, nil)
 
 	resp, err := handler.GetOrder(ctx, req)
 	if err != nil {
@@ -84,12 +90,12 @@ func TestGetOrder(t *testing.T) {
 
 func TestCancelOrder(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&orderv1.CancelOrderRequest{OrderId: "1001"})
 
-	mockClient.On("CancelOrder", ctx, "1001").Return(nil)
+	mockClient.On("CancelOrder", ctx, "U12345", "1001").Return(nil)
 
 	resp, err := handler.CancelOrder(ctx, req)
 	if err != nil {
@@ -103,7 +109,7 @@ func TestCancelOrder(t *testing.T) {
 
 func TestModifyOrder(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	qty := 20.0
@@ -112,7 +118,7 @@ func TestModifyOrder(t *testing.T) {
 		Quantity: &qty,
 	})
 
-	mockClient.On("ModifyOrder", ctx, "1001", mock.Anything).Return(&ibkr.OrderResponse{
+	mockClient.On("ModifyOrder", ctx, "U12345", "1001", mock.Anything).Return(&ibkr.OrderResponse{
 		OrderID:     "1001",
 		OrderStatus: "Submitted",
 	}, nil)
@@ -125,11 +131,313 @@ func TestModifyOrder(t *testing.T) {
 	if resp.Msg.OrderId != "1001" {
 		t.Errorf("OrderID = %v, want 1001", resp.Msg.OrderId)
 	}
+
+	// Modifying the quantity alone does not need the order type.
+	mockClient.AssertNotCalled(t, "GetLiveOrders", mock.Anything, mock.Anything)
+}
+
+func TestModifyOrder_StopPrice(t *testing.T) {
+	stop := 140.0
+
+	tests := []struct {
+		name      string
+		orderType string
+		want      ibkr.ModifyOrderRequest
+	}{
+		{name: "stop", orderType: "STP", want: ibkr.ModifyOrderRequest{Price: stop}},
+		{name: "stop limit keeps its limit price", orderType: "STP LMT", want: ibkr.ModifyOrderRequest{
+			Price:    150,
+			AuxPrice: stop,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockOrderClient)
+			handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+			ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+			req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", StopPrice: &stop})
+
+			mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+				{OrderID: "1001", OrigOrderType: tt.orderType, Price: 150, AuxPrice: 145},
+			}, nil)
+			mockClient.On("ModifyOrder", ctx, "U12345", "1001", &tt.want).
+				Return(&ibkr.OrderResponse{OrderID: "1001", OrderStatus: "Submitted"}, nil)
+
+			if _, err := handler.ModifyOrder(ctx, req); err != nil {
+				t.Fatalf("ModifyOrder() error = %v", err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModifyOrder_PriceNotTaken(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	limit := 150.0
+	req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", LimitPrice: &limit})
+
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+		{OrderID: "1001", OrigOrderType: "Stop", Price: 145},
+	}, nil)
+
+	_, err := handler.ModifyOrder(ctx, req)
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+	}
+
+	mockClient.AssertNotCalled(t, "ModifyOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModifyOrder_OffsetAndTrailing(t *testing.T) {
+	offset, trailing := 0.05, 1.5
+
+	tests := []struct {
+		name      string
+		orderType string
+		msg       *orderv1.ModifyOrderRequest
+		want      *ibkr.ModifyOrderRequest
+	}{
+		{
+			name:      "relative offset keeps its limit price",
+			orderType: "REL",
+			msg:       &orderv1.ModifyOrderRequest{OrderId: "1001", Offset: &offset},
+			want:      &ibkr.ModifyOrderRequest{Price: 150, AuxPrice: offset},
+		},
+		{
+			name:      "trailing amount keeps its stop price",
+			orderType: "TRAIL",
+			msg: &orderv1.ModifyOrderRequest{
+				OrderId:        "1001",
+				TrailingAmount: &trailing,
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_PERCENT,
+			},
+			want: &ibkr.ModifyOrderRequest{Price: 145, TrailingAmt: trailing, TrailingType: "%"},
+		},
+		{
+			name:      "offset of an order that takes none",
+			orderType: "LMT",
+			msg:       &orderv1.ModifyOrderRequest{OrderId: "1001", Offset: &offset},
+		},
+		{
+			name:      "trailing amount of an order that takes none",
+			orderType: "STP",
+			msg: &orderv1.ModifyOrderRequest{
+				OrderId:        "1001",
+				TrailingAmount: &trailing,
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_AMOUNT,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockOrderClient)
+			handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+			ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+			mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+				{OrderID: "1001", OrigOrderType: tt.orderType, Price: 150, AuxPrice: 145},
+			}, nil)
+
+			if tt.want == nil {
+				_, err := handler.ModifyOrder(ctx, connect.NewRequest(tt.msg))
+				if connect.CodeOf(err) != connect.CodeInvalidArgument {
+					t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+				}
+
+				mockClient.AssertNotCalled(t, "ModifyOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			mockClient.On("ModifyOrder", ctx, "U12345", "1001", tt.want).
+				Return(&ibkr.OrderResponse{OrderID: "1001", OrderStatus: "Submitted"}, nil)
+
+			if _, err := handler.ModifyOrder(ctx, connect.NewRequest(tt.msg)); err != nil {
+				t.Fatalf("ModifyOrder() error = %v", err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModifyOrder_CachedType(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	stop := 140.0
+
+	// The type of an order placed through the service is known without listing orders.
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.Anything).
+		Return([]ibkr.OrderResponse{{OrderID: "1001", OrderStatus: "Submitted"}}, nil)
+
+	if _, err := handler.PlaceOrder(ctx, connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument: &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+		Side:       orderv1.OrderSide_ORDER_SIDE_SELL,
+		Type:       orderv1.OrderType_ORDER_TYPE_STOP,
+		Quantity:   10,
+		StopPrice:  &stop,
+	})); err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	mockClient.On("ModifyOrder", ctx, "U12345", "1001", &ibkr.ModifyOrderRequest{Price: stop}).
+		Return(&ibkr.OrderResponse{OrderID: "1001", OrderStatus: "Submitted"}, nil).Twice()
+
+	for range 2 {
+		req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", StopPrice: &stop})
+		if _, err := handler.ModifyOrder(ctx, req); err != nil {
+			t.Fatalf("ModifyOrder() error = %v", err)
+		}
+	}
+
+	mockClient.AssertNotCalled(t, "GetLiveOrders", mock.Anything, mock.Anything)
+
+	// One listing serves the modifications of all the orders listed.
+	limit := 155.0
+
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+		{OrderID: "1002", OrigOrderType: "Limit", Price: 150},
+		{OrderID: "1003", OrigOrderType: "Limit", Price: 151},
+	}, nil).Once()
+	mockClient.On("ModifyOrder", ctx, "U12345", mock.Anything, &ibkr.ModifyOrderRequest{Price: limit}).
+		Return(&ibkr.OrderResponse{OrderStatus: "Submitted"}, nil).Twice()
+
+	for _, orderID := range []string{"1002", "1003"} {
+		req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: orderID, LimitPrice: &limit})
+		if _, err := handler.ModifyOrder(ctx, req); err != nil {
+			t.Fatalf("ModifyOrder(%s) error = %v", orderID, err)
+		}
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestModifyOrder_UnmappedType(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	limit, stop := 150.0, 140.0
+	req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", LimitPrice: &limit, StopPrice: &stop})
+
+	// Prices of orders of unmapped types are forwarded as given.
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+		{OrderID: "1001", OrigOrderType: "Pegged to Market", Price: 145},
+	}, nil)
+	mockClient.On("ModifyOrder", ctx, "U12345", "1001", &ibkr.ModifyOrderRequest{Price: limit, AuxPrice: stop}).
+		Return(&ibkr.OrderResponse{OrderID: "1001", OrderStatus: "Submitted"}, nil)
+
+	if _, err := handler.ModifyOrder(ctx, req); err != nil {
+		t.Fatalf("ModifyOrder() error = %v", err)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestModifyOrder_UnknownCachedType(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	limit := 150.0
+	req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", LimitPrice: &limit})
+
+	// A placed order whose type is not known is validated against the type the Gateway lists.
+	orderTypes := handler.(*OrderServiceHandler).orderTypes
+	orderTypes.set("U12345", "1001", orderv1.OrderType_ORDER_TYPE_UNSPECIFIED)
+
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{
+		{OrderID: "1001", OrigOrderType: "Stop", Price: 145},
+	}, nil).Once()
+
+	_, err := handler.ModifyOrder(ctx, req)
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Code = %v, want InvalidArgument", connect.CodeOf(err))
+	}
+
+	// The listing fills the cache.
+	if orderType, ok := orderTypes.get("U12345", "1001"); !ok || orderType != orderv1.OrderType_ORDER_TYPE_STOP {
+		t.Errorf("Cached type = %v, %v, want STOP", orderType, ok)
+	}
+
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "ModifyOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModifyOrder_NotFound(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	stop := 140.0
+	req := connect.NewRequest(&orderv1.ModifyOrderRequest{OrderId: "1001", StopPrice: &stop})
+
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return([]ibkr.Order{}, nil)
+
+	_, err := handler.ModifyOrder(ctx, req)
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Code = %v, want NotFound", connect.CodeOf(err))
+	}
+}
+
+func TestMapIBKROrderToProto_Prices(t *testing.T) {
+	tests := []struct {
+		name      string
+		order     ibkr.Order
+		wantLimit *float64
+		wantStop  *float64
+	}{
+		{
+			name:      "limit",
+			order:     ibkr.Order{OrigOrderType: "Limit", Price: 150},
+			wantLimit: proto.Float64(150),
+		},
+		{
+			name:     "stop listed with its price",
+			order:    ibkr.Order{OrigOrderType: "Stop", Price: 145},
+			wantStop: proto.Float64(145),
+		},
+		{
+			name:     "stop listed with its auxiliary price",
+			order:    ibkr.Order{OrigOrderType: "Stop", AuxPrice: 145},
+			wantStop: proto.Float64(145),
+		},
+		{
+			name:      "stop limit",
+			order:     ibkr.Order{OrigOrderType: "Stop Limit", Price: 150, AuxPrice: 145},
+			wantLimit: proto.Float64(150),
+			wantStop:  proto.Float64(145),
+		},
+		{
+			name:  "market",
+			order: ibkr.Order{OrigOrderType: "Market"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := mapIBKROrderToProto(&tt.order)
+
+			if !reflect.DeepEqual(order.LimitPrice, tt.wantLimit) || !reflect.DeepEqual(order.StopPrice, tt.wantStop) {
+				t.Errorf("prices = %v, %v, want %v, %v", order.LimitPrice, order.StopPrice, tt.wantLimit, tt.wantStop)
+			}
+		})
+	}
 }
 
 func TestListOrders(t *testing.T) {
 	mockClient := new(MockOrderClient)
-	handler := NewOrderServiceHandler(mockClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	limit := int32(10)
@@ -141,7 +449,7 @@ func TestListOrders(t *testing.T) {
 		{OrderID: "1001", Status: "Submitted", Ticker: "AAPL"},
 		{OrderID: "1002", Status: "Filled", Ticker: "GOOGL"},
 	}
-	mockClient.On("GetLiveOrders", ctx).Return(orders, nil)
+	mockClient.On("GetLiveOrders", ctx, "U12345").Return(orders, nil)
 
 	resp, err := handler.ListOrders(ctx, req)
 	if err != nil {
@@ -152,3 +460,890 @@ func TestListOrders(t *testing.T) {
 		t.Errorf("Orders count = %v, want 2", len(resp.Msg.Orders))
 	}
 }
+
+func TestPlaceOrder_ConfirmationRequired(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
+		Quantity:    10,
+		TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+	})
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.Anything).Return([]ibkr.OrderResponse{{
+		ReplyID:    "a1b2",
+		Message:    []string{"The order price exceeds the price cap."},
+		MessageIDs: []string{"o163"},
+	}}, nil)
+
+	resp, err := handler.PlaceOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	if resp.Msg.Status != orderv1.OrderStatus_ORDER_STATUS_CONFIRMATION_REQUIRED {
+		t.Errorf("Status = %v, want CONFIRMATION_REQUIRED", resp.Msg.Status)
+	}
+	if resp.Msg.Confirmation.GetReplyId() != "a1b2" {
+		t.Errorf("ReplyId = %v, want a1b2", resp.Msg.Confirmation.GetReplyId())
+	}
+	if len(resp.Msg.Confirmation.GetMessageIds()) != 1 {
+		t.Errorf("Expected 1 message ID, got %d", len(resp.Msg.Confirmation.GetMessageIds()))
+	}
+}
+
+func TestConfirmOrder(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+	_ = handler.(*OrderServiceHandler).replies.Issue(context.Background(), "U12345", "a1b2", nil)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.ConfirmOrderRequest{
+		AccountId: "U12345",
+		ReplyId:   "a1b2",
+		Confirmed: true,
+	})
+
+	mockClient.On("ReplyOrder", ctx, "a1b2", true).Return([]ibkr.OrderResponse{{
+		OrderID:     "1001",
+		OrderStatus: "Submitted",
+	}}, nil)
+
+	resp, err := handler.ConfirmOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("ConfirmOrder() error = %v", err)
+	}
+
+	if resp.Msg.OrderId != "1001" {
+		t.Errorf("OrderID = %v, want 1001", resp.Msg.OrderId)
+	}
+	if resp.Msg.Status != orderv1.OrderStatus_ORDER_STATUS_SUBMITTED {
+		t.Errorf("Status = %v, want SUBMITTED", resp.Msg.Status)
+	}
+	if resp.Msg.Confirmation != nil {
+		t.Errorf("Confirmation = %v, want nil", resp.Msg.Confirmation)
+	}
+}
+
+func TestConfirmOrder_ReplyOfAnotherAccount(t *testing.T) {
+	accountClient := new(MockAccountClient)
+	accountClient.On("GetAccounts", mock.Anything).Return(advisorAccounts(), nil)
+
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, NewAccountResolver(accountClient, time.Minute), newTestContractResolver())
+
+	// The question was asked for an order of U33333.
+	mockClient.On("PlaceOrder", mock.Anything, "U33333", mock.Anything).Return([]ibkr.OrderResponse{{
+		ReplyID:    "a1b2",
+		MessageIDs: []string{"o163"},
+	}}, nil)
+
+	placeCtx := middleware.SetAccountIDInContext(context.Background(), "U33333")
+	if _, err := handler.PlaceOrder(placeCtx, connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument: &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+		Side:       orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:       orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:   10,
+	})); err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		caller    string
+		replyID   string
+		wantFound bool
+	}{
+		{name: "other account", caller: "F11111", replyID: "a1b2"},
+		{name: "unknown reply", caller: "U33333", replyID: "c3d4"},
+		{name: "own reply", caller: "U33333", replyID: "a1b2", wantFound: true},
+	}
+
+	mockClient.On("ReplyOrder", mock.Anything, "a1b2", true).Return([]ibkr.OrderResponse{{
+		OrderID:     "1001",
+		OrderStatus: "Submitted",
+	}}, nil).Once()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := middleware.SetAccountIDInContext(context.Background(), tt.caller)
+			req := connect.NewRequest(&orderv1.ConfirmOrderRequest{ReplyId: tt.replyID, Confirmed: true})
+
+			_, err := handler.ConfirmOrder(ctx, req)
+			if tt.wantFound {
+				if err != nil {
+					t.Fatalf("ConfirmOrder() error = %v", err)
+				}
+
+				return
+			}
+
+			if connect.CodeOf(err) != connect.CodeNotFound {
+				t.Errorf("ConfirmOrder() error = %v, want NotFound", err)
+			}
+		})
+	}
+
+	// An answered question cannot be answered again.
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U33333")
+	if _, err := handler.ConfirmOrder(ctx, connect.NewRequest(&orderv1.ConfirmOrderRequest{
+		ReplyId:   "a1b2",
+		Confirmed: true,
+	})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("second ConfirmOrder() error = %v, want NotFound", err)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlaceOrder_Future(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	contracts := new(MockContractResolver)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), contracts)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	secType, expiry := "FUT", "202409"
+	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "ES"},
+		SecType:     &secType,
+		Expiry:      &expiry,
+		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:    1,
+		TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+	})
+
+	contracts.On("ResolveContract", ctx, ibkr.ContractQuery{Symbol: "ES", SecType: "FUT", Expiry: "202409"}).
+		Return(&ibkr.ResolvedContract{ConID: 568550526, Symbol: "ES", SecType: "FUT", Expiry: "20240920"}, nil)
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.MatchedBy(func(orders []ibkr.PlaceOrderRequest) bool {
+		return len(orders) == 1 && orders[0].ConID == 568550526 && orders[0].SecType == "FUT" && orders[0].Ticker == "ES"
+	})).Return([]ibkr.OrderResponse{{OrderID: "1002", OrderStatus: "Submitted"}}, nil)
+
+	resp, err := handler.PlaceOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	if resp.Msg.OrderId != "1002" {
+		t.Errorf("OrderID = %v, want 1002", resp.Msg.OrderId)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlaceOrder_Conid(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	contracts := new(MockContractResolver)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), contracts)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument:  &orderv1.PlaceOrderRequest_Conid{Conid: 72063691},
+		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:    1,
+		TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+	})
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.MatchedBy(func(orders []ibkr.PlaceOrderRequest) bool {
+		return len(orders) == 1 && orders[0].ConID == 72063691
+	})).Return([]ibkr.OrderResponse{{OrderID: "1003", OrderStatus: "Submitted"}}, nil)
+
+	if _, err := handler.PlaceOrder(ctx, req); err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	// The contract ID is used as is, without a search.
+	contracts.AssertNotCalled(t, "ResolveContract", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlaceOrderRequest_Instrument(t *testing.T) {
+	bySymbol := func(symbol string) *orderv1.PlaceOrderRequest {
+		return &orderv1.PlaceOrderRequest{Instrument: &orderv1.PlaceOrderRequest_Symbol{Symbol: symbol}}
+	}
+
+	byConid := func(conID int64) *orderv1.PlaceOrderRequest {
+		return &orderv1.PlaceOrderRequest{Instrument: &orderv1.PlaceOrderRequest_Conid{Conid: conID}}
+	}
+
+	tests := []struct {
+		name  string
+		msg   *orderv1.PlaceOrderRequest
+		valid bool
+	}{
+		{name: "symbol", msg: bySymbol("AAPL"), valid: true},
+		{name: "class share with a dot", msg: bySymbol("BRK.B"), valid: true},
+		{name: "class share with a space", msg: bySymbol("BRK B"), valid: true},
+		{name: "class share with a dash", msg: bySymbol("RDS-A"), valid: true},
+		{name: "numeric", msg: bySymbol("7203"), valid: true},
+		{name: "FX pair", msg: bySymbol("EUR.USD"), valid: true},
+		{name: "lower case", msg: bySymbol("aapl")},
+		{name: "trailing separator", msg: bySymbol("BRK.")},
+		{name: "double separator", msg: bySymbol("BRK..B")},
+		{name: "conid", msg: byConid(265598), valid: true},
+		{name: "zero conid", msg: byConid(0)},
+		{name: "missing", msg: &orderv1.PlaceOrderRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg
+			msg.AccountId = "U12345"
+			msg.Side = orderv1.OrderSide_ORDER_SIDE_BUY
+			msg.Type = orderv1.OrderType_ORDER_TYPE_MARKET
+			msg.Quantity = 1
+			msg.TimeInForce = orderv1.TimeInForce_TIME_IN_FORCE_DAY
+
+			if err := protovalidate.Validate(msg); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPlaceOrder_ContractNotFound(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	contracts := new(MockContractResolver)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), contracts)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "NOPE"},
+		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:    1,
+		TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+	})
+
+	contracts.On("ResolveContract", ctx, mock.Anything).Return(nil, ibkr.ErrContractNotFound)
+
+	_, err := handler.PlaceOrder(ctx, req)
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Code = %v, want NotFound", connect.CodeOf(err))
+	}
+
+	mockClient.AssertNotCalled(t, "PlaceOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceOrderRequest_OrderType(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name  string
+		msg   *orderv1.PlaceOrderRequest
+		valid bool
+	}{
+		{
+			name:  "limit",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LIMIT, LimitPrice: price(150)},
+			valid: true,
+		},
+		{
+			name: "limit without limit price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LIMIT},
+		},
+		{
+			name: "stop price on a limit order",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:       orderv1.OrderType_ORDER_TYPE_LIMIT,
+				LimitPrice: price(150),
+				StopPrice:  price(145),
+			},
+		},
+		{
+			name: "limit price on a market order",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MARKET, LimitPrice: price(150)},
+		},
+		{
+			name:  "stop",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_STOP, StopPrice: price(145)},
+			valid: true,
+		},
+		{
+			name: "stop without stop price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_STOP},
+		},
+		{
+			name: "limit price on a stop order",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:       orderv1.OrderType_ORDER_TYPE_STOP,
+				LimitPrice: price(150),
+				StopPrice:  price(145),
+			},
+		},
+		{
+			name: "stop limit",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:       orderv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+				LimitPrice: price(150),
+				StopPrice:  price(145),
+			},
+			valid: true,
+		},
+		{
+			name: "stop limit without stop price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_STOP_LIMIT, LimitPrice: price(150)},
+		},
+		{
+			name: "stop limit without limit price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_STOP_LIMIT, StopPrice: price(145)},
+		},
+		{
+			name:  "market on close",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MOC},
+			valid: true,
+		},
+		{
+			name:  "limit on close",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LOC, LimitPrice: price(150)},
+			valid: true,
+		},
+		{
+			name: "limit on close without limit price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LOC},
+		},
+		{
+			name: "trailing stop",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:           orderv1.OrderType_ORDER_TYPE_TRAIL,
+				TrailingAmount: price(1.5),
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_AMOUNT,
+			},
+			valid: true,
+		},
+		{
+			name: "trailing stop without trailing type",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_TRAIL, TrailingAmount: price(1.5)},
+		},
+		{
+			name: "trailing stop limit without limit price",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:           orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT,
+				TrailingAmount: price(2),
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_PERCENT,
+			},
+		},
+		{
+			name: "trailing amount on a limit order",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:           orderv1.OrderType_ORDER_TYPE_LIMIT,
+				LimitPrice:     price(150),
+				TrailingAmount: price(1.5),
+			},
+		},
+		{
+			name:  "market if touched",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MIT, StopPrice: price(145)},
+			valid: true,
+		},
+		{
+			name: "market if touched without trigger price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MIT},
+		},
+		{
+			name: "limit if touched without limit price",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LIT, StopPrice: price(145)},
+		},
+		{
+			name:  "relative",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_REL, Offset: price(0.05)},
+			valid: true,
+		},
+		{
+			name: "relative without offset",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_REL},
+		},
+		{
+			name: "offset on a midprice order",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MIDPRICE, Offset: price(0.05)},
+		},
+		{
+			name:  "midprice",
+			msg:   &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MIDPRICE},
+			valid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := tt.msg
+			msg.AccountId = "U12345"
+			msg.Instrument = &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"}
+			msg.Side = orderv1.OrderSide_ORDER_SIDE_BUY
+			msg.Quantity = 1
+			msg.TimeInForce = orderv1.TimeInForce_TIME_IN_FORCE_DAY
+
+			if err := protovalidate.Validate(msg); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestSetOrderPrices(t *testing.T) {
+	limit, stop, trailing, offset := 150.0, 145.0, 2.0, 0.05
+
+	tests := []struct {
+		name string
+		msg  *orderv1.PlaceOrderRequest
+		want ibkr.PlaceOrderRequest
+	}{
+		{
+			name: "limit",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_LIMIT, LimitPrice: &limit},
+			want: ibkr.PlaceOrderRequest{Price: limit},
+		},
+		{
+			name: "stop",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_STOP, StopPrice: &stop},
+			want: ibkr.PlaceOrderRequest{Price: stop},
+		},
+		{
+			name: "stop limit",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:       orderv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+				LimitPrice: &limit,
+				StopPrice:  &stop,
+			},
+			want: ibkr.PlaceOrderRequest{Price: limit, AuxPrice: stop},
+		},
+		{
+			name: "trailing stop",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:           orderv1.OrderType_ORDER_TYPE_TRAIL,
+				StopPrice:      &stop,
+				TrailingAmount: &trailing,
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_PERCENT,
+			},
+			want: ibkr.PlaceOrderRequest{Price: stop, TrailingAmt: trailing, TrailingType: "%"},
+		},
+		{
+			name: "trailing stop limit",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:           orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT,
+				LimitPrice:     &limit,
+				StopPrice:      &stop,
+				TrailingAmount: &trailing,
+				TrailingType:   orderv1.TrailingType_TRAILING_TYPE_AMOUNT,
+			},
+			want: ibkr.PlaceOrderRequest{Price: limit, AuxPrice: stop, TrailingAmt: trailing, TrailingType: "amt"},
+		},
+		{
+			name: "market if touched",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MIT, StopPrice: &stop},
+			want: ibkr.PlaceOrderRequest{Price: stop},
+		},
+		{
+			name: "limit if touched",
+			msg: &orderv1.PlaceOrderRequest{
+				Type:       orderv1.OrderType_ORDER_TYPE_LIT,
+				LimitPrice: &limit,
+				StopPrice:  &stop,
+			},
+			want: ibkr.PlaceOrderRequest{Price: limit, AuxPrice: stop},
+		},
+		{
+			name: "relative",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_REL, Offset: &offset},
+			want: ibkr.PlaceOrderRequest{AuxPrice: offset},
+		},
+		{
+			name: "market on close",
+			msg:  &orderv1.PlaceOrderRequest{Type: orderv1.OrderType_ORDER_TYPE_MOC},
+			want: ibkr.PlaceOrderRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ibkr.PlaceOrderRequest
+
+			setOrderPrices(&got, tt.msg)
+
+			if got != tt.want {
+				t.Errorf("setOrderPrices() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapOrderType(t *testing.T) {
+	for value := range orderv1.OrderType_name {
+		orderType := orderv1.OrderType(value)
+		if orderType == orderv1.OrderType_ORDER_TYPE_UNSPECIFIED {
+			continue
+		}
+
+		if got := mapOrderTypeFromString(mapOrderType(orderType)); got != orderType {
+			t.Errorf("mapOrderTypeFromString(mapOrderType(%v)) = %v", orderType, got)
+		}
+	}
+
+	names := map[string]orderv1.OrderType{
+		"Limit":               orderv1.OrderType_ORDER_TYPE_LIMIT,
+		"Stop Limit":          orderv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+		"STOP_LIMIT":          orderv1.OrderType_ORDER_TYPE_STOP_LIMIT,
+		"Trailing Stop":       orderv1.OrderType_ORDER_TYPE_TRAIL,
+		"TRAIL LIMIT":         orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT,
+		"Market On Close":     orderv1.OrderType_ORDER_TYPE_MOC,
+		"Limit if Touched":    orderv1.OrderType_ORDER_TYPE_LIT,
+		"Relative":            orderv1.OrderType_ORDER_TYPE_REL,
+		"midprice":            orderv1.OrderType_ORDER_TYPE_MIDPRICE,
+		"VWAP":                orderv1.OrderType_ORDER_TYPE_UNSPECIFIED,
+		"Trailing Stop Limit": orderv1.OrderType_ORDER_TYPE_TRAIL_LIMIT,
+	}
+
+	for name, want := range names {
+		if got := mapOrderTypeFromString(name); got != want {
+			t.Errorf("mapOrderTypeFromString(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestPlaceBracketOrder(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	limit := 150.0
+	req := connect.NewRequest(&orderv1.PlaceBracketOrderRequest{
+		Entry: &orderv1.PlaceOrderRequest{
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+			Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
+			Quantity:    10,
+			LimitPrice:  &limit,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+		},
+		TakeProfitPrice: 160,
+		StopLossPrice:   145,
+		ExitTimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_GTC,
+	})
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.MatchedBy(func(orders []ibkr.PlaceOrderRequest) bool {
+		if len(orders) != 3 || orders[0].COID == "" {
+			return false
+		}
+
+		entry, takeProfit, stopLoss := orders[0], orders[1], orders[2]
+
+		return entry.OrderType == "LMT" && entry.Price == 150 && entry.Tif == "DAY" &&
+			takeProfit.ParentID == entry.COID && takeProfit.OrderType == "LMT" && takeProfit.Price == 160 &&
+			stopLoss.ParentID == entry.COID && stopLoss.OrderType == "STP" && stopLoss.Price == 145 &&
+			takeProfit.Side == "SELL" && stopLoss.Side == "SELL" && stopLoss.Quantity == 10 &&
+			takeProfit.Tif == "GTC" && stopLoss.Tif == "GTC" && stopLoss.ConID == 265598
+	})).Return([]ibkr.OrderResponse{
+		{OrderID: "1001", OrderStatus: "Submitted"},
+		{OrderID: "1002", OrderStatus: "PreSubmitted"},
+		{OrderID: "1003", OrderStatus: "PreSubmitted"},
+	}, nil)
+
+	resp, err := handler.PlaceBracketOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("PlaceBracketOrder() error = %v", err)
+	}
+
+	if !slices.Equal(resp.Msg.OrderIds, []string{"1001", "1002", "1003"}) {
+		t.Errorf("OrderIds = %v, want [1001 1002 1003]", resp.Msg.OrderIds)
+	}
+
+	if resp.Msg.Status != orderv1.OrderStatus_ORDER_STATUS_SUBMITTED {
+		t.Errorf("Status = %v, want SUBMITTED", resp.Msg.Status)
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestPlaceBracketOrder_ConfirmationRequired(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.PlaceBracketOrderRequest{
+		Entry: &orderv1.PlaceOrderRequest{
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Side:        orderv1.OrderSide_ORDER_SIDE_SELL,
+			Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
+			Quantity:    10,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+		},
+		TakeProfitPrice: 140,
+		StopLossPrice:   155,
+	})
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.MatchedBy(func(orders []ibkr.PlaceOrderRequest) bool {
+		// The exit orders default to the time in force of the entry order.
+		return len(orders) == 3 && orders[1].Side == "BUY" && orders[2].Tif == "DAY"
+	})).Return([]ibkr.OrderResponse{{ReplyID: "a1b2", MessageIDs: []string{"o163"}}}, nil)
+
+	resp, err := handler.PlaceBracketOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("PlaceBracketOrder() error = %v", err)
+	}
+
+	if resp.Msg.Status != orderv1.OrderStatus_ORDER_STATUS_CONFIRMATION_REQUIRED || len(resp.Msg.OrderIds) != 0 {
+		t.Errorf("Expected a pending confirmation and no order IDs, got %v", resp.Msg)
+	}
+
+	if resp.Msg.Confirmation.GetReplyId() != "a1b2" {
+		t.Errorf("ReplyId = %v, want a1b2", resp.Msg.Confirmation.GetReplyId())
+	}
+}
+
+func TestPlaceOrderGroup(t *testing.T) {
+	limit, stop := 160.0, 145.0
+	orders := []*orderv1.PlaceOrderRequest{
+		{
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Side:        orderv1.OrderSide_ORDER_SIDE_SELL,
+			Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
+			Quantity:    10,
+			LimitPrice:  &limit,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_GTC,
+		},
+		{
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Side:        orderv1.OrderSide_ORDER_SIDE_SELL,
+			Type:        orderv1.OrderType_ORDER_TYPE_STOP,
+			Quantity:    10,
+			StopPrice:   &stop,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_GTC,
+		},
+	}
+
+	tests := []struct {
+		name  string
+		group *string
+	}{
+		{name: "named group", group: proto.String("exit-aapl")},
+		{name: "generated group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockOrderClient)
+			handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+
+			ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+			req := connect.NewRequest(&orderv1.PlaceOrderGroupRequest{Orders: orders, OcaGroup: tt.group})
+
+			var group string
+
+			mockClient.On("PlaceOrder", ctx, "U12345", mock.MatchedBy(func(orders []ibkr.PlaceOrderRequest) bool {
+				group = orders[0].OCAGroup
+
+				return len(orders) == 2 && group != "" && orders[1].OCAGroup == group &&
+					orders[0].IsSingleGroup && orders[1].IsSingleGroup &&
+					orders[0].Price == 160 && orders[1].OrderType == "STP" && orders[1].Price == 145
+			})).Return([]ibkr.OrderResponse{
+				{OrderID: "1001", OrderStatus: "Submitted"},
+				{OrderID: "1002", OrderStatus: "Submitted"},
+			}, nil)
+
+			resp, err := handler.PlaceOrderGroup(ctx, req)
+			if err != nil {
+				t.Fatalf("PlaceOrderGroup() error = %v", err)
+			}
+
+			if !slices.Equal(resp.Msg.OrderIds, []string{"1001", "1002"}) {
+				t.Errorf("OrderIds = %v, want [1001 1002]", resp.Msg.OrderIds)
+			}
+
+			if resp.Msg.OcaGroup != group || (tt.group != nil && group != *tt.group) {
+				t.Errorf("OcaGroup = %v, sent %v", resp.Msg.OcaGroup, group)
+			}
+		})
+	}
+}
+
+// failingReplyStore is a ReplyStore whose database is unavailable.
+type failingReplyStore struct{}
+
+func (failingReplyStore) Issue(context.Context, string, string, []string) error {
+	return errors.New("connection refused")
+}
+
+func (failingReplyStore) Issued(context.Context, string, string) ([]string, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingReplyStore) Forget(context.Context, string) error {
+	return errors.New("connection refused")
+}
+
+func TestConfirmOrder_SharedReplyStore(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	store := newReplyTracker(replyTTL)
+
+	// The question is asked through one replica and answered through another.
+	placing := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver(), WithReplyStore(store))
+	confirming := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver(), WithReplyStore(store))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.Anything).Return([]ibkr.OrderResponse{{
+		ReplyID:    "a1b2",
+		MessageIDs: []string{"o163"},
+	}}, nil)
+	mockClient.On("ReplyOrder", ctx, "a1b2", true).Return([]ibkr.OrderResponse{{
+		OrderID:     "1001",
+		OrderStatus: "Submitted",
+	}}, nil)
+
+	if _, err := placing.PlaceOrder(ctx, connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument: &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+		Side:       orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:       orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:   10,
+	})); err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	resp, err := confirming.ConfirmOrder(ctx, connect.NewRequest(&orderv1.ConfirmOrderRequest{ReplyId: "a1b2", Confirmed: true}))
+	if err != nil {
+		t.Fatalf("ConfirmOrder() error = %v", err)
+	}
+
+	if resp.Msg.OrderId != "1001" {
+		t.Errorf("OrderId = %v, want 1001", resp.Msg.OrderId)
+	}
+}
+
+func TestReplyStore_Unavailable(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver(),
+		WithReplyStore(failingReplyStore{}))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+
+	mockClient.On("PlaceOrder", ctx, "U12345", mock.Anything).Return([]ibkr.OrderResponse{{
+		ReplyID:    "a1b2",
+		MessageIDs: []string{"o163"},
+	}}, nil)
+
+	_, err := handler.PlaceOrder(ctx, connect.NewRequest(&orderv1.PlaceOrderRequest{
+		Instrument: &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+		Side:       orderv1.OrderSide_ORDER_SIDE_BUY,
+		Type:       orderv1.OrderType_ORDER_TYPE_MARKET,
+		Quantity:   10,
+	}))
+	if connect.CodeOf(err) != connect.CodeInternal {
+		t.Errorf("PlaceOrder() error = %v, want Internal", err)
+	}
+
+	_, err = handler.ConfirmOrder(ctx, connect.NewRequest(&orderv1.ConfirmOrderRequest{ReplyId: "a1b2", Confirmed: true}))
+	if connect.CodeOf(err) != connect.CodeInternal {
+		t.Errorf("ConfirmOrder() error = %v, want Internal", err)
+	}
+
+	mockClient.AssertNotCalled(t, "ReplyOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConfirmOrder_Bracket(t *testing.T) {
+	mockClient := new(MockOrderClient)
+	handler := NewOrderServiceHandler(mockClient, newTestAccountResolver(), newTestContractResolver())
+	_ = handler.(*OrderServiceHandler).replies.Issue(context.Background(), "U12345", "a1b2", nil)
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&orderv1.ConfirmOrderRequest{ReplyId: "a1b2", Confirmed: true})
+
+	mockClient.On("ReplyOrder", ctx, "a1b2", true).Return([]ibkr.OrderResponse{
+		{OrderID: "1001", OrderStatus: "Submitted"},
+		{OrderID: "1002", OrderStatus: "PreSubmitted"},
+		{OrderID: "1003", OrderStatus: "PreSubmitted"},
+	}, nil)
+
+	resp, err := handler.ConfirmOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("ConfirmOrder() error = %v", err)
+	}
+
+	if resp.Msg.OrderId != "1001" || !slices.Equal(resp.Msg.OrderIds, []string{"1001", "1002", "1003"}) {
+		t.Errorf("Unexpected order IDs %v and %v", resp.Msg.OrderId, resp.Msg.OrderIds)
+	}
+}
+
+func TestPlaceBracketOrderRequest_Prices(t *testing.T) {
+	bracket := func(side orderv1.OrderSide, limitPrice *float64, takeProfit, stopLoss float64) *orderv1.PlaceBracketOrderRequest {
+		orderType := orderv1.OrderType_ORDER_TYPE_MARKET
+		if limitPrice != nil {
+			orderType = orderv1.OrderType_ORDER_TYPE_LIMIT
+		}
+
+		return &orderv1.PlaceBracketOrderRequest{
+			Entry: &orderv1.PlaceOrderRequest{
+				AccountId:   "U12345",
+				Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+				Side:        side,
+				Type:        orderType,
+				Quantity:    10,
+				LimitPrice:  limitPrice,
+				TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+			},
+			TakeProfitPrice: takeProfit,
+			StopLossPrice:   stopLoss,
+		}
+	}
+
+	buy, sell := orderv1.OrderSide_ORDER_SIDE_BUY, orderv1.OrderSide_ORDER_SIDE_SELL
+
+	tests := []struct {
+		name  string
+		msg   *orderv1.PlaceBracketOrderRequest
+		valid bool
+	}{
+		{name: "buy at market", msg: bracket(buy, nil, 160, 145), valid: true},
+		{name: "buy at a limit", msg: bracket(buy, proto.Float64(150), 160, 145), valid: true},
+		{name: "buy with exits swapped", msg: bracket(buy, nil, 145, 160)},
+		{name: "buy with the limit above the take profit", msg: bracket(buy, proto.Float64(165), 160, 145)},
+		{name: "sell at a limit", msg: bracket(sell, proto.Float64(150), 140, 155), valid: true},
+		{name: "sell with exits swapped", msg: bracket(sell, nil, 155, 140)},
+		{name: "missing entry", msg: &orderv1.PlaceBracketOrderRequest{TakeProfitPrice: 160, StopLossPrice: 145}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := protovalidate.Validate(tt.msg); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPlaceOrderGroupRequest_Accounts(t *testing.T) {
+	order := func(accountID string) *orderv1.PlaceOrderRequest {
+		return &orderv1.PlaceOrderRequest{
+			AccountId:   accountID,
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+			Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
+			Quantity:    10,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		orders []*orderv1.PlaceOrderRequest
+		valid  bool
+	}{
+		{name: "same account", orders: []*orderv1.PlaceOrderRequest{order("U12345"), order("U12345")}, valid: true},
+		{name: "different accounts", orders: []*orderv1.PlaceOrderRequest{order("U12345"), order("U54321")}},
+		{name: "single order", orders: []*orderv1.PlaceOrderRequest{order("U12345")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := protovalidate.Validate(&orderv1.PlaceOrderGroupRequest{Orders: tt.orders})
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	orderv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1"
+)
+
+// orderTypeTTL is how long the type of an order is remembered after it was last seen.
+const orderTypeTTL = 24 * time.Hour
+
+// orderTypeCache remembers the types of the orders of each account, as placed through the
+// service or listed by the Gateway. ModifyOrder needs the type of an order to map its
+// prices, and listing the live orders is paced at one request every 5 seconds.
+type orderTypeCache struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	types map[orderKey]cachedOrderType
+}
+
+// orderKey identifies an order of an account.
+type orderKey struct {
+	accountID string
+	orderID   string
+}
+
+// cachedOrderType is the type of an order and when it is forgotten.
+type cachedOrderType struct {
+	orderType orderv1.OrderType
+	expiresAt time.Time
+}
+
+// newOrderTypeCache creates an orderTypeCache that forgets orders after ttl.
+func newOrderTypeCache(ttl time.Duration) *orderTypeCache {
+	return &orderTypeCache{
+		ttl:   ttl,
+		types: make(map[orderKey]cachedOrderType),
+	}
+}
+
+// set remembers the type of an order. An unspecified type is not remembered, so that the
+// type of the order is taken from the Gateway's listing instead.
+func (c *orderTypeCache) set(accountID, orderID string, orderType orderv1.OrderType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := orderKey{accountID: accountID, orderID: orderID}
+
+	now := c.purge()
+	if orderType == orderv1.OrderType_ORDER_TYPE_UNSPECIFIED {
+		delete(c.types, key)
+
+		return
+	}
+
+	c.types[key] = cachedOrderType{
+		orderType: orderType,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// setListed remembers the types of orders listed by the Gateway.
+func (c *orderTypeCache) setListed(accountID string, orders []ibkr.Order) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.purge()
+	for i := range orders {
+		c.types[orderKey{accountID: accountID, orderID: orders[i].OrderID}] = cachedOrderType{
+			orderType: mapOrderTypeFromString(orders[i].OrigOrderType),
+			expiresAt: now.Add(c.ttl),
+		}
+	}
+}
+
+// purge drops expired orders, so that filled and cancelled ones do not accumulate, and
+// returns the current time. The caller must hold c.mu.
+func (c *orderTypeCache) purge() time.Time {
+	now := time.Now()
+
+	for key, cached := range c.types {
+		if now.After(cached.expiresAt) {
+			delete(c.types, key)
+		}
+	}
+
+	return now
+}
+
+// get returns the type of an order, if it is known.
+func (c *orderTypeCache) get(accountID, orderID string) (orderv1.OrderType, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.types[orderKey{accountID: accountID, orderID: orderID}]
+	if !ok || time.Now().After(cached.expiresAt) {
+		return orderv1.OrderType_ORDER_TYPE_UNSPECIFIED, false
+	}
+
+	return cached.orderType, true
+}
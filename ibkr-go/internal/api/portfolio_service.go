@@ -6,7 +6,6 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
-	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/money"
 	portfoliov1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/portfolio/v1"
 	"github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/portfolio/v1/portfoliov1connect"
@@ -17,12 +16,17 @@ const percentageMultiplier = 100
 // PortfolioServiceHandler implements the PortfolioService ConnectRPC service.
 type PortfolioServiceHandler struct {
 	ibkrClient ibkr.PortfolioClient
+	accounts   *AccountResolver
 }
 
 // NewPortfolioServiceHandler creates a new PortfolioService handler.
-func NewPortfolioServiceHandler(ibkrClient ibkr.PortfolioClient) portfoliov1connect.PortfolioServiceHandler {
+func NewPortfolioServiceHandler(
+	ibkrClient ibkr.PortfolioClient,
+	accounts *AccountResolver,
+) portfoliov1connect.PortfolioServiceHandler {
 	return &PortfolioServiceHandler{
 		ibkrClient: ibkrClient,
+		accounts:   accounts,
 	}
 }
 
@@ -31,22 +35,22 @@ func (h *PortfolioServiceHandler) GetPortfolio(
 	ctx context.Context,
 	req *connect.Request[portfoliov1.GetPortfolioRequest],
 ) (*connect.Response[portfoliov1.GetPortfolioResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get portfolio positions from IBKR Gateway.
-	positions, err := h.ibkrClient.GetPortfolio(ctx)
+	positions, err := h.ibkrClient.GetPortfolio(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get portfolio: %w", err))
+		return nil, gatewayError("failed to get portfolio", err)
 	}
 
 	// Get account summary for total value and cash balance.
-	summary, err := h.ibkrClient.GetAccountSummary(ctx)
+	summary, err := h.ibkrClient.GetAccountSummary(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get account summary: %w", err))
+		return nil, gatewayError("failed to get account summary", err)
 	}
 
 	// Map to proto portfolio.
@@ -87,16 +91,16 @@ func (h *PortfolioServiceHandler) GetPositions(
 	ctx context.Context,
 	req *connect.Request[portfoliov1.GetPositionsRequest],
 ) (*connect.Response[portfoliov1.GetPositionsResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get portfolio positions from IBKR Gateway.
-	positions, err := h.ibkrClient.GetPortfolio(ctx)
+	positions, err := h.ibkrClient.GetPortfolio(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get positions: %w", err))
+		return nil, gatewayError("failed to get positions", err)
 	}
 
 	// Map to proto positions.
@@ -110,8 +114,6 @@ func (h *PortfolioServiceHandler) GetPositions(
 		protoPositions = append(protoPositions, pos)
 	}
 
-	_ = accountID
-
 	return connect.NewResponse(&portfoliov1.GetPositionsResponse{
 		Positions: protoPositions,
 	}), nil
@@ -122,16 +124,16 @@ func (h *PortfolioServiceHandler) GetAccountSummary(
 	ctx context.Context,
 	req *connect.Request[portfoliov1.GetAccountSummaryRequest],
 ) (*connect.Response[portfoliov1.GetAccountSummaryResponse], error) {
-	// Get account ID from context.
-	accountID, ok := middleware.GetAccountIDFromContext(ctx)
-	if !ok {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("account ID not found in context"))
+	// Resolve the account and check that the caller may use it.
+	accountID, err := h.accounts.Resolve(ctx, req.Msg.AccountId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get account summary from IBKR Gateway.
-	summary, err := h.ibkrClient.GetAccountSummary(ctx)
+	summary, err := h.ibkrClient.GetAccountSummary(ctx, accountID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get account summary: %w", err))
+		return nil, gatewayError("failed to get account summary", err)
 	}
 
 	// Convert all money fields.
@@ -12,7 +12,7 @@ import (
 
 func TestGetPortfolio(t *testing.T) {
 	mockClient := new(MockPortfolioClient)
-	handler := NewPortfolioServiceHandler(mockClient)
+	handler := NewPortfolioServiceHandler(mockClient, newTestAccountResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&portfoliov1.GetPortfolioRequest{AccountId: "U12345"})
@@ -21,14 +21,14 @@ func TestGetPortfolio(t *testing.T) {
 	positions := []ibkr.Position{
 		{ContractDesc: "AAPL", Position: 10, MktValue: 1500, Currency: "USD"},
 	}
-	mockClient.On("GetPortfolio", ctx).Return(positions, nil)
+	mockClient.On("GetPortfolio", ctx, "U12345").Return(positions, nil)
 
 	summary := &ibkr.AccountSummary{
 		NetLiquidation: 10000,
 		TotalCashValue: 5000,
 		Currency:       "USD",
 	}
-	mockClient.On("GetAccountSummary", ctx).Return(summary, nil)
+	mockClient.On("GetAccountSummary", ctx, "U12345").Return(summary, nil)
 
 	resp, err := handler.GetPortfolio(ctx, req)
 	if err != nil {
@@ -42,7 +42,7 @@ func TestGetPortfolio(t *testing.T) {
 
 func TestGetAccountSummary(t *testing.T) {
 	mockClient := new(MockPortfolioClient)
-	handler := NewPortfolioServiceHandler(mockClient)
+	handler := NewPortfolioServiceHandler(mockClient, newTestAccountResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&portfoliov1.GetAccountSummaryRequest{})
@@ -51,7 +51,7 @@ func TestGetAccountSummary(t *testing.T) {
 		NetLiquidation: 10000,
 		Currency:       "USD",
 	}
-	mockClient.On("GetAccountSummary", ctx).Return(summary, nil)
+	mockClient.On("GetAccountSummary", ctx, "U12345").Return(summary, nil)
 
 	resp, err := handler.GetAccountSummary(ctx, req)
 	if err != nil {
@@ -65,7 +65,7 @@ func TestGetAccountSummary(t *testing.T) {
 
 func TestGetPositions(t *testing.T) {
 	mockClient := new(MockPortfolioClient)
-	handler := NewPortfolioServiceHandler(mockClient)
+	handler := NewPortfolioServiceHandler(mockClient, newTestAccountResolver())
 
 	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
 	req := connect.NewRequest(&portfoliov1.GetPositionsRequest{})
@@ -73,7 +73,7 @@ func TestGetPositions(t *testing.T) {
 	positions := []ibkr.Position{
 		{ContractDesc: "AAPL", Position: 10, MktValue: 1500, Currency: "USD"},
 	}
-	mockClient.On("GetPortfolio", ctx).Return(positions, nil)
+	mockClient.On("GetPortfolio", ctx, "U12345").Return(positions, nil)
 
 	resp, err := handler.GetPositions(ctx, req)
 	if err != nil {
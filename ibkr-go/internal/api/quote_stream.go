@@ -0,0 +1,317 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/quotes"
+	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultQuoteStreamMinInterval       = time.Second
+	defaultQuoteStreamMaxInterval       = time.Minute
+	defaultQuoteStreamInterval          = 5 * time.Second
+	defaultQuoteStreamHeartbeatInterval = 15 * time.Second
+	defaultQuoteStreamSnapshotInterval  = time.Minute
+)
+
+// quoteKeyFields are the quote fields that are not compared between updates: they
+// identify the quote rather than describe the market.
+var quoteKeyFields = map[protoreflect.Name]bool{
+	"symbol":     true,
+	"conid":      true,
+	"updated_at": true,
+}
+
+// QuoteStreamConfig configures the cadence of StreamQuotes. Zero values fall back to the defaults.
+type QuoteStreamConfig struct {
+	// MinInterval is the lower bound of the interval clients pick. Streamed contracts
+	// are polled at the shortest interval of the open streams.
+	MinInterval time.Duration
+	// MaxInterval is the upper bound of the interval clients pick.
+	MaxInterval time.Duration
+	// Interval is the interval of clients that pick none.
+	Interval time.Duration
+	// HeartbeatInterval is the time without quotes after which a stream sends a heartbeat.
+	HeartbeatInterval time.Duration
+	// SnapshotInterval is the interval between full quotes of delta streams.
+	SnapshotInterval time.Duration
+}
+
+// withDefaults returns the config with defaults for zero values and the interval within bounds.
+func (c QuoteStreamConfig) withDefaults() QuoteStreamConfig {
+	if c.MinInterval <= 0 {
+		c.MinInterval = defaultQuoteStreamMinInterval
+	}
+
+	if c.MaxInterval <= 0 {
+		c.MaxInterval = defaultQuoteStreamMaxInterval
+	}
+
+	c.MaxInterval = max(c.MaxInterval, c.MinInterval)
+
+	if c.Interval <= 0 {
+		c.Interval = defaultQuoteStreamInterval
+	}
+
+	c.Interval = min(max(c.Interval, c.MinInterval), c.MaxInterval)
+
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = defaultQuoteStreamHeartbeatInterval
+	}
+
+	if c.SnapshotInterval <= 0 {
+		c.SnapshotInterval = defaultQuoteStreamSnapshotInterval
+	}
+
+	return c
+}
+
+// interval returns the interval requested in milliseconds, clamped to the bounds, or
+// the default interval when none is requested.
+func (c QuoteStreamConfig) interval(requestedMs *int32) time.Duration {
+	if requestedMs == nil {
+		return c.Interval
+	}
+
+	return min(max(time.Duration(*requestedMs)*time.Millisecond, c.MinInterval), c.MaxInterval)
+}
+
+// MarketDataOption configures a MarketDataServiceHandler.
+type MarketDataOption func(*MarketDataServiceHandler)
+
+// WithQuoteStreamConfig sets the cadence of StreamQuotes.
+func WithQuoteStreamConfig(config QuoteStreamConfig) MarketDataOption {
+	return func(h *MarketDataServiceHandler) {
+		h.streamConfig = config
+	}
+}
+
+// quoteStreamer sends the quotes of the contracts of a stream, either full or as the
+// fields changed since the previous quote of the contract.
+type quoteStreamer struct {
+	send    func(*marketdatav1.StreamQuotesResponse) error
+	symbols map[int][]string
+	conIDs  []int
+	delta   bool
+
+	// pending holds the latest quote of each contract received since its last send.
+	pending map[int]*marketdatav1.Quote
+	// sent holds the last quote sent of each contract, in full.
+	sent map[int]*marketdatav1.Quote
+}
+
+func newQuoteStreamer(
+	symbols map[int][]string,
+	delta bool,
+	send func(*marketdatav1.StreamQuotesResponse) error,
+) *quoteStreamer {
+	return &quoteStreamer{
+		send:    send,
+		symbols: symbols,
+		conIDs:  slices.Sorted(maps.Keys(symbols)),
+		delta:   delta,
+		pending: make(map[int]*marketdatav1.Quote),
+		sent:    make(map[int]*marketdatav1.Quote),
+	}
+}
+
+// streamQuotes sends the quotes of a subscription at most once per interval and
+// contract until the client leaves or the subscription ends. The first quote of a
+// contract is sent as soon as it is received, and a heartbeat after every heartbeat
+// interval without quotes. Failed polls are skipped: the stream keeps the last quotes
+// and resumes with the next successful poll.
+func (h *MarketDataServiceHandler) streamQuotes(
+	ctx context.Context,
+	sub *quotes.Subscription,
+	streamer *quoteStreamer,
+	interval time.Duration,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	heartbeat := time.NewTimer(h.streamConfig.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// Full quotes are only needed periodically when sending changed fields.
+	var snapshots <-chan time.Time
+
+	if streamer.delta {
+		snapshotTicker := time.NewTicker(h.streamConfig.SnapshotInterval)
+		defer snapshotTicker.Stop()
+
+		snapshots = snapshotTicker.C
+	}
+
+	for {
+		var (
+			sent int
+			err  error
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return subscriptionError(sub.Err())
+			}
+
+			if update.Err != nil {
+				h.logger.Warn("Failed to poll streamed quote",
+					slog.Int("conid", update.ConID),
+					slog.String("error", update.Err.Error()),
+				)
+
+				continue
+			}
+
+			streamer.pending[update.ConID] = mapSnapshotToQuote(&update.Snapshot, "")
+
+			if _, ok := streamer.sent[update.ConID]; !ok {
+				sent, err = streamer.flush(update.ConID, false)
+			}
+		case <-ticker.C:
+			sent, err = streamer.flushAll(false)
+		case <-snapshots:
+			sent, err = streamer.flushAll(true)
+		case <-heartbeat.C:
+			err = streamer.send(&marketdatav1.StreamQuotesResponse{Heartbeat: true})
+			sent = 1
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if sent > 0 {
+			heartbeat.Reset(h.streamConfig.HeartbeatInterval)
+		}
+	}
+}
+
+// flushAll sends the pending quotes of every contract, or the quotes of every contract
+// in full when full is set. It returns the number of messages sent.
+func (s *quoteStreamer) flushAll(full bool) (int, error) {
+	total := 0
+
+	for _, conID := range s.conIDs {
+		sent, err := s.flush(conID, full)
+		if err != nil {
+			return total, err
+		}
+
+		total += sent
+	}
+
+	return total, nil
+}
+
+// flush sends the pending quote of a contract to every symbol it was requested by. Outside
+// delta mode, and for full or first quotes, the quote is sent in full; otherwise only
+// its changed fields are sent, and nothing when none changed.
+func (s *quoteStreamer) flush(conID int, full bool) (int, error) {
+	quote, pending := s.pending[conID]
+	last := s.sent[conID]
+
+	switch {
+	case pending:
+		delete(s.pending, conID)
+	case full && last != nil:
+		quote = last
+	default:
+		return 0, nil
+	}
+
+	resp := &marketdatav1.StreamQuotesResponse{Quote: quote, Full: true}
+
+	if s.delta && !full && last != nil {
+		partial, changed := diffQuote(last, quote)
+		if len(changed) == 0 {
+			return 0, nil
+		}
+
+		resp = &marketdatav1.StreamQuotesResponse{Quote: partial, ChangedFields: changed}
+	}
+
+	s.sent[conID] = quote
+
+	for _, symbol := range s.symbols[conID] {
+		msg := proto.CloneOf(resp)
+		// Instruments requested by contract ID get the symbol of the snapshot.
+		msg.Quote.Symbol = cmp.Or(symbol, quote.Symbol)
+
+		if err := s.send(msg); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(s.symbols[conID]), nil
+}
+
+// diffQuote returns a quote with the fields of quote that differ from prev, and the names
+// of those fields.
+func diffQuote(prev, quote *marketdatav1.Quote) (*marketdatav1.Quote, []string) {
+	partial := &marketdatav1.Quote{Conid: quote.Conid, UpdatedAt: quote.UpdatedAt}
+
+	var changed []string
+
+	prevMsg, msg, partialMsg := prev.ProtoReflect(), quote.ProtoReflect(), partial.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if quoteKeyFields[field.Name()] || prevMsg.Get(field).Equal(msg.Get(field)) {
+			continue
+		}
+
+		if msg.Has(field) {
+			partialMsg.Set(field, msg.Get(field))
+		}
+
+		changed = append(changed, string(field.Name()))
+	}
+
+	return partial, changed
+}
+
+// streamInstruments returns the instruments of a stream request, given either by the
+// symbol fields, by contract ID or as a list.
+func streamInstruments(msg *marketdatav1.StreamQuotesRequest) []*marketdatav1.QuoteInstrument {
+	if len(msg.Instruments) > 0 {
+		return msg.Instruments
+	}
+
+	instrument := &marketdatav1.QuoteInstrument{
+		Exchange: msg.Exchange,
+		Currency: msg.Currency,
+		SecType:  msg.SecType,
+		Expiry:   msg.Expiry,
+	}
+
+	if msg.GetConid() > 0 {
+		instrument.Instrument = &marketdatav1.QuoteInstrument_Conid{Conid: msg.GetConid()}
+	} else {
+		instrument.Instrument = &marketdatav1.QuoteInstrument_Symbol{Symbol: msg.GetSymbol()}
+	}
+
+	return []*marketdatav1.QuoteInstrument{instrument}
+}
+
+// subscriptionError maps the reason a quote subscription ended to a connect error.
+func subscriptionError(err error) *connect.Error {
+	if errors.Is(err, quotes.ErrSlowConsumer) {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	return connect.NewError(connect.CodeUnavailable, err)
+}
@@ -0,0 +1,303 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/middleware"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/quotes"
+	marketdatav1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1"
+	"github.com/stretchr/testify/mock"
+)
+
+// sequenceSource returns its snapshots one poll at a time, then the last one forever.
+type sequenceSource struct {
+	mu        sync.Mutex
+	snapshots []ibkr.MarketDataSnapshot
+	calls     int
+}
+
+func (s *sequenceSource) GetMarketData(_ context.Context, _ []int, _ []string) ([]ibkr.MarketDataSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.snapshots[min(s.calls, len(s.snapshots)-1)]
+	s.calls++
+
+	return []ibkr.MarketDataSnapshot{snapshot}, nil
+}
+
+// runQuoteStream streams the quotes of AAPL, conid 1, from source and returns the
+// first count messages sent.
+func runQuoteStream(t *testing.T, source quotes.SnapshotSource, config QuoteStreamConfig, delta bool, count int) []*marketdatav1.StreamQuotesResponse {
+	t.Helper()
+
+	h := &MarketDataServiceHandler{
+		quotes:       quotes.NewHub(source, quotes.Config{Interval: config.MinInterval}, slog.Default()),
+		streamConfig: config.withDefaults(),
+		logger:       slog.Default(),
+	}
+	defer h.quotes.Close()
+
+	sub, err := h.quotes.Subscribe(0, 1)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages := make(chan *marketdatav1.StreamQuotesResponse, count)
+	streamer := newQuoteStreamer(map[int][]string{1: {"AAPL"}}, delta, func(msg *marketdatav1.StreamQuotesResponse) error {
+		select {
+		case messages <- msg:
+		default:
+			cancel()
+		}
+
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.streamQuotes(ctx, sub, streamer, h.streamConfig.Interval)
+	}()
+
+	received := make([]*marketdatav1.StreamQuotesResponse, 0, count)
+	for range count {
+		select {
+		case msg := <-messages:
+			received = append(received, msg)
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out after %d messages", len(received))
+		}
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("streamQuotes() error = %v", err)
+	}
+
+	return received
+}
+
+func TestQuoteStreamConfig_Interval(t *testing.T) {
+	config := QuoteStreamConfig{MinInterval: time.Second, MaxInterval: 10 * time.Second}.withDefaults()
+
+	ms := func(value int32) *int32 { return &value }
+
+	tests := []struct {
+		requested *int32
+		want      time.Duration
+	}{
+		{requested: nil, want: 5 * time.Second},
+		{requested: ms(2500), want: 2500 * time.Millisecond},
+		{requested: ms(10), want: time.Second},
+		{requested: ms(600000), want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := config.interval(tt.requested); got != tt.want {
+			t.Errorf("interval(%v) = %v, want %v", tt.requested, got, tt.want)
+		}
+	}
+
+	if config.HeartbeatInterval != defaultQuoteStreamHeartbeatInterval {
+		t.Errorf("HeartbeatInterval = %v, want the default", config.HeartbeatInterval)
+	}
+}
+
+func TestStreamQuotes_Delta(t *testing.T) {
+	source := &sequenceSource{snapshots: []ibkr.MarketDataSnapshot{
+		{ConID: 1, LastPrice: 100, Bid: 99.5, Volume: 1000},
+		{ConID: 1, LastPrice: 101, Bid: 99.5, Volume: 1200},
+	}}
+
+	config := QuoteStreamConfig{
+		MinInterval:       5 * time.Millisecond,
+		Interval:          5 * time.Millisecond,
+		HeartbeatInterval: 50 * time.Millisecond,
+		SnapshotInterval:  time.Hour,
+	}
+
+	messages := runQuoteStream(t, source, config, true, 3)
+
+	first := messages[0]
+	if !first.Full || first.Quote.Symbol != "AAPL" || first.Quote.Last != 100 || first.Quote.Bid != 99.5 {
+		t.Errorf("Expected a full first quote, got %v", first)
+	}
+
+	partial := messages[1]
+	if partial.Full || !slices.Equal(partial.ChangedFields, []string{"last", "volume"}) {
+		t.Errorf("Expected the changed last and volume, got %v", partial)
+	}
+
+	if partial.Quote.Symbol != "AAPL" || partial.Quote.Last != 101 || partial.Quote.Volume != 1200 || partial.Quote.Bid != 0 {
+		t.Errorf("Expected only the changed fields to be set, got %v", partial.Quote)
+	}
+
+	// Nothing changes afterwards, so the stream goes quiet until the heartbeat.
+	if heartbeat := messages[2]; !heartbeat.Heartbeat || heartbeat.Quote != nil {
+		t.Errorf("Expected a heartbeat, got %v", heartbeat)
+	}
+}
+
+func TestStreamQuotes_PeriodicFullQuotes(t *testing.T) {
+	source := &sequenceSource{snapshots: []ibkr.MarketDataSnapshot{{ConID: 1, LastPrice: 100}}}
+
+	config := QuoteStreamConfig{
+		MinInterval:       5 * time.Millisecond,
+		Interval:          5 * time.Millisecond,
+		HeartbeatInterval: time.Hour,
+		SnapshotInterval:  20 * time.Millisecond,
+	}
+
+	for i, msg := range runQuoteStream(t, source, config, true, 3) {
+		if !msg.Full || msg.Quote.Last != 100 {
+			t.Errorf("messages[%d] = %v, want a full quote", i, msg)
+		}
+	}
+}
+
+func TestStreamQuotes_FullMode(t *testing.T) {
+	source := &sequenceSource{snapshots: []ibkr.MarketDataSnapshot{
+		{ConID: 1, LastPrice: 100},
+		{ConID: 1, LastPrice: 101},
+	}}
+
+	config := QuoteStreamConfig{
+		MinInterval:       5 * time.Millisecond,
+		Interval:          5 * time.Millisecond,
+		HeartbeatInterval: time.Hour,
+	}
+
+	messages := runQuoteStream(t, source, config, false, 2)
+
+	if !messages[1].Full || messages[1].Quote.Last != 101 || len(messages[1].ChangedFields) != 0 {
+		t.Errorf("Expected full quotes outside delta mode, got %v", messages[1])
+	}
+}
+
+// failingSource fails its first polls, then returns its snapshot.
+type failingSource struct {
+	mu       sync.Mutex
+	failures int
+	snapshot ibkr.MarketDataSnapshot
+}
+
+func (s *failingSource) GetMarketData(_ context.Context, _ []int, _ []string) ([]ibkr.MarketDataSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+
+		return nil, errors.New("gateway unavailable")
+	}
+
+	return []ibkr.MarketDataSnapshot{s.snapshot}, nil
+}
+
+func TestStreamQuotes_SkipsFailedPolls(t *testing.T) {
+	source := &failingSource{
+		failures: 2,
+		snapshot: ibkr.MarketDataSnapshot{ConID: 1, Symbol: "AAPL", LastPrice: 150},
+	}
+
+	messages := runQuoteStream(t, source, QuoteStreamConfig{
+		MinInterval:       5 * time.Millisecond,
+		Interval:          5 * time.Millisecond,
+		HeartbeatInterval: time.Hour,
+	}, false, 1)
+
+	if messages[0].GetQuote().GetLast() != 150 {
+		t.Errorf("Expected the quote of the first successful poll, got %v", messages[0])
+	}
+}
+
+func TestQuoteStreamer_SymbolsOfContract(t *testing.T) {
+	var sent []*marketdatav1.StreamQuotesResponse
+
+	streamer := newQuoteStreamer(map[int][]string{1: {"SHOP", "SHOP.TO"}}, false, func(msg *marketdatav1.StreamQuotesResponse) error {
+		sent = append(sent, msg)
+
+		return nil
+	})
+	streamer.pending[1] = &marketdatav1.Quote{Last: 50}
+
+	if n, err := streamer.flushAll(false); err != nil || n != 2 {
+		t.Fatalf("flushAll() = %d, %v", n, err)
+	}
+
+	if sent[0].Quote.Symbol != "SHOP" || sent[1].Quote.Symbol != "SHOP.TO" || sent[1].Quote.Last != 50 {
+		t.Errorf("Expected a quote per symbol, got %v", sent)
+	}
+
+	// Nothing is pending anymore.
+	if n, _ := streamer.flushAll(false); n != 0 {
+		t.Errorf("Expected nothing to send, sent %d", n)
+	}
+}
+
+func TestQuoteStreamer_SymbolOfConid(t *testing.T) {
+	var sent []*marketdatav1.StreamQuotesResponse
+
+	// An instrument requested by contract ID has no symbol of its own.
+	streamer := newQuoteStreamer(map[int][]string{1: {""}}, false, func(msg *marketdatav1.StreamQuotesResponse) error {
+		sent = append(sent, msg)
+
+		return nil
+	})
+	streamer.pending[1] = &marketdatav1.Quote{Symbol: "BRK B", Conid: 1, Last: 410}
+
+	if n, err := streamer.flushAll(false); err != nil || n != 1 {
+		t.Fatalf("flushAll() = %d, %v", n, err)
+	}
+
+	if sent[0].Quote.Symbol != "BRK B" || sent[0].Quote.Conid != 1 {
+		t.Errorf("Expected the symbol of the snapshot, got %v", sent[0].Quote)
+	}
+}
+
+func TestStreamInstruments(t *testing.T) {
+	exchange := "NYSE"
+
+	bySymbol := streamInstruments(&marketdatav1.StreamQuotesRequest{
+		Instrument: &marketdatav1.StreamQuotesRequest_Symbol{Symbol: "BRK.B"},
+		Exchange:   &exchange,
+	})
+	if len(bySymbol) != 1 || bySymbol[0].GetSymbol() != "BRK.B" || bySymbol[0].GetExchange() != "NYSE" {
+		t.Errorf("Unexpected instruments %v", bySymbol)
+	}
+
+	byConid := streamInstruments(&marketdatav1.StreamQuotesRequest{
+		Instrument: &marketdatav1.StreamQuotesRequest_Conid{Conid: 72063691},
+	})
+	if len(byConid) != 1 || byConid[0].GetConid() != 72063691 || byConid[0].GetSymbol() != "" {
+		t.Errorf("Unexpected instruments %v", byConid)
+	}
+}
+
+func TestStreamQuotes_ContractNotFound(t *testing.T) {
+	mockClient := new(MockMarketDataClient)
+	mockClient.On("SearchContracts", mock.Anything, "NOPE").Return([]ibkr.Contract{}, nil)
+
+	handler := NewMarketDataServiceHandler(mockClient, ibkr.NewResolver(mockClient))
+
+	ctx := middleware.SetAccountIDInContext(context.Background(), "U12345")
+	req := connect.NewRequest(&marketdatav1.StreamQuotesRequest{
+		Instruments: []*marketdatav1.QuoteInstrument{{Instrument: &marketdatav1.QuoteInstrument_Symbol{Symbol: "NOPE"}}},
+	})
+
+	if err := handler.StreamQuotes(ctx, req, nil); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Code = %v, want NotFound", connect.CodeOf(err))
+	}
+}
@@ -0,0 +1,394 @@
+// Package bars caches historical bars in the bars table, keyed by conid, bar size and
+// whether bars outside regular trading hours are included.
+package bars
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// DefaultSettleDelay is the default time after the end of a bar before it is cached.
+const DefaultSettleDelay = 20 * time.Minute
+
+// Source fetches bars from the Gateway. *ibkr.Client implements it.
+type Source interface {
+	GetHistory(ctx context.Context, req ibkr.HistoryRequest) (*ibkr.History, error)
+}
+
+// Cache serves historical bars from the bars table and fetches only the missing ranges
+// from the Gateway.
+//
+// The bar_coverage table records the ranges whose bars are all cached, so that ranges
+// without any bars, such as nights, weekends and holidays, are not requested again; the
+// gaps between them are what is fetched. Only finalized bars are cached: a bar is final
+// once the settle delay has passed after its end, so the still-forming last bar and bars
+// that may still arrive late are always fetched from the Gateway. Failing to read the
+// cache falls back to the Gateway, and failing to write it is logged.
+type Cache struct {
+	querier db.Querier
+	source  Source
+	settle  time.Duration
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// key identifies a series of cached bars.
+type key struct {
+	conID      int
+	barSize    string
+	outsideRTH bool
+}
+
+// span is a time range, from start inclusive to end exclusive.
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// NewCache creates a new bar Cache. A zero settle delay falls back to DefaultSettleDelay.
+func NewCache(querier db.Querier, source Source, settle time.Duration, logger *slog.Logger) *Cache {
+	if settle == 0 {
+		settle = DefaultSettleDelay
+	}
+
+	return &Cache{
+		querier: querier,
+		source:  source,
+		settle:  settle,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// GetHistory returns the bars of a request like ibkr.Client.GetHistory, serving the cached
+// ones from the bars table. The missing ranges are fetched from the Gateway newest first,
+// and only until the request limit is reached.
+func (c *Cache) GetHistory(ctx context.Context, req ibkr.HistoryRequest) (*ibkr.History, error) {
+	barSize, err := ibkr.ParseHistoryDuration(req.BarSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now()
+
+	start, end, err := req.Range(now)
+	if err != nil {
+		return nil, err
+	}
+
+	k := key{conID: req.ConID, barSize: req.BarSize, outsideRTH: req.OutsideRTH}
+
+	// Bars starting at or after final are not final yet.
+	final := earlier(now.Add(-barSize-c.settle), end)
+
+	gaps, err := c.gaps(ctx, k, span{start: start, end: final})
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to read bar coverage, serving from the Gateway",
+			slog.Int("conid", req.ConID),
+			slog.String("error", err.Error()),
+		)
+
+		return c.source.GetHistory(ctx, req)
+	}
+
+	// The bars that are not final yet are always fetched.
+	live := span{start: later(start, final), end: end}
+
+	hasLive := live.start.Before(live.end)
+	if hasLive {
+		gaps = append([]span{live}, gaps...)
+	}
+
+	history := &ibkr.History{Start: start}
+	fetched := make(map[int64]ibkr.HistoricalBar)
+
+	for i, gap := range gaps {
+		if req.Limit > 0 && len(fetched) >= req.Limit {
+			// Older gaps are left for the next page.
+			history.More = true
+
+			break
+		}
+
+		// Gaps shorter than a bar are widened to one, the shortest period the Gateway serves.
+		resp, err := c.source.GetHistory(ctx, ibkr.HistoryRequest{
+			ConID:      req.ConID,
+			BarSize:    req.BarSize,
+			Start:      earlier(gap.start, gap.end.Add(-barSize)),
+			End:        gap.end,
+			Limit:      req.Limit,
+			OutsideRTH: req.OutsideRTH,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			history.HistoricalDataResponse = resp.HistoricalDataResponse
+		}
+
+		for _, bar := range resp.Data {
+			if bar.Time >= start.UnixMilli() && bar.Time < end.UnixMilli() {
+				fetched[bar.Time] = bar
+			}
+		}
+
+		if i == 0 && hasLive {
+			continue
+		}
+
+		// Only the part of the gap the bars were fetched for is covered.
+		if resp.More && len(resp.Data) > 0 {
+			gap.start = later(gap.start, time.UnixMilli(resp.Data[0].Time))
+		}
+
+		if err := c.store(ctx, k, gap, resp.Data); err != nil {
+			c.logger.WarnContext(ctx, "Failed to cache bars",
+				slog.Int("conid", req.ConID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	cached, err := c.cached(ctx, k, span{start: start, end: final}, req.Limit)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to read cached bars, serving from the Gateway",
+			slog.Int("conid", req.ConID),
+			slog.String("error", err.Error()),
+		)
+
+		return c.source.GetHistory(ctx, req)
+	}
+
+	for _, bar := range cached {
+		fetched[bar.Time] = bar
+	}
+
+	history.Data = slices.SortedFunc(maps.Values(fetched), func(a, b ibkr.HistoricalBar) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+
+	if req.Limit > 0 && len(history.Data) > req.Limit {
+		history.Data = history.Data[len(history.Data)-req.Limit:]
+		history.More = true
+	}
+
+	history.Points = len(history.Data)
+
+	return history, nil
+}
+
+// Invalidate removes every cached bar of a contract, so that its bars are fetched from
+// the Gateway again, e.g. after a split. It returns the number of bars removed.
+func (c *Cache) Invalidate(ctx context.Context, conID int) (int64, error) {
+	// Coverage goes first, so that no range is considered cached without its bars.
+	if err := c.querier.DeleteBarCoverageByConid(ctx, int64(conID)); err != nil {
+		return 0, fmt.Errorf("failed to delete bar coverage: %w", err)
+	}
+
+	deleted, err := c.querier.DeleteBarsByConid(ctx, int64(conID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete bars: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// gaps returns the parts of r not covered by cached bars, newest first.
+func (c *Cache) gaps(ctx context.Context, k key, r span) ([]span, error) {
+	if !r.start.Before(r.end) {
+		return nil, nil
+	}
+
+	coverage, err := c.coverage(ctx, k, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var gaps []span
+
+	cursor := r.start
+
+	for _, row := range coverage {
+		if row.StartTime.Time.After(cursor) {
+			gaps = append(gaps, span{start: cursor, end: earlier(row.StartTime.Time, r.end)})
+		}
+
+		cursor = later(cursor, row.EndTime.Time)
+	}
+
+	if cursor.Before(r.end) {
+		gaps = append(gaps, span{start: cursor, end: r.end})
+	}
+
+	slices.Reverse(gaps)
+
+	return gaps, nil
+}
+
+// coverage returns the covered ranges that overlap or touch r, oldest first.
+func (c *Cache) coverage(ctx context.Context, k key, r span) ([]db.BarCoverage, error) {
+	rows, err := c.querier.ListBarCoverage(ctx, db.ListBarCoverageParams{
+		Conid:      int64(k.conID),
+		BarSize:    k.barSize,
+		OutsideRth: k.outsideRTH,
+		StartTime:  timestamp(r.start),
+		EndTime:    timestamp(r.end),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bar coverage: %w", err)
+	}
+
+	return rows, nil
+}
+
+// cached returns the most recent cached bars within r, at most limit of them plus one
+// so that callers can tell whether there are more, or all of them when limit is zero.
+func (c *Cache) cached(ctx context.Context, k key, r span, limit int) ([]ibkr.HistoricalBar, error) {
+	if !r.start.Before(r.end) {
+		return nil, nil
+	}
+
+	maxBars := int32(math.MaxInt32)
+	if limit > 0 && limit < math.MaxInt32 {
+		maxBars = int32(limit + 1)
+	}
+
+	rows, err := c.querier.ListBars(ctx, db.ListBarsParams{
+		Conid:      int64(k.conID),
+		BarSize:    k.barSize,
+		OutsideRth: k.outsideRTH,
+		StartTime:  timestamp(r.start),
+		EndTime:    timestamp(r.end),
+		MaxBars:    maxBars,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bars: %w", err)
+	}
+
+	bars := make([]ibkr.HistoricalBar, 0, len(rows))
+	for _, row := range rows {
+		bars = append(bars, fromRow(row))
+	}
+
+	return bars, nil
+}
+
+// store caches the bars fetched for r and records r as covered, merged with the covered
+// ranges it overlaps or touches. The bars are written first, so that a failure never
+// leaves a range covered without its bars.
+func (c *Cache) store(ctx context.Context, k key, r span, bars []ibkr.HistoricalBar) error {
+	inRange := make([]ibkr.HistoricalBar, 0, len(bars))
+
+	for _, bar := range bars {
+		if bar.Time >= r.start.UnixMilli() && bar.Time < r.end.UnixMilli() {
+			inRange = append(inRange, bar)
+		}
+	}
+
+	if len(inRange) > 0 {
+		if err := c.querier.UpsertBars(ctx, upsertParams(k, inRange)); err != nil {
+			return fmt.Errorf("failed to upsert bars: %w", err)
+		}
+	}
+
+	overlapping, err := c.coverage(ctx, k, r)
+	if err != nil {
+		return err
+	}
+
+	merged := r
+	ids := make([]int64, 0, len(overlapping))
+
+	for _, row := range overlapping {
+		merged.start = earlier(merged.start, row.StartTime.Time)
+		merged.end = later(merged.end, row.EndTime.Time)
+		ids = append(ids, row.ID)
+	}
+
+	err = c.querier.InsertBarCoverage(ctx, db.InsertBarCoverageParams{
+		Conid:      int64(k.conID),
+		BarSize:    k.barSize,
+		OutsideRth: k.outsideRTH,
+		StartTime:  timestamp(merged.start),
+		EndTime:    timestamp(merged.end),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert bar coverage: %w", err)
+	}
+
+	if len(ids) > 0 {
+		if err := c.querier.DeleteBarCoverage(ctx, ids); err != nil {
+			return fmt.Errorf("failed to delete merged bar coverage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+// timestamp converts t to a timestamp column value, which is stored in UTC.
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
+
+func fromRow(row db.Bar) ibkr.HistoricalBar {
+	return ibkr.HistoricalBar{
+		Time:   row.BarTime.Time.UnixMilli(),
+		Open:   row.Open,
+		High:   row.High,
+		Low:    row.Low,
+		Close:  row.Close,
+		Volume: row.Volume,
+	}
+}
+
+func upsertParams(k key, bars []ibkr.HistoricalBar) db.UpsertBarsParams {
+	params := db.UpsertBarsParams{
+		Conid:      int64(k.conID),
+		BarSize:    k.barSize,
+		OutsideRth: k.outsideRTH,
+		BarTimes:   make([]pgtype.Timestamp, 0, len(bars)),
+		Opens:      make([]float64, 0, len(bars)),
+		Highs:      make([]float64, 0, len(bars)),
+		Lows:       make([]float64, 0, len(bars)),
+		Closes:     make([]float64, 0, len(bars)),
+		Volumes:    make([]int64, 0, len(bars)),
+	}
+
+	for _, bar := range bars {
+		params.BarTimes = append(params.BarTimes, timestamp(time.UnixMilli(bar.Time)))
+		params.Opens = append(params.Opens, bar.Open)
+		params.Highs = append(params.Highs, bar.High)
+		params.Lows = append(params.Lows, bar.Low)
+		params.Closes = append(params.Closes, bar.Close)
+		params.Volumes = append(params.Volumes, bar.Volume)
+	}
+
+	return params
+}
@@ -0,0 +1,366 @@
+package bars
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// testNow is the time of the tests: with hourly bars and the default settle delay, the
+// bars starting before 11:10 are final.
+var testNow = time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)
+
+// fakeQuerier keeps the bars and bar_coverage tables in memory.
+type fakeQuerier struct {
+	db.Querier
+
+	mu       sync.Mutex
+	bars     map[int64]db.Bar
+	coverage []db.BarCoverage
+	nextID   int64
+	err      error
+}
+
+func newFakeQuerier() *fakeQuerier {
+	return &fakeQuerier{bars: make(map[int64]db.Bar)}
+}
+
+func (q *fakeQuerier) ListBars(_ context.Context, arg db.ListBarsParams) ([]db.Bar, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	var rows []db.Bar
+
+	for _, bar := range q.bars {
+		if bar.Conid == arg.Conid && !bar.BarTime.Time.Before(arg.StartTime.Time) && bar.BarTime.Time.Before(arg.EndTime.Time) {
+			rows = append(rows, bar)
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b db.Bar) int {
+		return b.BarTime.Time.Compare(a.BarTime.Time)
+	})
+
+	return rows[:min(len(rows), int(arg.MaxBars))], nil
+}
+
+func (q *fakeQuerier) UpsertBars(_ context.Context, arg db.UpsertBarsParams) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, barTime := range arg.BarTimes {
+		q.bars[barTime.Time.UnixMilli()] = db.Bar{
+			Conid:      arg.Conid,
+			BarSize:    arg.BarSize,
+			OutsideRth: arg.OutsideRth,
+			BarTime:    barTime,
+			Open:       arg.Opens[i],
+			High:       arg.Highs[i],
+			Low:        arg.Lows[i],
+			Close:      arg.Closes[i],
+			Volume:     arg.Volumes[i],
+		}
+	}
+
+	return nil
+}
+
+func (q *fakeQuerier) ListBarCoverage(_ context.Context, arg db.ListBarCoverageParams) ([]db.BarCoverage, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	var rows []db.BarCoverage
+
+	for _, row := range q.coverage {
+		if row.Conid == arg.Conid && !row.StartTime.Time.After(arg.EndTime.Time) && !row.EndTime.Time.Before(arg.StartTime.Time) {
+			rows = append(rows, row)
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b db.BarCoverage) int {
+		return a.StartTime.Time.Compare(b.StartTime.Time)
+	})
+
+	return rows, nil
+}
+
+func (q *fakeQuerier) InsertBarCoverage(_ context.Context, arg db.InsertBarCoverageParams) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextID++
+	q.coverage = append(q.coverage, db.BarCoverage{
+		ID:         q.nextID,
+		Conid:      arg.Conid,
+		BarSize:    arg.BarSize,
+		OutsideRth: arg.OutsideRth,
+		StartTime:  arg.StartTime,
+		EndTime:    arg.EndTime,
+	})
+
+	return nil
+}
+
+func (q *fakeQuerier) DeleteBarCoverage(_ context.Context, ids []int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.coverage = slices.DeleteFunc(q.coverage, func(row db.BarCoverage) bool {
+		return slices.Contains(ids, row.ID)
+	})
+
+	return nil
+}
+
+func (q *fakeQuerier) DeleteBarCoverageByConid(_ context.Context, conid int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.coverage = slices.DeleteFunc(q.coverage, func(row db.BarCoverage) bool {
+		return row.Conid == conid
+	})
+
+	return nil
+}
+
+func (q *fakeQuerier) DeleteBarsByConid(_ context.Context, conid int64) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var deleted int64
+
+	for t, bar := range q.bars {
+		if bar.Conid == conid {
+			delete(q.bars, t)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// hourlySource returns a bar at every hour of the requested range and records the ranges.
+type hourlySource struct {
+	requests []ibkr.HistoryRequest
+}
+
+func (s *hourlySource) GetHistory(_ context.Context, req ibkr.HistoryRequest) (*ibkr.History, error) {
+	s.requests = append(s.requests, req)
+
+	history := &ibkr.History{Start: req.Start}
+
+	for t := req.Start.Truncate(time.Hour); t.Before(req.End); t = t.Add(time.Hour) {
+		if !t.Before(req.Start) {
+			history.Data = append(history.Data, ibkr.HistoricalBar{Time: t.UnixMilli(), Close: float64(t.Hour())})
+		}
+	}
+
+	if req.Limit > 0 && len(history.Data) > req.Limit {
+		history.Data = history.Data[len(history.Data)-req.Limit:]
+		history.More = true
+	}
+
+	return history, nil
+}
+
+func newTestCache(querier db.Querier, source Source) *Cache {
+	cache := NewCache(querier, source, 0, slog.Default())
+	cache.now = func() time.Time { return testNow }
+
+	return cache
+}
+
+func hourlyRequest(start time.Time) ibkr.HistoryRequest {
+	return ibkr.HistoryRequest{ConID: 265598, BarSize: "1h", Start: start, End: testNow}
+}
+
+func TestCache_ServesCachedBars(t *testing.T) {
+	querier := newFakeQuerier()
+	source := &hourlySource{}
+	cache := newTestCache(querier, source)
+
+	req := hourlyRequest(testNow.Add(-10 * time.Hour))
+
+	first, err := cache.GetHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(first.Data) != 10 {
+		t.Fatalf("Expected 10 bars, got %d", len(first.Data))
+	}
+
+	source.requests = nil
+
+	second, err := cache.GetHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if !slices.Equal(first.Data, second.Data) {
+		t.Errorf("Expected the same bars from the cache, got %v, want %v", second.Data, first.Data)
+	}
+
+	// Only the bars that are not final are fetched again.
+	if len(source.requests) != 1 || !source.requests[0].End.Equal(testNow) {
+		t.Fatalf("Expected only the live range to be fetched, got %+v", source.requests)
+	}
+
+	if start := source.requests[0].Start; start.Before(testNow.Add(-90 * time.Minute)) {
+		t.Errorf("Expected the live range to start at the final bar boundary, got %v", start)
+	}
+}
+
+func TestCache_DoesNotCacheFormingBars(t *testing.T) {
+	querier := newFakeQuerier()
+	cache := newTestCache(querier, &hourlySource{})
+
+	if _, err := cache.GetHistory(context.Background(), hourlyRequest(testNow.Add(-5*time.Hour))); err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	// The 12:00 bar is still forming and the 11:00 bar is final.
+	if _, ok := querier.bars[time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC).UnixMilli()]; ok {
+		t.Error("Expected the forming bar not to be cached")
+	}
+
+	if _, ok := querier.bars[time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC).UnixMilli()]; !ok {
+		t.Error("Expected the final bar to be cached")
+	}
+
+	for _, row := range querier.coverage {
+		if row.EndTime.Time.After(testNow.Add(-80 * time.Minute)) {
+			t.Errorf("Expected coverage to end before the forming bars, got %v", row.EndTime.Time)
+		}
+	}
+}
+
+func TestCache_FetchesOnlyMissingRanges(t *testing.T) {
+	querier := newFakeQuerier()
+	source := &hourlySource{}
+	cache := newTestCache(querier, source)
+
+	recent := testNow.Add(-5 * time.Hour)
+	if _, err := cache.GetHistory(context.Background(), hourlyRequest(recent)); err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	source.requests = nil
+
+	older := testNow.Add(-24 * time.Hour)
+
+	history, err := cache.GetHistory(context.Background(), hourlyRequest(older))
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(history.Data) != 24 {
+		t.Errorf("Expected 24 bars, got %d", len(history.Data))
+	}
+
+	// The live range, then the gap before the cached range.
+	if len(source.requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %+v", source.requests)
+	}
+
+	if gap := source.requests[1]; !gap.Start.Equal(older) || !gap.End.Equal(recent) {
+		t.Errorf("Expected the gap from %v to %v, got %v to %v", older, recent, gap.Start, gap.End)
+	}
+
+	// The covered ranges were merged.
+	if len(querier.coverage) != 1 || !querier.coverage[0].StartTime.Time.Equal(older) {
+		t.Errorf("Expected a single covered range from %v, got %+v", older, querier.coverage)
+	}
+}
+
+func TestCache_Limit(t *testing.T) {
+	querier := newFakeQuerier()
+	source := &hourlySource{}
+	cache := newTestCache(querier, source)
+
+	req := hourlyRequest(testNow.Add(-24 * time.Hour))
+	req.Limit = 3
+
+	history, err := cache.GetHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(history.Data) != 3 || !history.More {
+		t.Fatalf("Expected 3 bars and more, got %d bars, more %v", len(history.Data), history.More)
+	}
+
+	if want := time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC).UnixMilli(); history.Data[0].Time != want {
+		t.Errorf("Expected the most recent bars, first at %d, got %d", want, history.Data[0].Time)
+	}
+
+	// Only the most recent bars of the gap were fetched, so only their range is covered.
+	if len(querier.coverage) != 1 || querier.coverage[0].StartTime.Time.Before(testNow.Add(-5*time.Hour)) {
+		t.Errorf("Expected coverage of the fetched bars only, got %+v", querier.coverage)
+	}
+}
+
+func TestCache_FallsBackToGateway(t *testing.T) {
+	querier := newFakeQuerier()
+	querier.err = errors.New("connection refused")
+
+	source := &hourlySource{}
+	cache := newTestCache(querier, source)
+
+	req := hourlyRequest(testNow.Add(-10 * time.Hour))
+
+	history, err := cache.GetHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(history.Data) != 10 || len(source.requests) != 1 || source.requests[0] != req {
+		t.Errorf("Expected the request to be served by the Gateway, got %d bars from %+v", len(history.Data), source.requests)
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	querier := newFakeQuerier()
+	source := &hourlySource{}
+	cache := newTestCache(querier, source)
+
+	req := hourlyRequest(testNow.Add(-10 * time.Hour))
+	if _, err := cache.GetHistory(context.Background(), req); err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	deleted, err := cache.Invalidate(context.Background(), 265598)
+	if err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	if deleted != 9 || len(querier.bars) != 0 || len(querier.coverage) != 0 {
+		t.Errorf("Expected the 9 final bars and their coverage removed, got %d deleted", deleted)
+	}
+
+	// Everything is fetched again.
+	source.requests = nil
+
+	if _, err := cache.GetHistory(context.Background(), req); err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(source.requests) != 2 {
+		t.Errorf("Expected the bars to be fetched again, got %+v", source.requests)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -15,6 +17,51 @@ const (
 	DefaultDBLogLevel = 2
 	// RequiredEncryptionKeyLength is the required length for AES-256 encryption key.
 	RequiredEncryptionKeyLength = 32
+	// DefaultIBKRRetryMaxAttempts is the default number of attempts for retryable Gateway requests.
+	DefaultIBKRRetryMaxAttempts = 3
+	// DefaultIBKRRetryInitialBackoff is the default delay before the first Gateway retry.
+	DefaultIBKRRetryInitialBackoff = 200 * time.Millisecond
+	// DefaultIBKRRetryMaxBackoff is the default maximum delay between Gateway retries.
+	DefaultIBKRRetryMaxBackoff = 5 * time.Second
+	// DefaultIBKRRateLimitGlobal is the default global Gateway request rate, in requests per second.
+	DefaultIBKRRateLimitGlobal = 10
+	// DefaultIBKRRateLimitSnapshot is the default market data snapshot rate, in requests per second.
+	DefaultIBKRRateLimitSnapshot = 10
+	// DefaultIBKRRateLimitHistory is the default historical data rate, in requests per second.
+	DefaultIBKRRateLimitHistory = 5
+	// DefaultIBKRRateLimitOrders is the default live orders rate, in requests per second.
+	DefaultIBKRRateLimitOrders = 0.2
+	// DefaultIBKRRateLimitTickle is the default tickle rate, in requests per second.
+	DefaultIBKRRateLimitTickle = 1
+	// DefaultIBKRTickleInterval is the default interval between Gateway session keepalive checks.
+	DefaultIBKRTickleInterval = time.Minute
+	// DefaultIBKRReauthInitialBackoff is the default delay before re-checking after a reauthentication.
+	DefaultIBKRReauthInitialBackoff = 5 * time.Second
+	// DefaultIBKRReauthMaxBackoff is the default maximum delay between reauthentication attempts.
+	DefaultIBKRReauthMaxBackoff = 2 * time.Minute
+	// DefaultIBKRSnapshotMaxWait is the default time pre-flight market data snapshots are re-polled for.
+	DefaultIBKRSnapshotMaxWait = 2 * time.Second
+	// DefaultIBKRSnapshotPollInterval is the default delay between pre-flight snapshot polls.
+	DefaultIBKRSnapshotPollInterval = 250 * time.Millisecond
+	// DefaultFuturesRollDays is the default number of days before the last trading day on which
+	// the front-month futures contract rolls.
+	DefaultFuturesRollDays = 0
+	// DefaultInstrumentCacheTTL is the default time after which cached instruments are refreshed.
+	DefaultInstrumentCacheTTL = 24 * time.Hour
+	// DefaultBarCacheSettleDelay is the default time after the end of a bar before it is cached.
+	DefaultBarCacheSettleDelay = 20 * time.Minute
+	// DefaultQuoteStreamMinInterval is the default lower bound of the quote stream interval,
+	// and so of the interval at which streamed contracts are polled.
+	DefaultQuoteStreamMinInterval = time.Second
+	// DefaultQuoteStreamMaxInterval is the default upper bound of the quote stream interval.
+	DefaultQuoteStreamMaxInterval = time.Minute
+	// DefaultQuoteStreamInterval is the default quote stream interval when the client sets none.
+	DefaultQuoteStreamInterval = 5 * time.Second
+	// DefaultQuoteStreamHeartbeatInterval is the default time without quotes after which a
+	// stream sends a heartbeat.
+	DefaultQuoteStreamHeartbeatInterval = 15 * time.Second
+	// DefaultQuoteStreamSnapshotInterval is the default interval between full quotes of delta streams.
+	DefaultQuoteStreamSnapshotInterval = time.Minute
 )
 
 // Config holds all application configuration.
@@ -36,7 +83,51 @@ type Config struct {
 	// IBKR Gateway.
 	IBKRGatewayURL     string
 	IBKRGatewayKeyPath string
-	IBKRAccountID      string
+
+	// IBKR Gateway retries.
+	IBKRRetryMaxAttempts    int
+	IBKRRetryInitialBackoff time.Duration
+	IBKRRetryMaxBackoff     time.Duration
+
+	// IBKR Gateway pacing, in requests per second (0 disables a limit).
+	IBKRRateLimitGlobal   float64
+	IBKRRateLimitSnapshot float64
+	IBKRRateLimitHistory  float64
+	IBKRRateLimitOrders   float64
+	IBKRRateLimitTickle   float64
+
+	// IBKR Gateway session keepalive.
+	IBKRTickleInterval       time.Duration
+	IBKRReauthInitialBackoff time.Duration
+	IBKRReauthMaxBackoff     time.Duration
+
+	// IBKR market data snapshot pre-flight re-polling.
+	IBKRSnapshotMaxWait      time.Duration
+	IBKRSnapshotPollInterval time.Duration
+
+	// IBKR order confirmation questions answered automatically.
+	IBKRAutoConfirmMessageIDs []string
+	IBKRAutoConfirmAll        bool
+
+	// Instrument master.
+	InstrumentCacheTTL time.Duration
+
+	// Historical bar cache.
+	BarCacheSettleDelay time.Duration
+
+	// Accounts allowed to call administrative RPCs, e.g. InvalidateHistoricalCache.
+	AdminAccountIDs []string
+
+	// Quote streaming cadence.
+	QuoteStreamMinInterval       time.Duration
+	QuoteStreamMaxInterval       time.Duration
+	QuoteStreamInterval          time.Duration
+	QuoteStreamHeartbeatInterval time.Duration
+	QuoteStreamSnapshotInterval  time.Duration
+
+	// Futures front-month roll, in days before the last trading day.
+	FuturesRollDays         int
+	FuturesRollDaysBySymbol map[string]int
 
 	// mTLS.
 	MTLSEnabled        bool
@@ -68,7 +159,41 @@ func Load() (*Config, error) {
 
 		IBKRGatewayURL:     getEnv("IBKR_GATEWAY_URL", "http://localhost:5000"),
 		IBKRGatewayKeyPath: getEnv("IBKR_GATEWAY_KEY_PATH", ""),
-		IBKRAccountID:      getEnv("IBKR_ACCOUNT_ID", ""),
+
+		IBKRRetryMaxAttempts:    getEnvInt("IBKR_RETRY_MAX_ATTEMPTS", DefaultIBKRRetryMaxAttempts),
+		IBKRRetryInitialBackoff: getEnvDuration("IBKR_RETRY_INITIAL_BACKOFF", DefaultIBKRRetryInitialBackoff),
+		IBKRRetryMaxBackoff:     getEnvDuration("IBKR_RETRY_MAX_BACKOFF", DefaultIBKRRetryMaxBackoff),
+
+		IBKRRateLimitGlobal:   getEnvFloat("IBKR_RATE_LIMIT_GLOBAL", DefaultIBKRRateLimitGlobal),
+		IBKRRateLimitSnapshot: getEnvFloat("IBKR_RATE_LIMIT_SNAPSHOT", DefaultIBKRRateLimitSnapshot),
+		IBKRRateLimitHistory:  getEnvFloat("IBKR_RATE_LIMIT_HISTORY", DefaultIBKRRateLimitHistory),
+		IBKRRateLimitOrders:   getEnvFloat("IBKR_RATE_LIMIT_ORDERS", DefaultIBKRRateLimitOrders),
+		IBKRRateLimitTickle:   getEnvFloat("IBKR_RATE_LIMIT_TICKLE", DefaultIBKRRateLimitTickle),
+
+		IBKRTickleInterval:       getEnvDuration("IBKR_TICKLE_INTERVAL", DefaultIBKRTickleInterval),
+		IBKRReauthInitialBackoff: getEnvDuration("IBKR_REAUTH_INITIAL_BACKOFF", DefaultIBKRReauthInitialBackoff),
+		IBKRReauthMaxBackoff:     getEnvDuration("IBKR_REAUTH_MAX_BACKOFF", DefaultIBKRReauthMaxBackoff),
+
+		IBKRSnapshotMaxWait:      getEnvDuration("IBKR_SNAPSHOT_MAX_WAIT", DefaultIBKRSnapshotMaxWait),
+		IBKRSnapshotPollInterval: getEnvDuration("IBKR_SNAPSHOT_POLL_INTERVAL", DefaultIBKRSnapshotPollInterval),
+
+		IBKRAutoConfirmMessageIDs: getEnvList("IBKR_AUTO_CONFIRM_MESSAGE_IDS"),
+		IBKRAutoConfirmAll:        getEnvBool("IBKR_AUTO_CONFIRM_ALL", false),
+
+		InstrumentCacheTTL: getEnvDuration("INSTRUMENT_CACHE_TTL", DefaultInstrumentCacheTTL),
+
+		BarCacheSettleDelay: getEnvDuration("BAR_CACHE_SETTLE_DELAY", DefaultBarCacheSettleDelay),
+
+		AdminAccountIDs: getEnvList("ADMIN_ACCOUNT_IDS"),
+
+		QuoteStreamMinInterval:       getEnvDuration("QUOTE_STREAM_MIN_INTERVAL", DefaultQuoteStreamMinInterval),
+		QuoteStreamMaxInterval:       getEnvDuration("QUOTE_STREAM_MAX_INTERVAL", DefaultQuoteStreamMaxInterval),
+		QuoteStreamInterval:          getEnvDuration("QUOTE_STREAM_INTERVAL", DefaultQuoteStreamInterval),
+		QuoteStreamHeartbeatInterval: getEnvDuration("QUOTE_STREAM_HEARTBEAT_INTERVAL", DefaultQuoteStreamHeartbeatInterval),
+		QuoteStreamSnapshotInterval:  getEnvDuration("QUOTE_STREAM_SNAPSHOT_INTERVAL", DefaultQuoteStreamSnapshotInterval),
+
+		FuturesRollDays:         getEnvInt("FUTURES_ROLL_DAYS", DefaultFuturesRollDays),
+		FuturesRollDaysBySymbol: getEnvIntMap("FUTURES_ROLL_DAYS_BY_SYMBOL"),
 
 		MTLSEnabled:        getEnvBool("MTLS_ENABLED", false),
 		MTLSCACertPath:     getEnv("MTLS_CA_CERT_PATH", ""),
@@ -142,3 +267,60 @@ func getEnvBool(key string, defaultValue bool) bool {
 
 	return defaultValue
 }
+
+// getEnvFloat retrieves an environment variable as float64 or returns a default value.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+
+	return defaultValue
+}
+
+// getEnvList retrieves a comma-separated environment variable as a list, skipping empty items.
+func getEnvList(key string) []string {
+	var values []string
+
+	for item := range strings.SplitSeq(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
+// getEnvIntMap retrieves a comma-separated list of KEY:int pairs (e.g. "ES:8,CL:3") as a map,
+// skipping malformed pairs.
+func getEnvIntMap(key string) map[string]int {
+	values := make(map[string]int)
+
+	for _, item := range getEnvList(key) {
+		name, value, ok := strings.Cut(item, ":")
+		if !ok {
+			continue
+		}
+
+		intValue, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		values[strings.TrimSpace(name)] = intValue
+	}
+
+	return values
+}
+
+// getEnvDuration retrieves an environment variable as a duration (e.g. "500ms") or returns a default value.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+
+	return defaultValue
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -270,3 +271,192 @@ func TestEncryptionKeyLength(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_IBKRRetrySettings(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+	t.Setenv("IBKR_RETRY_MAX_ATTEMPTS", "5")
+	t.Setenv("IBKR_RETRY_INITIAL_BACKOFF", "50ms")
+	t.Setenv("IBKR_RETRY_MAX_BACKOFF", "invalid")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.IBKRRetryMaxAttempts != 5 {
+		t.Errorf("IBKRRetryMaxAttempts = %v, want 5", cfg.IBKRRetryMaxAttempts)
+	}
+	if cfg.IBKRRetryInitialBackoff != 50*time.Millisecond {
+		t.Errorf("IBKRRetryInitialBackoff = %v, want 50ms", cfg.IBKRRetryInitialBackoff)
+	}
+	if cfg.IBKRRetryMaxBackoff != DefaultIBKRRetryMaxBackoff {
+		t.Errorf("IBKRRetryMaxBackoff = %v, want default %v", cfg.IBKRRetryMaxBackoff, DefaultIBKRRetryMaxBackoff)
+	}
+}
+
+func TestLoad_IBKRRateLimits(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+	t.Setenv("IBKR_RATE_LIMIT_SNAPSHOT", "2.5")
+	t.Setenv("IBKR_RATE_LIMIT_TICKLE", "0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.IBKRRateLimitGlobal != DefaultIBKRRateLimitGlobal {
+		t.Errorf("IBKRRateLimitGlobal = %v, want %v", cfg.IBKRRateLimitGlobal, DefaultIBKRRateLimitGlobal)
+	}
+	if cfg.IBKRRateLimitSnapshot != 2.5 {
+		t.Errorf("IBKRRateLimitSnapshot = %v, want 2.5", cfg.IBKRRateLimitSnapshot)
+	}
+	if cfg.IBKRRateLimitTickle != 0 {
+		t.Errorf("IBKRRateLimitTickle = %v, want 0", cfg.IBKRRateLimitTickle)
+	}
+}
+
+func TestLoad_IBKRAutoConfirm(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+	t.Setenv("IBKR_AUTO_CONFIRM_MESSAGE_IDS", "o163, o354,,")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.IBKRAutoConfirmMessageIDs) != 2 || cfg.IBKRAutoConfirmMessageIDs[1] != "o354" {
+		t.Errorf("IBKRAutoConfirmMessageIDs = %v, want [o163 o354]", cfg.IBKRAutoConfirmMessageIDs)
+	}
+	if cfg.IBKRAutoConfirmAll {
+		t.Error("IBKRAutoConfirmAll should default to false")
+	}
+}
+
+func TestLoad_InstrumentCacheTTL(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.InstrumentCacheTTL != DefaultInstrumentCacheTTL {
+		t.Errorf("InstrumentCacheTTL = %v, want default %v", cfg.InstrumentCacheTTL, DefaultInstrumentCacheTTL)
+	}
+
+	t.Setenv("INSTRUMENT_CACHE_TTL", "6h")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.InstrumentCacheTTL != 6*time.Hour {
+		t.Errorf("InstrumentCacheTTL = %v, want 6h", cfg.InstrumentCacheTTL)
+	}
+}
+
+func TestLoad_BarCacheSettleDelay(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.BarCacheSettleDelay != DefaultBarCacheSettleDelay {
+		t.Errorf("BarCacheSettleDelay = %v, want default %v", cfg.BarCacheSettleDelay, DefaultBarCacheSettleDelay)
+	}
+
+	t.Setenv("BAR_CACHE_SETTLE_DELAY", "5m")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.BarCacheSettleDelay != 5*time.Minute {
+		t.Errorf("BarCacheSettleDelay = %v, want 5m", cfg.BarCacheSettleDelay)
+	}
+}
+
+func TestLoad_QuoteStream(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.QuoteStreamMinInterval != DefaultQuoteStreamMinInterval ||
+		cfg.QuoteStreamMaxInterval != DefaultQuoteStreamMaxInterval ||
+		cfg.QuoteStreamInterval != DefaultQuoteStreamInterval ||
+		cfg.QuoteStreamHeartbeatInterval != DefaultQuoteStreamHeartbeatInterval ||
+		cfg.QuoteStreamSnapshotInterval != DefaultQuoteStreamSnapshotInterval {
+		t.Errorf("Unexpected quote stream defaults: %+v", cfg)
+	}
+
+	t.Setenv("QUOTE_STREAM_MIN_INTERVAL", "500ms")
+	t.Setenv("QUOTE_STREAM_HEARTBEAT_INTERVAL", "30s")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.QuoteStreamMinInterval != 500*time.Millisecond || cfg.QuoteStreamHeartbeatInterval != 30*time.Second {
+		t.Errorf("QuoteStreamMinInterval = %v, QuoteStreamHeartbeatInterval = %v",
+			cfg.QuoteStreamMinInterval, cfg.QuoteStreamHeartbeatInterval)
+	}
+}
+
+func TestLoad_FuturesRollDays(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+	t.Setenv("FUTURES_ROLL_DAYS", "5")
+	t.Setenv("FUTURES_ROLL_DAYS_BY_SYMBOL", "ES:8, CL:3,bad,NQ:x")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.FuturesRollDays != 5 {
+		t.Errorf("FuturesRollDays = %v, want 5", cfg.FuturesRollDays)
+	}
+	if len(cfg.FuturesRollDaysBySymbol) != 2 || cfg.FuturesRollDaysBySymbol["ES"] != 8 || cfg.FuturesRollDaysBySymbol["CL"] != 3 {
+		t.Errorf("FuturesRollDaysBySymbol = %v, want map[CL:3 ES:8]", cfg.FuturesRollDaysBySymbol)
+	}
+}
+
+func TestLoad_IBKRSnapshotPolicy(t *testing.T) {
+	t.Setenv("DB_WRITE_DSN", "postgres://write")
+	t.Setenv("DB_READ_DSN", "postgres://read")
+	t.Setenv("ENCRYPTION_KEY", "12345678901234567890123456789012")
+	t.Setenv("IBKR_SNAPSHOT_MAX_WAIT", "0s")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.IBKRSnapshotMaxWait != 0 {
+		t.Errorf("IBKRSnapshotMaxWait = %v, want 0", cfg.IBKRSnapshotMaxWait)
+	}
+	if cfg.IBKRSnapshotPollInterval != DefaultIBKRSnapshotPollInterval {
+		t.Errorf("IBKRSnapshotPollInterval = %v, want default %v", cfg.IBKRSnapshotPollInterval, DefaultIBKRSnapshotPollInterval)
+	}
+}
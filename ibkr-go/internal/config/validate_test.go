@@ -18,7 +18,6 @@ func TestValidate(t *testing.T) {
 				DBWriteDSN:     "postgres://test",
 				DBReadDSN:      "postgres://test-read",
 				IBKRGatewayURL: "http://localhost:5000",
-				IBKRAccountID:  "U12345",
 				EncryptionKey:  []byte("0123456789abcdef0123456789abcdef"),
 			},
 			wantErr: false,
@@ -28,7 +27,6 @@ func TestValidate(t *testing.T) {
 			cfg: &Config{
 				DBWriteDSN:     "postgres://test",
 				IBKRGatewayURL: "http://localhost:5000",
-				IBKRAccountID:  "U12345",
 				EncryptionKey:  []byte("0123456789abcdef0123456789abcdef"),
 			},
 			wantErr: true,
@@ -38,7 +36,6 @@ func TestValidate(t *testing.T) {
 			cfg: &Config{
 				AppName:        "test",
 				IBKRGatewayURL: "http://localhost:5000",
-				IBKRAccountID:  "U12345",
 				EncryptionKey:  []byte("0123456789abcdef0123456789abcdef"),
 			},
 			wantErr: true,
@@ -48,7 +45,6 @@ func TestValidate(t *testing.T) {
 			cfg: &Config{
 				AppName:       "test",
 				DBWriteDSN:    "postgres://test",
-				IBKRAccountID: "U12345",
 				EncryptionKey: []byte("0123456789abcdef0123456789abcdef"),
 			},
 			wantErr: true,
@@ -69,7 +65,6 @@ func TestValidate(t *testing.T) {
 				AppName:        "test",
 				DBWriteDSN:     "postgres://test",
 				IBKRGatewayURL: "http://localhost:5000",
-				IBKRAccountID:  "U12345",
 			},
 			wantErr: true,
 		},
@@ -79,7 +74,6 @@ func TestValidate(t *testing.T) {
 				AppName:        "test",
 				DBWriteDSN:     "postgres://test",
 				IBKRGatewayURL: "http://localhost:5000",
-				IBKRAccountID:  "U12345",
 				EncryptionKey:  []byte("short"),
 			},
 			wantErr: true,
@@ -8,6 +8,51 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Bar struct {
+	Conid      int64            `json:"conid"`
+	BarSize    string           `json:"bar_size"`
+	OutsideRth bool             `json:"outside_rth"`
+	BarTime    pgtype.Timestamp `json:"bar_time"`
+	Open       float64          `json:"open"`
+	High       float64          `json:"high"`
+	Low        float64          `json:"low"`
+	Close      float64          `json:"close"`
+	Volume     int64            `json:"volume"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type BarCoverage struct {
+	ID         int64            `json:"id"`
+	Conid      int64            `json:"conid"`
+	BarSize    string           `json:"bar_size"`
+	OutsideRth bool             `json:"outside_rth"`
+	StartTime  pgtype.Timestamp `json:"start_time"`
+	EndTime    pgtype.Timestamp `json:"end_time"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type Instrument struct {
+	Conid        int64            `json:"conid"`
+	Symbol       string           `json:"symbol"`
+	SecType      string           `json:"sec_type"`
+	Exchange     string           `json:"exchange"`
+	Currency     string           `json:"currency"`
+	Multiplier   string           `json:"multiplier"`
+	TradingClass string           `json:"trading_class"`
+	Description  string           `json:"description"`
+	RefreshedAt  pgtype.Timestamp `json:"refreshed_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+type OrderReply struct {
+	ReplyID    string           `json:"reply_id"`
+	AccountID  string           `json:"account_id"`
+	OrderTypes []string         `json:"order_types"`
+	ExpiresAt  pgtype.Timestamp `json:"expires_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
 type Session struct {
 	ID                    pgtype.UUID      `json:"id"`
 	AccountID             string           `json:"account_id"`
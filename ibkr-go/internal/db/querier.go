@@ -9,10 +9,25 @@ import (
 )
 
 type Querier interface {
+	CreateOrderReply(ctx context.Context, arg CreateOrderReplyParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	DeleteBarCoverage(ctx context.Context, ids []int64) error
+	DeleteBarCoverageByConid(ctx context.Context, conid int64) error
+	DeleteBarsByConid(ctx context.Context, conid int64) (int64, error)
+	DeleteExpiredOrderReplies(ctx context.Context) error
 	DeleteExpiredSessions(ctx context.Context) error
+	DeleteInstrumentsNotIn(ctx context.Context, arg DeleteInstrumentsNotInParams) error
+	DeleteOrderReply(ctx context.Context, replyID string) error
 	DeleteSessionByHash(ctx context.Context, sessionTokenHash string) error
+	GetInstrument(ctx context.Context, conid int64) (Instrument, error)
+	GetOrderReply(ctx context.Context, arg GetOrderReplyParams) (OrderReply, error)
 	GetSessionByHash(ctx context.Context, sessionTokenHash string) (Session, error)
+	InsertBarCoverage(ctx context.Context, arg InsertBarCoverageParams) error
+	ListBarCoverage(ctx context.Context, arg ListBarCoverageParams) ([]BarCoverage, error)
+	ListBars(ctx context.Context, arg ListBarsParams) ([]Bar, error)
+	ListInstrumentsBySymbol(ctx context.Context, arg ListInstrumentsBySymbolParams) ([]Instrument, error)
+	UpsertBars(ctx context.Context, arg UpsertBarsParams) error
+	UpsertInstruments(ctx context.Context, arg UpsertInstrumentsParams) error
 }
 
 var _ Querier = (*Queries)(nil)
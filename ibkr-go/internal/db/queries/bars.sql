@@ -0,0 +1,70 @@
+-- name: ListBars :many
+SELECT * FROM bars
+WHERE conid = @conid
+AND bar_size = @bar_size
+AND outside_rth = @outside_rth
+AND bar_time >= @start_time
+AND bar_time < @end_time
+ORDER BY bar_time DESC
+LIMIT @max_bars;
+
+-- name: UpsertBars :exec
+INSERT INTO bars (
+    conid,
+    bar_size,
+    outside_rth,
+    bar_time,
+    open,
+    high,
+    low,
+    close,
+    volume
+)
+SELECT
+    @conid::bigint,
+    @bar_size::varchar,
+    @outside_rth::boolean,
+    unnest(@bar_times::timestamp[]),
+    unnest(@opens::double precision[]),
+    unnest(@highs::double precision[]),
+    unnest(@lows::double precision[]),
+    unnest(@closes::double precision[]),
+    unnest(@volumes::bigint[])
+ON CONFLICT (conid, bar_size, outside_rth, bar_time) DO UPDATE SET
+    open = EXCLUDED.open,
+    high = EXCLUDED.high,
+    low = EXCLUDED.low,
+    close = EXCLUDED.close,
+    volume = EXCLUDED.volume;
+
+-- name: ListBarCoverage :many
+SELECT * FROM bar_coverage
+WHERE conid = @conid
+AND bar_size = @bar_size
+AND outside_rth = @outside_rth
+AND start_time <= @end_time
+AND end_time >= @start_time
+ORDER BY start_time;
+
+-- name: InsertBarCoverage :exec
+INSERT INTO bar_coverage (
+    conid,
+    bar_size,
+    outside_rth,
+    start_time,
+    end_time
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: DeleteBarCoverage :exec
+DELETE FROM bar_coverage
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: DeleteBarCoverageByConid :exec
+DELETE FROM bar_coverage
+WHERE conid = $1;
+
+-- name: DeleteBarsByConid :execrows
+DELETE FROM bars
+WHERE conid = $1;
@@ -0,0 +1,47 @@
+-- name: GetInstrument :one
+SELECT * FROM instruments
+WHERE conid = $1
+LIMIT 1;
+
+-- name: ListInstrumentsBySymbol :many
+SELECT * FROM instruments
+WHERE symbol = $1
+AND sec_type = $2
+ORDER BY conid;
+
+-- name: UpsertInstruments :exec
+INSERT INTO instruments (
+    conid,
+    symbol,
+    sec_type,
+    exchange,
+    currency,
+    multiplier,
+    trading_class,
+    description
+)
+SELECT
+    unnest(@conids::bigint[]),
+    unnest(@symbols::varchar[]),
+    unnest(@sec_types::varchar[]),
+    unnest(@exchanges::varchar[]),
+    unnest(@currencies::varchar[]),
+    unnest(@multipliers::varchar[]),
+    unnest(@trading_classes::varchar[]),
+    unnest(@descriptions::varchar[])
+ON CONFLICT (conid) DO UPDATE SET
+    symbol = EXCLUDED.symbol,
+    sec_type = EXCLUDED.sec_type,
+    exchange = EXCLUDED.exchange,
+    currency = EXCLUDED.currency,
+    multiplier = EXCLUDED.multiplier,
+    trading_class = EXCLUDED.trading_class,
+    description = EXCLUDED.description,
+    refreshed_at = NOW(),
+    updated_at = NOW();
+
+-- name: DeleteInstrumentsNotIn :exec
+DELETE FROM instruments
+WHERE symbol = @symbol
+AND sec_type = @sec_type
+AND NOT (conid = ANY(@conids::bigint[]));
@@ -0,0 +1,28 @@
+-- name: CreateOrderReply :exec
+INSERT INTO order_replies (
+    reply_id,
+    account_id,
+    order_types,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (reply_id) DO UPDATE SET
+    account_id = EXCLUDED.account_id,
+    order_types = EXCLUDED.order_types,
+    expires_at = EXCLUDED.expires_at;
+
+-- name: GetOrderReply :one
+SELECT * FROM order_replies
+WHERE reply_id = $1
+AND account_id = $2
+AND expires_at > NOW()
+LIMIT 1;
+
+-- name: DeleteExpiredOrderReplies :exec
+DELETE FROM order_replies
+WHERE expires_at <= NOW();
+
+-- name: DeleteOrderReply :exec
+DELETE FROM order_replies
+WHERE reply_id = $1;
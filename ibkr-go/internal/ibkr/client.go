@@ -2,9 +2,7 @@ package ibkr
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -27,6 +25,18 @@ type AuthStatusResponse struct {
 	} `json:"serverInfo"`
 }
 
+// TickleResponse represents the response of the tickle endpoint.
+type TickleResponse struct {
+	// Session is the session token, used to authenticate WebSocket connections.
+	Session    string `json:"session"`
+	SSOExpires int64  `json:"ssoExpires"`
+	Collission bool   `json:"collission"`
+	UserID     int64  `json:"userId"`
+	Iserver    struct {
+		AuthStatus AuthStatusResponse `json:"authStatus"`
+	} `json:"iserver"`
+}
+
 // Account represents an IBKR account.
 type Account struct {
 	ID             string `json:"id"`
@@ -43,74 +53,109 @@ type Account struct {
 	ClearingStatus string `json:"clearingStatus"`
 	Covestor       bool   `json:"covestor"`
 	Parent         struct {
-		MmcID       int    `json:"mmc"`
-		AccountID   string `json:"accountId"`
-		IsMParent   bool   `json:"isMParent"`
-		IsMChild    bool   `json:"isMChild"`
-		IsMultiplex bool   `json:"isMultiplex"`
+		Mmc         []string `json:"mmc"`
+		AccountID   string   `json:"accountId"`
+		IsMParent   bool     `json:"isMParent"`
+		IsMChild    bool     `json:"isMChild"`
+		IsMultiplex bool     `json:"isMultiplex"`
 	} `json:"parent"`
 }
 
 // Client is an HTTP client for the IBKR Client Portal Gateway API.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	accountID  string
+	baseURL        string
+	httpClient     *http.Client
+	retryPolicy    RetryPolicy
+	limiter        *rateLimiter
+	confirmPolicy  ConfirmPolicy
+	snapshotPolicy SnapshotPolicy
+	logger         *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRetryPolicy sets the policy used to retry transient Gateway failures.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimits sets the client-side pacing limits for Gateway requests.
+func WithRateLimits(limits RateLimits) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(limits)
+	}
+}
+
+// WithLogger sets the logger used by the client.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
 }
 
-// NewClient creates a new IBKR Gateway client.
-func NewClient(baseURL, accountID string) *Client {
-	return &Client{
-		baseURL:   baseURL,
-		accountID: accountID,
+// NewClient creates a new IBKR Gateway client. The client is not bound to an
+// account: account-scoped methods take the account ID explicitly, and GetAccounts
+// lists the accounts available to the Gateway session.
+func NewClient(baseURL string, opts ...Option) *Client {
+	client := &Client{
+		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: DefaultHTTPTimeout,
 		},
+		retryPolicy:    DefaultRetryPolicy(),
+		limiter:        newRateLimiter(DefaultRateLimits()),
+		confirmPolicy:  ConfirmNone,
+		snapshotPolicy: DefaultSnapshotPolicy(),
+		logger:         slog.Default(),
 	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
 }
 
 // Ping checks if the Gateway is accessible.
 func (c *Client) Ping(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/api/tickle", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	return c.do(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/v1/api/tickle",
+		bucket: bucketTickle,
+	}, nil)
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to ping gateway: %w", err)
-	}
-	defer resp.Body.Close()
+// Tickle keeps the session alive and returns the session details, including the
+// session token required by the WebSocket API.
+func (c *Client) Tickle(ctx context.Context) (*TickleResponse, error) {
+	var tickle TickleResponse
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("gateway returned status %d", resp.StatusCode)
+	err := c.do(ctx, apiRequest{
+		method: http.MethodGet,
+		path:   "/v1/api/tickle",
+		bucket: bucketTickle,
+	}, &tickle)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &tickle, nil
 }
 
 // AuthStatus checks the current authentication status.
 func (c *Client) AuthStatus(ctx context.Context) (*AuthStatusResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/api/iserver/auth/status", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	var authStatus AuthStatusResponse
 
-	resp, err := c.httpClient.Do(req)
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodPost,
+		path:       "/v1/api/iserver/auth/status",
+		idempotent: true,
+	}, &authStatus)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check auth status: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("auth status check failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var authStatus AuthStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authStatus); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	return &authStatus, nil
@@ -118,48 +163,23 @@ func (c *Client) AuthStatus(ctx context.Context) (*AuthStatusResponse, error) {
 
 // Reauthenticate triggers reauthentication.
 func (c *Client) Reauthenticate(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/api/iserver/reauthenticate", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to reauthenticate: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-
-		return fmt.Errorf("reauthentication failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return c.do(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/v1/api/iserver/reauthenticate",
+	}, nil)
 }
 
 // GetAccounts retrieves the list of accounts.
 func (c *Client) GetAccounts(ctx context.Context) ([]Account, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/api/portfolio/accounts", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	var accounts []Account
 
-	resp, err := c.httpClient.Do(req)
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/portfolio/accounts",
+		idempotent: true,
+	}, &accounts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get accounts: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("get accounts failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var accounts []Account
-	if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	return accounts, nil
@@ -18,7 +18,7 @@ func TestClient_SearchContracts_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	contracts, err := client.SearchContracts(context.Background(), "AAPL")
 	if err != nil {
 		t.Errorf("SearchContracts() error = %v", err)
@@ -37,7 +37,7 @@ func TestClient_SearchContracts_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.SearchContracts(context.Background(), "INVALID")
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -54,7 +54,7 @@ func TestClient_GetMarketData_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	snapshots, err := client.GetMarketData(context.Background(), []int{265598}, nil)
 	if err != nil {
 		t.Errorf("GetMarketData() error = %v", err)
@@ -70,7 +70,7 @@ func TestClient_GetMarketData_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.GetMarketData(context.Background(), []int{999999}, nil)
 	if err == nil {
 		t.Error("Expected error, got nil")
@@ -89,7 +89,7 @@ func TestClient_GetHistoricalData_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	resp, err := client.GetHistoricalData(context.Background(), 265598, "1d", "1h")
 	if err != nil {
 		t.Errorf("GetHistoricalData() error = %v", err)
@@ -109,19 +109,19 @@ func TestClient_PlaceOrder_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	req := &PlaceOrderRequest{
+	client := NewClient(server.URL)
+	req := PlaceOrderRequest{
 		ConID:     265598,
 		OrderType: "MKT",
 		Side:      "BUY",
 		Quantity:  100,
 	}
-	resp, err := client.PlaceOrder(context.Background(), req)
+	resp, err := client.PlaceOrder(context.Background(), "U12345", req)
 	if err != nil {
 		t.Errorf("PlaceOrder() error = %v", err)
 	}
-	if resp.OrderID != "12345" {
-		t.Errorf("OrderID = %v, want 12345", resp.OrderID)
+	if resp[0].OrderID != "12345" {
+		t.Errorf("OrderID = %v, want 12345", resp[0].OrderID)
 	}
 }
 
@@ -131,8 +131,8 @@ func TestClient_PlaceOrder_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.PlaceOrder(context.Background(), &PlaceOrderRequest{})
+	client := NewClient(server.URL)
+	_, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{})
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -145,8 +145,8 @@ func TestClient_PlaceOrder_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.PlaceOrder(context.Background(), &PlaceOrderRequest{})
+	client := NewClient(server.URL)
+	_, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{})
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -162,11 +162,11 @@ func TestClient_ModifyOrder_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	req := &ModifyOrderRequest{
 		Quantity: 200,
 	}
-	resp, err := client.ModifyOrder(context.Background(), "12345", req)
+	resp, err := client.ModifyOrder(context.Background(), "U12345", "12345", req)
 	if err != nil {
 		t.Errorf("ModifyOrder() error = %v", err)
 	}
@@ -181,8 +181,8 @@ func TestClient_ModifyOrder_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.ModifyOrder(context.Background(), "123", &ModifyOrderRequest{})
+	client := NewClient(server.URL)
+	_, err := client.ModifyOrder(context.Background(), "U12345", "123", &ModifyOrderRequest{})
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -195,8 +195,8 @@ func TestClient_ModifyOrder_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.ModifyOrder(context.Background(), "123", &ModifyOrderRequest{})
+	client := NewClient(server.URL)
+	_, err := client.ModifyOrder(context.Background(), "U12345", "123", &ModifyOrderRequest{})
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -208,8 +208,8 @@ func TestClient_CancelOrder_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	err := client.CancelOrder(context.Background(), "12345")
+	client := NewClient(server.URL)
+	err := client.CancelOrder(context.Background(), "U12345", "12345")
 	if err != nil {
 		t.Errorf("CancelOrder() error = %v", err)
 	}
@@ -221,8 +221,8 @@ func TestClient_CancelOrder_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	err := client.CancelOrder(context.Background(), "12345")
+	client := NewClient(server.URL)
+	err := client.CancelOrder(context.Background(), "U12345", "12345")
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -240,8 +240,8 @@ func TestClient_GetLiveOrders_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	orders, err := client.GetLiveOrders(context.Background())
+	client := NewClient(server.URL)
+	orders, err := client.GetLiveOrders(context.Background(), "U12345")
 	if err != nil {
 		t.Errorf("GetLiveOrders() error = %v", err)
 	}
@@ -256,8 +256,8 @@ func TestClient_GetLiveOrders_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.GetLiveOrders(context.Background())
+	client := NewClient(server.URL)
+	_, err := client.GetLiveOrders(context.Background(), "U12345")
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -270,8 +270,8 @@ func TestClient_GetLiveOrders_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	_, err := client.GetLiveOrders(context.Background())
+	client := NewClient(server.URL)
+	_, err := client.GetLiveOrders(context.Background(), "U12345")
 	if err == nil {
 		t.Error("Expected error")
 	}
@@ -17,7 +17,7 @@ func TestClient_Ping(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	err := client.Ping(context.Background())
 	if err != nil {
 		t.Errorf("Ping() error = %v", err)
@@ -30,7 +30,7 @@ func TestClient_Ping_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	err := client.Ping(context.Background())
 	if err == nil {
 		t.Error("Expected error from Ping()")
@@ -45,7 +45,7 @@ func TestClient_AuthStatus(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	status, err := client.AuthStatus(context.Background())
 	if err != nil {
 		t.Fatalf("AuthStatus() error = %v", err)
@@ -61,7 +61,7 @@ func TestClient_AuthStatus_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.AuthStatus(context.Background())
 	if err == nil {
 		t.Error("Expected error")
@@ -75,7 +75,7 @@ func TestClient_AuthStatus_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.AuthStatus(context.Background())
 	if err == nil {
 		t.Error("Expected error")
@@ -88,7 +88,7 @@ func TestClient_Reauthenticate(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	err := client.Reauthenticate(context.Background())
 	if err != nil {
 		t.Errorf("Reauthenticate() error = %v", err)
@@ -101,13 +101,37 @@ func TestClient_Reauthenticate_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	err := client.Reauthenticate(context.Background())
 	if err == nil {
 		t.Error("Expected error")
 	}
 }
 
+func TestClient_Tickle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/api/tickle" {
+			t.Errorf("Expected path /v1/api/tickle, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"session":"abc123","iserver":{"authStatus":{"authenticated":true,"connected":true}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	tickle, err := client.Tickle(context.Background())
+	if err != nil {
+		t.Fatalf("Tickle() error = %v", err)
+	}
+	if tickle.Session != "abc123" {
+		t.Errorf("Session = %q, want abc123", tickle.Session)
+	}
+	if !tickle.Iserver.AuthStatus.Authenticated {
+		t.Error("Expected authenticated session")
+	}
+}
+
 func TestClient_GetAccounts(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -116,7 +140,7 @@ func TestClient_GetAccounts(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	accounts, err := client.GetAccounts(context.Background())
 	if err != nil {
 		t.Fatalf("GetAccounts() error = %v", err)
@@ -132,7 +156,7 @@ func TestClient_GetAccounts_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.GetAccounts(context.Background())
 	if err == nil {
 		t.Error("Expected error")
@@ -146,7 +170,7 @@ func TestClient_GetAccounts_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	_, err := client.GetAccounts(context.Background())
 	if err == nil {
 		t.Error("Expected error")
@@ -0,0 +1,107 @@
+package ibkr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// maxOrderReplies bounds the number of questions answered automatically for one request.
+const maxOrderReplies = 10
+
+// ConfirmPolicy decides whether an order confirmation question returned by the
+// Gateway (see OrderResponse.NeedsConfirmation) may be confirmed automatically.
+type ConfirmPolicy func(question *OrderResponse) bool
+
+// ConfirmNone never confirms questions automatically. It is the default policy.
+func ConfirmNone(*OrderResponse) bool {
+	return false
+}
+
+// ConfirmAll confirms every question automatically.
+func ConfirmAll(*OrderResponse) bool {
+	return true
+}
+
+// ConfirmMessageIDs returns a policy that confirms a question automatically when
+// all of its message IDs (e.g. "o163" for the price cap warning) are allowlisted.
+// Questions without message IDs are never confirmed.
+func ConfirmMessageIDs(ids ...string) ConfirmPolicy {
+	allowed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		allowed[id] = true
+	}
+
+	return func(question *OrderResponse) bool {
+		if len(question.MessageIDs) == 0 {
+			return false
+		}
+
+		return !slices.ContainsFunc(question.MessageIDs, func(id string) bool {
+			return !allowed[id]
+		})
+	}
+}
+
+// WithConfirmPolicy sets the policy used to answer order confirmation questions automatically.
+// A nil policy is equivalent to ConfirmNone.
+func WithConfirmPolicy(policy ConfirmPolicy) Option {
+	return func(c *Client) {
+		if policy == nil {
+			policy = ConfirmNone
+		}
+
+		c.confirmPolicy = policy
+	}
+}
+
+// answerQuestions confirms questions allowed by the confirm policy until the Gateway
+// returns responses that are not an automatically confirmable question.
+func (c *Client) answerQuestions(ctx context.Context, responses orderResponses) (orderResponses, error) {
+	for range maxOrderReplies {
+		orderResp, err := responses.first()
+		if err != nil {
+			return nil, err
+		}
+
+		if !orderResp.NeedsConfirmation() || !c.confirmPolicy(orderResp) {
+			return responses, nil
+		}
+
+		c.logger.InfoContext(ctx, "Auto-confirming order warning",
+			slog.String("reply_id", orderResp.ReplyID),
+			slog.Any("message_ids", orderResp.MessageIDs),
+			slog.Any("messages", orderResp.Message),
+		)
+
+		next, err := c.reply(ctx, orderResp.ReplyID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = next
+	}
+
+	return nil, fmt.Errorf("order still requires confirmation after %d replies", maxOrderReplies)
+}
+
+// reply sends a single answer to an order confirmation question.
+func (c *Client) reply(ctx context.Context, replyID string, confirmed bool) (orderResponses, error) {
+	var responses orderResponses
+
+	err := c.do(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   "/v1/api/iserver/reply/" + url.PathEscape(replyID),
+		body: struct {
+			Confirmed bool `json:"confirmed"`
+		}{Confirmed: confirmed},
+	}, &responses)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
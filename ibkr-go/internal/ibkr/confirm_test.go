@@ -0,0 +1,141 @@
+package ibkr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// questionGateway asks the given questions, in order, before accepting an order.
+func questionGateway(t *testing.T, questions []string, replies *[]string) *httptest.Server {
+	t.Helper()
+
+	next := 0
+	respond := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if next < len(questions) {
+			id := questions[next]
+			next++
+			w.Write([]byte(`[{"id":"reply-` + id + `","message":["warning ` + id + `"],"messageIds":["` + id + `"]}]`))
+
+			return
+		}
+
+		w.Write([]byte(`[{"order_id":"1001","order_status":"Submitted"}]`))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/api/iserver/account/U12345/orders", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)
+	})
+	mux.HandleFunc("/v1/api/iserver/reply/", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Confirmed bool `json:"confirmed"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !body.Confirmed {
+			t.Errorf("Expected confirmed reply, got %+v (err %v)", body, err)
+		}
+
+		*replies = append(*replies, r.URL.Path)
+		respond(w)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_PlaceOrder_AutoConfirmsAllowlistedQuestions(t *testing.T) {
+	var replies []string
+	server := questionGateway(t, []string{"o163", "o354"}, &replies)
+
+	client := NewClient(server.URL, WithConfirmPolicy(ConfirmMessageIDs("o163", "o354")))
+	responses, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{ConID: 265598})
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	resp := responses[0]
+
+	if resp.OrderID != "1001" || resp.NeedsConfirmation() {
+		t.Errorf("Expected submitted order, got %+v", resp)
+	}
+	if len(replies) != 2 || replies[0] != "/v1/api/iserver/reply/reply-o163" {
+		t.Errorf("Unexpected replies %v", replies)
+	}
+}
+
+func TestClient_PlaceOrder_ReturnsUnconfirmedQuestion(t *testing.T) {
+	var replies []string
+	server := questionGateway(t, []string{"o163", "o10331"}, &replies)
+
+	client := NewClient(server.URL, WithConfirmPolicy(ConfirmMessageIDs("o163")))
+	responses, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{ConID: 265598})
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	resp := responses[0]
+
+	if !resp.NeedsConfirmation() {
+		t.Fatalf("Expected a pending question, got %+v", resp)
+	}
+	if resp.ReplyID != "reply-o10331" {
+		t.Errorf("ReplyID = %v, want reply-o10331", resp.ReplyID)
+	}
+	if len(replies) != 1 {
+		t.Errorf("Expected 1 automatic reply, got %v", replies)
+	}
+
+	// The caller confirms the remaining question explicitly.
+	responses, err = client.ReplyOrder(context.Background(), resp.ReplyID, true)
+	if err != nil {
+		t.Fatalf("ReplyOrder() error = %v", err)
+	}
+	if responses[0].OrderID != "1001" {
+		t.Errorf("OrderID = %v, want 1001", responses[0].OrderID)
+	}
+}
+
+func TestClient_PlaceOrder_DefaultPolicyConfirmsNothing(t *testing.T) {
+	var replies []string
+	server := questionGateway(t, []string{"o163"}, &replies)
+
+	client := NewClient(server.URL)
+	responses, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{ConID: 265598})
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	resp := responses[0]
+
+	if !resp.NeedsConfirmation() || len(replies) != 0 {
+		t.Errorf("Expected the question to be returned unanswered, got %+v and replies %v", resp, replies)
+	}
+}
+
+func TestConfirmMessageIDs(t *testing.T) {
+	policy := ConfirmMessageIDs("o163", "o354")
+
+	tests := []struct {
+		name string
+		ids  []string
+		want bool
+	}{
+		{"all allowlisted", []string{"o163", "o354"}, true},
+		{"one not allowlisted", []string{"o163", "o10331"}, false},
+		{"no message IDs", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy(&OrderResponse{ReplyID: "r", MessageIDs: tt.ids}); got != tt.want {
+				t.Errorf("policy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
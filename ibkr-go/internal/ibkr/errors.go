@@ -0,0 +1,79 @@
+package ibkr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Gateway answers a request with a non-success status.
+type APIError struct {
+	// StatusCode is the HTTP status code returned by the Gateway.
+	StatusCode int
+	// Method is the HTTP method of the failed request.
+	Method string
+	// Endpoint is the request path, without the query string.
+	Endpoint string
+	// Message is the error message decoded from the Gateway response body, if any.
+	Message string
+	// Body is the raw response body.
+	Body string
+	// Retryable reports whether the failure is transient and the request may be retried.
+	Retryable bool
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
+	}
+
+	if msg == "" {
+		return fmt.Sprintf("%s %s failed with status %d", e.Method, e.Endpoint, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s %s failed with status %d: %s", e.Method, e.Endpoint, e.StatusCode, msg)
+}
+
+// errorBody is the error payload returned by the Gateway, e.g. {"error": "..."}.
+type errorBody struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// newAPIError builds an APIError from a failed Gateway response.
+func newAPIError(method, endpoint string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		Body:       strings.TrimSpace(string(body)),
+		Retryable:  isRetryableStatus(statusCode),
+	}
+
+	var decoded errorBody
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		apiErr.Message = decoded.Error
+		if apiErr.Message == "" {
+			apiErr.Message = decoded.Message
+		}
+	}
+
+	return apiErr
+}
+
+// isRetryableStatus reports whether a status code indicates a transient Gateway failure.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,93 @@
+package ibkr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_APIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantMessage   string
+		wantRetryable bool
+	}{
+		{
+			name:        "decoded error body",
+			status:      http.StatusUnauthorized,
+			body:        `{"error":"not authenticated","statusCode":401}`,
+			wantMessage: "not authenticated",
+		},
+		{
+			name:        "decoded message body",
+			status:      http.StatusNotFound,
+			body:        `{"message":"contract not found"}`,
+			wantMessage: "contract not found",
+		},
+		{
+			name:          "plain text body",
+			status:        http.StatusServiceUnavailable,
+			body:          "gateway unavailable",
+			wantRetryable: true,
+		},
+		{
+			name:          "rate limited",
+			status:        http.StatusTooManyRequests,
+			wantRetryable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+			_, err := client.SearchContracts(context.Background(), "AAPL")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Method != http.MethodGet {
+				t.Errorf("Method = %s, want GET", apiErr.Method)
+			}
+			if apiErr.Endpoint != "/v1/api/iserver/secdef/search" {
+				t.Errorf("Endpoint = %s, want /v1/api/iserver/secdef/search", apiErr.Endpoint)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("Body = %q, want %q", apiErr.Body, tt.body)
+			}
+			if apiErr.Retryable != tt.wantRetryable {
+				t.Errorf("Retryable = %v, want %v", apiErr.Retryable, tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	err := &APIError{StatusCode: 401, Method: "POST", Endpoint: "/v1/api/iserver/auth/status", Message: "no session"}
+	want := "POST /v1/api/iserver/auth/status failed with status 401: no session"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	err = &APIError{StatusCode: 503, Method: "GET", Endpoint: "/v1/api/tickle"}
+	want = "GET /v1/api/tickle failed with status 503"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
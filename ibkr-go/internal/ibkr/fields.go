@@ -0,0 +1,208 @@
+package ibkr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Market data snapshot field IDs.
+const (
+	FieldLastPrice              = "31"
+	FieldSymbol                 = "55"
+	FieldText                   = "58"
+	FieldHigh                   = "70"
+	FieldLow                    = "71"
+	FieldChange                 = "82"
+	FieldChangePercent          = "83"
+	FieldBid                    = "84"
+	FieldAskSize                = "85"
+	FieldAsk                    = "86"
+	FieldVolume                 = "87"
+	FieldBidSize                = "88"
+	FieldExchange               = "6004"
+	FieldConID                  = "6008"
+	FieldSecType                = "6070"
+	FieldMarketDataMarker       = "6119"
+	FieldUnderlyingConID        = "6457"
+	FieldMarketDataAvailability = "6509"
+	FieldCompanyName            = "7051"
+	FieldAskExchange            = "7057"
+	FieldLastExchange           = "7058"
+	FieldLastSize               = "7059"
+	FieldBidExchange            = "7068"
+	FieldImpliedHistVolRatio    = "7084"
+	FieldPutCallInterest        = "7085"
+	FieldPutCallVolume          = "7086"
+	FieldHistoricalVolatility   = "7087"
+	FieldOptionVolume           = "7089"
+	FieldCanBeTraded            = "7184"
+	FieldListingExchange        = "7221"
+	FieldAverageVolume          = "7282"
+	FieldOptionImpliedVol       = "7283"
+	FieldPutCallRatio           = "7285"
+	FieldDividendAmount         = "7286"
+	FieldDividendYield          = "7287"
+	FieldExDividendDate         = "7288"
+	FieldMarketCap              = "7289"
+	FieldPriceEarnings          = "7290"
+	FieldEarningsPerShare       = "7291"
+	FieldWeek52High             = "7293"
+	FieldWeek52Low              = "7294"
+	FieldOpen                   = "7295"
+	FieldClose                  = "7296"
+	FieldDelta                  = "7308"
+	FieldGamma                  = "7309"
+	FieldTheta                  = "7310"
+	FieldVega                   = "7311"
+	FieldImpliedVolatility      = "7633"
+	FieldMark                   = "7635"
+	FieldShortableShares        = "7636"
+	FieldFeeRate                = "7637"
+	FieldOptionOpenInterest     = "7638"
+	FieldShortable              = "7644"
+	FieldPriorClose             = "7741"
+	FieldVolumeLong             = "7762"
+	FieldHasTradingPermissions  = "7768"
+)
+
+// ErrUnknownField is returned for snapshot field names missing from the registry.
+var ErrUnknownField = errors.New("unknown market data field")
+
+// SnapshotField describes a market data snapshot field.
+type SnapshotField struct {
+	// ID is the field code sent to and returned by the Gateway, e.g. "31".
+	ID string
+	// Name is the stable name callers pick the field by, e.g. "last".
+	Name        string
+	Description string
+}
+
+// snapshotFields is the registry of the documented snapshot fields.
+var snapshotFields = []SnapshotField{
+	{ID: FieldLastPrice, Name: "last", Description: "Last price"},
+	{ID: FieldSymbol, Name: "symbol", Description: "Symbol"},
+	{ID: FieldText, Name: "text", Description: "Text"},
+	{ID: FieldHigh, Name: "high", Description: "Current day high price"},
+	{ID: FieldLow, Name: "low", Description: "Current day low price"},
+	{ID: FieldChange, Name: "change", Description: "Difference between the last price and the prior close"},
+	{ID: FieldChangePercent, Name: "change_percent", Description: "Change in percent"},
+	{ID: FieldBid, Name: "bid", Description: "Highest bid price"},
+	{ID: FieldAskSize, Name: "ask_size", Description: "Number of contracts or shares offered at the ask price"},
+	{ID: FieldAsk, Name: "ask", Description: "Lowest ask price"},
+	{ID: FieldVolume, Name: "volume", Description: "Volume for the day, formatted with K/M suffixes"},
+	{ID: FieldBidSize, Name: "bid_size", Description: "Number of contracts or shares bid at the bid price"},
+	{ID: FieldExchange, Name: "exchange", Description: "Exchange"},
+	{ID: FieldConID, Name: "conid", Description: "Contract identifier"},
+	{ID: FieldSecType, Name: "sec_type", Description: "Security type"},
+	{ID: FieldMarketDataMarker, Name: "market_data_marker", Description: "Market data delivery method marker"},
+	{ID: FieldUnderlyingConID, Name: "underlying_conid", Description: "Contract identifier of the underlying"},
+	{ID: FieldMarketDataAvailability, Name: "market_data_availability", Description: "Market data availability, e.g. RpB for real-time"},
+	{ID: FieldCompanyName, Name: "company_name", Description: "Company name"},
+	{ID: FieldAskExchange, Name: "ask_exchange", Description: "Exchanges offering at the ask price"},
+	{ID: FieldLastExchange, Name: "last_exchange", Description: "Exchange of the last trade"},
+	{ID: FieldLastSize, Name: "last_size", Description: "Size of the last trade"},
+	{ID: FieldBidExchange, Name: "bid_exchange", Description: "Exchanges bidding at the bid price"},
+	{ID: FieldImpliedHistVolRatio, Name: "implied_historical_volatility_ratio", Description: "Implied to historical volatility ratio, in percent"},
+	{ID: FieldPutCallInterest, Name: "put_call_interest", Description: "Put to call open interest ratio"},
+	{ID: FieldPutCallVolume, Name: "put_call_volume", Description: "Put to call volume ratio"},
+	{ID: FieldHistoricalVolatility, Name: "historical_volatility", Description: "30-day historical volatility, in percent"},
+	{ID: FieldOptionVolume, Name: "option_volume", Description: "Option volume"},
+	{ID: FieldCanBeTraded, Name: "can_be_traded", Description: "Whether the contract can be traded"},
+	{ID: FieldListingExchange, Name: "listing_exchange", Description: "Primary listing exchange"},
+	{ID: FieldAverageVolume, Name: "average_volume", Description: "90-day average daily volume"},
+	{ID: FieldOptionImpliedVol, Name: "option_implied_volatility", Description: "Implied volatility of the underlying from its options, in percent"},
+	{ID: FieldPutCallRatio, Name: "put_call_ratio", Description: "Put to call ratio"},
+	{ID: FieldDividendAmount, Name: "dividend_amount", Description: "Next expected dividend"},
+	{ID: FieldDividendYield, Name: "dividend_yield", Description: "Dividend yield, in percent"},
+	{ID: FieldExDividendDate, Name: "ex_dividend_date", Description: "Ex-date of the dividend"},
+	{ID: FieldMarketCap, Name: "market_cap", Description: "Market capitalization"},
+	{ID: FieldPriceEarnings, Name: "pe", Description: "Price to earnings ratio"},
+	{ID: FieldEarningsPerShare, Name: "eps", Description: "Earnings per share"},
+	{ID: FieldWeek52High, Name: "week52_high", Description: "52-week high price"},
+	{ID: FieldWeek52Low, Name: "week52_low", Description: "52-week low price"},
+	{ID: FieldOpen, Name: "open", Description: "Opening price of the day"},
+	{ID: FieldClose, Name: "close", Description: "Closing price of the day"},
+	{ID: FieldDelta, Name: "delta", Description: "Option delta"},
+	{ID: FieldGamma, Name: "gamma", Description: "Option gamma"},
+	{ID: FieldTheta, Name: "theta", Description: "Option theta"},
+	{ID: FieldVega, Name: "vega", Description: "Option vega"},
+	{ID: FieldImpliedVolatility, Name: "implied_volatility", Description: "Implied volatility of the option, in percent"},
+	{ID: FieldMark, Name: "mark", Description: "Mark price"},
+	{ID: FieldShortableShares, Name: "shortable_shares", Description: "Number of shares available to short"},
+	{ID: FieldFeeRate, Name: "fee_rate", Description: "Borrow fee rate"},
+	{ID: FieldOptionOpenInterest, Name: "option_open_interest", Description: "Option open interest"},
+	{ID: FieldShortable, Name: "shortable", Description: "Shortable difficulty"},
+	{ID: FieldPriorClose, Name: "prior_close", Description: "Closing price of the previous day"},
+	{ID: FieldVolumeLong, Name: "volume_long", Description: "Volume for the day, unformatted"},
+	{ID: FieldHasTradingPermissions, Name: "has_trading_permissions", Description: "Whether the account has trading permissions for the contract"},
+}
+
+var (
+	fieldsByName = make(map[string]SnapshotField, len(snapshotFields))
+	fieldsByID   = make(map[string]SnapshotField, len(snapshotFields))
+)
+
+func init() {
+	for _, field := range snapshotFields {
+		fieldsByName[field.Name] = field
+		fieldsByID[field.ID] = field
+	}
+}
+
+// DefaultSnapshotFields are the fields requested when GetMarketData is called without fields.
+var DefaultSnapshotFields = []string{
+	FieldLastPrice,
+	FieldSymbol,
+	FieldHigh,
+	FieldLow,
+	FieldBid,
+	FieldAsk,
+	FieldVolume,
+	FieldOpen,
+	FieldClose,
+	FieldVolumeLong,
+}
+
+// preflightIgnoredFields are returned even by pre-flight snapshots, so they do not
+// tell that the snapshot has data.
+var preflightIgnoredFields = map[string]bool{
+	FieldMarketDataMarker:       true,
+	FieldMarketDataAvailability: true,
+}
+
+// SnapshotFields returns the registry of snapshot fields.
+func SnapshotFields() []SnapshotField {
+	fields := make([]SnapshotField, len(snapshotFields))
+	copy(fields, snapshotFields)
+
+	return fields
+}
+
+// LookupField returns the snapshot field with the given name or ID.
+func LookupField(nameOrID string) (SnapshotField, bool) {
+	if field, ok := fieldsByName[strings.ToLower(nameOrID)]; ok {
+		return field, true
+	}
+
+	field, ok := fieldsByID[nameOrID]
+
+	return field, ok
+}
+
+// FieldIDs returns the IDs of the named snapshot fields. It returns an error wrapping
+// ErrUnknownField for names missing from the registry.
+func FieldIDs(names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+
+	for _, name := range names {
+		field, ok := LookupField(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+
+		ids = append(ids, field.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,58 @@
+package ibkr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupField(t *testing.T) {
+	tests := []struct {
+		nameOrID string
+		wantID   string
+	}{
+		{nameOrID: "last", wantID: FieldLastPrice},
+		{nameOrID: "BID_SIZE", wantID: FieldBidSize},
+		{nameOrID: "market_data_availability", wantID: FieldMarketDataAvailability},
+		{nameOrID: "7633", wantID: FieldImpliedVolatility},
+	}
+
+	for _, tt := range tests {
+		field, ok := LookupField(tt.nameOrID)
+		if !ok || field.ID != tt.wantID {
+			t.Errorf("LookupField(%q) = %+v, %v, want ID %s", tt.nameOrID, field, ok, tt.wantID)
+		}
+	}
+
+	if _, ok := LookupField("nope"); ok {
+		t.Error("LookupField(nope) should not find a field")
+	}
+}
+
+func TestFieldIDs(t *testing.T) {
+	ids, err := FieldIDs([]string{"bid", "ask", "delta"})
+	if err != nil {
+		t.Fatalf("FieldIDs() error = %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != FieldBid || ids[2] != FieldDelta {
+		t.Errorf("FieldIDs() = %v", ids)
+	}
+
+	if _, err := FieldIDs([]string{"bid", "nope"}); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestSnapshotFields_Unique(t *testing.T) {
+	names := make(map[string]bool)
+	ids := make(map[string]bool)
+
+	for _, field := range SnapshotFields() {
+		if names[field.Name] || ids[field.ID] {
+			t.Errorf("Duplicate field %+v", field)
+		}
+
+		names[field.Name] = true
+		ids[field.ID] = true
+	}
+}
@@ -0,0 +1,218 @@
+package ibkr
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecTypeFuture is the security type of futures contracts.
+const SecTypeFuture = "FUT"
+
+// expiryDateLayout is the layout of futures expiration dates, e.g. 20241220.
+const expiryDateLayout = "20060102"
+
+// FutureContract represents a futures contract listed for an underlying symbol.
+type FutureContract struct {
+	Symbol          string `json:"symbol"`
+	ConID           int    `json:"conid"`
+	UnderlyingConID int    `json:"underlyingConid"`
+	// ExpirationDate is the expiration date as a YYYYMMDD number.
+	ExpirationDate int `json:"expirationDate"`
+	// LastTradingDay is the last trading day as a YYYYMMDD number.
+	LastTradingDay     int `json:"ltd"`
+	ShortFuturesCutOff int `json:"shortFuturesCutOff"`
+	LongFuturesCutOff  int `json:"longFuturesCutOff"`
+}
+
+// lastTradingDay returns the last trading day of the contract, falling back to the
+// expiration date when the Gateway did not return one.
+func (f FutureContract) lastTradingDay() (time.Time, error) {
+	day := f.LastTradingDay
+	if day == 0 {
+		day = f.ExpirationDate
+	}
+
+	return time.Parse(expiryDateLayout, strconv.Itoa(day))
+}
+
+// RollRules decide when front-month futures resolution moves on to the next contract.
+type RollRules struct {
+	// Days is the number of days before the last trading day on which the front month
+	// rolls to the next contract. Zero keeps a contract until its last trading day.
+	Days int
+	// DaysBySymbol overrides Days for specific root symbols, e.g. {"CL": 3}.
+	DaysBySymbol map[string]int
+}
+
+// days returns the roll days of a root symbol.
+func (r RollRules) days(symbol string) int {
+	if days, ok := r.DaysBySymbol[symbol]; ok {
+		return days
+	}
+
+	return r.Days
+}
+
+// GetFutures retrieves the non-expired futures contracts of the given root symbols, keyed by symbol.
+func (c *Client) GetFutures(ctx context.Context, symbols []string) (map[string][]FutureContract, error) {
+	params := url.Values{}
+	params.Set("symbols", strings.Join(symbols, ","))
+
+	var futures map[string][]FutureContract
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/trsrv/futures",
+		query:      params,
+		idempotent: true,
+	}, &futures)
+	if err != nil {
+		return nil, err
+	}
+
+	return futures, nil
+}
+
+// resolveFuture picks the futures contract of a root symbol: the contract expiring on
+// the query expiry when it is set, the front month under the roll rules otherwise.
+// Contracts not listed on the query exchange or in the query currency are left out
+// before picking, so the front month is the front month of that listing.
+func (r *Resolver) resolveFuture(ctx context.Context, query ContractQuery) (*ResolvedContract, error) {
+	futures, err := r.client.GetFutures(ctx, []string{query.Symbol})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get futures: %w", err)
+	}
+
+	contracts := slices.Clone(futures[query.Symbol])
+	slices.SortFunc(contracts, func(a, b FutureContract) int {
+		return cmp.Compare(a.ExpirationDate, b.ExpirationDate)
+	})
+
+	var candidates []FutureContract
+	if query.Expiry != "" {
+		candidates = expiryMatches(query.Expiry, contracts)
+	} else {
+		candidates, err = r.unrolled(query.Symbol, contracts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates, err = r.listed(ctx, query, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// The front month is the first contract of the listing.
+	if query.Expiry == "" && len(candidates) > 1 {
+		candidates = candidates[:1]
+	}
+
+	matches := make([]ResolvedContract, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		contract := ResolvedContract{
+			ConID:   candidate.ConID,
+			Symbol:  query.Symbol,
+			SecType: SecTypeFuture,
+			Expiry:  strconv.Itoa(candidate.ExpirationDate),
+		}
+
+		if err := r.describe(ctx, &contract); err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, contract)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrContractNotFound, query)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &AmbiguousContractError{Query: query, Candidates: matches}
+	}
+}
+
+// listed returns the candidates listed on the query exchange and in the query currency.
+// The Gateway lists futures without their exchange and currency, so the listings of all
+// candidates are looked up in a single request before any of them is described.
+func (r *Resolver) listed(ctx context.Context, query ContractQuery, candidates []FutureContract) ([]FutureContract, error) {
+	if (query.Exchange == "" && query.Currency == "") || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	conIDs := make([]int, 0, len(candidates))
+	for _, candidate := range candidates {
+		conIDs = append(conIDs, candidate.ConID)
+	}
+
+	secDefs, err := r.client.GetSecDefs(ctx, conIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security definitions: %w", err)
+	}
+
+	listings := make(map[int]SecDef, len(secDefs))
+	for _, secDef := range secDefs {
+		listings[secDef.ConID] = secDef
+	}
+
+	var listed []FutureContract
+
+	for _, candidate := range candidates {
+		listing, ok := listings[candidate.ConID]
+		if !ok ||
+			(query.Exchange != "" && !strings.EqualFold(listing.ListingExchange, query.Exchange)) ||
+			(query.Currency != "" && !strings.EqualFold(listing.Currency, query.Currency)) {
+			continue
+		}
+
+		listed = append(listed, candidate)
+	}
+
+	return listed, nil
+}
+
+// unrolled returns the contracts that have not reached their roll date, the given
+// number of days before their last trading day. Contracts are sorted by expiration, so
+// the first one returned is the front month.
+func (r *Resolver) unrolled(symbol string, contracts []FutureContract) ([]FutureContract, error) {
+	now := r.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	rollDays := r.rollRules.days(symbol)
+
+	for i := range contracts {
+		lastTradingDay, err := contracts[i].lastTradingDay()
+		if err != nil {
+			return nil, fmt.Errorf("invalid last trading day of future %d: %w", contracts[i].ConID, err)
+		}
+
+		if !today.After(lastTradingDay.AddDate(0, 0, -rollDays)) {
+			return contracts[i:], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// expiryMatches returns the contracts whose expiration date matches an expiry, either
+// a month (YYYYMM) or a date (YYYYMMDD).
+func expiryMatches(expiry string, contracts []FutureContract) []FutureContract {
+	var matches []FutureContract
+
+	for _, contract := range contracts {
+		if strings.HasPrefix(strconv.Itoa(contract.ExpirationDate), expiry) {
+			matches = append(matches, contract)
+		}
+	}
+
+	return matches
+}
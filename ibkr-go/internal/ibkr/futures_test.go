@@ -0,0 +1,275 @@
+package ibkr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFuturesServer serves the ES futures, their listings and their contract details.
+func newFuturesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v1/api/trsrv/futures":
+			if r.URL.Query().Get("symbols") != "ES" {
+				w.Write([]byte(`{}`))
+
+				return
+			}
+
+			// Listed out of order on purpose.
+			w.Write([]byte(`{"ES":[
+				{"symbol":"ES","conid":551601561,"underlyingConid":11004968,"expirationDate":20240621,"ltd":20240621},
+				{"symbol":"ES","conid":495512563,"underlyingConid":11004968,"expirationDate":20240315,"ltd":20240315},
+				{"symbol":"ES","conid":568550526,"underlyingConid":11004968,"expirationDate":20240920,"ltd":20240920}
+			]}`))
+		case "/v1/api/trsrv/secdef":
+			w.Write([]byte(`{"secdef":[
+				{"conid":551601561,"ticker":"ES","assetClass":"FUT","listingExchange":"CME","currency":"USD"},
+				{"conid":495512563,"ticker":"ES","assetClass":"FUT","listingExchange":"CME","currency":"USD"},
+				{"conid":568550526,"ticker":"ES","assetClass":"FUT","listingExchange":"CME","currency":"USD"}
+			]}`))
+		case "/v1/api/iserver/secdef/info":
+			if r.URL.Query().Get("sectype") != SecTypeFuture {
+				t.Errorf("sectype = %q, want FUT", r.URL.Query().Get("sectype"))
+			}
+
+			w.Write([]byte(`[{"conid":` + r.URL.Query().Get("conid") +
+				`,"symbol":"ES","secType":"FUT","listingExchange":"CME","currency":"USD","multiplier":"50","tradingClass":"ES"}]`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func newFuturesResolver(serverURL string, today string, rules RollRules) *Resolver {
+	resolver := NewResolver(NewClient(serverURL), WithRollRules(rules))
+	resolver.now = func() time.Time {
+		now, _ := time.Parse(expiryDateLayout, today)
+
+		return now
+	}
+
+	return resolver
+}
+
+func TestClient_GetFutures(t *testing.T) {
+	server := newFuturesServer(t)
+	defer server.Close()
+
+	futures, err := NewClient(server.URL).GetFutures(context.Background(), []string{"ES"})
+	if err != nil {
+		t.Fatalf("GetFutures() error = %v", err)
+	}
+
+	if len(futures["ES"]) != 3 || futures["ES"][1].LastTradingDay != 20240315 {
+		t.Errorf("Unexpected futures: %+v", futures)
+	}
+}
+
+func TestResolver_FrontMonth(t *testing.T) {
+	server := newFuturesServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		today     string
+		rules     RollRules
+		wantConID int
+	}{
+		{name: "nearest contract", today: "20240301", wantConID: 495512563},
+		{name: "last trading day", today: "20240315", wantConID: 495512563},
+		{name: "after last trading day", today: "20240316", wantConID: 551601561},
+		{name: "rolled before expiry", today: "20240308", rules: RollRules{Days: 8}, wantConID: 551601561},
+		{name: "not yet rolled", today: "20240306", rules: RollRules{Days: 8}, wantConID: 495512563},
+		{name: "symbol override", today: "20240308", rules: RollRules{Days: 8, DaysBySymbol: map[string]int{"ES": 2}}, wantConID: 495512563},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := newFuturesResolver(server.URL, tt.today, tt.rules)
+
+			contract, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "es", SecType: "FUT"})
+			if err != nil {
+				t.Fatalf("ResolveContract() error = %v", err)
+			}
+
+			if contract.ConID != tt.wantConID {
+				t.Errorf("ConID = %d, want %d", contract.ConID, tt.wantConID)
+			}
+
+			if contract.Exchange != "CME" || contract.Multiplier != "50" || contract.Expiry == "" {
+				t.Errorf("Unexpected contract: %+v", contract)
+			}
+		})
+	}
+}
+
+func TestResolver_FutureExpiry(t *testing.T) {
+	server := newFuturesServer(t)
+	defer server.Close()
+
+	resolver := newFuturesResolver(server.URL, "20240301", RollRules{})
+
+	contract, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "ES", SecType: "FUT", Expiry: "202409"})
+	if err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if contract.ConID != 568550526 || contract.Expiry != "20240920" {
+		t.Errorf("Unexpected contract: %+v", contract)
+	}
+
+	tests := []ContractQuery{
+		{Symbol: "ES", SecType: "FUT", Expiry: "202412"},
+		{Symbol: "ES", SecType: "FUT", Currency: "EUR"},
+		{Symbol: "NQ", SecType: "FUT"},
+	}
+
+	for _, query := range tests {
+		if _, err := resolver.ResolveContract(context.Background(), query); !errors.Is(err, ErrContractNotFound) {
+			t.Errorf("ResolveContract(%v) error = %v, want ErrContractNotFound", query, err)
+		}
+	}
+}
+
+func TestResolver_FutureExchange(t *testing.T) {
+	// The symbol is listed on two exchanges whose front months differ.
+	listings := map[string]string{
+		"1001": `"listingExchange":"CME","currency":"USD"`,
+		"1002": `"listingExchange":"EUREX","currency":"EUR"`,
+		"1003": `"listingExchange":"CME","currency":"USD"`,
+		"1004": `"listingExchange":"EUREX","currency":"EUR"`,
+	}
+
+	var described atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v1/api/trsrv/futures":
+			w.Write([]byte(`{"BTC":[
+				{"symbol":"BTC","conid":1001,"expirationDate":20240329,"ltd":20240329},
+				{"symbol":"BTC","conid":1002,"expirationDate":20240419,"ltd":20240419},
+				{"symbol":"BTC","conid":1003,"expirationDate":20240426,"ltd":20240426},
+				{"symbol":"BTC","conid":1004,"expirationDate":20240517,"ltd":20240517}
+			]}`))
+		case "/v1/api/trsrv/secdef":
+			var body struct {
+				ConIDs []int `json:"conids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode secdef request: %v", err)
+			}
+
+			secDefs := make([]string, 0, len(body.ConIDs))
+			for _, conID := range body.ConIDs {
+				id := strconv.Itoa(conID)
+				secDefs = append(secDefs, `{"conid":`+id+`,"ticker":"BTC","assetClass":"FUT",`+listings[id]+`}`)
+			}
+
+			w.Write([]byte(`{"secdef":[` + strings.Join(secDefs, ",") + `]}`))
+		case "/v1/api/iserver/secdef/info":
+			described.Add(1)
+
+			conID := r.URL.Query().Get("conid")
+			w.Write([]byte(`[{"conid":` + conID + `,"symbol":"BTC","secType":"FUT",` + listings[conID] + `}]`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	resolver := newFuturesResolver(server.URL, "20240301", RollRules{})
+
+	tests := []struct {
+		name      string
+		query     ContractQuery
+		wantConID int
+	}{
+		{name: "overall front month", query: ContractQuery{Symbol: "BTC", SecType: "FUT"}, wantConID: 1001},
+		{name: "front month of exchange", query: ContractQuery{Symbol: "BTC", SecType: "FUT", Exchange: "EUREX"}, wantConID: 1002},
+		{name: "front month of currency", query: ContractQuery{Symbol: "BTC", SecType: "FUT", Currency: "EUR"}, wantConID: 1002},
+		{name: "expiry on exchange", query: ContractQuery{Symbol: "BTC", SecType: "FUT", Exchange: "CME", Expiry: "202404"}, wantConID: 1003},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			described.Store(0)
+
+			contract, err := resolver.ResolveContract(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("ResolveContract() error = %v", err)
+			}
+
+			if contract.ConID != tt.wantConID {
+				t.Errorf("ConID = %d, want %d", contract.ConID, tt.wantConID)
+			}
+
+			// Only the picked contract is described.
+			if n := described.Load(); n != 1 {
+				t.Errorf("Described %d contracts, want 1", n)
+			}
+		})
+	}
+
+	// Both exchanges list an April contract.
+	var ambiguous *AmbiguousContractError
+
+	_, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "BTC", SecType: "FUT", Expiry: "202404"})
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Errorf("ResolveContract() error = %v, want an ambiguous contract with 2 candidates", err)
+	}
+}
+
+func TestResolver_ExpiryRequiresFuture(t *testing.T) {
+	resolver := NewResolver(NewClient("http://localhost"))
+
+	_, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "AAPL", Expiry: "202409"})
+	if !errors.Is(err, ErrUnsupportedSecType) {
+		t.Errorf("Expected ErrUnsupportedSecType, got %v", err)
+	}
+}
+
+func TestClient_GetSecDefs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/api/trsrv/secdef" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			ConIDs []int `json:"conids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.ConIDs) != 2 {
+			t.Errorf("Unexpected body %+v (err = %v)", body, err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"secdef":[
+			{"conid":495512563,"ticker":"ES","assetClass":"FUT","listingExchange":"CME","currency":"USD"},
+			{"conid":551601561,"ticker":"ES","assetClass":"FUT","listingExchange":"CME","currency":"USD"}
+		]}`))
+	}))
+	defer server.Close()
+
+	secDefs, err := NewClient(server.URL).GetSecDefs(context.Background(), []int{495512563, 551601561})
+	if err != nil {
+		t.Fatalf("GetSecDefs() error = %v", err)
+	}
+
+	if len(secDefs) != 2 || secDefs[1].ConID != 551601561 || secDefs[1].ListingExchange != "CME" {
+		t.Errorf("Unexpected security definitions: %+v", secDefs)
+	}
+}
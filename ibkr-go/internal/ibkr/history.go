@@ -0,0 +1,287 @@
+package ibkr
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// historyWindowBars is the number of bars requested per window. The Gateway returns
+	// at most 1000 points per request; windows stay well below so they are not truncated.
+	historyWindowBars = 500
+	// historyMaxPoints is the most points the Gateway returns for one request.
+	historyMaxPoints = 1000
+	// maxHistoryWindows bounds the number of requests made for one call.
+	maxHistoryWindows = 1000
+
+	// Largest periods of each unit the Gateway accepts.
+	maxHistoryMinutes = 30
+	maxHistoryHours   = 8
+	maxHistoryDays    = 1000
+
+	// historyTimeLayout is the layout of the startTime parameter, in UTC.
+	historyTimeLayout = "20060102-15:04:05"
+
+	historyDay = 24 * time.Hour
+)
+
+// HistoryBarSizes are the bar sizes the Gateway serves, smallest first.
+var HistoryBarSizes = []string{"1min", "2min", "3min", "5min", "10min", "15min", "30min", "1h", "1d", "1w", "1m"}
+
+// ErrInvalidHistoryRequest is returned for history requests with a malformed period or bar size.
+var ErrInvalidHistoryRequest = errors.New("invalid history request")
+
+// historyDurationPattern matches periods and bar sizes such as "30min", "1h", "2w" or "1y".
+var historyDurationPattern = regexp.MustCompile(`^([0-9]+)(min|mins|h|hour|hours|d|day|days|w|week|weeks|m|month|months|y|year|years)$`)
+
+// historyUnits are the durations of the period and bar size units. Months and years are
+// approximated; windows are bounded by the bars returned rather than by these durations.
+var historyUnits = map[string]time.Duration{
+	"min":    time.Minute,
+	"mins":   time.Minute,
+	"h":      time.Hour,
+	"hour":   time.Hour,
+	"hours":  time.Hour,
+	"d":      historyDay,
+	"day":    historyDay,
+	"days":   historyDay,
+	"w":      7 * historyDay,
+	"week":   7 * historyDay,
+	"weeks":  7 * historyDay,
+	"m":      30 * historyDay,
+	"month":  30 * historyDay,
+	"months": 30 * historyDay,
+	"y":      365 * historyDay,
+	"year":   365 * historyDay,
+	"years":  365 * historyDay,
+}
+
+// HistoryRequest describes the bars of a contract to fetch with GetHistory.
+type HistoryRequest struct {
+	ConID int
+	// Period is the lookback from End, e.g. "1y". It is ignored when Start is set.
+	Period string
+	// BarSize is the size of the bars, e.g. "1min" or "1d".
+	BarSize string
+	// Start is the start of the bars, inclusive. Defaults to End minus Period.
+	Start time.Time
+	// End is the end of the bars, exclusive. Defaults to now.
+	End time.Time
+	// Limit is the most bars returned, the most recent ones first. Zero means no limit.
+	Limit int
+	// OutsideRTH includes bars outside regular trading hours.
+	OutsideRTH bool
+}
+
+// Range returns the start and end of the requested bars, with now as the default end.
+func (r HistoryRequest) Range(now time.Time) (time.Time, time.Time, error) {
+	end := r.End
+	if end.IsZero() {
+		end = now
+	}
+
+	start := r.Start
+	if start.IsZero() {
+		period, err := ParseHistoryDuration(r.Period)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		start = end.Add(-period)
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start %s is not before end %s", ErrInvalidHistoryRequest, start, end)
+	}
+
+	return start, end, nil
+}
+
+// History holds the bars fetched by GetHistory, oldest first, in Data. Unlike those of
+// GetHistoricalData, the bars are in real prices and volumes, scaled by the factors of
+// the response of each window; the metadata is that of the most recent window.
+type History struct {
+	HistoricalDataResponse
+
+	// Start is the start of the requested bars.
+	Start time.Time
+	// More reports that Limit cut off older bars within the requested range.
+	More bool
+}
+
+// ParseHistoryDuration returns the duration of a period or bar size such as "1min" or "2w".
+func ParseHistoryDuration(s string) (time.Duration, error) {
+	match := historyDurationPattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("%w: malformed duration %q", ErrInvalidHistoryRequest, s)
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%w: malformed duration %q", ErrInvalidHistoryRequest, s)
+	}
+
+	return time.Duration(n) * historyUnits[match[2]], nil
+}
+
+// GetHistoricalData retrieves historical market data with a single Gateway request, which
+// the Gateway caps at 1000 points. GetHistory fetches longer lookbacks.
+func (c *Client) GetHistoricalData(
+	ctx context.Context,
+	conID int,
+	period, barSize string,
+) (*HistoricalDataResponse, error) {
+	return c.history(ctx, HistoryRequest{ConID: conID, BarSize: barSize}, period, time.Time{})
+}
+
+// GetHistory retrieves the bars of a contract over any lookback. Ranges longer than a
+// single request can return are split into windows walking back from the end with the
+// startTime parameter, one request at a time through the history rate limit, and the
+// bars of the windows are de-duplicated and merged.
+func (c *Client) GetHistory(ctx context.Context, req HistoryRequest) (*History, error) {
+	barSize, err := ParseHistoryDuration(req.BarSize)
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := !req.Start.IsZero() || !req.End.IsZero()
+
+	start, end, err := req.Range(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	history := &History{Start: start}
+	bars := make(map[int64]HistoricalBar)
+	windowEnd := end
+
+	for window := 0; window < maxHistoryWindows && windowEnd.After(start); window++ {
+		span := min(windowEnd.Sub(start), barSize*historyWindowBars)
+		period, covered := historyPeriod(span)
+		endParam := windowEnd
+
+		// A lookback that fits a single request is passed through as requested, and its
+		// bars are trusted to be within the period.
+		passthrough := window == 0 && !explicit && span == end.Sub(start)
+		if passthrough {
+			period, covered, endParam = req.Period, span, time.Time{}
+		}
+
+		resp, err := c.history(ctx, req, period, endParam)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.scale()
+
+		if window == 0 {
+			history.HistoricalDataResponse = *resp
+		}
+
+		earliest := windowEnd.UnixMilli()
+
+		for _, bar := range resp.Data {
+			earliest = min(earliest, bar.Time)
+
+			if passthrough || (bar.Time >= start.UnixMilli() && bar.Time < end.UnixMilli()) {
+				bars[bar.Time] = bar
+			}
+		}
+
+		switch {
+		case len(resp.Data) == 0:
+			// Nothing traded in the window; keep walking back.
+			windowEnd = windowEnd.Add(-covered)
+		case earliest >= windowEnd.UnixMilli():
+			// The window returned nothing older, so there is nothing further back.
+			windowEnd = start
+		case len(resp.Data) < historyMaxPoints && !windowEnd.Add(-covered).After(start):
+			windowEnd = start
+		default:
+			windowEnd = time.UnixMilli(earliest)
+		}
+
+		if req.Limit > 0 && len(bars) >= req.Limit {
+			history.More = len(bars) > req.Limit || windowEnd.After(start)
+
+			break
+		}
+	}
+
+	history.Data = slices.SortedFunc(maps.Values(bars), func(a, b HistoricalBar) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+
+	if req.Limit > 0 && len(history.Data) > req.Limit {
+		history.Data = history.Data[len(history.Data)-req.Limit:]
+	}
+
+	history.Points = len(history.Data)
+
+	return history, nil
+}
+
+// history requests one window of the bars of req, ending at end when it is set.
+func (c *Client) history(
+	ctx context.Context,
+	req HistoryRequest,
+	period string,
+	end time.Time,
+) (*HistoricalDataResponse, error) {
+	params := url.Values{}
+	params.Set("conid", strconv.Itoa(req.ConID))
+	params.Set("period", period)
+	params.Set("bar", req.BarSize)
+
+	if req.OutsideRTH {
+		params.Set("outsideRth", "true")
+	}
+
+	// Despite its name, the Gateway returns the bars leading up to startTime.
+	if !end.IsZero() {
+		params.Set("startTime", end.UTC().Format(historyTimeLayout))
+	}
+
+	var histData HistoricalDataResponse
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/iserver/marketdata/history",
+		query:      params,
+		idempotent: true,
+		bucket:     bucketHistory,
+	}, &histData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &histData, nil
+}
+
+// historyPeriod returns the largest period the Gateway accepts that does not exceed d,
+// and its duration. Periods are at least one minute long.
+func historyPeriod(d time.Duration) (string, time.Duration) {
+	switch {
+	case d >= historyDay:
+		days := min(int(d/historyDay), maxHistoryDays)
+
+		return fmt.Sprintf("%dd", days), time.Duration(days) * historyDay
+	case d >= time.Hour:
+		hours := min(int(d/time.Hour), maxHistoryHours)
+
+		return fmt.Sprintf("%dh", hours), time.Duration(hours) * time.Hour
+	default:
+		minutes := max(min(int(d/time.Minute), maxHistoryMinutes), 1)
+
+		return fmt.Sprintf("%dmin", minutes), time.Duration(minutes) * time.Minute
+	}
+}
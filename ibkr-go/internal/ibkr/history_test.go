@@ -0,0 +1,252 @@
+package ibkr
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// barGateway serves continuous bars for the period leading up to startTime, at most 1000
+// of them, and records the windows requested. Bars are a minute apart unless step is set,
+// and include one at startTime when overlap is set.
+type barGateway struct {
+	step    time.Duration
+	overlap bool
+
+	mu      sync.Mutex
+	windows []string
+}
+
+func (g *barGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	end, err := time.Parse(historyTimeLayout, query.Get("startTime"))
+	if err != nil {
+		http.Error(w, "missing startTime", http.StatusBadRequest)
+
+		return
+	}
+
+	period, err := ParseHistoryDuration(query.Get("period"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	g.mu.Lock()
+	g.windows = append(g.windows, query.Get("period")+" to "+query.Get("startTime"))
+	g.mu.Unlock()
+
+	step := cmp.Or(g.step, time.Minute)
+
+	var resp HistoricalDataResponse
+
+	for t := end.Add(-period); t.Before(end) || (g.overlap && t.Equal(end)); t = t.Add(step) {
+		resp.Data = append(resp.Data, HistoricalBar{Time: t.UnixMilli(), Close: float64(t.Minute())})
+	}
+
+	resp.Data = resp.Data[max(len(resp.Data)-historyMaxPoints, 0):]
+	resp.Points = len(resp.Data)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestClient_GetHistory_Windows(t *testing.T) {
+	gateway := &barGateway{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	client := NewClient(server.URL)
+	history, err := client.GetHistory(context.Background(), HistoryRequest{
+		ConID:   265598,
+		Period:  "1d",
+		BarSize: "1min",
+		End:     end,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	// 500 bars per window round down to 8h windows.
+	want := []string{"8h to 20240102-00:00:00", "8h to 20240101-16:00:00", "8h to 20240101-08:00:00"}
+	if len(gateway.windows) != len(want) {
+		t.Fatalf("Expected windows %v, got %v", want, gateway.windows)
+	}
+
+	for i := range want {
+		if gateway.windows[i] != want[i] {
+			t.Errorf("windows[%d] = %q, want %q", i, gateway.windows[i], want[i])
+		}
+	}
+
+	if len(history.Data) != 1440 || history.Points != 1440 {
+		t.Fatalf("Expected 1440 bars, got %d", len(history.Data))
+	}
+
+	for i, bar := range history.Data {
+		if want := end.Add(-24 * time.Hour).Add(time.Duration(i) * time.Minute).UnixMilli(); bar.Time != want {
+			t.Fatalf("Data[%d].Time = %d, want %d", i, bar.Time, want)
+		}
+	}
+
+	if history.More {
+		t.Error("Expected no more bars")
+	}
+}
+
+func TestClient_GetHistory_MergesOverlappingWindows(t *testing.T) {
+	// Every window repeats the oldest bar of the window after it.
+	gateway := &barGateway{step: time.Hour, overlap: true}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	client := NewClient(server.URL, WithRateLimits(RateLimits{}))
+	history, err := client.GetHistory(context.Background(), HistoryRequest{
+		ConID:   265598,
+		BarSize: "1h",
+		Start:   end.Add(-1000 * time.Hour),
+		End:     end,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(gateway.windows) < 2 {
+		t.Fatalf("Expected several windows, got %v", gateway.windows)
+	}
+
+	if len(history.Data) != 1000 {
+		t.Fatalf("Expected 1000 bars, got %d", len(history.Data))
+	}
+
+	for i := 1; i < len(history.Data); i++ {
+		if history.Data[i].Time-history.Data[i-1].Time != time.Hour.Milliseconds() {
+			t.Fatalf("Expected sorted, unique bars, got %d after %d", history.Data[i].Time, history.Data[i-1].Time)
+		}
+	}
+}
+
+func TestClient_GetHistory_Limit(t *testing.T) {
+	gateway := &barGateway{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	client := NewClient(server.URL)
+	history, err := client.GetHistory(context.Background(), HistoryRequest{
+		ConID:   265598,
+		Period:  "1d",
+		BarSize: "1min",
+		End:     end,
+		Limit:   600,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if len(gateway.windows) != 2 {
+		t.Errorf("Expected the windows to stop at the limit, got %v", gateway.windows)
+	}
+
+	if len(history.Data) != 600 || !history.More {
+		t.Fatalf("Expected 600 bars and more, got %d bars, more %v", len(history.Data), history.More)
+	}
+
+	if want := end.Add(-600 * time.Minute).UnixMilli(); history.Data[0].Time != want {
+		t.Errorf("Expected the most recent bars, first at %d, got %d", want, history.Data[0].Time)
+	}
+}
+
+func TestClient_GetHistory_SingleRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("period") != "1d" || query.Get("bar") != "1h" || query.Has("startTime") {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"AAPL","data":[{"t":1704067200000,"c":100},{"t":1704070800000,"c":101}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	history, err := client.GetHistory(context.Background(), HistoryRequest{ConID: 265598, Period: "1d", BarSize: "1h"})
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	if history.Symbol != "AAPL" || len(history.Data) != 2 {
+		t.Errorf("Expected the response passed through, got %+v", history)
+	}
+}
+
+func TestClient_GetHistory_ScalesBars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"ES","priceFactor":100,"volumeFactor":100,"mdAvailability":"DpB",` +
+			`"data":[{"t":1704067200000,"o":475025,"h":475100,"l":474950,"c":475050,"v":12}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	history, err := client.GetHistory(context.Background(), HistoryRequest{ConID: 495512563, Period: "1d", BarSize: "1h"})
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+
+	want := HistoricalBar{Time: 1704067200000, Open: 4750.25, High: 4751, Low: 4749.5, Close: 4750.5, Volume: 1200}
+	if len(history.Data) != 1 || history.Data[0] != want {
+		t.Errorf("Expected the bars scaled to %+v, got %+v", want, history.Data)
+	}
+
+	if !history.Delayed() {
+		t.Error("Expected delayed data")
+	}
+}
+
+func TestClient_GetHistory_InvalidRequest(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0")
+
+	for _, req := range []HistoryRequest{
+		{Period: "1d", BarSize: "1 minute"},
+		{Period: "0d", BarSize: "1min"},
+		{BarSize: "1min", Start: time.Unix(100, 0), End: time.Unix(100, 0)},
+	} {
+		if _, err := client.GetHistory(context.Background(), req); !errors.Is(err, ErrInvalidHistoryRequest) {
+			t.Errorf("GetHistory(%+v) error = %v, want ErrInvalidHistoryRequest", req, err)
+		}
+	}
+}
+
+func TestHistoryPeriod(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 30 * time.Second, want: "1min"},
+		{d: 25 * time.Minute, want: "25min"},
+		{d: 90 * time.Minute, want: "1h"},
+		{d: 500 * time.Minute, want: "8h"},
+		{d: 500 * time.Hour, want: "20d"},
+		{d: 5000 * 24 * time.Hour, want: "1000d"},
+	}
+
+	for _, tt := range tests {
+		if got, _ := historyPeriod(tt.d); got != tt.want {
+			t.Errorf("historyPeriod(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
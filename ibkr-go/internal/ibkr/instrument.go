@@ -0,0 +1,159 @@
+package ibkr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// InstrumentSearch describes the instruments to search for with SearchInstruments.
+type InstrumentSearch struct {
+	// Query is the symbol, or the company name when Name is set.
+	Query string
+	// Name searches by company name rather than by symbol.
+	Name bool
+	// SecType restricts the results to a security type, e.g. "STK". Optional.
+	SecType string
+}
+
+// ContractDetails represents the details of a contract returned by the contract info
+// endpoint.
+type ContractDetails struct {
+	ConID           int         `json:"con_id"`
+	Symbol          string      `json:"symbol"`
+	LocalSymbol     string      `json:"local_symbol"`
+	CompanyName     string      `json:"company_name"`
+	InstrumentType  string      `json:"instrument_type"`
+	Exchange        string      `json:"exchange"`
+	ValidExchanges  string      `json:"valid_exchanges"`
+	Currency        string      `json:"currency"`
+	TradingClass    string      `json:"trading_class"`
+	Multiplier      looseString `json:"multiplier"`
+	Industry        string      `json:"industry"`
+	Category        string      `json:"category"`
+	Cusip           string      `json:"cusip"`
+	MaturityDate    string      `json:"maturity_date"`
+	ContractMonth   string      `json:"contract_month"`
+	UnderlyingConID int         `json:"underlying_con_id"`
+	Text            string      `json:"text"`
+}
+
+// ContractRules represents the details and trading rules of a contract returned by the
+// info-and-rules endpoint.
+type ContractRules struct {
+	ContractDetails
+
+	Rules TradingRules `json:"rules"`
+}
+
+// TradingRules represents the rules for orders of a contract.
+type TradingRules struct {
+	// OrderTypes are the order types allowed, e.g. "limit" or "stop_limit".
+	OrderTypes []string `json:"orderTypes"`
+	// OrderTypesOutside are the order types allowed outside regular trading hours.
+	OrderTypesOutside []string `json:"orderTypesOutside"`
+	DefaultSize       float64  `json:"defaultSize"`
+	SizeIncrement     float64  `json:"sizeIncrement"`
+	// TIFTypes are the time in force values allowed, each followed by the order types it
+	// applies to, e.g. "GTC/o,a".
+	TIFTypes []string `json:"tifTypes"`
+	// Increment is the price increment, the tick size, of the lowest price band.
+	Increment       float64         `json:"increment"`
+	IncrementDigits int             `json:"incrementDigits"`
+	IncrementRules  []IncrementRule `json:"incrementRules"`
+	NegativeCapable bool            `json:"negativeCapable"`
+	// Error explains why the contract cannot be traded, when it cannot.
+	Error string `json:"error"`
+}
+
+// IncrementRule is the price increment of the prices from LowerEdge up to the lower edge
+// of the next rule.
+type IncrementRule struct {
+	LowerEdge float64 `json:"lowerEdge"`
+	Increment float64 `json:"increment"`
+}
+
+// looseString decodes a JSON string, number or null, which the Gateway uses
+// interchangeably for some fields, as a string.
+type looseString string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *looseString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = ""
+
+		return nil
+	}
+
+	*s = looseString(rawString(json.RawMessage(data)))
+
+	return nil
+}
+
+// SearchInstruments searches for contracts by symbol or company name.
+func (c *Client) SearchInstruments(ctx context.Context, search InstrumentSearch) ([]Contract, error) {
+	params := url.Values{}
+	params.Set("symbol", search.Query)
+
+	if search.Name {
+		params.Set("name", "true")
+	}
+
+	if search.SecType != "" {
+		params.Set("secType", search.SecType)
+	}
+
+	var contracts []Contract
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/iserver/secdef/search",
+		query:      params,
+		idempotent: true,
+	}, &contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	return contracts, nil
+}
+
+// GetContractDetails retrieves the details of a contract.
+func (c *Client) GetContractDetails(ctx context.Context, conID int) (*ContractDetails, error) {
+	var details ContractDetails
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/v1/api/iserver/contract/%d/info", conID),
+		idempotent: true,
+	}, &details)
+	if err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// GetContractRules retrieves the details and trading rules of a contract, for buy or for
+// sell orders.
+func (c *Client) GetContractRules(ctx context.Context, conID int, isBuy bool) (*ContractRules, error) {
+	params := url.Values{}
+	params.Set("isBuy", strconv.FormatBool(isBuy))
+
+	var rules ContractRules
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/v1/api/iserver/contract/%d/info-and-rules", conID),
+		query:      params,
+		idempotent: true,
+	}, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
@@ -0,0 +1,101 @@
+package ibkr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SearchInstruments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v1/api/iserver/secdef/search" || query.Get("symbol") != "Apple" ||
+			query.Get("name") != "true" || query.Get("secType") != "STK" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"conid":265598,"symbol":"AAPL","companyName":"APPLE INC"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	contracts, err := client.SearchInstruments(context.Background(), InstrumentSearch{Query: "Apple", Name: true, SecType: "STK"})
+	if err != nil {
+		t.Fatalf("SearchInstruments() error = %v", err)
+	}
+
+	if len(contracts) != 1 || contracts[0].CompanyName != "APPLE INC" {
+		t.Errorf("Unexpected contracts %+v", contracts)
+	}
+}
+
+func TestClient_SearchInstruments_BySymbol(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.Query(); query.Has("name") || query.Has("secType") {
+			t.Errorf("Expected only the symbol, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.SearchInstruments(context.Background(), InstrumentSearch{Query: "AAPL"}); err != nil {
+		t.Fatalf("SearchInstruments() error = %v", err)
+	}
+}
+
+func TestClient_GetContractDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/api/iserver/contract/265598/info" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"con_id":265598,"symbol":"AAPL","instrument_type":"STK","exchange":"NASDAQ",` +
+			`"valid_exchanges":"SMART,NASDAQ","currency":"USD","multiplier":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	details, err := client.GetContractDetails(context.Background(), 265598)
+	if err != nil {
+		t.Fatalf("GetContractDetails() error = %v", err)
+	}
+
+	if details.ConID != 265598 || details.InstrumentType != "STK" || details.Multiplier != "" {
+		t.Errorf("Unexpected details %+v", details)
+	}
+}
+
+func TestClient_GetContractRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/api/iserver/contract/495512563/info-and-rules" || r.URL.Query().Get("isBuy") != "false" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"con_id":495512563,"symbol":"ES","instrument_type":"FUT","multiplier":50,` +
+			`"rules":{"orderTypes":["limit","market"],"orderTypesOutside":["limit"],"defaultSize":1,` +
+			`"sizeIncrement":1,"tifTypes":["DAY/o,a","GTC/o,a"],"increment":0.25,"incrementDigits":2,` +
+			`"incrementRules":[{"lowerEdge":0,"increment":0.25}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	rules, err := client.GetContractRules(context.Background(), 495512563, false)
+	if err != nil {
+		t.Fatalf("GetContractRules() error = %v", err)
+	}
+
+	if rules.Symbol != "ES" || rules.Multiplier != "50" {
+		t.Errorf("Unexpected details %+v", rules.ContractDetails)
+	}
+
+	if rules.Rules.Increment != 0.25 || len(rules.Rules.IncrementRules) != 1 || len(rules.Rules.OrderTypes) != 2 {
+		t.Errorf("Unexpected rules %+v", rules.Rules)
+	}
+}
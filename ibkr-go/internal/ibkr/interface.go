@@ -7,34 +7,63 @@ type BasicClient interface {
 	Ping(ctx context.Context) error
 	AuthStatus(ctx context.Context) (*AuthStatusResponse, error)
 	Reauthenticate(ctx context.Context) error
+	AccountClient
+}
+
+// AccountClient defines account discovery operations.
+type AccountClient interface {
 	GetAccounts(ctx context.Context) ([]Account, error)
 }
 
+// ContractClient defines contract lookup operations.
+type ContractClient interface {
+	SearchContracts(ctx context.Context, symbol string) ([]Contract, error)
+	GetContractInfo(ctx context.Context, req ContractInfoRequest) ([]ContractInfo, error)
+	GetSecDefs(ctx context.Context, conIDs []int) ([]SecDef, error)
+	GetStrikes(ctx context.Context, req StrikesRequest) (*Strikes, error)
+	GetFutures(ctx context.Context, symbols []string) (map[string][]FutureContract, error)
+}
+
+// InstrumentClient defines instrument search and contract detail operations.
+type InstrumentClient interface {
+	SearchInstruments(ctx context.Context, search InstrumentSearch) ([]Contract, error)
+	GetContractDetails(ctx context.Context, conID int) (*ContractDetails, error)
+	GetContractRules(ctx context.Context, conID int, isBuy bool) (*ContractRules, error)
+}
+
+// ContractResolver resolves an instrument description to a single contract.
+type ContractResolver interface {
+	ResolveContract(ctx context.Context, query ContractQuery) (*ResolvedContract, error)
+}
+
 // MarketDataClient defines market data operations.
 type MarketDataClient interface {
 	GetMarketData(ctx context.Context, conIDs []int, fields []string) ([]MarketDataSnapshot, error)
 	GetHistoricalData(ctx context.Context, conID int, period, barSize string) (*HistoricalDataResponse, error)
-	SearchContracts(ctx context.Context, symbol string) ([]Contract, error)
+	GetHistory(ctx context.Context, req HistoryRequest) (*History, error)
+	ContractClient
 }
 
 // OrderClient defines order operations.
 type OrderClient interface {
-	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderResponse, error)
-	ModifyOrder(ctx context.Context, orderID string, req *ModifyOrderRequest) (*OrderResponse, error)
-	CancelOrder(ctx context.Context, orderID string) error
-	GetLiveOrders(ctx context.Context) ([]Order, error)
+	PlaceOrder(ctx context.Context, accountID string, orders ...PlaceOrderRequest) ([]OrderResponse, error)
+	ModifyOrder(ctx context.Context, accountID, orderID string, req *ModifyOrderRequest) (*OrderResponse, error)
+	CancelOrder(ctx context.Context, accountID, orderID string) error
+	ReplyOrder(ctx context.Context, replyID string, confirmed bool) ([]OrderResponse, error)
+	GetLiveOrders(ctx context.Context, accountID string) ([]Order, error)
 }
 
 // PortfolioClient defines portfolio operations.
 type PortfolioClient interface {
-	GetPortfolio(ctx context.Context) ([]Position, error)
-	GetAccountSummary(ctx context.Context) (*AccountSummary, error)
+	GetPortfolio(ctx context.Context, accountID string) ([]Position, error)
+	GetAccountSummary(ctx context.Context, accountID string) (*AccountSummary, error)
 }
 
 // IBKRClient defines the interface for interacting with the IBKR Gateway API.
 type IBKRClient interface {
 	BasicClient
 	MarketDataClient
+	InstrumentClient
 	OrderClient
 	PortfolioClient
 }
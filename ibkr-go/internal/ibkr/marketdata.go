@@ -2,30 +2,12 @@ package ibkr
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
-// MarketDataSnapshot represents a market data snapshot.
-type MarketDataSnapshot struct {
-	ConID     int     `json:"conid"`
-	ConIDEx   string  `json:"conidEx"`
-	LastPrice float64 `json:"31,omitempty"`   // Last price.
-	Symbol    string  `json:"55,omitempty"`   // Symbol.
-	Bid       float64 `json:"84,omitempty"`   // Bid.
-	Ask       float64 `json:"86,omitempty"`   // Ask.
-	Volume    int64   `json:"87,omitempty"`   // Volume.
-	High      float64 `json:"70,omitempty"`   // High.
-	Low       float64 `json:"71,omitempty"`   // Low.
-	Close     float64 `json:"82,omitempty"`   // Close.
-	Open      float64 `json:"7295,omitempty"` // Open.
-	ServerID  string  `json:"_updated"`
-}
-
 // HistoricalDataResponse represents historical market data.
 type HistoricalDataResponse struct {
 	ServerID           string          `json:"serverId"`
@@ -60,6 +42,35 @@ type HistoricalBar struct {
 	Volume int64   `json:"v"`
 }
 
+// Delayed reports whether the bars are delayed rather than real-time: the market data
+// availability starts with D for delayed and Y for frozen delayed data.
+func (r *HistoricalDataResponse) Delayed() bool {
+	return r.MktDataDelay > 0 ||
+		strings.HasPrefix(r.MdAvailability, "D") ||
+		strings.HasPrefix(r.MdAvailability, "Y")
+}
+
+// scale converts the bars to real prices and volumes. The Gateway reports prices
+// multiplied by PriceFactor and volumes divided by VolumeFactor; factors of zero or one
+// leave the bars unchanged.
+func (r *HistoricalDataResponse) scale() {
+	for i := range r.Data {
+		bar := &r.Data[i]
+
+		if r.PriceFactor > 1 {
+			factor := float64(r.PriceFactor)
+			bar.Open /= factor
+			bar.High /= factor
+			bar.Low /= factor
+			bar.Close /= factor
+		}
+
+		if r.VolumeFactor > 1 {
+			bar.Volume *= int64(r.VolumeFactor)
+		}
+	}
+}
+
 // Contract represents a contract search result.
 type Contract struct {
 	ConID         int               `json:"conid"`
@@ -83,122 +94,183 @@ type ContractSection struct {
 	LegSecType string `json:"legSecType,omitempty"`
 }
 
-// GetMarketData retrieves market data snapshot for a contract.
-func (c *Client) GetMarketData(ctx context.Context, conIDs []int, fields []string) ([]MarketDataSnapshot, error) {
-	conIDsStr := make([]string, 0, len(conIDs))
-	for _, id := range conIDs {
-		conIDsStr = append(conIDsStr, fmt.Sprintf("%d", id))
-	}
+// Months returns the expiration months listed for a derivative security type, e.g. "OPT",
+// in the Gateway format ("JAN24"). The months of the matching section are preferred over
+// the opt and fop strings of the search result.
+func (c Contract) Months(secType string) []string {
+	months := ""
 
-	params := url.Values{}
-	params.Set("conids", strings.Join(conIDsStr, ","))
+	for _, section := range c.Sections {
+		if section.SecType == secType && section.Months != "" {
+			months = section.Months
 
-	if len(fields) > 0 {
-		params.Set("fields", strings.Join(fields, ","))
+			break
+		}
 	}
 
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/iserver/marketdata/snapshot?%s", c.baseURL, params.Encode()),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if months == "" {
+		switch secType {
+		case "OPT":
+			months = c.Opt
+		case "FOP":
+			months = c.Fop
+		case "WAR":
+			months = c.War
+		}
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get market data: %w", err)
+	var list []string
+
+	for month := range strings.SplitSeq(months, ";") {
+		if month = strings.TrimSpace(month); month != "" {
+			list = append(list, month)
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
+	return list
+}
 
-		return nil, fmt.Errorf("get market data failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
+// StrikesRequest identifies the option contracts whose strikes GetStrikes lists.
+type StrikesRequest struct {
+	// ConID is the contract ID of the underlying.
+	ConID int
+	// SecType is the derivative security type, "OPT" or "FOP".
+	SecType string
+	// Month is the expiration month in the Gateway format, e.g. "JAN24".
+	Month string
+	// Exchange defaults to SMART on the Gateway.
+	Exchange string
+}
 
-	var snapshots []MarketDataSnapshot
-	if err := json.NewDecoder(resp.Body).Decode(&snapshots); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+// Strikes lists the call and put strikes of an expiration month.
+type Strikes struct {
+	Call []float64 `json:"call"`
+	Put  []float64 `json:"put"`
+}
+
+// ContractInfoRequest identifies the contracts to look up with GetContractInfo.
+// Month, Exchange, Strike and Right narrow down derivative contracts.
+type ContractInfoRequest struct {
+	ConID    int
+	SecType  string
+	Month    string
+	Exchange string
+	Strike   float64
+	Right    string
+}
 
-	return snapshots, nil
+// ContractInfo represents contract details returned by the secdef info endpoint.
+type ContractInfo struct {
+	ConID           int     `json:"conid"`
+	Symbol          string  `json:"symbol"`
+	SecType         string  `json:"secType"`
+	Exchange        string  `json:"exchange"`
+	ListingExchange string  `json:"listingExchange"`
+	Currency        string  `json:"currency"`
+	CompanyName     string  `json:"companyName"`
+	Description1    string  `json:"desc1"`
+	Description2    string  `json:"desc2"`
+	MaturityDate    string  `json:"maturityDate"`
+	Right           string  `json:"right"`
+	Strike          float64 `json:"strike"`
+	Multiplier      string  `json:"multiplier"`
+	TradingClass    string  `json:"tradingClass"`
+	ValidExchanges  string  `json:"validExchanges"`
 }
 
-// GetHistoricalData retrieves historical market data.
-func (c *Client) GetHistoricalData(
-	ctx context.Context,
-	conID int,
-	period, barSize string,
-) (*HistoricalDataResponse, error) {
+// SearchContracts searches for contracts by symbol.
+func (c *Client) SearchContracts(ctx context.Context, symbol string) ([]Contract, error) {
+	return c.SearchInstruments(ctx, InstrumentSearch{Query: symbol})
+}
+
+// GetContractInfo retrieves the details of the contracts matching the request.
+func (c *Client) GetContractInfo(ctx context.Context, req ContractInfoRequest) ([]ContractInfo, error) {
 	params := url.Values{}
-	params.Set("conid", fmt.Sprintf("%d", conID))
-	params.Set("period", period)
-	params.Set("bar", barSize)
-
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/iserver/marketdata/history?%s", c.baseURL, params.Encode()),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	params.Set("conid", strconv.Itoa(req.ConID))
+	params.Set("sectype", req.SecType)
+
+	if req.Month != "" {
+		params.Set("month", req.Month)
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get historical data: %w", err)
+	if req.Exchange != "" {
+		params.Set("exchange", req.Exchange)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
+	if req.Strike != 0 {
+		params.Set("strike", strconv.FormatFloat(req.Strike, 'f', -1, 64))
+	}
 
-		return nil, fmt.Errorf("get historical data failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	if req.Right != "" {
+		params.Set("right", req.Right)
 	}
 
-	var histData HistoricalDataResponse
-	if err := json.NewDecoder(resp.Body).Decode(&histData); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var infos []ContractInfo
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/iserver/secdef/info",
+		query:      params,
+		idempotent: true,
+	}, &infos)
+	if err != nil {
+		return nil, err
 	}
 
-	return &histData, nil
+	return infos, nil
 }
 
-// SearchContracts searches for contracts by symbol.
-func (c *Client) SearchContracts(ctx context.Context, symbol string) ([]Contract, error) {
-	params := url.Values{}
-	params.Set("symbol", symbol)
-
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/iserver/secdef/search?%s", c.baseURL, params.Encode()),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// SecDef is the security definition of a contract returned by the batch secdef endpoint.
+type SecDef struct {
+	ConID           int    `json:"conid"`
+	Ticker          string `json:"ticker"`
+	AssetClass      string `json:"assetClass"`
+	ListingExchange string `json:"listingExchange"`
+	Currency        string `json:"currency"`
+	Name            string `json:"name"`
+}
+
+// GetSecDefs retrieves the security definitions of several contracts in one request.
+func (c *Client) GetSecDefs(ctx context.Context, conIDs []int) ([]SecDef, error) {
+	var resp struct {
+		SecDef []SecDef `json:"secdef"`
 	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodPost,
+		path:       "/v1/api/trsrv/secdef",
+		body:       map[string][]int{"conids": conIDs},
+		idempotent: true,
+	}, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search contracts: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
+	return resp.SecDef, nil
+}
+
+// GetStrikes retrieves the call and put strikes of a derivative expiration month.
+func (c *Client) GetStrikes(ctx context.Context, req StrikesRequest) (*Strikes, error) {
+	params := url.Values{}
+	params.Set("conid", strconv.Itoa(req.ConID))
+	params.Set("sectype", req.SecType)
+	params.Set("month", req.Month)
 
-		return nil, fmt.Errorf("search contracts failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	if req.Exchange != "" {
+		params.Set("exchange", req.Exchange)
 	}
 
-	var contracts []Contract
-	if err := json.NewDecoder(resp.Body).Decode(&contracts); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var strikes Strikes
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/iserver/secdef/strikes",
+		query:      params,
+		idempotent: true,
+	}, &strikes)
+	if err != nil {
+		return nil, err
 	}
 
-	return contracts, nil
+	return &strikes, nil
 }
@@ -15,7 +15,7 @@ func TestClient_SearchContracts(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	contracts, err := client.SearchContracts(context.Background(), "AAPL")
 	if err != nil {
 		t.Fatalf("SearchContracts() error = %v", err)
@@ -33,7 +33,7 @@ func TestClient_GetMarketData(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	snapshots, err := client.GetMarketData(context.Background(), []int{265598}, nil)
 	if err != nil {
 		t.Fatalf("GetMarketData() error = %v", err)
@@ -51,7 +51,7 @@ func TestClient_GetHistoricalData(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	data, err := client.GetHistoricalData(context.Background(), 265598, "1d", "1d")
 	if err != nil {
 		t.Fatalf("GetHistoricalData() error = %v", err)
@@ -60,3 +60,79 @@ func TestClient_GetHistoricalData(t *testing.T) {
 		t.Errorf("Expected 1 bar, got %d", len(data.Data))
 	}
 }
+
+func TestClient_GetStrikes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/api/iserver/secdef/strikes" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("conid") != "265598" || query.Get("sectype") != "OPT" || query.Get("month") != "JAN24" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"call":[185,190,195],"put":[185,190]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	strikes, err := client.GetStrikes(context.Background(), StrikesRequest{ConID: 265598, SecType: "OPT", Month: "JAN24"})
+	if err != nil {
+		t.Fatalf("GetStrikes() error = %v", err)
+	}
+	if len(strikes.Call) != 3 || len(strikes.Put) != 2 || strikes.Call[2] != 195 {
+		t.Errorf("Unexpected strikes: %+v", strikes)
+	}
+}
+
+func TestClient_GetContractInfo_Option(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("month") != "JAN24" || query.Get("strike") != "192.5" || query.Get("right") != "C" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"conid":664198375,"symbol":"AAPL","secType":"OPT","right":"C","strike":192.5,"maturityDate":"20240119","multiplier":"100","tradingClass":"AAPL"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	infos, err := client.GetContractInfo(context.Background(), ContractInfoRequest{
+		ConID:   265598,
+		SecType: "OPT",
+		Month:   "JAN24",
+		Strike:  192.5,
+		Right:   "C",
+	})
+	if err != nil {
+		t.Fatalf("GetContractInfo() error = %v", err)
+	}
+	if len(infos) != 1 || infos[0].Strike != 192.5 || infos[0].MaturityDate != "20240119" {
+		t.Errorf("Unexpected contract info: %+v", infos)
+	}
+}
+
+func TestContract_Months(t *testing.T) {
+	contract := Contract{
+		Opt: "JAN24;FEB24;",
+		Fop: "MAR24",
+		Sections: []ContractSection{
+			{SecType: "STK"},
+			{SecType: "OPT", Months: "JAN24;FEB24;MAR24"},
+			{SecType: "FOP"},
+		},
+	}
+
+	if months := contract.Months("OPT"); len(months) != 3 || months[2] != "MAR24" {
+		t.Errorf("Months(OPT) = %v, want the section months", months)
+	}
+	if months := contract.Months("FOP"); len(months) != 1 || months[0] != "MAR24" {
+		t.Errorf("Months(FOP) = %v, want the fop months", months)
+	}
+	if months := contract.Months("WAR"); len(months) != 0 {
+		t.Errorf("Months(WAR) = %v, want none", months)
+	}
+}
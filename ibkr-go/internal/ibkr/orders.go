@@ -4,35 +4,101 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 )
 
-// PlaceOrderRequest represents a request to place an order.
+// PlaceOrderRequest represents a request to place an order. SecType and Ticker are
+// optional: the Gateway identifies the contract by ConID.
+//
+// Price is the limit price of limit orders and the stop or trigger price of stop,
+// trailing stop and market if touched orders. AuxPrice is the second price of orders
+// that have two: the stop price of stop limit and trailing stop limit orders, the
+// trigger price of limit if touched orders and the offset of relative orders.
 type PlaceOrderRequest struct {
 	ConID     int     `json:"conid"`
-	SecType   string  `json:"secType"`
+	SecType   string  `json:"secType,omitempty"`
 	OrderType string  `json:"orderType"`
 	Side      string  `json:"side"`
 	Quantity  float64 `json:"quantity"`
 	Price     float64 `json:"price,omitempty"`
+	AuxPrice  float64 `json:"auxPrice,omitempty"`
 	Tif       string  `json:"tif"`
-	Ticker    string  `json:"ticker"`
+	Ticker    string  `json:"ticker,omitempty"`
+	// TrailingAmt is the distance of the stop price of trailing orders from the market,
+	// in TrailingType units: "amt" for a price amount or "%" for a percentage.
+	TrailingAmt  float64 `json:"trailingAmt,omitempty"`
+	TrailingType string  `json:"trailingType,omitempty"`
+	// COID is a client order ID, unique per account, that ParentID refers to in the
+	// orders attached to this order, e.g. the take profit and stop loss of a bracket.
+	COID     string `json:"cOID,omitempty"`
+	ParentID string `json:"parentId,omitempty"`
+	// OCAGroup names a one-cancels-all group: once an order of the group fills, the
+	// Gateway cancels the others. IsSingleGroup marks the orders of the group.
+	OCAGroup      string `json:"ocaGroup,omitempty"`
+	IsSingleGroup bool   `json:"isSingleGroup,omitempty"`
 }
 
 // ModifyOrderRequest represents a request to modify an order.
+// Price and AuxPrice are as in PlaceOrderRequest.
 type ModifyOrderRequest struct {
 	Quantity float64 `json:"quantity,omitempty"`
 	Price    float64 `json:"price,omitempty"`
+	AuxPrice float64 `json:"auxPrice,omitempty"`
+	// TrailingAmt and TrailingType are the trailing distance of trailing orders, as in
+	// PlaceOrderRequest.
+	TrailingAmt  float64 `json:"trailingAmt,omitempty"`
+	TrailingType string  `json:"trailingType,omitempty"`
 }
 
 // OrderResponse represents an order response from the Gateway.
+//
+// When an order triggers warnings, the Gateway does not submit it and instead
+// returns a question: ReplyID is set, OrderID is empty and Message holds the
+// warnings. The order is only submitted once the question is confirmed.
 type OrderResponse struct {
 	OrderID     string   `json:"order_id"`
 	OrderStatus string   `json:"order_status"`
-	EncryptedID string   `json:"id"`
+	ReplyID     string   `json:"id"`
 	Message     []string `json:"message"`
+	MessageIDs  []string `json:"messageIds"`
+}
+
+// NeedsConfirmation reports whether the response is a question awaiting a reply.
+func (r *OrderResponse) NeedsConfirmation() bool {
+	return r.OrderID == "" && r.ReplyID != ""
+}
+
+// orderResponses decodes order responses, which the Gateway returns either as a
+// list or as a single object.
+type orderResponses []OrderResponse
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *orderResponses) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, (*[]OrderResponse)(r))
+	}
+
+	var single OrderResponse
+	if err := json.Unmarshal(trimmed, &single); err != nil {
+		return err
+	}
+
+	*r = orderResponses{single}
+
+	return nil
+}
+
+// first returns the first response, or an error if the Gateway returned none.
+func (r orderResponses) first() (*OrderResponse, error) {
+	if len(r) == 0 {
+		return nil, errors.New("gateway returned an empty order response")
+	}
+
+	return &r[0], nil
 }
 
 // Order represents an order from the Gateway.
@@ -55,144 +121,97 @@ type Order struct {
 	OrigOrderType     string  `json:"origOrderType"`
 	Side              string  `json:"side"`
 	Price             float64 `json:"price"`
+	AuxPrice          float64 `json:"auxPrice"`
 	BgColor           string  `json:"bgColor"`
 	FgColor           string  `json:"fgColor"`
 }
 
-// PlaceOrder places a new order.
-func (c *Client) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderResponse, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/v1/api/iserver/account/%s/orders", c.baseURL, c.accountID),
-		bytes.NewReader(body),
-	)
+// PlaceOrder places new orders for an account in one request, which the Gateway accepts
+// or rejects together, e.g. the orders of a bracket or of a one-cancels-all group. It
+// returns a response per order.
+//
+// Confirmation questions allowed by the client's confirm policy are answered
+// automatically; any other question is returned to the caller as the only response,
+// see OrderResponse.NeedsConfirmation and ReplyOrder.
+func (c *Client) PlaceOrder(
+	ctx context.Context,
+	accountID string,
+	orders ...PlaceOrderRequest,
+) ([]OrderResponse, error) {
+	var responses orderResponses
+
+	err := c.do(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/v1/api/iserver/account/%s/orders", url.PathEscape(accountID)),
+		body: struct {
+			Orders []PlaceOrderRequest `json:"orders"`
+		}{Orders: orders},
+	}, &responses)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to place order: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("place order failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var orderResp OrderResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
-	return &orderResp, nil
+	return c.answerQuestions(ctx, responses)
 }
 
-// ModifyOrder modifies an existing order.
-func (c *Client) ModifyOrder(ctx context.Context, orderID string, req *ModifyOrderRequest) (*OrderResponse, error) {
-	body, err := json.Marshal(req)
+// ModifyOrder modifies an existing order. Confirmation questions are handled as in PlaceOrder.
+func (c *Client) ModifyOrder(
+	ctx context.Context,
+	accountID, orderID string,
+	req *ModifyOrderRequest,
+) (*OrderResponse, error) {
+	var responses orderResponses
+
+	err := c.do(ctx, apiRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/v1/api/iserver/account/%s/order/%s", url.PathEscape(accountID), url.PathEscape(orderID)),
+		body:   req,
+	}, &responses)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/v1/api/iserver/account/%s/order/%s", c.baseURL, c.accountID, orderID),
-		bytes.NewReader(body),
-	)
+	responses, err = c.answerQuestions(ctx, responses)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
+	return responses.first()
+}
 
-	resp, err := c.httpClient.Do(httpReq)
+// ReplyOrder answers an order confirmation question. Further questions are handled as in PlaceOrder,
+// and once the orders are submitted it returns a response per order.
+func (c *Client) ReplyOrder(ctx context.Context, replyID string, confirmed bool) ([]OrderResponse, error) {
+	responses, err := c.reply(ctx, replyID, confirmed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to modify order: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("modify order failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, err
 	}
 
-	var orderResp OrderResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &orderResp, nil
+	return c.answerQuestions(ctx, responses)
 }
 
 // CancelOrder cancels an order.
-func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodDelete,
-		fmt.Sprintf("%s/v1/api/iserver/account/%s/order/%s", c.baseURL, c.accountID, orderID),
-		nil,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return fmt.Errorf("cancel order failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+func (c *Client) CancelOrder(ctx context.Context, accountID, orderID string) error {
+	return c.do(ctx, apiRequest{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/v1/api/iserver/account/%s/order/%s", url.PathEscape(accountID), url.PathEscape(orderID)),
+	}, nil)
 }
 
-// GetLiveOrders retrieves live orders.
-func (c *Client) GetLiveOrders(ctx context.Context) ([]Order, error) {
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/iserver/account/%s/orders", c.baseURL, c.accountID),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get orders: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("get orders failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
+// GetLiveOrders retrieves live orders for an account.
+func (c *Client) GetLiveOrders(ctx context.Context, accountID string) ([]Order, error) {
 	var orders struct {
 		Orders []Order `json:"orders"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&orders); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/v1/api/iserver/account/%s/orders", url.PathEscape(accountID)),
+		idempotent: true,
+		bucket:     bucketOrders,
+	}, &orders)
+	if err != nil {
+		return nil, err
 	}
 
 	return orders.Orders, nil
@@ -2,6 +2,7 @@ package ibkr
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,8 +16,8 @@ func TestClient_PlaceOrder(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	req := &PlaceOrderRequest{
+	client := NewClient(server.URL)
+	req := PlaceOrderRequest{
 		ConID:     12345,
 		OrderType: "MKT",
 		Side:      "BUY",
@@ -24,12 +25,46 @@ func TestClient_PlaceOrder(t *testing.T) {
 		Tif:       "DAY",
 	}
 
-	resp, err := client.PlaceOrder(context.Background(), req)
+	resp, err := client.PlaceOrder(context.Background(), "U12345", req)
 	if err != nil {
 		t.Fatalf("PlaceOrder() error = %v", err)
 	}
-	if resp.OrderID != "12345" {
-		t.Errorf("OrderID = %v, want 12345", resp.OrderID)
+	if resp[0].OrderID != "12345" {
+		t.Errorf("OrderID = %v, want 12345", resp[0].OrderID)
+	}
+}
+
+func TestClient_PlaceOrder_Bracket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Orders []PlaceOrderRequest `json:"orders"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+
+		if len(body.Orders) != 3 || body.Orders[0].COID != "entry-1" || body.Orders[2].ParentID != "entry-1" {
+			t.Errorf("Unexpected orders %+v", body.Orders)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"order_id":"1","order_status":"Submitted"},{"order_id":"2","order_status":"PreSubmitted"},` +
+			`{"order_id":"3","order_status":"PreSubmitted"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	resp, err := client.PlaceOrder(context.Background(), "U12345",
+		PlaceOrderRequest{ConID: 265598, OrderType: "LMT", Side: "BUY", Price: 150, COID: "entry-1"},
+		PlaceOrderRequest{ConID: 265598, OrderType: "LMT", Side: "SELL", Price: 160, ParentID: "entry-1"},
+		PlaceOrderRequest{ConID: 265598, OrderType: "STP", Side: "SELL", Price: 145, ParentID: "entry-1"},
+	)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	if len(resp) != 3 || resp[0].OrderID != "1" || resp[2].OrderID != "3" {
+		t.Errorf("Unexpected responses %+v", resp)
 	}
 }
 
@@ -41,12 +76,12 @@ func TestClient_ModifyOrder(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
+	client := NewClient(server.URL)
 	req := &ModifyOrderRequest{
 		Quantity: 200,
 	}
 
-	resp, err := client.ModifyOrder(context.Background(), "12345", req)
+	resp, err := client.ModifyOrder(context.Background(), "U12345", "12345", req)
 	if err != nil {
 		t.Fatalf("ModifyOrder() error = %v", err)
 	}
@@ -61,8 +96,8 @@ func TestClient_CancelOrder(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	err := client.CancelOrder(context.Background(), "12345")
+	client := NewClient(server.URL)
+	err := client.CancelOrder(context.Background(), "U12345", "12345")
 	if err != nil {
 		t.Errorf("CancelOrder() error = %v", err)
 	}
@@ -76,8 +111,8 @@ func TestClient_GetLiveOrders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	orders, err := client.GetLiveOrders(context.Background())
+	client := NewClient(server.URL)
+	orders, err := client.GetLiveOrders(context.Background(), "U12345")
 	if err != nil {
 		t.Fatalf("GetLiveOrders() error = %v", err)
 	}
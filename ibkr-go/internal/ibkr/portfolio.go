@@ -2,10 +2,9 @@ package ibkr
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 )
 
 // Position represents a portfolio position.
@@ -50,65 +49,33 @@ type AccountSummary struct {
 	Currency            string  `json:"currency"`
 }
 
-// GetPortfolio retrieves portfolio positions.
-func (c *Client) GetPortfolio(ctx context.Context) ([]Position, error) {
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/portfolio/%s/positions/0", c.baseURL, c.accountID),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// GetPortfolio retrieves portfolio positions for an account.
+func (c *Client) GetPortfolio(ctx context.Context, accountID string) ([]Position, error) {
+	var positions []Position
 
-	resp, err := c.httpClient.Do(httpReq)
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/v1/api/portfolio/%s/positions/0", url.PathEscape(accountID)),
+		idempotent: true,
+	}, &positions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get portfolio: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("get portfolio failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var positions []Position
-	if err := json.NewDecoder(resp.Body).Decode(&positions); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	return positions, nil
 }
 
-// GetAccountSummary retrieves account summary information.
-func (c *Client) GetAccountSummary(ctx context.Context) (*AccountSummary, error) {
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/v1/api/portfolio/%s/summary", c.baseURL, c.accountID),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// GetAccountSummary retrieves account summary information for an account.
+func (c *Client) GetAccountSummary(ctx context.Context, accountID string) (*AccountSummary, error) {
+	var summary AccountSummary
 
-	resp, err := c.httpClient.Do(httpReq)
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       fmt.Sprintf("/v1/api/portfolio/%s/summary", url.PathEscape(accountID)),
+		idempotent: true,
+	}, &summary)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get account summary: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("get account summary failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var summary AccountSummary
-	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	return &summary, nil
@@ -16,8 +16,8 @@ func TestClient_GetPortfolio(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	positions, err := client.GetPortfolio(context.Background())
+	client := NewClient(server.URL)
+	positions, err := client.GetPortfolio(context.Background(), "U12345")
 	if err != nil {
 		t.Fatalf("GetPortfolio() error = %v", err)
 	}
@@ -50,8 +50,8 @@ func TestClient_GetAccountSummary(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "U12345")
-	summary, err := client.GetAccountSummary(context.Background())
+	client := NewClient(server.URL)
+	summary, err := client.GetAccountSummary(context.Background(), "U12345")
 	if err != nil {
 		t.Fatalf("GetAccountSummary() error = %v", err)
 	}
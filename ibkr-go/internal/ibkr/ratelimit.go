@@ -0,0 +1,167 @@
+package ibkr
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGlobalRate is the default global request rate, in requests per second.
+	DefaultGlobalRate = 10
+	// DefaultSnapshotRate is the default market data snapshot rate, in requests per second.
+	DefaultSnapshotRate = 10
+	// DefaultHistoryRate is the default historical data rate, in requests per second.
+	DefaultHistoryRate = 5
+	// DefaultOrdersRate is the default live orders rate, in requests per second (one every 5 seconds).
+	DefaultOrdersRate = 0.2
+	// DefaultTickleRate is the default tickle rate, in requests per second.
+	DefaultTickleRate = 1
+)
+
+// endpointBucket identifies a Gateway endpoint with its own pacing limit.
+type endpointBucket string
+
+const (
+	bucketSnapshot endpointBucket = "snapshot"
+	bucketHistory  endpointBucket = "history"
+	bucketOrders   endpointBucket = "orders"
+	bucketTickle   endpointBucket = "tickle"
+)
+
+// RateLimits configures client-side pacing of Gateway requests, in requests per second.
+// Every request consumes a token from the global bucket; requests to the snapshot,
+// history, live orders and tickle endpoints also consume a token from their own bucket.
+// The burst size of each bucket equals its rate, with a minimum of one request.
+// A rate of zero or less disables the corresponding bucket.
+type RateLimits struct {
+	Global   float64
+	Snapshot float64
+	History  float64
+	Orders   float64
+	Tickle   float64
+}
+
+// DefaultRateLimits returns rate limits matching the documented Client Portal API pacing.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		Global:   DefaultGlobalRate,
+		Snapshot: DefaultSnapshotRate,
+		History:  DefaultHistoryRate,
+		Orders:   DefaultOrdersRate,
+		Tickle:   DefaultTickleRate,
+	}
+}
+
+// rateLimiter paces requests with a global token bucket plus per-endpoint buckets.
+type rateLimiter struct {
+	global    *tokenBucket
+	endpoints map[endpointBucket]*tokenBucket
+}
+
+// newRateLimiter creates a rate limiter for the given limits.
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	return &rateLimiter{
+		global: newTokenBucket(limits.Global),
+		endpoints: map[endpointBucket]*tokenBucket{
+			bucketSnapshot: newTokenBucket(limits.Snapshot),
+			bucketHistory:  newTokenBucket(limits.History),
+			bucketOrders:   newTokenBucket(limits.Orders),
+			bucketTickle:   newTokenBucket(limits.Tickle),
+		},
+	}
+}
+
+// wait blocks until a request to the given endpoint bucket may be sent, or the context is done.
+// The endpoint and global tokens are reserved together and both are returned when the
+// context ends first, so cancelled requests do not consume either budget.
+func (l *rateLimiter) wait(ctx context.Context, bucket endpointBucket) error {
+	endpoint := l.endpoints[bucket]
+
+	delay := max(endpoint.reserve(), l.global.reserve())
+	if err := sleepContext(ctx, delay); err != nil {
+		endpoint.cancel()
+		l.global.cancel()
+
+		return err
+	}
+
+	return nil
+}
+
+// tokenBucket is a token-bucket rate limiter. A nil bucket never blocks.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket refilled at rate tokens per second.
+// It returns nil when rate is zero or negative.
+func newTokenBucket(rate float64) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	burst := math.Max(1, math.Floor(rate))
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (b *tokenBucket) cancel() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// sleepContext blocks for delay or until the context is done. A zero delay returns immediately.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package ibkr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Burst(t *testing.T) {
+	bucket := newTokenBucket(3)
+
+	for i := range 3 {
+		if delay := bucket.reserve(); delay != 0 {
+			t.Errorf("reserve() #%d delay = %v, want 0", i, delay)
+		}
+	}
+
+	if delay := bucket.reserve(); delay <= 0 || delay > time.Second/3 {
+		t.Errorf("reserve() after burst delay = %v, want within (0, %v]", delay, time.Second/3)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	if bucket := newTokenBucket(0); bucket != nil {
+		t.Error("Expected nil bucket for zero rate")
+	}
+
+	// Disabled buckets never block.
+	limiter := newRateLimiter(RateLimits{})
+	for range 100 {
+		if err := limiter.wait(context.Background(), bucketSnapshot); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+}
+
+func TestRateLimiter_WaitHonoursContext(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{Orders: 0.1})
+	if err := limiter.wait(context.Background(), bucketOrders); err != nil {
+		t.Fatalf("first wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.wait(ctx, bucketOrders); err != context.DeadlineExceeded {
+		t.Errorf("wait() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The cancelled reservation must be returned to the bucket.
+	if orders := limiter.endpoints[bucketOrders]; orders.tokens < -0.01 {
+		t.Errorf("tokens = %v, want the cancelled token returned", orders.tokens)
+	}
+}
+
+func TestRateLimiter_CancelReturnsBothTokens(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{Global: 0.1, Orders: 0.1})
+
+	// Drain the global bucket with a request that has no endpoint bucket.
+	if err := limiter.wait(context.Background(), ""); err != nil {
+		t.Fatalf("first wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.wait(ctx, bucketOrders); err != context.DeadlineExceeded {
+		t.Fatalf("wait() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The orders token reserved while waiting for the global bucket must be returned.
+	if orders := limiter.endpoints[bucketOrders]; orders.tokens < 0.99 {
+		t.Errorf("orders tokens = %v, want the cancelled token returned", orders.tokens)
+	}
+
+	if limiter.global.tokens < -0.01 {
+		t.Errorf("global tokens = %v, want the cancelled token returned", limiter.global.tokens)
+	}
+}
+
+func TestClient_RateLimit_PerEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	limits := RateLimits{Global: 100, Snapshot: 20}
+	client := NewClient(server.URL, WithRateLimits(limits))
+
+	start := time.Now()
+	for range 25 {
+		if _, err := client.GetMarketData(context.Background(), []int{265598}, nil); err != nil {
+			t.Fatalf("GetMarketData() error = %v", err)
+		}
+	}
+
+	// 20 requests fit in the burst; the remaining 5 are paced at 20 per second.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("25 snapshot requests took %v, want at least 200ms", elapsed)
+	}
+
+	// Other endpoints only share the global bucket and are not slowed down.
+	start = time.Now()
+	for range 10 {
+		if _, err := client.SearchContracts(context.Background(), "AAPL"); err != nil {
+			t.Fatalf("SearchContracts() error = %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("10 search requests took %v, want under 100ms", elapsed)
+	}
+}
+
+func TestClient_RateLimit_ContextExpires(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithRateLimits(RateLimits{Tickle: 0.1}))
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := client.Ping(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Ping() error = %v, want context.DeadlineExceeded", err)
+	}
+}
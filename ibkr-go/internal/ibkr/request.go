@@ -0,0 +1,118 @@
+package ibkr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// apiRequest describes a single call to the Gateway REST API.
+type apiRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	// idempotent marks requests that are safe to retry on transient failures.
+	idempotent bool
+	// bucket selects the per-endpoint pacing limit, in addition to the global one.
+	bucket endpointBucket
+}
+
+// do executes an API request and decodes a successful JSON response into out.
+// Non-success responses are returned as *APIError. A nil out discards the body.
+// Every attempt waits for the client's rate limiter, and transient failures are
+// retried according to the client's retry policy.
+func (c *Client) do(ctx context.Context, r apiRequest, out any) error {
+	maxAttempts := 1
+	if r.idempotent || unsafeRetriesAllowed(ctx) {
+		maxAttempts = max(c.retryPolicy.MaxAttempts, 1)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx, r.bucket); err != nil {
+			return err
+		}
+
+		err := c.doOnce(ctx, r, out)
+		if err == nil || attempt >= maxAttempts || !isRetryableError(err) {
+			return err
+		}
+
+		delay := c.retryPolicy.backoff(attempt)
+		c.recordRetry(ctx, r, attempt, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// doOnce performs a single attempt of an API request.
+func (c *Client) doOnce(ctx context.Context, r apiRequest, out any) error {
+	httpReq, err := c.newHTTPRequest(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", r.method, r.path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+
+		return newAPIError(r.method, r.path, resp.StatusCode, body)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// newHTTPRequest builds the HTTP request for an API request.
+func (c *Client) newHTTPRequest(ctx context.Context, r apiRequest) (*http.Request, error) {
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	var body io.Reader
+
+	if r.body != nil {
+		payload, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		body = bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if r.body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return httpReq, nil
+}
@@ -0,0 +1,326 @@
+package ibkr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSecType is the security type resolved when a query does not specify one.
+	DefaultSecType = "STK"
+	// SecTypeCash is the security type of currency pairs.
+	SecTypeCash = "CASH"
+	// preferredCurrency breaks ties between listings when a query does not specify a currency.
+	preferredCurrency = "USD"
+)
+
+var (
+	// ErrContractNotFound is returned when no contract matches a query.
+	ErrContractNotFound = errors.New("contract not found")
+	// ErrUnsupportedSecType is returned for security types the resolver cannot pick by symbol alone.
+	ErrUnsupportedSecType = errors.New("unsupported security type")
+)
+
+// resolvableSecTypes are the security types whose search result conid identifies the instrument.
+// Derivatives need an expiry, strike or right on top of the symbol.
+var resolvableSecTypes = map[string]bool{
+	"STK":       true,
+	"IND":       true,
+	"BOND":      true,
+	SecTypeCash: true,
+}
+
+// currencyPair matches a currency pair symbol, e.g. "EUR.USD".
+var currencyPair = regexp.MustCompile(`^([A-Z]{3})\.([A-Z]{3})$`)
+
+// ContractQuery describes the instrument to resolve. Only Symbol is required.
+type ContractQuery struct {
+	Symbol string
+	// SecType defaults to DefaultSecType.
+	SecType string
+	// Exchange is the listing exchange, e.g. "NASDAQ".
+	Exchange string
+	// Currency is the trading currency. USD listings are preferred when it is empty.
+	Currency string
+	// Expiry selects a futures contract by expiration month (YYYYMM) or date (YYYYMMDD).
+	// The front month is resolved when it is empty.
+	Expiry string
+}
+
+// String returns a human-readable description of the query.
+func (q ContractQuery) String() string {
+	s := q.Symbol + " " + q.SecType
+	if q.Exchange != "" {
+		s += " on " + q.Exchange
+	}
+
+	if q.Currency != "" {
+		s += " in " + q.Currency
+	}
+
+	if q.Expiry != "" {
+		s += " expiring " + q.Expiry
+	}
+
+	return s
+}
+
+// ResolvedContract is a contract picked for a query.
+type ResolvedContract struct {
+	ConID        int
+	Symbol       string
+	SecType      string
+	Exchange     string
+	Currency     string
+	Multiplier   string
+	TradingClass string
+	Description  string
+	// Expiry is the expiration date (YYYYMMDD) of futures contracts.
+	Expiry string
+}
+
+// String returns a human-readable description of the contract.
+func (c ResolvedContract) String() string {
+	s := fmt.Sprintf("%d %s %s %s %s", c.ConID, c.Symbol, c.SecType, c.Exchange, c.Currency)
+	if c.Expiry != "" {
+		s += " " + c.Expiry
+	}
+
+	return s
+}
+
+// AmbiguousContractError is returned when several contracts match a query.
+type AmbiguousContractError struct {
+	Query      ContractQuery
+	Candidates []ResolvedContract
+}
+
+// Error implements the error interface.
+func (e *AmbiguousContractError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		candidates = append(candidates, c.String())
+	}
+
+	return fmt.Sprintf("ambiguous contract %s, specify exchange or currency; candidates: %s",
+		e.Query, strings.Join(candidates, "; "))
+}
+
+// Resolver picks a contract for a symbol using the contract search results and the
+// contract details of each listing. Futures are picked among the contracts of their
+// root symbol by expiry.
+type Resolver struct {
+	client    ContractClient
+	rollRules RollRules
+	now       func() time.Time
+}
+
+// ResolverOption configures a Resolver.
+type ResolverOption func(*Resolver)
+
+// WithRollRules sets the rules deciding when the front-month futures contract rolls.
+func WithRollRules(rules RollRules) ResolverOption {
+	return func(r *Resolver) {
+		r.rollRules = rules
+	}
+}
+
+// NewResolver creates a new contract Resolver.
+func NewResolver(client ContractClient, opts ...ResolverOption) *Resolver {
+	resolver := &Resolver{
+		client: client,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(resolver)
+	}
+
+	return resolver
+}
+
+// ResolveContract returns the single contract matching the query. It returns an error
+// wrapping ErrContractNotFound when nothing matches and an *AmbiguousContractError when
+// several listings remain after applying the exchange and currency filters.
+func (r *Resolver) ResolveContract(ctx context.Context, query ContractQuery) (*ResolvedContract, error) {
+	query = query.Normalize()
+
+	if query.SecType == SecTypeFuture {
+		return r.resolveFuture(ctx, query)
+	}
+
+	if query.Expiry != "" {
+		return nil, fmt.Errorf("%w: expiry applies to %s only, got %s", ErrUnsupportedSecType, SecTypeFuture, query.SecType)
+	}
+
+	listings, err := r.Listings(ctx, query.Symbol, query.SecType)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectContract(query, listings)
+}
+
+// Listings returns every listing of a symbol with the given security type, with the
+// listing exchange and currency of each one filled in.
+func (r *Resolver) Listings(ctx context.Context, symbol, secType string) ([]ResolvedContract, error) {
+	query := ContractQuery{Symbol: symbol, SecType: secType}.Normalize()
+
+	if !resolvableSecTypes[query.SecType] {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSecType, query.SecType)
+	}
+
+	contracts, err := r.client.SearchContracts(ctx, query.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search contracts: %w", err)
+	}
+
+	listings := searchCandidates(contracts, query)
+	for i := range listings {
+		if err := r.describe(ctx, &listings[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return listings, nil
+}
+
+// SelectContract picks the listing matching the query. Listings on another exchange
+// or in another currency than requested are discarded; when the query has no currency
+// and several listings remain, USD listings are preferred.
+func SelectContract(query ContractQuery, listings []ResolvedContract) (*ResolvedContract, error) {
+	query = query.Normalize()
+
+	candidates := filterCandidates(listings, func(c ResolvedContract) bool {
+		return strings.EqualFold(c.Symbol, query.Symbol) && c.SecType == query.SecType &&
+			(query.Exchange == "" || strings.EqualFold(c.Exchange, query.Exchange)) &&
+			(query.Currency == "" || strings.EqualFold(c.Currency, query.Currency))
+	})
+
+	if len(candidates) > 1 && query.Currency == "" {
+		if preferred := filterCandidates(candidates, func(c ResolvedContract) bool {
+			return c.Currency == preferredCurrency
+		}); len(preferred) > 0 {
+			candidates = preferred
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrContractNotFound, query)
+	case 1:
+		return &candidates[0], nil
+	default:
+		return nil, &AmbiguousContractError{Query: query, Candidates: candidates}
+	}
+}
+
+// describe fills in the listing exchange and currency of a candidate.
+func (r *Resolver) describe(ctx context.Context, candidate *ResolvedContract) error {
+	infos, err := r.client.GetContractInfo(ctx, ContractInfoRequest{
+		ConID:   candidate.ConID,
+		SecType: candidate.SecType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get contract info for %d: %w", candidate.ConID, err)
+	}
+
+	for _, info := range infos {
+		if info.ConID != candidate.ConID && len(infos) > 1 {
+			continue
+		}
+
+		if info.ListingExchange != "" {
+			candidate.Exchange = info.ListingExchange
+		}
+
+		candidate.Currency = info.Currency
+		candidate.Multiplier = info.Multiplier
+		candidate.TradingClass = info.TradingClass
+
+		if info.CompanyName != "" {
+			candidate.Description = info.CompanyName
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// Normalize returns the query in upper case with the default security type filled in.
+// Symbols are rewritten to the form the Gateway lists them in: a currency pair such as
+// "EUR.USD" becomes the base currency symbol "EUR" with security type CASH and the quote
+// currency as currency, and class shares such as "BRK.B" or "RDS-A" take a space before
+// the class, "BRK B".
+func (q ContractQuery) Normalize() ContractQuery {
+	q.Symbol = strings.ToUpper(strings.TrimSpace(q.Symbol))
+	q.SecType = strings.ToUpper(q.SecType)
+	q.Exchange = strings.ToUpper(q.Exchange)
+	q.Currency = strings.ToUpper(q.Currency)
+	q.Expiry = strings.TrimSpace(q.Expiry)
+
+	if pair := currencyPair.FindStringSubmatch(q.Symbol); pair != nil && (q.SecType == "" || q.SecType == SecTypeCash) {
+		q.Symbol = pair[1]
+		q.SecType = SecTypeCash
+
+		if q.Currency == "" {
+			q.Currency = pair[2]
+		}
+	}
+
+	q.Symbol = strings.NewReplacer(".", " ", "-", " ").Replace(q.Symbol)
+
+	if q.SecType == "" {
+		q.SecType = DefaultSecType
+	}
+
+	return q
+}
+
+// searchCandidates returns the search results listing the query symbol with the query security type.
+func searchCandidates(contracts []Contract, query ContractQuery) []ResolvedContract {
+	var candidates []ResolvedContract
+
+	for _, contract := range contracts {
+		if !strings.EqualFold(contract.Symbol, query.Symbol) || !hasSection(contract, query.SecType) {
+			continue
+		}
+
+		candidates = append(candidates, ResolvedContract{
+			ConID:       contract.ConID,
+			Symbol:      contract.Symbol,
+			SecType:     query.SecType,
+			Exchange:    contract.Description,
+			Description: contract.CompanyName,
+		})
+	}
+
+	return candidates
+}
+
+func hasSection(contract Contract, secType string) bool {
+	for _, section := range contract.Sections {
+		if section.SecType == secType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filterCandidates(candidates []ResolvedContract, keep func(ResolvedContract) bool) []ResolvedContract {
+	var kept []ResolvedContract
+
+	for _, c := range candidates {
+		if keep(c) {
+			kept = append(kept, c)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,233 @@
+package ibkr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newSecdefServer serves contract search results and contract details for several listings.
+func newSecdefServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	infos := map[string]string{
+		"265598":    `[{"conid":265598,"symbol":"AAPL","secType":"STK","listingExchange":"NASDAQ","currency":"USD","companyName":"APPLE INC"}]`,
+		"38708077":  `[{"conid":38708077,"symbol":"AAPL","secType":"STK","listingExchange":"MEXI","currency":"MXN","companyName":"APPLE INC"}]`,
+		"273982664": `[{"conid":273982664,"symbol":"AAPL","secType":"STK","listingExchange":"EBS","currency":"CHF","companyName":"APPLE INC"}]`,
+		"493546048": `[{"conid":493546048,"symbol":"SHOP","secType":"STK","listingExchange":"NYSE","currency":"USD"}]`,
+		"495134040": `[{"conid":495134040,"symbol":"SHOP","secType":"STK","listingExchange":"TSE","currency":"CAD"}]`,
+		"495134041": `[{"conid":495134041,"symbol":"SHOP","secType":"STK","listingExchange":"NASDAQ","currency":"USD"}]`,
+		"72063691":  `[{"conid":72063691,"symbol":"BRK B","secType":"STK","listingExchange":"NYSE","currency":"USD","companyName":"BERKSHIRE HATHAWAY INC-CL B"}]`,
+		"29612193":  `[{"conid":29612193,"symbol":"RDS A","secType":"STK","listingExchange":"NYSE","currency":"USD","companyName":"ROYAL DUTCH SHELL-ADR A"}]`,
+		"12087792":  `[{"conid":12087792,"symbol":"EUR","secType":"CASH","listingExchange":"IDEALPRO","currency":"USD","companyName":"European Monetary Union Euro"}]`,
+		"12087797":  `[{"conid":12087797,"symbol":"EUR","secType":"CASH","listingExchange":"IDEALPRO","currency":"GBP","companyName":"European Monetary Union Euro"}]`,
+		"13881":     `[{"conid":13881,"symbol":"7203","secType":"STK","listingExchange":"TSEJ","currency":"JPY","companyName":"TOYOTA MOTOR CORP"}]`,
+		"51529211":  `[{"conid":51529211,"symbol":"7203","secType":"STK","listingExchange":"SEHK","currency":"HKD"}]`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v1/api/iserver/secdef/search":
+			switch r.URL.Query().Get("symbol") {
+			case "AAPL":
+				w.Write([]byte(`[
+					{"conid":265598,"symbol":"AAPL","description":"NASDAQ","companyName":"APPLE INC","sections":[{"secType":"STK"},{"secType":"OPT"}]},
+					{"conid":38708077,"symbol":"AAPL","description":"MEXI","sections":[{"secType":"STK"}]},
+					{"conid":273982664,"symbol":"AAPL","description":"EBS","sections":[{"secType":"STK"}]},
+					{"conid":999,"symbol":"AAPLX","description":"NASDAQ","sections":[{"secType":"STK"}]}
+				]`))
+			case "SHOP":
+				w.Write([]byte(`[
+					{"conid":493546048,"symbol":"SHOP","description":"NYSE","sections":[{"secType":"STK"}]},
+					{"conid":495134040,"symbol":"SHOP","description":"TSE","sections":[{"secType":"STK"}]},
+					{"conid":495134041,"symbol":"SHOP","description":"NASDAQ","sections":[{"secType":"STK"}]}
+				]`))
+			case "BRK B":
+				w.Write([]byte(`[
+					{"conid":72063691,"companyHeader":"BERKSHIRE HATHAWAY INC-CL B - NYSE","companyName":"BERKSHIRE HATHAWAY INC-CL B","symbol":"BRK B","description":"NYSE","sections":[{"secType":"STK"},{"secType":"OPT","months":"JAN25"}]}
+				]`))
+			case "RDS A":
+				w.Write([]byte(`[
+					{"conid":29612193,"companyHeader":"ROYAL DUTCH SHELL-ADR A - NYSE","companyName":"ROYAL DUTCH SHELL-ADR A","symbol":"RDS A","description":"NYSE","sections":[{"secType":"STK"}]}
+				]`))
+			case "EUR":
+				w.Write([]byte(`[
+					{"conid":12087792,"companyHeader":"European Monetary Union Euro - IDEALPRO","symbol":"EUR","description":"IDEALPRO","sections":[{"secType":"CASH","exchange":"IDEALPRO;"}]},
+					{"conid":12087797,"companyHeader":"European Monetary Union Euro - IDEALPRO","symbol":"EUR","description":"IDEALPRO","sections":[{"secType":"CASH","exchange":"IDEALPRO;"}]},
+					{"conid":121764205,"companyHeader":"Euro FX - CME","symbol":"EUR","description":"CME","sections":[{"secType":"FUT","months":"MAR25"}]}
+				]`))
+			case "7203":
+				w.Write([]byte(`[
+					{"conid":13881,"companyHeader":"TOYOTA MOTOR CORP - TSEJ","companyName":"TOYOTA MOTOR CORP","symbol":"7203","description":"TSEJ","sections":[{"secType":"STK"}]},
+					{"conid":51529211,"symbol":"7203","description":"SEHK","sections":[{"secType":"STK"}]}
+				]`))
+			default:
+				w.Write([]byte(`[]`))
+			}
+		case "/v1/api/iserver/secdef/info":
+			if r.URL.Query().Get("sectype") == "" {
+				t.Error("Expected sectype parameter")
+			}
+
+			info, ok := infos[r.URL.Query().Get("conid")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			w.Write([]byte(info))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestResolver_ResolveContract(t *testing.T) {
+	server := newSecdefServer(t)
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL))
+
+	tests := []struct {
+		name      string
+		query     ContractQuery
+		wantConID int
+	}{
+		{name: "prefers USD listing", query: ContractQuery{Symbol: "AAPL"}, wantConID: 265598},
+		{name: "by currency", query: ContractQuery{Symbol: "AAPL", Currency: "CHF"}, wantConID: 273982664},
+		{name: "by exchange", query: ContractQuery{Symbol: "aapl", Exchange: "mexi"}, wantConID: 38708077},
+		{name: "by exchange and currency", query: ContractQuery{Symbol: "SHOP", Exchange: "NYSE", Currency: "USD"}, wantConID: 493546048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract, err := resolver.ResolveContract(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("ResolveContract() error = %v", err)
+			}
+
+			if contract.ConID != tt.wantConID {
+				t.Errorf("ConID = %d, want %d", contract.ConID, tt.wantConID)
+			}
+
+			if contract.SecType != DefaultSecType || contract.Currency == "" {
+				t.Errorf("Unexpected contract: %+v", contract)
+			}
+		})
+	}
+}
+
+func TestResolver_SymbolForms(t *testing.T) {
+	server := newSecdefServer(t)
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL))
+
+	tests := []struct {
+		name        string
+		query       ContractQuery
+		wantConID   int
+		wantSymbol  string
+		wantSecType string
+	}{
+		{name: "class share with dot", query: ContractQuery{Symbol: "BRK.B"}, wantConID: 72063691, wantSymbol: "BRK B", wantSecType: "STK"},
+		{name: "class share with dash", query: ContractQuery{Symbol: "RDS-A"}, wantConID: 29612193, wantSymbol: "RDS A", wantSecType: "STK"},
+		{name: "currency pair", query: ContractQuery{Symbol: "EUR.USD"}, wantConID: 12087792, wantSymbol: "EUR", wantSecType: "CASH"},
+		{name: "currency pair with sec type", query: ContractQuery{Symbol: "eur.gbp", SecType: "CASH"}, wantConID: 12087797, wantSymbol: "EUR", wantSecType: "CASH"},
+		{name: "numeric symbol on exchange", query: ContractQuery{Symbol: "7203", Exchange: "TSEJ"}, wantConID: 13881, wantSymbol: "7203", wantSecType: "STK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract, err := resolver.ResolveContract(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("ResolveContract() error = %v", err)
+			}
+
+			if contract.ConID != tt.wantConID || contract.Symbol != tt.wantSymbol || contract.SecType != tt.wantSecType {
+				t.Errorf("Unexpected contract: %+v", contract)
+			}
+		})
+	}
+}
+
+func TestContractQuery_Normalize(t *testing.T) {
+	tests := []struct {
+		query ContractQuery
+		want  ContractQuery
+	}{
+		{query: ContractQuery{Symbol: "brk.b"}, want: ContractQuery{Symbol: "BRK B", SecType: "STK"}},
+		{query: ContractQuery{Symbol: "RDS-A"}, want: ContractQuery{Symbol: "RDS A", SecType: "STK"}},
+		{query: ContractQuery{Symbol: "EUR.USD"}, want: ContractQuery{Symbol: "EUR", SecType: "CASH", Currency: "USD"}},
+		// An explicit stock security type keeps a three-letter class as a class share.
+		{query: ContractQuery{Symbol: "ABC.DEF", SecType: "STK"}, want: ContractQuery{Symbol: "ABC DEF", SecType: "STK"}},
+		{query: ContractQuery{Symbol: "7203", Exchange: "tsej"}, want: ContractQuery{Symbol: "7203", SecType: "STK", Exchange: "TSEJ"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.Normalize(); got != tt.want {
+			t.Errorf("%+v.Normalize() = %+v, want %+v", tt.query, got, tt.want)
+		}
+
+		// Normalizing is idempotent.
+		if got := tt.want.Normalize(); got != tt.want {
+			t.Errorf("%+v.Normalize() = %+v, want it unchanged", tt.want, got)
+		}
+	}
+}
+
+func TestResolver_Ambiguous(t *testing.T) {
+	server := newSecdefServer(t)
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL))
+
+	_, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "SHOP"})
+
+	var ambiguous *AmbiguousContractError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Expected *AmbiguousContractError, got %v", err)
+	}
+
+	if len(ambiguous.Candidates) != 2 {
+		t.Errorf("Expected the 2 USD listings as candidates, got %v", ambiguous.Candidates)
+	}
+
+	if !strings.Contains(err.Error(), "493546048") || !strings.Contains(err.Error(), "495134041") {
+		t.Errorf("Error should list the candidates, got %q", err.Error())
+	}
+}
+
+func TestResolver_NotFound(t *testing.T) {
+	server := newSecdefServer(t)
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL))
+
+	tests := []ContractQuery{
+		{Symbol: "NOPE"},
+		{Symbol: "AAPL", Currency: "EUR"},
+		{Symbol: "AAPL", SecType: "IND"},
+	}
+
+	for _, query := range tests {
+		if _, err := resolver.ResolveContract(context.Background(), query); !errors.Is(err, ErrContractNotFound) {
+			t.Errorf("ResolveContract(%v) error = %v, want ErrContractNotFound", query, err)
+		}
+	}
+}
+
+func TestResolver_UnsupportedSecType(t *testing.T) {
+	resolver := NewResolver(NewClient("http://localhost"))
+
+	_, err := resolver.ResolveContract(context.Background(), ContractQuery{Symbol: "AAPL", SecType: "OPT"})
+	if !errors.Is(err, ErrUnsupportedSecType) {
+		t.Errorf("Expected ErrUnsupportedSecType, got %v", err)
+	}
+}
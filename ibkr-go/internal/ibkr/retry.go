@@ -0,0 +1,119 @@
+package ibkr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of attempts for retryable requests.
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default delay before the first retry.
+	DefaultRetryInitialBackoff = 200 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default upper bound for the delay between retries.
+	DefaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy controls how transient Gateway failures are retried.
+//
+// Only idempotent requests (market data, history, contract search, portfolio,
+// account summary, live orders, accounts and auth status) are retried by default. Order
+// placement, modification and cancellation are never retried unless the caller
+// opts in with WithUnsafeRetries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponentially growing delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+	}
+}
+
+// backoff returns the jittered delay before the given retry (1-based).
+// The delay doubles with every retry, is capped at MaxBackoff, and is then
+// randomized within [delay/2, delay] so that concurrent callers spread out.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	half := delay / 2 //nolint:mnd // Equal jitter keeps at least half of the delay.
+	if half <= 0 {
+		return delay
+	}
+
+	return half + rand.N(half+1) //nolint:gosec // Jitter does not need a secure source.
+}
+
+type unsafeRetriesKey struct{}
+
+// WithUnsafeRetries returns a context that allows non-idempotent requests, such as
+// placing, modifying or cancelling orders, to be retried on transient failures.
+// Only use it when a duplicate submission is acceptable or otherwise guarded against.
+func WithUnsafeRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unsafeRetriesKey{}, true)
+}
+
+// unsafeRetriesAllowed reports whether the caller opted in to retrying non-idempotent requests.
+func unsafeRetriesAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(unsafeRetriesKey{}).(bool)
+
+	return allowed
+}
+
+// isRetryableError reports whether a failed attempt may be retried.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// recordRetry reports a retry on the active trace span and in the logs.
+func (c *Client) recordRetry(ctx context.Context, r apiRequest, attempt int, delay time.Duration, err error) {
+	trace.SpanFromContext(ctx).AddEvent("ibkr.retry", trace.WithAttributes(
+		attribute.String("http.method", r.method),
+		attribute.String("ibkr.endpoint", r.path),
+		attribute.Int("ibkr.attempt", attempt),
+		attribute.String("ibkr.retry_delay", delay.String()),
+		attribute.String("error", err.Error()),
+	))
+
+	c.logger.WarnContext(ctx, "Retrying Gateway request",
+		slog.String("method", r.method),
+		slog.String("endpoint", r.path),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+		slog.String("error", err.Error()),
+	)
+}
@@ -0,0 +1,146 @@
+package ibkr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+// flakyServer fails the first failures requests with status, then responds with body.
+func flakyServer(t *testing.T, failures int32, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestClient_Retry_IdempotentRequest(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusServiceUnavailable, `[{"conid":265598,"symbol":"AAPL"}]`)
+
+	client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy()))
+	contracts, err := client.SearchContracts(context.Background(), "AAPL")
+	if err != nil {
+		t.Fatalf("SearchContracts() error = %v", err)
+	}
+	if len(contracts) != 1 {
+		t.Errorf("Expected 1 contract, got %d", len(contracts))
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, calls := flakyServer(t, 10, http.StatusBadGateway, `{}`)
+
+	client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy()))
+	if _, err := client.GetAccountSummary(context.Background(), "U12345"); err == nil {
+		t.Error("Expected error")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_NonRetryableStatus(t *testing.T) {
+	server, calls := flakyServer(t, 10, http.StatusBadRequest, `{}`)
+
+	client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy()))
+	if _, err := client.GetPortfolio(context.Background(), "U12345"); err == nil {
+		t.Error("Expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_OrderMutationsNotRetried(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusServiceUnavailable, `{"order_id":"1"}`)
+
+	client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy()))
+	if _, err := client.PlaceOrder(context.Background(), "U12345", PlaceOrderRequest{}); err == nil {
+		t.Error("Expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_OrderMutationsWithOptIn(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusServiceUnavailable, `{"order_id":"1"}`)
+
+	client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy()))
+	resp, err := client.PlaceOrder(WithUnsafeRetries(context.Background()), "U12345", PlaceOrderRequest{})
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+	if resp[0].OrderID != "1" {
+		t.Errorf("OrderID = %v, want 1", resp[0].OrderID)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_StopsOnContextCancel(t *testing.T) {
+	server, _ := flakyServer(t, 10, http.StatusServiceUnavailable, `{}`)
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+	client := NewClient(server.URL, WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetLiveOrders(ctx, "U12345"); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+		{8, time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			got := policy.backoff(tt.retry)
+			if got < tt.max/2 || got > tt.max {
+				t.Errorf("backoff(%d) = %v, want within [%v, %v]", tt.retry, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
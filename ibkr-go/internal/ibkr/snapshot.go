@@ -0,0 +1,276 @@
+package ibkr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSnapshotMaxWait is the default time GetMarketData re-polls pre-flight snapshots for.
+	DefaultSnapshotMaxWait = 2 * time.Second
+	// DefaultSnapshotPollInterval is the default delay between pre-flight snapshot polls.
+	DefaultSnapshotPollInterval = 250 * time.Millisecond
+)
+
+// SnapshotPolicy controls how pre-flight snapshots are re-polled.
+//
+// The first snapshot request for a conid makes the Gateway start collecting its market
+// data and usually returns none of the requested fields. GetMarketData requests such
+// pre-flight snapshots again until they carry data or MaxWait has elapsed.
+type SnapshotPolicy struct {
+	// MaxWait bounds the time spent re-polling. Zero disables re-polling.
+	MaxWait time.Duration
+	// PollInterval is the delay between polls.
+	PollInterval time.Duration
+}
+
+// DefaultSnapshotPolicy returns the default snapshot policy.
+func DefaultSnapshotPolicy() SnapshotPolicy {
+	return SnapshotPolicy{
+		MaxWait:      DefaultSnapshotMaxWait,
+		PollInterval: DefaultSnapshotPollInterval,
+	}
+}
+
+// WithSnapshotPolicy sets the policy used to re-poll pre-flight market data snapshots.
+func WithSnapshotPolicy(policy SnapshotPolicy) Option {
+	return func(c *Client) {
+		c.snapshotPolicy = policy
+	}
+}
+
+// MarketDataSnapshot represents a market data snapshot. Typed fields are decoded with
+// ParseSnapshotValue and are zero when the Gateway did not return them or returned a
+// value that is not numeric; Fields holds the raw value of every field returned, keyed
+// by field ID.
+type MarketDataSnapshot struct {
+	ConID     int
+	ConIDEx   string
+	LastPrice float64 // Last price.
+	Symbol    string  // Symbol.
+	Bid       float64 // Bid.
+	Ask       float64 // Ask.
+	Volume    int64   // Volume.
+	High      float64 // High.
+	Low       float64 // Low.
+	Close     float64 // Close.
+	Open      float64 // Open.
+	ServerID  string
+	Updated   time.Time // Time of the last update of the snapshot, zero when not returned.
+
+	// Option fields, only returned for option contracts.
+	Delta             float64 // Delta.
+	Gamma             float64 // Gamma.
+	Theta             float64 // Theta.
+	Vega              float64 // Vega.
+	ImpliedVolatility float64 // Implied volatility of the option, in percent.
+
+	// PriorClose reports that LastPrice is the prior close, as there is no live price.
+	PriorClose bool
+	// Halted reports that trading in the contract is halted.
+	Halted bool
+
+	Fields map[string]string
+}
+
+// Has reports whether the snapshot carries the given field.
+func (s *MarketDataSnapshot) Has(field string) bool {
+	_, ok := s.Fields[field]
+
+	return ok
+}
+
+// preflight reports whether the snapshot carries none of the requested fields.
+func (s *MarketDataSnapshot) preflight(fields []string) bool {
+	for _, field := range fields {
+		if !preflightIgnoredFields[field] && s.Has(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnmarshalJSON decodes a snapshot, keeping the raw value of every field.
+func (s *MarketDataSnapshot) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = MarketDataSnapshot{Fields: make(map[string]string)}
+
+	for key, value := range raw {
+		switch {
+		case key == "conid":
+			s.ConID = int(ParseSnapshotValue(rawString(value)).Number)
+		case key == "conidEx":
+			s.ConIDEx = rawString(value)
+		case key == "server_id":
+			s.ServerID = rawString(value)
+		case key == "_updated":
+			if updated := ParseSnapshotValue(rawString(value)).Number; updated > 0 {
+				s.Updated = time.UnixMilli(int64(updated))
+			}
+		case isFieldID(key):
+			s.Fields[key] = rawString(value)
+		}
+	}
+
+	s.Symbol = s.Fields[FieldSymbol]
+	s.LastPrice = s.number(FieldLastPrice)
+	s.Bid = s.number(FieldBid)
+	s.Ask = s.number(FieldAsk)
+	s.High = s.number(FieldHigh)
+	s.Low = s.number(FieldLow)
+	s.Close = s.number(FieldClose)
+	s.Open = s.number(FieldOpen)
+	s.Delta = s.number(FieldDelta)
+	s.Gamma = s.number(FieldGamma)
+	s.Theta = s.number(FieldTheta)
+	s.Vega = s.number(FieldVega)
+	s.ImpliedVolatility = s.number(FieldImpliedVolatility)
+
+	if s.Has(FieldVolumeLong) {
+		s.Volume = int64(s.number(FieldVolumeLong))
+	} else {
+		s.Volume = int64(s.number(FieldVolume))
+	}
+
+	last := s.Value(FieldLastPrice)
+	s.PriorClose = last.PriorClose
+	s.Halted = last.Halted
+
+	return nil
+}
+
+// Value decodes a field of the snapshot. The value is not Valid when the field is absent.
+func (s *MarketDataSnapshot) Value(field string) SnapshotValue {
+	raw, ok := s.Fields[field]
+	if !ok {
+		return SnapshotValue{}
+	}
+
+	return ParseSnapshotValue(raw)
+}
+
+// number decodes a numeric field, returning zero when it is absent or not a number.
+func (s *MarketDataSnapshot) number(field string) float64 {
+	return s.Value(field).Number
+}
+
+// GetMarketData retrieves market data snapshots for contracts. DefaultSnapshotFields are
+// requested when no fields are given. Pre-flight snapshots are re-polled according to the
+// client's snapshot policy; snapshots still without data when it gives up are returned as is.
+func (c *Client) GetMarketData(ctx context.Context, conIDs []int, fields []string) ([]MarketDataSnapshot, error) {
+	if len(fields) == 0 {
+		fields = DefaultSnapshotFields
+	}
+
+	snapshots, err := c.snapshot(ctx, conIDs, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(c.snapshotPolicy.MaxWait)
+
+	for {
+		pending := pendingSnapshots(snapshots, fields)
+		if len(pending) == 0 || time.Now().Add(c.snapshotPolicy.PollInterval).After(deadline) {
+			return snapshots, nil
+		}
+
+		timer := time.NewTimer(c.snapshotPolicy.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		polled, err := c.snapshot(ctx, slices.Sorted(maps.Keys(pending)), fields)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snapshot := range polled {
+			if i, ok := pending[snapshot.ConID]; ok {
+				snapshots[i] = snapshot
+			}
+		}
+	}
+}
+
+// snapshot performs a single market data snapshot request.
+func (c *Client) snapshot(ctx context.Context, conIDs []int, fields []string) ([]MarketDataSnapshot, error) {
+	conIDsStr := make([]string, 0, len(conIDs))
+	for _, id := range conIDs {
+		conIDsStr = append(conIDsStr, strconv.Itoa(id))
+	}
+
+	params := url.Values{}
+	params.Set("conids", strings.Join(conIDsStr, ","))
+	params.Set("fields", strings.Join(fields, ","))
+
+	var snapshots []MarketDataSnapshot
+
+	err := c.do(ctx, apiRequest{
+		method:     http.MethodGet,
+		path:       "/v1/api/iserver/marketdata/snapshot",
+		query:      params,
+		idempotent: true,
+		bucket:     bucketSnapshot,
+	}, &snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// pendingSnapshots returns the index of every pre-flight snapshot, keyed by conid.
+func pendingSnapshots(snapshots []MarketDataSnapshot, fields []string) map[int]int {
+	pending := make(map[int]int)
+
+	for i := range snapshots {
+		if snapshots[i].ConID != 0 && snapshots[i].preflight(fields) {
+			pending[snapshots[i].ConID] = i
+		}
+	}
+
+	return pending
+}
+
+// isFieldID reports whether a key is a numeric market data field ID.
+func isFieldID(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rawString returns a JSON string value unquoted, and any other value as its JSON text.
+func rawString(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+
+	return string(bytes.TrimSpace(value))
+}
@@ -0,0 +1,219 @@
+package ibkr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMarketDataSnapshot_UnmarshalJSON(t *testing.T) {
+	data := `{"conid":265598,"conidEx":"265598","_updated":1702334859712,"server_id":"q0","31":"193.18","55":"AAPL",` +
+		`"84":"193.06","86":193.2,"87":"1.2M","7762":"1234567","7296":"192.5","82":"+0.68","6509":"RpB"}`
+
+	var snapshot MarketDataSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if snapshot.ConID != 265598 || snapshot.Symbol != "AAPL" || snapshot.ServerID != "q0" {
+		t.Errorf("Unexpected snapshot: %+v", snapshot)
+	}
+	if !snapshot.Updated.Equal(time.UnixMilli(1702334859712)) {
+		t.Errorf("Updated = %v, want the time of _updated", snapshot.Updated)
+	}
+	if snapshot.LastPrice != 193.18 || snapshot.Bid != 193.06 || snapshot.Ask != 193.2 || snapshot.Close != 192.5 {
+		t.Errorf("Unexpected prices: %+v", snapshot)
+	}
+	if snapshot.Volume != 1234567 {
+		t.Errorf("Volume = %d, want the unformatted volume 1234567", snapshot.Volume)
+	}
+	if !snapshot.Has(FieldChange) || snapshot.Fields[FieldChange] != "+0.68" || snapshot.Fields[FieldMarketDataAvailability] != "RpB" {
+		t.Errorf("Unexpected raw fields: %v", snapshot.Fields)
+	}
+	if snapshot.Has(FieldOpen) {
+		t.Error("Snapshot should not have the open field")
+	}
+}
+
+func TestClient_GetMarketData_PreflightRepoll(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fields") != strings.Join(DefaultSnapshotFields, ",") {
+			t.Errorf("fields = %q, want the default fields", r.URL.Query().Get("fields"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// The first call only starts the market data stream for the contract.
+		if calls.Add(1) == 1 {
+			w.Write([]byte(`[{"conid":265598,"conidEx":"265598","6509":"RpB"},{"conid":8314,"31":"130.10"}]`))
+
+			return
+		}
+
+		if r.URL.Query().Get("conids") != "265598" {
+			t.Errorf("conids = %q, want only the pre-flight contract", r.URL.Query().Get("conids"))
+		}
+
+		w.Write([]byte(`[{"conid":265598,"31":"193.18"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSnapshotPolicy(SnapshotPolicy{MaxWait: time.Second, PollInterval: time.Millisecond}))
+
+	snapshots, err := client.GetMarketData(context.Background(), []int{265598, 8314}, nil)
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 snapshot requests, got %d", calls.Load())
+	}
+	if len(snapshots) != 2 || snapshots[0].LastPrice != 193.18 || snapshots[1].LastPrice != 130.10 {
+		t.Errorf("Unexpected snapshots: %+v", snapshots)
+	}
+}
+
+func TestClient_GetMarketData_PreflightDeadline(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"conid":265598}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSnapshotPolicy(SnapshotPolicy{MaxWait: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}))
+
+	snapshots, err := client.GetMarketData(context.Background(), []int{265598}, []string{FieldLastPrice})
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	if len(snapshots) != 1 || snapshots[0].Has(FieldLastPrice) {
+		t.Errorf("Expected the pre-flight snapshot, got %+v", snapshots)
+	}
+	if n := calls.Load(); n < 2 || n > 6 {
+		t.Errorf("Expected a few polls within the deadline, got %d", n)
+	}
+}
+
+func TestClient_GetMarketData_NoRepoll(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"conid":265598}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSnapshotPolicy(SnapshotPolicy{}))
+
+	if _, err := client.GetMarketData(context.Background(), []int{265598}, nil); err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single snapshot request, got %d", calls.Load())
+	}
+}
+
+// getFixtureSnapshots serves a captured snapshot response and decodes it with GetMarketData.
+func getFixtureSnapshots(t *testing.T, fixture string, conIDs ...int) []MarketDataSnapshot {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithSnapshotPolicy(SnapshotPolicy{}))
+
+	snapshots, err := client.GetMarketData(context.Background(), conIDs, nil)
+	if err != nil {
+		t.Fatalf("GetMarketData() error = %v", err)
+	}
+
+	return snapshots
+}
+
+func TestMarketDataSnapshot_Fixtures(t *testing.T) {
+	t.Run("stock", func(t *testing.T) {
+		snapshots := getFixtureSnapshots(t, "snapshot_stock.json", 265598, 756733)
+		if len(snapshots) != 2 {
+			t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+		}
+
+		live := snapshots[0]
+		if live.LastPrice != 193.18 || live.Bid != 193.17 || live.Ask != 193.2 || live.Open != 192.49 {
+			t.Errorf("Unexpected prices: %+v", live)
+		}
+		if live.Volume != 54213318 || live.PriorClose || live.Halted {
+			t.Errorf("Unexpected live snapshot: %+v", live)
+		}
+		if v := live.Value(FieldAskSize); v.Number != 1200 {
+			t.Errorf("Ask size = %v, want 1200", v.Number)
+		}
+
+		closed := snapshots[1]
+		if closed.LastPrice != 456.69 || !closed.PriorClose {
+			t.Errorf("Expected the prior close as last price, got %+v", closed)
+		}
+		if closed.Volume != 1200 {
+			t.Errorf("Volume = %d, want the expanded volume 1200", closed.Volume)
+		}
+	})
+
+	t.Run("halted", func(t *testing.T) {
+		snapshots := getFixtureSnapshots(t, "snapshot_halted.json", 36285627)
+		if len(snapshots) != 1 {
+			t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+
+		halted := snapshots[0]
+		if halted.ConID != 36285627 || !halted.Halted || halted.LastPrice != 0 || halted.Volume != 0 {
+			t.Errorf("Unexpected halted snapshot: %+v", halted)
+		}
+		if v := halted.Value(FieldPriorClose); v.Number != 14.67 {
+			t.Errorf("Prior close = %v, want 14.67", v.Number)
+		}
+	})
+
+	t.Run("option", func(t *testing.T) {
+		snapshots := getFixtureSnapshots(t, "snapshot_option.json", 664198375)
+		if len(snapshots) != 1 {
+			t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+
+		option := snapshots[0]
+		if option.Delta != 0.521 || option.Theta != -0.112 || option.ImpliedVolatility != 24.5 {
+			t.Errorf("Unexpected greeks: %+v", option)
+		}
+		if v := option.Value(FieldOptionOpenInterest); v.Number != 12300 {
+			t.Errorf("Open interest = %v, want 12300", v.Number)
+		}
+	})
+
+	t.Run("pre-flight", func(t *testing.T) {
+		snapshots := getFixtureSnapshots(t, "snapshot_preflight.json", 265598)
+		if len(snapshots) != 1 || !snapshots[0].preflight(DefaultSnapshotFields) {
+			t.Errorf("Expected a pre-flight snapshot, got %+v", snapshots)
+		}
+	})
+}
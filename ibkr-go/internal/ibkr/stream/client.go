@@ -0,0 +1,496 @@
+// Package stream implements a client for the Client Portal Gateway WebSocket API.
+//
+// The client authenticates with the session token returned by the tickle endpoint,
+// keeps the socket alive with heartbeats and reconnects with exponential backoff
+// when it drops, resubscribing to every active topic. Decoded updates are delivered
+// on the MarketData, Orders and PnL channels, which consumers must keep draining.
+package stream
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultHeartbeatInterval is the default interval between heartbeat messages.
+	// The Gateway closes sockets that stay silent for about a minute.
+	DefaultHeartbeatInterval = 30 * time.Second
+	// DefaultReadTimeout is the default time without any inbound message after which
+	// the connection is considered dead and re-established.
+	DefaultReadTimeout = 90 * time.Second
+	// DefaultReconnectInitialBackoff is the default delay before the first reconnect attempt.
+	DefaultReconnectInitialBackoff = time.Second
+	// DefaultReconnectMaxBackoff is the default upper bound for the reconnect backoff.
+	DefaultReconnectMaxBackoff = 30 * time.Second
+	// DefaultBufferSize is the default capacity of each update channel.
+	DefaultBufferSize = 256
+
+	wsPath           = "/v1/api/ws"
+	sessionCookie    = "api"
+	heartbeatMessage = "tic"
+	writeTimeout     = 10 * time.Second
+)
+
+// ErrNoSession is returned when the Gateway does not provide a session token.
+var ErrNoSession = errors.New("gateway returned no session token")
+
+// SessionSource provides the session token used to authenticate the WebSocket.
+// *ibkr.Client implements it.
+type SessionSource interface {
+	Tickle(ctx context.Context) (*ibkr.TickleResponse, error)
+}
+
+// Config configures a Client. Zero values fall back to the defaults.
+type Config struct {
+	// HeartbeatInterval is the interval between heartbeat messages sent to the Gateway.
+	HeartbeatInterval time.Duration
+	// ReadTimeout is how long the connection may stay silent before it is re-established.
+	ReadTimeout time.Duration
+	// ReconnectInitialBackoff is the delay before the first reconnect attempt.
+	ReconnectInitialBackoff time.Duration
+	// ReconnectMaxBackoff caps the delay between reconnect attempts.
+	ReconnectMaxBackoff time.Duration
+	// BufferSize is the capacity of each update channel.
+	BufferSize int
+	// TLSConfig is used for wss:// connections, e.g. to trust the Gateway certificate.
+	TLSConfig *tls.Config
+}
+
+// Client is a WebSocket client for the Client Portal Gateway streaming API.
+type Client struct {
+	url      string
+	origin   string
+	sessions SessionSource
+	config   Config
+	logger   *slog.Logger
+
+	marketData chan MarketDataUpdate
+	orders     chan OrderUpdate
+	pnl        chan PnLUpdate
+
+	// mu guards the connection and the subscriptions, so that a subscription is
+	// either sent on the live connection or replayed when the next one opens.
+	mu             sync.Mutex
+	conn           *websocket.Conn
+	marketDataSubs map[int][]string
+	ordersSub      bool
+	pnlSub         bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClient creates a new streaming client for the Gateway at gatewayURL, the same
+// base URL used by ibkr.Client.
+func NewClient(gatewayURL string, sessions SessionSource, config Config, logger *slog.Logger) (*Client, error) {
+	wsURL, err := websocketURL(gatewayURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+
+	if config.ReadTimeout <= 0 {
+		config.ReadTimeout = DefaultReadTimeout
+	}
+
+	if config.ReconnectInitialBackoff <= 0 {
+		config.ReconnectInitialBackoff = DefaultReconnectInitialBackoff
+	}
+
+	if config.ReconnectMaxBackoff < config.ReconnectInitialBackoff {
+		config.ReconnectMaxBackoff = max(DefaultReconnectMaxBackoff, config.ReconnectInitialBackoff)
+	}
+
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultBufferSize
+	}
+
+	return &Client{
+		url:            wsURL,
+		origin:         gatewayURL,
+		sessions:       sessions,
+		config:         config,
+		logger:         logger,
+		marketData:     make(chan MarketDataUpdate, config.BufferSize),
+		orders:         make(chan OrderUpdate, config.BufferSize),
+		pnl:            make(chan PnLUpdate, config.BufferSize),
+		marketDataSubs: make(map[int][]string),
+	}, nil
+}
+
+// websocketURL derives the WebSocket endpoint from the Gateway base URL.
+func websocketURL(gatewayURL string) (string, error) {
+	u, err := url.Parse(gatewayURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid gateway URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid gateway URL scheme: %q", u.Scheme)
+	}
+
+	u.Path = wsPath
+
+	return u.String(), nil
+}
+
+// MarketData returns the channel of market data updates. It is closed when the client stops.
+func (c *Client) MarketData() <-chan MarketDataUpdate {
+	return c.marketData
+}
+
+// Orders returns the channel of live order updates. It is closed when the client stops.
+func (c *Client) Orders() <-chan OrderUpdate {
+	return c.orders
+}
+
+// PnL returns the channel of profit and loss updates. It is closed when the client stops.
+func (c *Client) PnL() <-chan PnLUpdate {
+	return c.pnl
+}
+
+// Start connects in the background and keeps the connection up until Stop is called
+// or ctx is cancelled.
+func (c *Client) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+
+	go c.run(ctx)
+}
+
+// Stop closes the connection, waits for the client to exit and closes the update channels.
+func (c *Client) Stop() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	<-c.done
+}
+
+// SubscribeMarketData subscribes to market data for a contract. Fields are the
+// numeric market data field IDs, see the Field constants.
+func (c *Client) SubscribeMarketData(conID int, fields []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.marketDataSubs[conID] = fields
+
+	return c.send(marketDataSubscription(conID, fields))
+}
+
+// UnsubscribeMarketData cancels the market data subscription for a contract.
+func (c *Client) UnsubscribeMarketData(conID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.marketDataSubs, conID)
+
+	return c.send(fmt.Sprintf("umd+%d+{}", conID))
+}
+
+// SubscribeOrders subscribes to live order updates.
+func (c *Client) SubscribeOrders() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ordersSub = true
+
+	return c.send("sor+{}")
+}
+
+// UnsubscribeOrders cancels the live order subscription.
+func (c *Client) UnsubscribeOrders() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ordersSub = false
+
+	return c.send("uor+{}")
+}
+
+// SubscribePnL subscribes to profit and loss updates.
+func (c *Client) SubscribePnL() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pnlSub = true
+
+	return c.send("spl+{}")
+}
+
+// UnsubscribePnL cancels the profit and loss subscription.
+func (c *Client) UnsubscribePnL() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pnlSub = false
+
+	return c.send("upl{}")
+}
+
+func marketDataSubscription(conID int, fields []string) string {
+	args, _ := json.Marshal(struct {
+		Fields []string `json:"fields"`
+	}{Fields: fields})
+
+	return fmt.Sprintf("smd+%d+%s", conID, args)
+}
+
+// send writes a message on the live connection. Without a connection the message is
+// dropped; subscriptions are replayed when the next connection opens.
+// The caller must hold c.mu.
+func (c *Client) send(msg string) error {
+	if c.conn == nil {
+		return nil
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+
+	if err := websocket.Message.Send(c.conn, msg); err != nil {
+		return fmt.Errorf("failed to send %q: %w", msg, err)
+	}
+
+	return nil
+}
+
+// attach makes conn the live connection and replays the active subscriptions on it.
+func (c *Client) attach(conn *websocket.Conn) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = conn
+
+	for conID, fields := range c.marketDataSubs {
+		if err := c.send(marketDataSubscription(conID, fields)); err != nil {
+			return err
+		}
+	}
+
+	if c.ordersSub {
+		if err := c.send("sor+{}"); err != nil {
+			return err
+		}
+	}
+
+	if c.pnlSub {
+		return c.send("spl+{}")
+	}
+
+	return nil
+}
+
+// detach clears the live connection.
+func (c *Client) detach() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn = nil
+}
+
+// run connects and reconnects until ctx is cancelled.
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+	defer close(c.pnl)
+	defer close(c.orders)
+	defer close(c.marketData)
+
+	backoff := c.config.ReconnectInitialBackoff
+
+	for {
+		connected, err := c.serve(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			backoff = c.config.ReconnectInitialBackoff
+		}
+
+		c.logger.WarnContext(ctx, "Gateway WebSocket disconnected, reconnecting",
+			slog.Duration("delay", backoff),
+			slog.String("error", err.Error()),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, c.config.ReconnectMaxBackoff)
+	}
+}
+
+// serve opens a connection and reads from it until it fails. It reports whether the
+// connection was established.
+func (c *Client) serve(ctx context.Context) (bool, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Closing the connection unblocks the read loop when the client stops.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	defer c.detach()
+
+	if err := c.attach(conn); err != nil {
+		return true, err
+	}
+
+	heartbeatDone := make(chan struct{})
+	defer close(heartbeatDone)
+
+	go c.heartbeat(conn, heartbeatDone)
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(c.config.ReadTimeout)); err != nil {
+			return true, fmt.Errorf("failed to set read deadline: %w", err)
+		}
+
+		var msg []byte
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			return true, fmt.Errorf("failed to read message: %w", err)
+		}
+
+		c.dispatch(ctx, msg)
+	}
+}
+
+// dial authenticates with the session token and opens the WebSocket.
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	tickle, err := c.sessions.Tickle(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if tickle.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	wsConfig, err := websocket.NewConfig(c.url, c.origin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebSocket config: %w", err)
+	}
+
+	wsConfig.TlsConfig = c.config.TLSConfig
+	wsConfig.Header.Set("Cookie", (&http.Cookie{Name: sessionCookie, Value: tickle.Session}).String())
+
+	conn, err := wsConfig.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", c.url, err)
+	}
+
+	return conn, nil
+}
+
+// heartbeat sends heartbeat messages until done is closed. A failed heartbeat
+// closes the connection so that the read loop reconnects.
+func (c *Client) heartbeat(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(c.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		err := c.send(heartbeatMessage)
+		c.mu.Unlock()
+
+		if err != nil {
+			conn.Close()
+
+			return
+		}
+	}
+}
+
+// dispatch decodes a message and delivers it on the matching channel.
+func (c *Client) dispatch(ctx context.Context, msg []byte) {
+	var env envelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		c.logger.DebugContext(ctx, "Ignoring undecodable WebSocket message", slog.String("error", err.Error()))
+
+		return
+	}
+
+	switch {
+	case isMarketDataTopic(env.Topic):
+		update, err := decodeMarketData(msg)
+		if err != nil {
+			c.logger.WarnContext(ctx, "Failed to decode market data update", slog.String("error", err.Error()))
+
+			return
+		}
+
+		deliver(ctx, c.marketData, update)
+	case env.Topic == topicOrders:
+		updates, err := decodeOrders(env.Args)
+		if err != nil {
+			c.logger.WarnContext(ctx, "Failed to decode order update", slog.String("error", err.Error()))
+
+			return
+		}
+
+		for _, update := range updates {
+			deliver(ctx, c.orders, update)
+		}
+	case env.Topic == topicPnL:
+		updates, err := decodePnL(env.Args)
+		if err != nil {
+			c.logger.WarnContext(ctx, "Failed to decode PnL update", slog.String("error", err.Error()))
+
+			return
+		}
+
+		for _, update := range updates {
+			deliver(ctx, c.pnl, update)
+		}
+	case env.Topic == topicStatus:
+		var status ibkr.AuthStatusResponse
+		if err := json.Unmarshal(env.Args, &status); err == nil && !status.Authenticated {
+			c.logger.WarnContext(ctx, "Gateway WebSocket session is not authenticated",
+				slog.String("message", status.Message),
+			)
+		}
+	}
+}
+
+// deliver sends an update, blocking until the consumer receives it or ctx is done.
+func deliver[T any](ctx context.Context, ch chan<- T, update T) {
+	select {
+	case ch <- update:
+	case <-ctx.Done():
+	}
+}
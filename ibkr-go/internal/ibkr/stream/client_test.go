@@ -0,0 +1,295 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"golang.org/x/net/websocket"
+)
+
+const testTimeout = 2 * time.Second
+
+type fakeSessions struct {
+	session string
+}
+
+func (s fakeSessions) Tickle(_ context.Context) (*ibkr.TickleResponse, error) {
+	return &ibkr.TickleResponse{Session: s.session}, nil
+}
+
+type received struct {
+	connection int
+	msg        string
+}
+
+// fakeGateway is a WebSocket server standing in for the Gateway /v1/api/ws endpoint.
+type fakeGateway struct {
+	mu          sync.Mutex
+	connections int
+	cookies     []string
+
+	messages  chan received
+	onMessage func(ws *websocket.Conn, connection int, msg string)
+}
+
+func newFakeGateway(t *testing.T, onMessage func(ws *websocket.Conn, connection int, msg string)) (*httptest.Server, *fakeGateway) {
+	t.Helper()
+
+	gateway := &fakeGateway{
+		messages:  make(chan received, 100),
+		onMessage: onMessage,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/api/ws", websocket.Handler(gateway.serve))
+
+	return httptest.NewServer(mux), gateway
+}
+
+func (g *fakeGateway) serve(ws *websocket.Conn) {
+	g.mu.Lock()
+	g.connections++
+	connection := g.connections
+	g.cookies = append(g.cookies, ws.Request().Header.Get("Cookie"))
+	g.mu.Unlock()
+
+	for {
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+
+		select {
+		case g.messages <- received{connection: connection, msg: msg}:
+		default:
+		}
+
+		if g.onMessage != nil {
+			g.onMessage(ws, connection, msg)
+		}
+	}
+}
+
+// next returns the next message received by the server that is not a heartbeat.
+func (g *fakeGateway) next(t *testing.T) received {
+	t.Helper()
+
+	for {
+		select {
+		case r := <-g.messages:
+			if r.msg == heartbeatMessage {
+				continue
+			}
+
+			return r
+		case <-time.After(testTimeout):
+			t.Fatal("Timed out waiting for a message from the client")
+
+			return received{}
+		}
+	}
+}
+
+func newTestClient(t *testing.T, serverURL string, config Config) *Client {
+	t.Helper()
+
+	if config.ReconnectInitialBackoff == 0 {
+		config.ReconnectInitialBackoff = 10 * time.Millisecond
+	}
+
+	client, err := NewClient(serverURL, fakeSessions{session: "sess-1"}, config, slog.Default())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return client
+}
+
+func TestClient_SubscribeMarketData(t *testing.T) {
+	server, gateway := newFakeGateway(t, func(ws *websocket.Conn, _ int, msg string) {
+		if strings.HasPrefix(msg, "smd+265598+") {
+			websocket.Message.Send(ws, `{"topic":"smd+265598","conid":265598,"_updated":1702334859712,"31":"193.18","84":"193.06","86":"193.20","55":"AAPL"}`)
+		}
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, Config{})
+	if err := client.SubscribeMarketData(265598, []string{FieldLastPrice, FieldBid, FieldAsk}); err != nil {
+		t.Fatalf("SubscribeMarketData() error = %v", err)
+	}
+
+	client.Start(context.Background())
+	defer client.Stop()
+
+	if r := gateway.next(t); r.msg != `smd+265598+{"fields":["31","84","86"]}` {
+		t.Errorf("Subscription message = %q", r.msg)
+	}
+
+	select {
+	case update := <-client.MarketData():
+		if update.ConID != 265598 || update.LastPrice != 193.18 || update.Symbol != "AAPL" {
+			t.Errorf("Unexpected update: %+v", update)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for market data")
+	}
+
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+
+	if gateway.cookies[0] != "api=sess-1" {
+		t.Errorf("Cookie = %q, want api=sess-1", gateway.cookies[0])
+	}
+}
+
+func TestClient_ReconnectResubscribes(t *testing.T) {
+	server, gateway := newFakeGateway(t, func(ws *websocket.Conn, connection int, msg string) {
+		// Drop the first connection as soon as the live orders subscription arrives.
+		if connection == 1 && msg == "sor+{}" {
+			ws.Close()
+		}
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, Config{})
+	client.Start(context.Background())
+	defer client.Stop()
+
+	if err := client.SubscribeMarketData(265598, []string{FieldLastPrice}); err != nil {
+		t.Fatalf("SubscribeMarketData() error = %v", err)
+	}
+
+	if err := client.SubscribeMarketData(8314, []string{FieldLastPrice}); err != nil {
+		t.Fatalf("SubscribeMarketData() error = %v", err)
+	}
+
+	if err := client.UnsubscribeMarketData(8314); err != nil {
+		t.Fatalf("UnsubscribeMarketData() error = %v", err)
+	}
+
+	if err := client.SubscribeOrders(); err != nil {
+		t.Fatalf("SubscribeOrders() error = %v", err)
+	}
+
+	// Collect what the second connection receives: only the active subscriptions.
+	replayed := make(map[string]bool)
+	for len(replayed) < 2 {
+		r := gateway.next(t)
+		if r.connection == 2 {
+			replayed[r.msg] = true
+		}
+	}
+
+	if !replayed[`smd+265598+{"fields":["31"]}`] {
+		t.Errorf("Market data subscription not replayed, got %v", replayed)
+	}
+
+	if !replayed["sor+{}"] {
+		t.Errorf("Orders subscription not replayed, got %v", replayed)
+	}
+}
+
+func TestClient_Heartbeat(t *testing.T) {
+	server, gateway := newFakeGateway(t, nil)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, Config{HeartbeatInterval: 10 * time.Millisecond})
+	client.Start(context.Background())
+	defer client.Stop()
+
+	select {
+	case r := <-gateway.messages:
+		if r.msg != heartbeatMessage {
+			t.Errorf("Message = %q, want %q", r.msg, heartbeatMessage)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for a heartbeat")
+	}
+}
+
+func TestClient_OrdersAndPnL(t *testing.T) {
+	server, _ := newFakeGateway(t, func(ws *websocket.Conn, _ int, msg string) {
+		switch msg {
+		case "sor+{}":
+			websocket.Message.Send(ws, `{"topic":"sor","args":[{"acct":"U12345","conid":265598,"orderId":1001,"ticker":"AAPL","side":"BUY","status":"Submitted","price":"190.00","totalSize":10}]}`)
+		case "spl+{}":
+			websocket.Message.Send(ws, `{"topic":"spl","args":{"U12345.Core":{"rowType":1,"dpl":15.7,"nl":10000.5,"upl":607.25}}}`)
+		}
+	})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, Config{})
+	client.Start(context.Background())
+	defer client.Stop()
+
+	if err := client.SubscribeOrders(); err != nil {
+		t.Fatalf("SubscribeOrders() error = %v", err)
+	}
+
+	if err := client.SubscribePnL(); err != nil {
+		t.Fatalf("SubscribePnL() error = %v", err)
+	}
+
+	select {
+	case order := <-client.Orders():
+		if order.OrderID != "1001" || order.Price != 190 || order.AccountID != "U12345" {
+			t.Errorf("Unexpected order update: %+v", order)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for an order update")
+	}
+
+	select {
+	case pnl := <-client.PnL():
+		if pnl.AccountID != "U12345" || pnl.DailyPnL != 15.7 || pnl.NetLiquidity != 10000.5 {
+			t.Errorf("Unexpected PnL update: %+v", pnl)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Timed out waiting for a PnL update")
+	}
+}
+
+func TestClient_StopClosesChannels(t *testing.T) {
+	server, _ := newFakeGateway(t, nil)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, Config{})
+	client.Start(context.Background())
+	client.Stop()
+
+	if _, ok := <-client.MarketData(); ok {
+		t.Error("Expected market data channel to be closed")
+	}
+}
+
+func TestWebsocketURL(t *testing.T) {
+	tests := []struct {
+		gatewayURL string
+		want       string
+		wantErr    bool
+	}{
+		{gatewayURL: "http://localhost:5000", want: "ws://localhost:5000/v1/api/ws"},
+		{gatewayURL: "https://localhost:5000", want: "wss://localhost:5000/v1/api/ws"},
+		{gatewayURL: "ftp://localhost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := websocketURL(tt.gatewayURL)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("websocketURL(%q) error = %v, wantErr %v", tt.gatewayURL, err, tt.wantErr)
+
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("websocketURL(%q) = %q, want %q", tt.gatewayURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+const (
+	topicMarketDataPrefix = "smd+"
+	topicOrders           = "sor"
+	topicPnL              = "spl"
+	topicStatus           = "sts"
+)
+
+// Market data field IDs, as used in subscriptions and in MarketDataUpdate.Fields.
+// They are the snapshot field IDs of the ibkr package, see ibkr.SnapshotFields.
+const (
+	FieldLastPrice = ibkr.FieldLastPrice
+	FieldSymbol    = ibkr.FieldSymbol
+	FieldHigh      = ibkr.FieldHigh
+	FieldLow       = ibkr.FieldLow
+	FieldClose     = ibkr.FieldClose
+	FieldBid       = ibkr.FieldBid
+	FieldAsk       = ibkr.FieldAsk
+	FieldVolume    = ibkr.FieldVolume
+	FieldOpen      = ibkr.FieldOpen
+)
+
+// envelope is the part common to every message pushed by the Gateway.
+type envelope struct {
+	Topic string          `json:"topic"`
+	Args  json.RawMessage `json:"args"`
+}
+
+func isMarketDataTopic(topic string) bool {
+	return strings.HasPrefix(topic, topicMarketDataPrefix)
+}
+
+// MarketDataUpdate is a market data update for a single contract. The Gateway only
+// sends the fields that changed, so typed fields are zero when absent from the update;
+// Fields holds every field that was present, keyed by field ID.
+type MarketDataUpdate struct {
+	ConID     int
+	Updated   time.Time
+	Symbol    string
+	LastPrice float64
+	Bid       float64
+	Ask       float64
+	Volume    float64
+	High      float64
+	Low       float64
+	Close     float64
+	Open      float64
+	Fields    map[string]string
+}
+
+// Has reports whether the update carries the given field.
+func (u MarketDataUpdate) Has(field string) bool {
+	_, ok := u.Fields[field]
+
+	return ok
+}
+
+// decodeMarketData decodes a field-coded market data message.
+func decodeMarketData(msg []byte) (MarketDataUpdate, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &raw); err != nil {
+		return MarketDataUpdate{}, fmt.Errorf("failed to decode market data: %w", err)
+	}
+
+	update := MarketDataUpdate{Fields: make(map[string]string)}
+
+	if err := json.Unmarshal(raw["conid"], &update.ConID); err != nil {
+		return MarketDataUpdate{}, fmt.Errorf("invalid conid: %w", err)
+	}
+
+	var updated int64
+	if err := json.Unmarshal(raw["_updated"], &updated); err == nil && updated > 0 {
+		update.Updated = time.UnixMilli(updated)
+	}
+
+	for key, value := range raw {
+		if !isFieldID(key) {
+			continue
+		}
+
+		update.Fields[key] = rawString(value)
+	}
+
+	update.Symbol = update.Fields[FieldSymbol]
+	update.LastPrice = parseNumber(update.Fields[FieldLastPrice])
+	update.Bid = parseNumber(update.Fields[FieldBid])
+	update.Ask = parseNumber(update.Fields[FieldAsk])
+	update.Volume = parseNumber(update.Fields[FieldVolume])
+	update.High = parseNumber(update.Fields[FieldHigh])
+	update.Low = parseNumber(update.Fields[FieldLow])
+	update.Close = parseNumber(update.Fields[FieldClose])
+	update.Open = parseNumber(update.Fields[FieldOpen])
+
+	return update, nil
+}
+
+// isFieldID reports whether a key is a numeric market data field ID.
+func isFieldID(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rawString returns a JSON string value unquoted, and any other value as its JSON text.
+func rawString(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+
+	return string(bytes.TrimSpace(value))
+}
+
+// parseNumber parses a numeric field value, returning zero when it is absent or not a number.
+// Prefixed and abbreviated values are decoded as in snapshots, see ibkr.ParseSnapshotValue.
+func parseNumber(value string) float64 {
+	return ibkr.ParseSnapshotValue(value).Number
+}
+
+// number is a JSON number the Gateway may also encode as a string.
+type number float64
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	*n = number(parseNumber(rawString(data)))
+
+	return nil
+}
+
+// OrderUpdate is a live order update.
+type OrderUpdate struct {
+	AccountID         string
+	ConID             int
+	OrderID           string
+	Ticker            string
+	SecType           string
+	Side              string
+	OrderType         string
+	Status            string
+	Description       string
+	Price             float64
+	AvgPrice          float64
+	TotalSize         float64
+	FilledQuantity    float64
+	RemainingQuantity float64
+}
+
+type orderUpdateWire struct {
+	Acct              string          `json:"acct"`
+	ConID             int             `json:"conid"`
+	OrderID           json.RawMessage `json:"orderId"`
+	Ticker            string          `json:"ticker"`
+	SecType           string          `json:"secType"`
+	Side              string          `json:"side"`
+	OrderType         string          `json:"orderType"`
+	Status            string          `json:"status"`
+	OrderDesc         string          `json:"orderDesc"`
+	Price             number          `json:"price"`
+	AvgPrice          number          `json:"avgPrice"`
+	TotalSize         number          `json:"totalSize"`
+	FilledQuantity    number          `json:"filledQuantity"`
+	RemainingQuantity number          `json:"remainingQuantity"`
+}
+
+// decodeOrders decodes the arguments of a live orders message.
+func decodeOrders(args json.RawMessage) ([]OrderUpdate, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	var wire []orderUpdateWire
+	if err := json.Unmarshal(args, &wire); err != nil {
+		return nil, fmt.Errorf("failed to decode orders: %w", err)
+	}
+
+	updates := make([]OrderUpdate, 0, len(wire))
+	for _, w := range wire {
+		updates = append(updates, OrderUpdate{
+			AccountID:         w.Acct,
+			ConID:             w.ConID,
+			OrderID:           rawString(w.OrderID),
+			Ticker:            w.Ticker,
+			SecType:           w.SecType,
+			Side:              w.Side,
+			OrderType:         w.OrderType,
+			Status:            w.Status,
+			Description:       w.OrderDesc,
+			Price:             float64(w.Price),
+			AvgPrice:          float64(w.AvgPrice),
+			TotalSize:         float64(w.TotalSize),
+			FilledQuantity:    float64(w.FilledQuantity),
+			RemainingQuantity: float64(w.RemainingQuantity),
+		})
+	}
+
+	return updates, nil
+}
+
+// PnLUpdate is a profit and loss update for an account partition.
+type PnLUpdate struct {
+	// Key identifies the partition, e.g. "U1234567.Core".
+	Key             string
+	AccountID       string
+	DailyPnL        float64
+	UnrealizedPnL   float64
+	NetLiquidity    float64
+	ExcessLiquidity float64
+	MarketValue     float64
+}
+
+type pnlUpdateWire struct {
+	DailyPnL        number `json:"dpl"`
+	UnrealizedPnL   number `json:"upl"`
+	NetLiquidity    number `json:"nl"`
+	ExcessLiquidity number `json:"el"`
+	MarketValue     number `json:"mv"`
+}
+
+// decodePnL decodes the arguments of a PnL message.
+func decodePnL(args json.RawMessage) ([]PnLUpdate, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	var wire map[string]pnlUpdateWire
+	if err := json.Unmarshal(args, &wire); err != nil {
+		return nil, fmt.Errorf("failed to decode PnL: %w", err)
+	}
+
+	updates := make([]PnLUpdate, 0, len(wire))
+	for key, w := range wire {
+		accountID, _, _ := strings.Cut(key, ".")
+
+		updates = append(updates, PnLUpdate{
+			Key:             key,
+			AccountID:       accountID,
+			DailyPnL:        float64(w.DailyPnL),
+			UnrealizedPnL:   float64(w.UnrealizedPnL),
+			NetLiquidity:    float64(w.NetLiquidity),
+			ExcessLiquidity: float64(w.ExcessLiquidity),
+			MarketValue:     float64(w.MarketValue),
+		})
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDecodeMarketData(t *testing.T) {
+	msg := []byte(`{"topic":"smd+265598","conid":265598,"_updated":1702334859712,"server_id":"q0","31":"193.18","84":"193.06","87":"1,234","7295":193.5}`)
+
+	update, err := decodeMarketData(msg)
+	if err != nil {
+		t.Fatalf("decodeMarketData() error = %v", err)
+	}
+
+	if update.ConID != 265598 {
+		t.Errorf("ConID = %d, want 265598", update.ConID)
+	}
+
+	if !update.Updated.Equal(time.UnixMilli(1702334859712)) {
+		t.Errorf("Updated = %v", update.Updated)
+	}
+
+	if update.LastPrice != 193.18 || update.Bid != 193.06 || update.Volume != 1234 || update.Open != 193.5 {
+		t.Errorf("Unexpected typed fields: %+v", update)
+	}
+
+	if update.Has(FieldAsk) {
+		t.Error("Expected ask to be absent from the update")
+	}
+
+	if len(update.Fields) != 4 {
+		t.Errorf("Fields = %v, want 4 field IDs", update.Fields)
+	}
+}
+
+func TestDecodeMarketData_PrefixedValues(t *testing.T) {
+	msg := []byte(`{"topic":"smd+756733","conid":756733,"31":"C456.69","87":"3.4M","84":""}`)
+
+	update, err := decodeMarketData(msg)
+	if err != nil {
+		t.Fatalf("decodeMarketData() error = %v", err)
+	}
+
+	if update.LastPrice != 456.69 || update.Volume != 3400000 || update.Bid != 0 {
+		t.Errorf("Unexpected typed fields: %+v", update)
+	}
+}
+
+func TestDecodeMarketData_InvalidConID(t *testing.T) {
+	if _, err := decodeMarketData([]byte(`{"topic":"smd+x"}`)); err == nil {
+		t.Error("Expected error for missing conid")
+	}
+}
+
+func TestDecodeOrders(t *testing.T) {
+	args := json.RawMessage(`[{"acct":"U12345","conid":265598,"orderId":"1001","orderType":"Limit","filledQuantity":"4","remainingQuantity":6}]`)
+
+	updates, err := decodeOrders(args)
+	if err != nil {
+		t.Fatalf("decodeOrders() error = %v", err)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("Expected 1 update, got %d", len(updates))
+	}
+
+	if updates[0].OrderID != "1001" || updates[0].FilledQuantity != 4 || updates[0].RemainingQuantity != 6 {
+		t.Errorf("Unexpected order update: %+v", updates[0])
+	}
+}
+
+func TestDecodePnL(t *testing.T) {
+	args := json.RawMessage(`{"U12345.Core":{"rowType":1,"dpl":-12.5,"upl":100,"nl":5000,"el":4000,"mv":1000}}`)
+
+	updates, err := decodePnL(args)
+	if err != nil {
+		t.Fatalf("decodePnL() error = %v", err)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("Expected 1 update, got %d", len(updates))
+	}
+
+	want := PnLUpdate{
+		Key:             "U12345.Core",
+		AccountID:       "U12345",
+		DailyPnL:        -12.5,
+		UnrealizedPnL:   100,
+		NetLiquidity:    5000,
+		ExcessLiquidity: 4000,
+		MarketValue:     1000,
+	}
+	if updates[0] != want {
+		t.Errorf("decodePnL() = %+v, want %+v", updates[0], want)
+	}
+}
@@ -0,0 +1,217 @@
+package ibkr
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTickleInterval is the default interval between session keepalive checks.
+	DefaultTickleInterval = time.Minute
+	// DefaultReauthInitialBackoff is the default delay before re-checking after a reauthentication attempt.
+	DefaultReauthInitialBackoff = 5 * time.Second
+	// DefaultReauthMaxBackoff is the default upper bound for the reauthentication backoff.
+	DefaultReauthMaxBackoff = 2 * time.Minute
+)
+
+// SessionState is the brokerage session state as last observed by the Supervisor.
+type SessionState struct {
+	Authenticated bool
+	Connected     bool
+	Competing     bool
+	// Message is the status message returned by the Gateway, if any.
+	Message string
+	// LastChecked is when the session was last checked.
+	LastChecked time.Time
+	// LastError is the error from the last check, or empty if it succeeded.
+	LastError string
+	// Reauthenticating reports whether the session dropped and reauthentication is in progress.
+	Reauthenticating bool
+}
+
+// Ready reports whether the session can serve brokerage requests.
+func (s SessionState) Ready() bool {
+	return s.Authenticated && s.Connected && !s.Competing
+}
+
+// SupervisorConfig configures a Supervisor.
+type SupervisorConfig struct {
+	// TickleInterval is the interval between keepalive checks while the session is healthy.
+	TickleInterval time.Duration
+	// ReauthInitialBackoff is the delay before re-checking after the first reauthentication attempt.
+	ReauthInitialBackoff time.Duration
+	// ReauthMaxBackoff caps the delay between reauthentication attempts.
+	ReauthMaxBackoff time.Duration
+}
+
+// Supervisor keeps the Gateway brokerage session alive. It tickles the Gateway on an
+// interval, watches the authentication status and reauthenticates with exponential
+// backoff when the session drops.
+type Supervisor struct {
+	client BasicClient
+	config SupervisorConfig
+	logger *slog.Logger
+
+	mu    sync.RWMutex
+	state SessionState
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSupervisor creates a new session Supervisor. Zero config values fall back to the defaults.
+func NewSupervisor(client BasicClient, config SupervisorConfig, logger *slog.Logger) *Supervisor {
+	if config.TickleInterval <= 0 {
+		config.TickleInterval = DefaultTickleInterval
+	}
+
+	if config.ReauthInitialBackoff <= 0 {
+		config.ReauthInitialBackoff = DefaultReauthInitialBackoff
+	}
+
+	if config.ReauthMaxBackoff < config.ReauthInitialBackoff {
+		config.ReauthMaxBackoff = max(DefaultReauthMaxBackoff, config.ReauthInitialBackoff)
+	}
+
+	return &Supervisor{
+		client: client,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Start runs the supervisor in the background until Stop is called or ctx is cancelled.
+func (s *Supervisor) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go s.run(ctx)
+}
+
+// Stop stops the supervisor and waits for it to exit.
+func (s *Supervisor) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+}
+
+// State returns the last observed session state.
+func (s *Supervisor) State() SessionState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.state
+}
+
+// Ready reports whether the brokerage session can serve requests.
+func (s *Supervisor) Ready() bool {
+	return s.State().Ready()
+}
+
+// run checks the session until ctx is cancelled.
+func (s *Supervisor) run(ctx context.Context) {
+	defer close(s.done)
+
+	backoff := s.config.ReauthInitialBackoff
+
+	for {
+		delay := s.config.TickleInterval
+
+		if s.check(ctx) {
+			backoff = s.config.ReauthInitialBackoff
+		} else {
+			delay = backoff
+			backoff = min(backoff*2, s.config.ReauthMaxBackoff)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// check tickles the Gateway, refreshes the session state and reauthenticates if the
+// session dropped. It reports whether the session is healthy.
+func (s *Supervisor) check(ctx context.Context) bool {
+	if err := s.client.Ping(ctx); err != nil {
+		s.recordError(ctx, "Gateway tickle failed", err)
+
+		return false
+	}
+
+	status, err := s.client.AuthStatus(ctx)
+	if err != nil {
+		s.recordError(ctx, "Gateway auth status check failed", err)
+
+		return false
+	}
+
+	state := SessionState{
+		Authenticated: status.Authenticated,
+		Connected:     status.Connected,
+		Competing:     status.Competing,
+		Message:       status.Message,
+		LastChecked:   time.Now(),
+	}
+
+	if state.Ready() {
+		s.setState(state)
+
+		return true
+	}
+
+	// Reauthenticating would take the session away from the competing one, so
+	// wait for it to be released instead.
+	if state.Competing {
+		s.logger.WarnContext(ctx, "Gateway session is competing with another session")
+		s.setState(state)
+
+		return false
+	}
+
+	s.logger.WarnContext(ctx, "Gateway session dropped, reauthenticating",
+		slog.Bool("authenticated", state.Authenticated),
+		slog.Bool("connected", state.Connected),
+	)
+
+	state.Reauthenticating = true
+
+	if err := s.client.Reauthenticate(ctx); err != nil {
+		state.LastError = err.Error()
+		s.logger.ErrorContext(ctx, "Gateway reauthentication failed", slog.String("error", err.Error()))
+	}
+
+	s.setState(state)
+
+	return false
+}
+
+// recordError marks the session as unavailable after a failed check.
+func (s *Supervisor) recordError(ctx context.Context, msg string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.logger.ErrorContext(ctx, msg, slog.String("error", err.Error()))
+	s.setState(SessionState{
+		LastChecked: time.Now(),
+		LastError:   err.Error(),
+	})
+}
+
+func (s *Supervisor) setState(state SessionState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state
+}
@@ -0,0 +1,190 @@
+package ibkr
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGateway simulates the session endpoints of the Client Portal Gateway.
+type fakeGateway struct {
+	mu            sync.Mutex
+	authenticated bool
+	connected     bool
+	competing     bool
+	tickleStatus  int
+	tickles       int
+	reauths       int
+}
+
+func (g *fakeGateway) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/api/tickle", func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.tickles++
+		if g.tickleStatus != 0 {
+			w.WriteHeader(g.tickleStatus)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/v1/api/iserver/auth/status", func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		json.NewEncoder(w).Encode(AuthStatusResponse{
+			Authenticated: g.authenticated,
+			Connected:     g.connected,
+			Competing:     g.competing,
+		})
+	})
+
+	mux.HandleFunc("/v1/api/iserver/reauthenticate", func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.reauths++
+		g.authenticated = true
+		g.connected = true
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
+
+func (g *fakeGateway) counts() (tickles, reauths int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.tickles, g.reauths
+}
+
+func startSupervisor(t *testing.T, gateway *fakeGateway) *Supervisor {
+	t.Helper()
+
+	server := httptest.NewServer(gateway.handler())
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL,
+		WithRateLimits(RateLimits{}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+
+	supervisor := NewSupervisor(client, SupervisorConfig{
+		TickleInterval:       10 * time.Millisecond,
+		ReauthInitialBackoff: 5 * time.Millisecond,
+		ReauthMaxBackoff:     20 * time.Millisecond,
+	}, slog.Default())
+	supervisor.Start(context.Background())
+	t.Cleanup(supervisor.Stop)
+
+	return supervisor
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSupervisor_HealthySession(t *testing.T) {
+	gateway := &fakeGateway{authenticated: true, connected: true}
+	supervisor := startSupervisor(t, gateway)
+
+	waitFor(t, func() bool {
+		tickles, _ := gateway.counts()
+
+		return tickles >= 3
+	})
+
+	if !supervisor.Ready() {
+		t.Errorf("Ready() = false, state = %+v", supervisor.State())
+	}
+	if _, reauths := gateway.counts(); reauths != 0 {
+		t.Errorf("Expected no reauthentication, got %d", reauths)
+	}
+}
+
+func TestSupervisor_ReauthenticatesDroppedSession(t *testing.T) {
+	gateway := &fakeGateway{authenticated: false, connected: true}
+	supervisor := startSupervisor(t, gateway)
+
+	waitFor(t, supervisor.Ready)
+
+	if _, reauths := gateway.counts(); reauths != 1 {
+		t.Errorf("Expected 1 reauthentication, got %d", reauths)
+	}
+
+	state := supervisor.State()
+	if state.Reauthenticating || state.LastError != "" {
+		t.Errorf("Unexpected state after recovery: %+v", state)
+	}
+}
+
+func TestSupervisor_CompetingSession(t *testing.T) {
+	gateway := &fakeGateway{authenticated: true, connected: true, competing: true}
+	supervisor := startSupervisor(t, gateway)
+
+	waitFor(t, func() bool { return supervisor.State().Competing })
+
+	if supervisor.Ready() {
+		t.Error("Ready() = true for a competing session")
+	}
+	if _, reauths := gateway.counts(); reauths != 0 {
+		t.Errorf("Expected no reauthentication for a competing session, got %d", reauths)
+	}
+}
+
+func TestSupervisor_GatewayUnavailable(t *testing.T) {
+	gateway := &fakeGateway{authenticated: true, connected: true, tickleStatus: http.StatusServiceUnavailable}
+	supervisor := startSupervisor(t, gateway)
+
+	waitFor(t, func() bool { return supervisor.State().LastError != "" })
+
+	if supervisor.Ready() {
+		t.Error("Ready() = true while the Gateway is unavailable")
+	}
+
+	gateway.mu.Lock()
+	gateway.tickleStatus = 0
+	gateway.mu.Unlock()
+
+	waitFor(t, supervisor.Ready)
+}
+
+func TestSupervisor_Stop(t *testing.T) {
+	gateway := &fakeGateway{authenticated: true, connected: true}
+	supervisor := startSupervisor(t, gateway)
+
+	waitFor(t, supervisor.Ready)
+	supervisor.Stop()
+
+	// A tickle already in flight when Stop was called may still reach the server.
+	time.Sleep(20 * time.Millisecond)
+
+	tickles, _ := gateway.counts()
+	time.Sleep(50 * time.Millisecond)
+
+	if after, _ := gateway.counts(); after != tickles {
+		t.Errorf("Supervisor kept tickling after Stop: %d -> %d", tickles, after)
+	}
+}
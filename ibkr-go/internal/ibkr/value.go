@@ -0,0 +1,74 @@
+package ibkr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Prefixes the Gateway puts in front of snapshot prices.
+const (
+	// priorClosePrefix marks a price that is the prior close, as there is no live price yet.
+	priorClosePrefix = "C"
+	// haltedPrefix marks a contract whose trading is halted; it may stand on its own.
+	haltedPrefix = "H"
+)
+
+// magnitudeSuffixes are the multipliers of abbreviated values such as "1.2K" or "3.4M".
+var magnitudeSuffixes = map[byte]float64{
+	'K': 1e3,
+	'M': 1e6,
+	'B': 1e9,
+}
+
+// SnapshotValue is a decoded snapshot field value.
+type SnapshotValue struct {
+	// Number is the numeric value, with K/M/B abbreviations expanded.
+	Number float64
+	// Valid reports whether a number was decoded.
+	Valid bool
+	// PriorClose reports that the value is the prior close rather than a live price ("C" prefix).
+	PriorClose bool
+	// Halted reports that trading in the contract is halted ("H" prefix).
+	Halted bool
+}
+
+// ParseSnapshotValue decodes a raw snapshot field value as returned by the Gateway, e.g.
+// "193.18", "C193.18" for a prior close, "H" for a halted contract, "1.2K" or "3.4M" for
+// a volume, "1,234" or "+0.68%". Values that are not numeric are returned with Valid unset.
+func ParseSnapshotValue(raw string) SnapshotValue {
+	var value SnapshotValue
+
+	s := strings.TrimSpace(raw)
+
+	switch {
+	case strings.HasPrefix(s, priorClosePrefix):
+		value.PriorClose = true
+		s = strings.TrimPrefix(s, priorClosePrefix)
+	case strings.HasPrefix(s, haltedPrefix):
+		value.Halted = true
+		s = strings.TrimPrefix(s, haltedPrefix)
+	}
+
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.TrimSuffix(s, "%")
+
+	multiplier := 1.0
+
+	if n := len(s); n > 0 {
+		if m, ok := magnitudeSuffixes[s[n-1]]; ok {
+			multiplier = m
+			s = s[:n-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return value
+	}
+
+	value.Number = number * multiplier
+	value.Valid = true
+
+	return value
+}
@@ -0,0 +1,42 @@
+package ibkr
+
+import "testing"
+
+func TestParseSnapshotValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want SnapshotValue
+	}{
+		{raw: "193.18", want: SnapshotValue{Number: 193.18, Valid: true}},
+		{raw: " 42 ", want: SnapshotValue{Number: 42, Valid: true}},
+		{raw: "+0.68", want: SnapshotValue{Number: 0.68, Valid: true}},
+		{raw: "-0.112", want: SnapshotValue{Number: -0.112, Valid: true}},
+		{raw: "+0.35%", want: SnapshotValue{Number: 0.35, Valid: true}},
+		{raw: "1,200", want: SnapshotValue{Number: 1200, Valid: true}},
+		{raw: "1.2K", want: SnapshotValue{Number: 1200, Valid: true}},
+		{raw: "54.2M", want: SnapshotValue{Number: 54200000, Valid: true}},
+		{raw: "2.1B", want: SnapshotValue{Number: 2100000000, Valid: true}},
+		{raw: "C456.69", want: SnapshotValue{Number: 456.69, Valid: true, PriorClose: true}},
+		{raw: "H", want: SnapshotValue{Halted: true}},
+		{raw: "H14.67", want: SnapshotValue{Number: 14.67, Valid: true, Halted: true}},
+		{raw: "", want: SnapshotValue{}},
+		{raw: "-", want: SnapshotValue{}},
+		{raw: "RpB", want: SnapshotValue{}},
+		{raw: "NaN", want: SnapshotValue{}},
+	}
+
+	for _, tt := range tests {
+		got := ParseSnapshotValue(tt.raw)
+
+		// Compare expanded values with a tolerance, as K/M/B multiplication is inexact.
+		diff := got.Number - tt.want.Number
+		if diff < -1e-6 || diff > 1e-6 {
+			t.Errorf("ParseSnapshotValue(%q).Number = %v, want %v", tt.raw, got.Number, tt.want.Number)
+		}
+
+		got.Number = tt.want.Number
+		if got != tt.want {
+			t.Errorf("ParseSnapshotValue(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
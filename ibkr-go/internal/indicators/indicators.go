@@ -0,0 +1,285 @@
+// Package indicators computes technical indicators over price bars.
+//
+// Every indicator returns series aligned with its input: the value at index i is the
+// indicator as of bar i. Values before the indicator has seen enough bars to be defined,
+// its warm-up, are NaN. A period below one yields no values, only NaN.
+package indicators
+
+import (
+	"math"
+	"time"
+)
+
+// Bar is a price bar.
+type Bar struct {
+	// Time is the start of the bar.
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// MACDResult holds the series of the moving average convergence divergence.
+type MACDResult struct {
+	// MACD is the fast EMA minus the slow EMA.
+	MACD []float64
+	// Signal is the EMA of MACD.
+	Signal []float64
+	// Histogram is MACD minus Signal.
+	Histogram []float64
+}
+
+// BollingerResult holds the series of the Bollinger bands.
+type BollingerResult struct {
+	// Middle is the SMA of the values.
+	Middle []float64
+	// Upper is Middle plus the standard deviations times the population standard
+	// deviation of the values over the period.
+	Upper []float64
+	// Lower is Middle minus the standard deviations times the standard deviation.
+	Lower []float64
+}
+
+// Closes returns the close prices of the bars.
+func Closes(bars []Bar) []float64 {
+	closes := make([]float64, len(bars))
+	for i, bar := range bars {
+		closes[i] = bar.Close
+	}
+
+	return closes
+}
+
+// SMA returns the simple moving average of values over period values.
+func SMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 {
+		return out
+	}
+
+	var sum float64
+
+	for i, value := range values {
+		sum += value
+		if i >= period {
+			sum -= values[i-period]
+		}
+
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+
+	return out
+}
+
+// EMA returns the exponential moving average of values over period values, with a
+// smoothing factor of 2/(period+1), seeded with the SMA of the first period values.
+// Leading NaN values, such as the warm-up of another indicator, are skipped.
+func EMA(values []float64, period int) []float64 {
+	return ema(values, period, 2/float64(period+1))
+}
+
+// RSI returns the relative strength index of values over period changes, with Wilder's
+// smoothing. It is 100 when there were no losses and 50 when there was no change.
+func RSI(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 || len(values) <= period {
+		return out
+	}
+
+	var avgGain, avgLoss float64
+
+	for i := 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gain, loss := math.Max(change, 0), math.Max(-change, 0)
+
+		if i <= period {
+			// The first averages are the means of the first period changes.
+			avgGain += gain / float64(period)
+			avgLoss += loss / float64(period)
+
+			if i < period {
+				continue
+			}
+		} else {
+			avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+			avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		}
+
+		out[i] = rsi(avgGain, avgLoss)
+	}
+
+	return out
+}
+
+// MACD returns the moving average convergence divergence of values: the fast EMA minus
+// the slow EMA, its signal EMA and their difference.
+func MACD(values []float64, fast, slow, signal int) MACDResult {
+	result := MACDResult{
+		MACD:      nans(len(values)),
+		Signal:    nans(len(values)),
+		Histogram: nans(len(values)),
+	}
+
+	if fast < 1 || slow < 1 || signal < 1 {
+		return result
+	}
+
+	fastEMA, slowEMA := EMA(values, fast), EMA(values, slow)
+
+	for i := range values {
+		result.MACD[i] = fastEMA[i] - slowEMA[i]
+	}
+
+	result.Signal = EMA(result.MACD, signal)
+
+	for i := range values {
+		result.Histogram[i] = result.MACD[i] - result.Signal[i]
+	}
+
+	return result
+}
+
+// ATR returns the average true range of the bars over period bars, with Wilder's
+// smoothing. The true range needs the previous close, so the first value is at index
+// period.
+func ATR(bars []Bar, period int) []float64 {
+	out := nans(len(bars))
+	if period < 1 || len(bars) <= period {
+		return out
+	}
+
+	var atr float64
+
+	for i := 1; i < len(bars); i++ {
+		prevClose := bars[i-1].Close
+		tr := max(bars[i].High-bars[i].Low, math.Abs(bars[i].High-prevClose), math.Abs(bars[i].Low-prevClose))
+
+		if i <= period {
+			atr += tr / float64(period)
+
+			if i < period {
+				continue
+			}
+		} else {
+			atr = (atr*float64(period-1) + tr) / float64(period)
+		}
+
+		out[i] = atr
+	}
+
+	return out
+}
+
+// Bollinger returns the Bollinger bands of values over period values, stdDev population
+// standard deviations away from their SMA.
+func Bollinger(values []float64, period int, stdDev float64) BollingerResult {
+	result := BollingerResult{
+		Middle: SMA(values, period),
+		Upper:  nans(len(values)),
+		Lower:  nans(len(values)),
+	}
+
+	for i, mean := range result.Middle {
+		if math.IsNaN(mean) {
+			continue
+		}
+
+		var variance float64
+		for _, value := range values[i-period+1 : i+1] {
+			variance += (value - mean) * (value - mean)
+		}
+
+		deviation := stdDev * math.Sqrt(variance/float64(period))
+		result.Upper[i] = mean + deviation
+		result.Lower[i] = mean - deviation
+	}
+
+	return result
+}
+
+// VWAP returns the volume-weighted average of the typical prices of the bars, the mean
+// of high, low and close. The average restarts at every anchor interval, aligned to the
+// Unix epoch in UTC, e.g. every day with an anchor of 24 hours; a zero anchor never
+// restarts it. It is NaN until there was volume.
+func VWAP(bars []Bar, anchor time.Duration) []float64 {
+	out := nans(len(bars))
+
+	var (
+		session             time.Time
+		priceVolume, volume float64
+	)
+
+	for i, bar := range bars {
+		if anchor > 0 {
+			if start := Bucket(bar.Time, anchor); i == 0 || !start.Equal(session) {
+				session, priceVolume, volume = start, 0, 0
+			}
+		}
+
+		priceVolume += (bar.High + bar.Low + bar.Close) / 3 * bar.Volume
+		volume += bar.Volume
+
+		if volume > 0 {
+			out[i] = priceVolume / volume
+		}
+	}
+
+	return out
+}
+
+// ema returns the exponential moving average of values with the smoothing factor alpha,
+// seeded with the SMA of the first period values after the leading NaN values.
+func ema(values []float64, period int, alpha float64) []float64 {
+	out := nans(len(values))
+	if period < 1 {
+		return out
+	}
+
+	first := 0
+	for first < len(values) && math.IsNaN(values[first]) {
+		first++
+	}
+
+	seed := first + period - 1
+	if seed >= len(values) {
+		return out
+	}
+
+	var sum float64
+	for _, value := range values[first : seed+1] {
+		sum += value
+	}
+
+	out[seed] = sum / float64(period)
+
+	for i := seed + 1; i < len(values); i++ {
+		out[i] = alpha*values[i] + (1-alpha)*out[i-1]
+	}
+
+	return out
+}
+
+func rsi(avgGain, avgLoss float64) float64 {
+	switch {
+	case avgLoss == 0 && avgGain == 0:
+		return 50
+	case avgLoss == 0:
+		return 100
+	default:
+		return 100 - 100/(1+avgGain/avgLoss)
+	}
+}
+
+// nans returns n NaN values.
+func nans(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+
+	return out
+}
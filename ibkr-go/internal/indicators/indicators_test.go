@@ -0,0 +1,163 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const tolerance = 0.01
+
+// assertSeries checks a series against the expected values, where NaN expects the warm-up.
+func assertSeries(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("%s[%d] = %v, want NaN", name, i, got[i])
+			}
+
+			continue
+		}
+
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func ramp(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i + 1)
+	}
+
+	return values
+}
+
+func TestSMA(t *testing.T) {
+	nan := math.NaN()
+
+	assertSeries(t, "SMA", SMA([]float64{1, 2, 3, 4, 5, 6}, 3), []float64{nan, nan, 2, 3, 4, 5})
+	assertSeries(t, "SMA(1)", SMA([]float64{4, 5}, 1), []float64{4, 5})
+	assertSeries(t, "SMA(short)", SMA([]float64{1, 2}, 3), []float64{nan, nan})
+	assertSeries(t, "SMA(0)", SMA([]float64{1, 2}, 0), []float64{nan, nan})
+}
+
+func TestEMA(t *testing.T) {
+	nan := math.NaN()
+
+	// Seeded with the SMA of 1, 2 and 3, then lagging a ramp by one with a factor of 0.5.
+	assertSeries(t, "EMA", EMA(ramp(6), 3), []float64{nan, nan, 2, 3, 4, 5})
+
+	// Leading NaN values are skipped.
+	assertSeries(t, "EMA(NaN)", EMA([]float64{nan, 1, 2, 3, 4}, 3), []float64{nan, nan, nan, 2, 3})
+}
+
+func TestRSI(t *testing.T) {
+	// Wilder's example as published by StockCharts.
+	closes := []float64{
+		44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+		45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+	}
+
+	got := RSI(closes, 14)
+	want := []float64{70.46, 66.25, 66.48, 69.35, 66.29, 57.92}
+
+	for i := range 14 {
+		if !math.IsNaN(got[i]) {
+			t.Errorf("RSI[%d] = %v, want NaN", i, got[i])
+		}
+	}
+
+	assertSeries(t, "RSI", got[14:], want)
+}
+
+func TestRSI_Extremes(t *testing.T) {
+	nan := math.NaN()
+
+	assertSeries(t, "RSI(rising)", RSI(ramp(4), 2), []float64{nan, nan, 100, 100})
+	assertSeries(t, "RSI(flat)", RSI([]float64{5, 5, 5}, 2), []float64{nan, nan, 50})
+	assertSeries(t, "RSI(mixed)", RSI([]float64{1, 2, 3, 2, 3}, 2), []float64{nan, nan, 100, 50, 75})
+}
+
+func TestMACD(t *testing.T) {
+	values := ramp(10)
+	result := MACD(values, 2, 4, 3)
+
+	fast, slow := EMA(values, 2), EMA(values, 4)
+
+	for i := range values {
+		if i < 3 {
+			if !math.IsNaN(result.MACD[i]) {
+				t.Errorf("MACD[%d] = %v, want NaN before the slow EMA", i, result.MACD[i])
+			}
+
+			continue
+		}
+
+		if want := fast[i] - slow[i]; math.Abs(result.MACD[i]-want) > tolerance {
+			t.Errorf("MACD[%d] = %v, want %v", i, result.MACD[i], want)
+		}
+	}
+
+	// On a ramp the EMAs lag by a constant, so MACD settles and the histogram goes to zero.
+	signal := EMA(result.MACD, 3)
+	assertSeries(t, "Signal", result.Signal, signal)
+
+	if first := 5; math.IsNaN(result.Signal[first]) || !math.IsNaN(result.Signal[first-1]) {
+		t.Errorf("Expected the signal to start at index %d, got %v", first, result.Signal)
+	}
+
+	if last := result.Histogram[len(values)-1]; math.Abs(last) > tolerance {
+		t.Errorf("Histogram = %v, want about 0 on a ramp", last)
+	}
+}
+
+func TestATR(t *testing.T) {
+	bars := []Bar{
+		{High: 10, Low: 8, Close: 9},
+		{High: 11, Low: 9, Close: 10},  // TR 2.
+		{High: 14, Low: 12, Close: 13}, // TR 4, from the previous close.
+		{High: 13, Low: 7, Close: 8},   // TR 6.
+	}
+
+	nan := math.NaN()
+
+	// The first ATR is the mean of the first two true ranges, then (3*1 + 6) / 2.
+	assertSeries(t, "ATR", ATR(bars, 2), []float64{nan, nan, 3, 4.5})
+}
+
+func TestBollinger(t *testing.T) {
+	result := Bollinger([]float64{1, 2, 3, 4, 5}, 3, 2)
+
+	nan := math.NaN()
+	deviation := 2 * math.Sqrt(2.0/3)
+
+	assertSeries(t, "Middle", result.Middle, []float64{nan, nan, 2, 3, 4})
+	assertSeries(t, "Upper", result.Upper, []float64{nan, nan, 2 + deviation, 3 + deviation, 4 + deviation})
+	assertSeries(t, "Lower", result.Lower, []float64{nan, nan, 2 - deviation, 3 - deviation, 4 - deviation})
+}
+
+func TestVWAP(t *testing.T) {
+	day := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	bars := []Bar{
+		{Time: day, High: 11, Low: 9, Close: 10, Volume: 0},
+		{Time: day.Add(time.Hour), High: 12, Low: 9, Close: 12, Volume: 100},
+		{Time: day.Add(2 * time.Hour), High: 15, Low: 15, Close: 15, Volume: 300},
+		{Time: day.Add(24 * time.Hour), High: 20, Low: 20, Close: 20, Volume: 50},
+	}
+
+	nan := math.NaN()
+
+	// (11*100 + 15*300) / 400 = 14, restarting the next day.
+	assertSeries(t, "VWAP", VWAP(bars, 24*time.Hour), []float64{nan, 11, 14, 20})
+
+	// Without an anchor, (11*100 + 15*300 + 20*50) / 450.
+	assertSeries(t, "VWAP(no anchor)", VWAP(bars, 0), []float64{nan, 11, 14, 6600.0 / 450})
+}
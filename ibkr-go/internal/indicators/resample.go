@@ -0,0 +1,45 @@
+package indicators
+
+import "time"
+
+// epoch is the origin of the buckets bars are resampled into.
+var epoch = time.Unix(0, 0).UTC()
+
+// Bucket returns the start of the interval of the given size that t falls in. Intervals
+// are aligned to the Unix epoch in UTC, so that e.g. two-hour intervals start at even
+// hours and one-day intervals at midnight UTC.
+func Bucket(t time.Time, size time.Duration) time.Time {
+	offset := t.Sub(epoch) % size
+	if offset < 0 {
+		offset += size
+	}
+
+	return t.Add(-offset).UTC()
+}
+
+// Resample aggregates bars, oldest first, into bars of the given size: each bar starts at
+// its Bucket and has the open of its first bar, the close of its last bar, the extremes of
+// their highs and lows, and the sum of their volumes. Buckets without bars are skipped, so
+// the bars of e.g. three-day buckets span fewer days over weekends.
+func Resample(bars []Bar, size time.Duration) []Bar {
+	var out []Bar
+
+	for _, bar := range bars {
+		start := Bucket(bar.Time, size)
+
+		if n := len(out); n > 0 && out[n-1].Time.Equal(start) {
+			last := &out[n-1]
+			last.High = max(last.High, bar.High)
+			last.Low = min(last.Low, bar.Low)
+			last.Close = bar.Close
+			last.Volume += bar.Volume
+
+			continue
+		}
+
+		bar.Time = start
+		out = append(out, bar)
+	}
+
+	return out
+}
@@ -0,0 +1,82 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucket(t *testing.T) {
+	at := time.Date(2024, 1, 10, 15, 45, 0, 0, time.UTC)
+
+	tests := []struct {
+		size time.Duration
+		want time.Time
+	}{
+		{size: 2 * time.Hour, want: time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC)},
+		{size: 24 * time.Hour, want: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		// January 9 is 19731 days after the epoch, a multiple of 3.
+		{size: 72 * time.Hour, want: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := Bucket(at, tt.size); !got.Equal(tt.want) {
+			t.Errorf("Bucket(%v, %v) = %v, want %v", at, tt.size, got, tt.want)
+		}
+	}
+
+	// Times in other zones are bucketed by their instant.
+	local := at.In(time.FixedZone("EST", -5*60*60))
+	if got := Bucket(local, 2*time.Hour); !got.Equal(tests[0].want) {
+		t.Errorf("Bucket(%v) = %v, want %v", local, got, tests[0].want)
+	}
+}
+
+func TestResample(t *testing.T) {
+	start := time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC)
+
+	var hourly []Bar
+	for i := range 5 {
+		price := float64(10 + i)
+		hourly = append(hourly, Bar{
+			Time:   start.Add(time.Duration(i) * time.Hour),
+			Open:   price,
+			High:   price + 2,
+			Low:    price - 1,
+			Close:  price + 1,
+			Volume: 100,
+		})
+	}
+
+	got := Resample(hourly, 2*time.Hour)
+
+	// 13:00 falls in the 12:00 bucket, then 14:00-15:00 and 16:00-17:00.
+	want := []Bar{
+		{Time: start.Add(-time.Hour), Open: 10, High: 12, Low: 9, Close: 11, Volume: 100},
+		{Time: start.Add(time.Hour), Open: 11, High: 14, Low: 10, Close: 13, Volume: 200},
+		{Time: start.Add(3 * time.Hour), Open: 13, High: 16, Low: 12, Close: 15, Volume: 200},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Resample() returned %d bars, want %d: %+v", len(got), len(want), got)
+	}
+
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) || got[i].Open != want[i].Open || got[i].High != want[i].High ||
+			got[i].Low != want[i].Low || got[i].Close != want[i].Close || got[i].Volume != want[i].Volume {
+			t.Errorf("bar %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestResample_SkipsEmptyBuckets(t *testing.T) {
+	friday := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	daily := []Bar{
+		{Time: friday, Close: 1},
+		{Time: friday.Add(3 * 24 * time.Hour), Close: 2},
+	}
+
+	got := Resample(daily, 3*24*time.Hour)
+	if len(got) != 2 || got[0].Close != 1 || got[1].Close != 2 {
+		t.Errorf("Expected a bar per non-empty bucket, got %+v", got)
+	}
+}
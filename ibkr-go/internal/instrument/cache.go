@@ -0,0 +1,235 @@
+// Package instrument keeps the instrument master: the contracts resolved from the
+// Gateway, persisted in the instruments table and keyed by conid.
+package instrument
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+// DefaultTTL is the default time after which cached listings are refreshed from the Gateway.
+const DefaultTTL = 24 * time.Hour
+
+// ListingSource looks up contracts at the Gateway. *ibkr.Resolver implements it.
+type ListingSource interface {
+	ibkr.ContractResolver
+	// Listings returns every listing of a symbol with the given security type.
+	Listings(ctx context.Context, symbol, secType string) ([]ibkr.ResolvedContract, error)
+}
+
+// Cache resolves contracts through the instruments table.
+//
+// The cached rows for a symbol and security type are treated as the complete set of
+// its listings, so contract selection behaves exactly as with the Gateway resolver.
+// Listings older than the TTL are refreshed from the Gateway on the next lookup; if the
+// Gateway is unavailable the stale listings are served instead. Futures are resolved at
+// the Gateway on every lookup, as the front month moves with the calendar.
+type Cache struct {
+	querier db.Querier
+	source  ListingSource
+	ttl     time.Duration
+	logger  *slog.Logger
+}
+
+// NewCache creates a new instrument Cache. A zero ttl falls back to DefaultTTL.
+func NewCache(querier db.Querier, source ListingSource, ttl time.Duration, logger *slog.Logger) *Cache {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Cache{
+		querier: querier,
+		source:  source,
+		ttl:     ttl,
+		logger:  logger,
+	}
+}
+
+// ResolveContract returns the single contract matching the query, see ibkr.SelectContract.
+func (c *Cache) ResolveContract(ctx context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+	query = query.Normalize()
+
+	if query.SecType == ibkr.SecTypeFuture || query.Expiry != "" {
+		return c.source.ResolveContract(ctx, query)
+	}
+
+	rows, err := c.querier.ListInstrumentsBySymbol(ctx, db.ListInstrumentsBySymbolParams{
+		Symbol:  query.Symbol,
+		SecType: query.SecType,
+	})
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to read cached instruments",
+			slog.String("symbol", query.Symbol),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	cached := make([]ibkr.ResolvedContract, 0, len(rows))
+	for _, row := range rows {
+		cached = append(cached, fromRow(row))
+	}
+
+	if len(rows) > 0 && c.fresh(rows) {
+		return ibkr.SelectContract(query, cached)
+	}
+
+	listings, err := c.refresh(ctx, query.Symbol, query.SecType)
+	if err != nil {
+		if len(cached) == 0 {
+			return nil, err
+		}
+
+		c.logger.WarnContext(ctx, "Serving stale instruments, refresh failed",
+			slog.String("symbol", query.Symbol),
+			slog.String("error", err.Error()),
+		)
+
+		return ibkr.SelectContract(query, cached)
+	}
+
+	return ibkr.SelectContract(query, listings)
+}
+
+// Instrument returns the cached contract with the given conid. It returns an error
+// wrapping ibkr.ErrContractNotFound when the conid is not in the instrument master.
+func (c *Cache) Instrument(ctx context.Context, conID int) (*ibkr.ResolvedContract, error) {
+	row, err := c.querier.GetInstrument(ctx, int64(conID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: conid %d", ibkr.ErrContractNotFound, conID)
+		}
+
+		return nil, fmt.Errorf("failed to get instrument: %w", err)
+	}
+
+	contract := fromRow(row)
+
+	return &contract, nil
+}
+
+// Import upserts contracts into the instrument master in a single statement, e.g. to
+// seed it from a list of known instruments.
+func (c *Cache) Import(ctx context.Context, contracts []ibkr.ResolvedContract) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+
+	normalized := make([]ibkr.ResolvedContract, 0, len(contracts))
+
+	for _, contract := range contracts {
+		if contract.ConID <= 0 || contract.Symbol == "" {
+			return fmt.Errorf("invalid instrument %s: conid and symbol are required", contract)
+		}
+
+		query := ibkr.ContractQuery{Symbol: contract.Symbol, SecType: contract.SecType}.Normalize()
+		contract.Symbol = query.Symbol
+		contract.SecType = query.SecType
+		normalized = append(normalized, contract)
+	}
+
+	if err := c.querier.UpsertInstruments(ctx, upsertParams(normalized)); err != nil {
+		return fmt.Errorf("failed to import instruments: %w", err)
+	}
+
+	return nil
+}
+
+// refresh looks up the listings of a symbol at the Gateway and replaces the cached ones.
+// Failing to write the cache is logged but does not fail the lookup.
+func (c *Cache) refresh(ctx context.Context, symbol, secType string) ([]ibkr.ResolvedContract, error) {
+	listings, err := c.source.Listings(ctx, symbol, secType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store(ctx, symbol, secType, listings); err != nil {
+		c.logger.WarnContext(ctx, "Failed to cache instruments",
+			slog.String("symbol", symbol),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return listings, nil
+}
+
+// store upserts the listings of a symbol and removes the listings that disappeared.
+func (c *Cache) store(ctx context.Context, symbol, secType string, listings []ibkr.ResolvedContract) error {
+	if len(listings) > 0 {
+		if err := c.querier.UpsertInstruments(ctx, upsertParams(listings)); err != nil {
+			return fmt.Errorf("failed to upsert instruments: %w", err)
+		}
+	}
+
+	conIDs := make([]int64, 0, len(listings))
+	for _, listing := range listings {
+		conIDs = append(conIDs, int64(listing.ConID))
+	}
+
+	err := c.querier.DeleteInstrumentsNotIn(ctx, db.DeleteInstrumentsNotInParams{
+		Symbol:  symbol,
+		SecType: secType,
+		Conids:  conIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete delisted instruments: %w", err)
+	}
+
+	return nil
+}
+
+// fresh reports whether every row was refreshed within the TTL.
+func (c *Cache) fresh(rows []db.Instrument) bool {
+	for _, row := range rows {
+		if !row.RefreshedAt.Valid || time.Since(row.RefreshedAt.Time) > c.ttl {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fromRow(row db.Instrument) ibkr.ResolvedContract {
+	return ibkr.ResolvedContract{
+		ConID:        int(row.Conid),
+		Symbol:       row.Symbol,
+		SecType:      row.SecType,
+		Exchange:     row.Exchange,
+		Currency:     row.Currency,
+		Multiplier:   row.Multiplier,
+		TradingClass: row.TradingClass,
+		Description:  row.Description,
+	}
+}
+
+func upsertParams(contracts []ibkr.ResolvedContract) db.UpsertInstrumentsParams {
+	params := db.UpsertInstrumentsParams{
+		Conids:         make([]int64, 0, len(contracts)),
+		Symbols:        make([]string, 0, len(contracts)),
+		SecTypes:       make([]string, 0, len(contracts)),
+		Exchanges:      make([]string, 0, len(contracts)),
+		Currencies:     make([]string, 0, len(contracts)),
+		Multipliers:    make([]string, 0, len(contracts)),
+		TradingClasses: make([]string, 0, len(contracts)),
+		Descriptions:   make([]string, 0, len(contracts)),
+	}
+
+	for _, contract := range contracts {
+		params.Conids = append(params.Conids, int64(contract.ConID))
+		params.Symbols = append(params.Symbols, contract.Symbol)
+		params.SecTypes = append(params.SecTypes, contract.SecType)
+		params.Exchanges = append(params.Exchanges, contract.Exchange)
+		params.Currencies = append(params.Currencies, contract.Currency)
+		params.Multipliers = append(params.Multipliers, contract.Multiplier)
+		params.TradingClasses = append(params.TradingClasses, contract.TradingClass)
+		params.Descriptions = append(params.Descriptions, contract.Description)
+	}
+
+	return params
+}
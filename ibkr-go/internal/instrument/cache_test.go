@@ -0,0 +1,331 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQuerier is a mock implementation of db.Querier
+type MockQuerier struct {
+	mock.Mock
+}
+
+func (m *MockQuerier) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.Session), args.Error(1)
+}
+
+func (m *MockQuerier) DeleteExpiredSessions(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteInstrumentsNotIn(ctx context.Context, arg db.DeleteInstrumentsNotInParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteSessionByHash(ctx context.Context, sessionTokenHash string) error {
+	args := m.Called(ctx, sessionTokenHash)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) GetInstrument(ctx context.Context, conid int64) (db.Instrument, error) {
+	args := m.Called(ctx, conid)
+	return args.Get(0).(db.Instrument), args.Error(1)
+}
+
+func (m *MockQuerier) GetSessionByHash(ctx context.Context, sessionTokenHash string) (db.Session, error) {
+	args := m.Called(ctx, sessionTokenHash)
+	return args.Get(0).(db.Session), args.Error(1)
+}
+
+func (m *MockQuerier) ListInstrumentsBySymbol(ctx context.Context, arg db.ListInstrumentsBySymbolParams) ([]db.Instrument, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.Instrument), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertInstruments(ctx context.Context, arg db.UpsertInstrumentsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarCoverage(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarCoverageByConid(ctx context.Context, conid int64) error {
+	args := m.Called(ctx, conid)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarsByConid(ctx context.Context, conid int64) (int64, error) {
+	args := m.Called(ctx, conid)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) InsertBarCoverage(ctx context.Context, arg db.InsertBarCoverageParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListBarCoverage(ctx context.Context, arg db.ListBarCoverageParams) ([]db.BarCoverage, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.BarCoverage), args.Error(1)
+}
+
+func (m *MockQuerier) ListBars(ctx context.Context, arg db.ListBarsParams) ([]db.Bar, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.Bar), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertBars(ctx context.Context, arg db.UpsertBarsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CreateOrderReply(ctx context.Context, arg db.CreateOrderReplyParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteExpiredOrderReplies(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteOrderReply(ctx context.Context, replyID string) error {
+	args := m.Called(ctx, replyID)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) GetOrderReply(ctx context.Context, arg db.GetOrderReplyParams) (db.OrderReply, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.OrderReply), args.Error(1)
+}
+
+// fakeSource returns fixed listings and counts Gateway lookups.
+type fakeSource struct {
+	listings []ibkr.ResolvedContract
+	err      error
+	calls    int
+	resolved int
+}
+
+func (s *fakeSource) ResolveContract(_ context.Context, query ibkr.ContractQuery) (*ibkr.ResolvedContract, error) {
+	s.resolved++
+
+	return &ibkr.ResolvedContract{ConID: 7, Symbol: query.Symbol, SecType: query.SecType}, s.err
+}
+
+func (s *fakeSource) Listings(_ context.Context, _, _ string) ([]ibkr.ResolvedContract, error) {
+	s.calls++
+
+	return s.listings, s.err
+}
+
+var shopListings = []ibkr.ResolvedContract{
+	{ConID: 1, Symbol: "SHOP", SecType: "STK", Exchange: "NYSE", Currency: "USD"},
+	{ConID: 2, Symbol: "SHOP", SecType: "STK", Exchange: "TSE", Currency: "CAD"},
+}
+
+func shopRows(refreshedAt time.Time) []db.Instrument {
+	rows := make([]db.Instrument, 0, len(shopListings))
+	for _, listing := range shopListings {
+		rows = append(rows, db.Instrument{
+			Conid:       int64(listing.ConID),
+			Symbol:      listing.Symbol,
+			SecType:     listing.SecType,
+			Exchange:    listing.Exchange,
+			Currency:    listing.Currency,
+			RefreshedAt: pgtype.Timestamp{Time: refreshedAt, Valid: true},
+		})
+	}
+
+	return rows
+}
+
+var shopQuery = db.ListInstrumentsBySymbolParams{Symbol: "SHOP", SecType: "STK"}
+
+func TestCache_ResolveContract_Hit(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("ListInstrumentsBySymbol", ctx, shopQuery).Return(shopRows(time.Now()), nil)
+
+	source := &fakeSource{}
+	cache := NewCache(querier, source, time.Hour, slog.Default())
+
+	contract, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "shop", Currency: "CAD"})
+	if err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if contract.ConID != 2 {
+		t.Errorf("ConID = %d, want 2", contract.ConID)
+	}
+
+	if source.calls != 0 {
+		t.Errorf("Expected no Gateway lookup on a cache hit, got %d", source.calls)
+	}
+}
+
+func TestCache_ResolveContract_MissStoresListings(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("ListInstrumentsBySymbol", ctx, shopQuery).Return([]db.Instrument{}, nil)
+	querier.On("UpsertInstruments", ctx, upsertParams(shopListings)).Return(nil)
+	querier.On("DeleteInstrumentsNotIn", ctx, db.DeleteInstrumentsNotInParams{
+		Symbol:  "SHOP",
+		SecType: "STK",
+		Conids:  []int64{1, 2},
+	}).Return(nil)
+
+	source := &fakeSource{listings: shopListings}
+	cache := NewCache(querier, source, time.Hour, slog.Default())
+
+	contract, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "SHOP"})
+	if err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if contract.ConID != 1 {
+		t.Errorf("ConID = %d, want the USD listing", contract.ConID)
+	}
+
+	querier.AssertExpectations(t)
+}
+
+func TestCache_ResolveContract_StaleRefreshes(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("ListInstrumentsBySymbol", ctx, shopQuery).Return(shopRows(time.Now().Add(-2*time.Hour)), nil)
+	querier.On("UpsertInstruments", ctx, mock.Anything).Return(nil)
+	querier.On("DeleteInstrumentsNotIn", ctx, mock.Anything).Return(nil)
+
+	source := &fakeSource{listings: shopListings[:1]}
+	cache := NewCache(querier, source, time.Hour, slog.Default())
+
+	if _, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "SHOP"}); err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if source.calls != 1 {
+		t.Errorf("Expected stale listings to be refreshed, got %d lookups", source.calls)
+	}
+
+	// The TSE listing is gone after the refresh.
+	_, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "SHOP", Currency: "CAD"})
+	if !errors.Is(err, ibkr.ErrContractNotFound) {
+		t.Errorf("Expected ErrContractNotFound for the delisted listing, got %v", err)
+	}
+}
+
+func TestCache_ResolveContract_ServesStaleOnGatewayError(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("ListInstrumentsBySymbol", ctx, shopQuery).Return(shopRows(time.Now().Add(-2*time.Hour)), nil)
+
+	source := &fakeSource{err: errors.New("gateway unavailable")}
+	cache := NewCache(querier, source, time.Hour, slog.Default())
+
+	contract, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "SHOP", Exchange: "TSE"})
+	if err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if contract.ConID != 2 {
+		t.Errorf("ConID = %d, want 2", contract.ConID)
+	}
+}
+
+func TestCache_ResolveContract_GatewayErrorWithoutCache(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("ListInstrumentsBySymbol", ctx, shopQuery).Return([]db.Instrument{}, nil)
+
+	gatewayErr := errors.New("gateway unavailable")
+	cache := NewCache(querier, &fakeSource{err: gatewayErr}, time.Hour, slog.Default())
+
+	if _, err := cache.ResolveContract(ctx, ibkr.ContractQuery{Symbol: "SHOP"}); !errors.Is(err, gatewayErr) {
+		t.Errorf("Expected the Gateway error, got %v", err)
+	}
+}
+
+func TestCache_Instrument(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("GetInstrument", ctx, int64(1)).Return(shopRows(time.Now())[0], nil)
+	querier.On("GetInstrument", ctx, int64(9)).Return(db.Instrument{}, pgx.ErrNoRows)
+
+	cache := NewCache(querier, &fakeSource{}, 0, slog.Default())
+
+	contract, err := cache.Instrument(ctx, 1)
+	if err != nil {
+		t.Fatalf("Instrument() error = %v", err)
+	}
+
+	if contract.Symbol != "SHOP" || contract.Exchange != "NYSE" {
+		t.Errorf("Unexpected instrument: %+v", contract)
+	}
+
+	if _, err := cache.Instrument(ctx, 9); !errors.Is(err, ibkr.ErrContractNotFound) {
+		t.Errorf("Expected ErrContractNotFound, got %v", err)
+	}
+}
+
+func TestCache_Import(t *testing.T) {
+	ctx := context.Background()
+	querier := new(MockQuerier)
+	querier.On("UpsertInstruments", ctx, db.UpsertInstrumentsParams{
+		Conids:         []int64{265598},
+		Symbols:        []string{"AAPL"},
+		SecTypes:       []string{"STK"},
+		Exchanges:      []string{"NASDAQ"},
+		Currencies:     []string{"USD"},
+		Multipliers:    []string{""},
+		TradingClasses: []string{"NMS"},
+		Descriptions:   []string{""},
+	}).Return(nil)
+
+	cache := NewCache(querier, &fakeSource{}, time.Hour, slog.Default())
+
+	err := cache.Import(ctx, []ibkr.ResolvedContract{
+		{ConID: 265598, Symbol: "aapl", Exchange: "NASDAQ", Currency: "USD", TradingClass: "NMS"},
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	querier.AssertExpectations(t)
+
+	if err := cache.Import(ctx, []ibkr.ResolvedContract{{Symbol: "AAPL"}}); err == nil {
+		t.Error("Expected error for an instrument without conid")
+	}
+}
+
+func TestCache_ResolveContract_FuturesBypassCache(t *testing.T) {
+	querier := new(MockQuerier)
+	source := &fakeSource{}
+	cache := NewCache(querier, source, time.Hour, slog.Default())
+
+	contract, err := cache.ResolveContract(context.Background(), ibkr.ContractQuery{Symbol: "ES", SecType: "FUT"})
+	if err != nil {
+		t.Fatalf("ResolveContract() error = %v", err)
+	}
+
+	if contract.ConID != 7 || source.resolved != 1 {
+		t.Errorf("Expected the future to be resolved at the Gateway, got %+v", contract)
+	}
+
+	querier.AssertNotCalled(t, "ListInstrumentsBySymbol", mock.Anything, mock.Anything)
+}
@@ -38,6 +38,81 @@ func (m *MockQuerier) GetSessionByHash(ctx context.Context, sessionTokenHash str
 	return args.Get(0).(db.Session), args.Error(1)
 }
 
+func (m *MockQuerier) DeleteInstrumentsNotIn(ctx context.Context, arg db.DeleteInstrumentsNotInParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) GetInstrument(ctx context.Context, conid int64) (db.Instrument, error) {
+	args := m.Called(ctx, conid)
+	return args.Get(0).(db.Instrument), args.Error(1)
+}
+
+func (m *MockQuerier) ListInstrumentsBySymbol(ctx context.Context, arg db.ListInstrumentsBySymbolParams) ([]db.Instrument, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.Instrument), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertInstruments(ctx context.Context, arg db.UpsertInstrumentsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarCoverage(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarCoverageByConid(ctx context.Context, conid int64) error {
+	args := m.Called(ctx, conid)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteBarsByConid(ctx context.Context, conid int64) (int64, error) {
+	args := m.Called(ctx, conid)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuerier) InsertBarCoverage(ctx context.Context, arg db.InsertBarCoverageParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListBarCoverage(ctx context.Context, arg db.ListBarCoverageParams) ([]db.BarCoverage, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.BarCoverage), args.Error(1)
+}
+
+func (m *MockQuerier) ListBars(ctx context.Context, arg db.ListBarsParams) ([]db.Bar, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]db.Bar), args.Error(1)
+}
+
+func (m *MockQuerier) UpsertBars(ctx context.Context, arg db.UpsertBarsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CreateOrderReply(ctx context.Context, arg db.CreateOrderReplyParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteExpiredOrderReplies(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) DeleteOrderReply(ctx context.Context, replyID string) error {
+	args := m.Called(ctx, replyID)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) GetOrderReply(ctx context.Context, arg db.GetOrderReplyParams) (db.OrderReply, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(db.OrderReply), args.Error(1)
+}
+
 func TestExtractToken(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,368 @@
+// Package quotes fans market data out to many subscribers of the same contracts.
+//
+// A Hub polls every contract subscribed to from a single loop, requesting the snapshots
+// of up to MaxBatchSize contracts at once, so that N streams of the same symbol cost the
+// Gateway one snapshot request per poll interval rather than N. The loop polls at the
+// shortest interval any open subscription asked for; it starts with the first
+// subscription and stops when the last one leaves. Each subscriber has a bounded buffer;
+// the Hub never blocks on a subscriber that falls behind but applies the
+// SlowConsumerPolicy instead.
+package quotes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+const (
+	// DefaultInterval is the default interval between snapshot polls.
+	DefaultInterval = 5 * time.Second
+	// MaxBatchSize is the number of contracts the Gateway serves per snapshot request.
+	MaxBatchSize = 100
+	// DefaultBufferSize is the default capacity of each subscriber buffer.
+	DefaultBufferSize = 16
+)
+
+var (
+	// ErrSlowConsumer ends a subscription that fell behind under the Disconnect policy.
+	ErrSlowConsumer = errors.New("quote subscriber is too slow to keep up")
+	// ErrHubClosed is returned by Subscribe after Close, and ends open subscriptions.
+	ErrHubClosed = errors.New("quote hub is closed")
+)
+
+// SnapshotSource provides market data snapshots. *ibkr.Client implements it.
+type SnapshotSource interface {
+	GetMarketData(ctx context.Context, conIDs []int, fields []string) ([]ibkr.MarketDataSnapshot, error)
+}
+
+// SlowConsumerPolicy decides what happens to an update for a subscriber whose buffer is full.
+type SlowConsumerPolicy int
+
+const (
+	// DropOldest discards the oldest buffered update to make room for the new one.
+	DropOldest SlowConsumerPolicy = iota
+	// Disconnect ends the subscription with ErrSlowConsumer.
+	Disconnect
+)
+
+// Config configures a Hub. Zero values fall back to the defaults.
+type Config struct {
+	// Interval is the poll interval of subscriptions that ask for none.
+	Interval time.Duration
+	// BufferSize is the capacity of each subscriber buffer, per contract subscribed to.
+	BufferSize int
+	// SlowConsumer is the policy applied to subscribers whose buffer is full.
+	SlowConsumer SlowConsumerPolicy
+	// Fields are the snapshot fields polled, nil for the client's default fields.
+	Fields []string
+}
+
+// Update is a market data update of a contract. Err is set when the poll of the
+// contract failed; the subscription stays open and later polls may succeed.
+// Snapshot.Fields is shared between subscribers and must not be modified.
+type Update struct {
+	ConID    int
+	Snapshot ibkr.MarketDataSnapshot
+	Err      error
+}
+
+// Hub shares upstream market data polling between subscribers.
+type Hub struct {
+	source SnapshotSource
+	config Config
+	logger *slog.Logger
+	// wake makes the poller poll right away, e.g. for newly subscribed contracts.
+	wake chan struct{}
+
+	// mu guards the feeds, the poller and every subscription state, and is held while
+	// fanning out.
+	mu     sync.Mutex
+	feeds  map[int]*feed
+	stop   context.CancelFunc
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+// feed is a contract polled and its subscribers.
+type feed struct {
+	conID       int
+	subscribers map[*Subscription]struct{}
+	last        *Update
+}
+
+// Subscription receives the updates of one or more contracts until it is closed.
+type Subscription struct {
+	hub      *Hub
+	feeds    []*feed
+	interval time.Duration
+	updates  chan Update
+	err      error
+	closed   bool
+}
+
+// NewHub creates a hub polling snapshots from source.
+func NewHub(source SnapshotSource, config Config, logger *slog.Logger) *Hub {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultBufferSize
+	}
+
+	return &Hub{
+		source: source,
+		config: config,
+		logger: logger,
+		wake:   make(chan struct{}, 1),
+		feeds:  make(map[int]*feed),
+	}
+}
+
+// Subscribe subscribes to the updates of contracts, polled at least once per interval;
+// a zero interval selects the configured one. The latest updates of contracts already
+// polled are delivered right away, and the others are polled right away.
+func (h *Hub) Subscribe(interval time.Duration, conIDs ...int) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	if interval <= 0 {
+		interval = h.config.Interval
+	}
+
+	conIDs = slices.Compact(slices.Sorted(slices.Values(conIDs)))
+
+	// Poll right away when the subscription adds contracts or a shorter interval.
+	wake := interval < h.interval()
+
+	sub := &Subscription{
+		hub:      h,
+		feeds:    make([]*feed, 0, len(conIDs)),
+		interval: interval,
+		updates:  make(chan Update, h.config.BufferSize*len(conIDs)),
+	}
+
+	for _, conID := range conIDs {
+		f, ok := h.feeds[conID]
+		if !ok {
+			f = &feed{
+				conID:       conID,
+				subscribers: make(map[*Subscription]struct{}),
+			}
+			h.feeds[conID] = f
+			wake = true
+		}
+
+		f.subscribers[sub] = struct{}{}
+		sub.feeds = append(sub.feeds, f)
+
+		if f.last != nil {
+			sub.updates <- *f.last
+		}
+	}
+
+	if h.stop == nil && len(h.feeds) > 0 {
+		h.startPoller()
+	} else if wake {
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return sub, nil
+}
+
+// Close ends every subscription with ErrHubClosed and waits for the poller to exit.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+
+	for _, f := range h.feeds {
+		for sub := range f.subscribers {
+			h.remove(sub, ErrHubClosed)
+		}
+	}
+	h.mu.Unlock()
+
+	h.wg.Wait()
+}
+
+// startPoller starts the poll loop. h.mu must be held.
+func (h *Hub) startPoller() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stop = cancel
+
+	h.wg.Go(func() {
+		h.poll(ctx)
+	})
+}
+
+// interval returns the shortest interval of the open subscriptions, or the configured
+// interval without any. h.mu must be held.
+func (h *Hub) interval() time.Duration {
+	interval := time.Duration(0)
+
+	for _, f := range h.feeds {
+		for sub := range f.subscribers {
+			if interval == 0 || sub.interval < interval {
+				interval = sub.interval
+			}
+		}
+	}
+
+	if interval == 0 {
+		return h.config.Interval
+	}
+
+	return interval
+}
+
+// poll fetches the snapshots of every contract subscribed to, right away and then once
+// per the shortest interval subscribed with, until ctx is done.
+func (h *Hub) poll(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-h.wake:
+		}
+
+		h.mu.Lock()
+		conIDs := slices.Sorted(maps.Keys(h.feeds))
+		interval := h.interval()
+		h.mu.Unlock()
+
+		for batch := range slices.Chunk(conIDs, MaxBatchSize) {
+			h.fetch(ctx, batch)
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// fetch polls the snapshots of a batch of contracts and publishes them to the
+// subscribers of each contract.
+func (h *Hub) fetch(ctx context.Context, conIDs []int) {
+	snapshots, err := h.source.GetMarketData(ctx, conIDs, h.config.Fields)
+	if ctx.Err() != nil {
+		return
+	}
+
+	updates := make([]Update, 0, len(conIDs))
+
+	if err != nil {
+		for _, conID := range conIDs {
+			updates = append(updates, Update{ConID: conID, Err: err})
+		}
+	} else {
+		for i := range snapshots {
+			updates = append(updates, Update{ConID: snapshots[i].ConID, Snapshot: snapshots[i]})
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, update := range updates {
+		if f, ok := h.feeds[update.ConID]; ok {
+			h.publish(f, update)
+		}
+	}
+}
+
+// publish delivers an update to every subscriber of a feed without blocking. h.mu must
+// be held.
+func (h *Hub) publish(f *feed, update Update) {
+	if update.Err == nil {
+		f.last = &update
+	}
+
+	for sub := range f.subscribers {
+		select {
+		case sub.updates <- update:
+			continue
+		default:
+		}
+
+		if h.config.SlowConsumer == Disconnect {
+			h.logger.Warn("Disconnecting slow quote subscriber", slog.Int("conid", f.conID))
+			h.remove(sub, ErrSlowConsumer)
+
+			continue
+		}
+
+		// Make room by dropping the oldest update, unless the subscriber just took it.
+		// Only the subscriber receives concurrently, so the send cannot block.
+		select {
+		case <-sub.updates:
+		default:
+		}
+
+		sub.updates <- update
+	}
+}
+
+// remove ends a subscription with err, drops the contracts it was the last subscriber
+// of and stops the poller once no contract is left. h.mu must be held.
+func (h *Hub) remove(sub *Subscription, err error) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	sub.err = err
+	close(sub.updates)
+
+	for _, f := range sub.feeds {
+		delete(f.subscribers, sub)
+
+		if len(f.subscribers) == 0 && h.feeds[f.conID] == f {
+			delete(h.feeds, f.conID)
+		}
+	}
+
+	if len(h.feeds) == 0 && h.stop != nil {
+		h.stop()
+		h.stop = nil
+	}
+}
+
+// Updates returns the channel updates are delivered on. It is closed when the
+// subscription ends; Err then tells why.
+func (s *Subscription) Updates() <-chan Update {
+	return s.updates
+}
+
+// Err returns the reason the hub ended the subscription: ErrSlowConsumer or
+// ErrHubClosed. It is nil while the subscription is open or after Close.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	return s.err
+}
+
+// Close ends the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s, nil)
+}
@@ -0,0 +1,320 @@
+package quotes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
+)
+
+const testInterval = 10 * time.Millisecond
+
+// fakeSource counts snapshot requests per contract and returns the count as last price.
+type fakeSource struct {
+	mu      sync.Mutex
+	calls   map[int]int
+	batches [][]int
+	err     error
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{calls: make(map[int]int)}
+}
+
+func (s *fakeSource) GetMarketData(_ context.Context, conIDs []int, _ []string) ([]ibkr.MarketDataSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, slices.Clone(conIDs))
+
+	snapshots := make([]ibkr.MarketDataSnapshot, 0, len(conIDs))
+	for _, conID := range conIDs {
+		s.calls[conID]++
+		snapshots = append(snapshots, ibkr.MarketDataSnapshot{ConID: conID, LastPrice: float64(s.calls[conID])})
+	}
+
+	return snapshots, s.err
+}
+
+func (s *fakeSource) callCount(conID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[conID]
+}
+
+func (s *fakeSource) requests() [][]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.batches)
+}
+
+func receive(t *testing.T, sub *Subscription) Update {
+	t.Helper()
+
+	select {
+	case update, ok := <-sub.Updates():
+		if !ok {
+			t.Fatalf("Subscription ended: %v", sub.Err())
+		}
+
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for an update")
+	}
+
+	return Update{}
+}
+
+func waitClosed(t *testing.T, sub *Subscription) {
+	t.Helper()
+
+	deadline := time.After(time.Second)
+
+	for {
+		select {
+		case _, ok := <-sub.Updates():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("Timed out waiting for the subscription to end")
+		}
+	}
+}
+
+func TestHub_SharesUpstreamPolls(t *testing.T) {
+	hub := NewHub(newFakeSource(), Config{Interval: testInterval}, slog.Default())
+	defer hub.Close()
+
+	first, err := hub.Subscribe(0, 265598)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	second, err := hub.Subscribe(0, 265598)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	for range 3 {
+		// Both subscribers receive the same poll.
+		a, b := receive(t, first), receive(t, second)
+		if a.ConID != 265598 || a.Snapshot.LastPrice != b.Snapshot.LastPrice {
+			t.Errorf("Unexpected updates: %+v, %+v", a, b)
+		}
+	}
+
+	hub.mu.Lock()
+	feeds := len(hub.feeds)
+	hub.mu.Unlock()
+
+	if feeds != 1 {
+		t.Errorf("Expected one feed for both subscribers, got %d", feeds)
+	}
+}
+
+func TestHub_SubscribeMany(t *testing.T) {
+	hub := NewHub(newFakeSource(), Config{Interval: time.Hour}, slog.Default())
+	defer hub.Close()
+
+	single, _ := hub.Subscribe(0, 2)
+	receive(t, single)
+
+	sub, err := hub.Subscribe(0, 1, 2, 1)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	seen := make(map[int]bool)
+	for range 2 {
+		seen[receive(t, sub).ConID] = true
+	}
+
+	if !seen[1] || !seen[2] {
+		t.Errorf("Expected updates of both contracts, got %v", seen)
+	}
+
+	sub.Close()
+
+	hub.mu.Lock()
+	_, polled := hub.feeds[2]
+	feeds := len(hub.feeds)
+	hub.mu.Unlock()
+
+	if feeds != 1 || !polled {
+		t.Errorf("Expected only the contract still subscribed to, got %d feeds", feeds)
+	}
+}
+
+func TestHub_StopsPollerAfterLastSubscriber(t *testing.T) {
+	source := newFakeSource()
+	hub := NewHub(source, Config{Interval: testInterval}, slog.Default())
+	defer hub.Close()
+
+	first, _ := hub.Subscribe(0, 1)
+	second, _ := hub.Subscribe(0, 1)
+
+	receive(t, first)
+	first.Close()
+	first.Close()
+
+	receive(t, second)
+	second.Close()
+
+	hub.mu.Lock()
+	feeds := len(hub.feeds)
+	hub.mu.Unlock()
+
+	if feeds != 0 {
+		t.Errorf("Expected the poller to stop, got %d feeds", feeds)
+	}
+
+	calls := source.callCount(1)
+	time.Sleep(5 * testInterval)
+
+	if after := source.callCount(1); after > calls+1 {
+		t.Errorf("Expected no polls after the last subscriber left, got %d more", after-calls)
+	}
+}
+
+func TestHub_BatchesContracts(t *testing.T) {
+	source := newFakeSource()
+	hub := NewHub(source, Config{Interval: time.Hour}, slog.Default())
+	defer hub.Close()
+
+	conIDs := make([]int, 0, MaxBatchSize+1)
+	for conID := range MaxBatchSize + 1 {
+		conIDs = append(conIDs, conID+1)
+	}
+
+	sub, err := hub.Subscribe(0, conIDs...)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	for range conIDs {
+		receive(t, sub)
+	}
+
+	// Every contract is polled once, in requests of at most MaxBatchSize contracts.
+	batches := source.requests()
+	if len(batches) != 2 || len(batches[0]) != MaxBatchSize || len(batches[1]) != 1 {
+		t.Errorf("Expected two requests of %d and 1 contracts, got %d", MaxBatchSize, len(batches))
+	}
+}
+
+func TestHub_PollsAtShortestInterval(t *testing.T) {
+	source := newFakeSource()
+	hub := NewHub(source, Config{Interval: time.Hour}, slog.Default())
+	defer hub.Close()
+
+	slow, _ := hub.Subscribe(time.Hour, 1)
+	receive(t, slow)
+
+	// A faster subscriber of another contract speeds up the polls of both.
+	fast, _ := hub.Subscribe(testInterval, 2)
+
+	for range 3 {
+		receive(t, fast)
+	}
+
+	if calls := source.callCount(1); calls < 3 {
+		t.Errorf("Expected contract 1 to be polled with contract 2, got %d polls", calls)
+	}
+
+	// Once the faster subscriber leaves, the hub polls at the remaining interval again.
+	fast.Close()
+	time.Sleep(2 * testInterval)
+
+	calls := source.callCount(1)
+	time.Sleep(5 * testInterval)
+
+	if after := source.callCount(1); after != calls {
+		t.Errorf("Expected no polls at the slow interval, got %d more", after-calls)
+	}
+}
+
+func TestHub_LateSubscriberGetsLatestUpdate(t *testing.T) {
+	hub := NewHub(newFakeSource(), Config{Interval: time.Hour}, slog.Default())
+	defer hub.Close()
+
+	first, _ := hub.Subscribe(0, 1)
+	update := receive(t, first)
+
+	late, _ := hub.Subscribe(0, 1)
+	if got := receive(t, late); got.Snapshot.LastPrice != update.Snapshot.LastPrice {
+		t.Errorf("Late subscriber got %+v, want the latest update %+v", got, update)
+	}
+}
+
+func TestHub_SlowConsumerDropsOldest(t *testing.T) {
+	source := newFakeSource()
+	hub := NewHub(source, Config{Interval: testInterval, BufferSize: 1}, slog.Default())
+	defer hub.Close()
+
+	sub, _ := hub.Subscribe(0, 1)
+
+	for source.callCount(1) < 4 {
+		time.Sleep(testInterval)
+	}
+
+	if update := receive(t, sub); update.Snapshot.LastPrice < 3 {
+		t.Errorf("Expected a recent update, got last price %v", update.Snapshot.LastPrice)
+	}
+
+	if err := sub.Err(); err != nil {
+		t.Errorf("Expected the subscription to stay open, got %v", err)
+	}
+}
+
+func TestHub_SlowConsumerDisconnects(t *testing.T) {
+	hub := NewHub(newFakeSource(), Config{Interval: testInterval, BufferSize: 1, SlowConsumer: Disconnect}, slog.Default())
+	defer hub.Close()
+
+	slow, _ := hub.Subscribe(0, 1)
+	time.Sleep(3 * testInterval)
+
+	waitClosed(t, slow)
+
+	if !errors.Is(slow.Err(), ErrSlowConsumer) {
+		t.Errorf("Err() = %v, want ErrSlowConsumer", slow.Err())
+	}
+}
+
+func TestHub_DeliversUpstreamErrors(t *testing.T) {
+	source := newFakeSource()
+	source.err = errors.New("gateway unavailable")
+
+	hub := NewHub(source, Config{Interval: testInterval}, slog.Default())
+	defer hub.Close()
+
+	sub, _ := hub.Subscribe(0, 1)
+
+	if update := receive(t, sub); update.Err == nil {
+		t.Error("Expected the upstream error to be delivered")
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(newFakeSource(), Config{Interval: testInterval}, slog.Default())
+
+	sub, _ := hub.Subscribe(0, 1)
+	hub.Close()
+
+	waitClosed(t, sub)
+
+	if !errors.Is(sub.Err(), ErrHubClosed) {
+		t.Errorf("Err() = %v, want ErrHubClosed", sub.Err())
+	}
+
+	if _, err := hub.Subscribe(0, 1); !errors.Is(err, ErrHubClosed) {
+		t.Errorf("Subscribe() error = %v, want ErrHubClosed", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package replies keeps the confirmation questions asked by the Gateway for placed and
+// modified orders, persisted in the order_replies table until they are answered.
+package replies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+)
+
+// DefaultTTL is the default time a question can be answered after it was asked.
+const DefaultTTL = 5 * time.Minute
+
+// Store records the account each question was asked for in the database, so that a
+// question asked through one server replica can be answered through any other.
+type Store struct {
+	querier db.Querier
+	ttl     time.Duration
+}
+
+// NewStore creates a new Store. A zero ttl falls back to DefaultTTL.
+func NewStore(querier db.Querier, ttl time.Duration) *Store {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Store{
+		querier: querier,
+		ttl:     ttl,
+	}
+}
+
+// Issue records a question as asked for an account about orders of the given Gateway
+// types, in the order they were sent.
+func (s *Store) Issue(ctx context.Context, accountID, replyID string, orderTypes []string) error {
+	// Drop expired questions so that unanswered ones do not accumulate.
+	if err := s.querier.DeleteExpiredOrderReplies(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired order replies: %w", err)
+	}
+
+	if orderTypes == nil {
+		orderTypes = []string{}
+	}
+
+	err := s.querier.CreateOrderReply(ctx, db.CreateOrderReplyParams{
+		ReplyID:    replyID,
+		AccountID:  accountID,
+		OrderTypes: orderTypes,
+		ExpiresAt: pgtype.Timestamp{
+			Time:  time.Now().Add(s.ttl),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store order reply: %w", err)
+	}
+
+	return nil
+}
+
+// Issued reports whether a question was asked for an account and has not expired, and
+// returns the Gateway types of the orders it was asked about.
+func (s *Store) Issued(ctx context.Context, accountID, replyID string) ([]string, bool, error) {
+	row, err := s.querier.GetOrderReply(ctx, db.GetOrderReplyParams{
+		ReplyID:   replyID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("failed to get order reply: %w", err)
+	}
+
+	return row.OrderTypes, true, nil
+}
+
+// Forget removes an answered question.
+func (s *Store) Forget(ctx context.Context, replyID string) error {
+	if err := s.querier.DeleteOrderReply(ctx, replyID); err != nil {
+		return fmt.Errorf("failed to delete order reply: %w", err)
+	}
+
+	return nil
+}
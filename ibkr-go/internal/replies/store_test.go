@@ -0,0 +1,161 @@
+package replies
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/db"
+)
+
+// fakeQuerier keeps the order_replies table in memory.
+type fakeQuerier struct {
+	db.Querier
+
+	mu      sync.Mutex
+	replies map[string]db.OrderReply
+	err     error
+}
+
+func newFakeQuerier() *fakeQuerier {
+	return &fakeQuerier{replies: make(map[string]db.OrderReply)}
+}
+
+func (q *fakeQuerier) CreateOrderReply(_ context.Context, arg db.CreateOrderReplyParams) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return q.err
+	}
+
+	q.replies[arg.ReplyID] = db.OrderReply{
+		ReplyID:    arg.ReplyID,
+		AccountID:  arg.AccountID,
+		OrderTypes: arg.OrderTypes,
+		ExpiresAt:  arg.ExpiresAt,
+	}
+
+	return nil
+}
+
+func (q *fakeQuerier) GetOrderReply(_ context.Context, arg db.GetOrderReplyParams) (db.OrderReply, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return db.OrderReply{}, q.err
+	}
+
+	row, ok := q.replies[arg.ReplyID]
+	if !ok || row.AccountID != arg.AccountID || !time.Now().Before(row.ExpiresAt.Time) {
+		return db.OrderReply{}, pgx.ErrNoRows
+	}
+
+	return row, nil
+}
+
+func (q *fakeQuerier) DeleteExpiredOrderReplies(_ context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return q.err
+	}
+
+	for id, row := range q.replies {
+		if !time.Now().Before(row.ExpiresAt.Time) {
+			delete(q.replies, id)
+		}
+	}
+
+	return nil
+}
+
+func (q *fakeQuerier) DeleteOrderReply(_ context.Context, replyID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.err != nil {
+		return q.err
+	}
+
+	delete(q.replies, replyID)
+
+	return nil
+}
+
+func TestStore_IssuedToAccount(t *testing.T) {
+	querier := newFakeQuerier()
+	store := NewStore(querier, 0)
+	ctx := context.Background()
+
+	if err := store.Issue(ctx, "U12345", "a1b2", []string{"LMT", "STP"}); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	orderTypes, ok, err := store.Issued(ctx, "U12345", "a1b2")
+	if err != nil || !ok {
+		t.Fatalf("Issued() = %v, %v; want issued", ok, err)
+	}
+	if !slices.Equal(orderTypes, []string{"LMT", "STP"}) {
+		t.Errorf("order types = %v, want [LMT STP]", orderTypes)
+	}
+
+	// Questions are only known to the account they were asked for.
+	if _, ok, _ := store.Issued(ctx, "U99999", "a1b2"); ok {
+		t.Error("Issued() for another account = true, want false")
+	}
+
+	if err := store.Forget(ctx, "a1b2"); err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+
+	if _, ok, _ := store.Issued(ctx, "U12345", "a1b2"); ok {
+		t.Error("Issued() after Forget = true, want false")
+	}
+}
+
+func TestStore_Expired(t *testing.T) {
+	querier := newFakeQuerier()
+	store := NewStore(querier, time.Millisecond)
+	ctx := context.Background()
+
+	if err := store.Issue(ctx, "U12345", "a1b2", nil); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok, _ := store.Issued(ctx, "U12345", "a1b2"); ok {
+		t.Error("Issued() after expiry = true, want false")
+	}
+
+	// Expired questions are dropped when the next one is asked.
+	if err := store.Issue(ctx, "U12345", "c3d4", nil); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	if _, ok := querier.replies["a1b2"]; ok {
+		t.Error("expired reply was not deleted")
+	}
+}
+
+func TestStore_Error(t *testing.T) {
+	querier := newFakeQuerier()
+	querier.err = errors.New("connection refused")
+	store := NewStore(querier, 0)
+	ctx := context.Background()
+
+	if err := store.Issue(ctx, "U12345", "a1b2", nil); err == nil {
+		t.Error("Issue() error = nil, want error")
+	}
+
+	if _, _, err := store.Issued(ctx, "U12345", "a1b2"); err == nil {
+		t.Error("Issued() error = nil, want error")
+	}
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE instruments (
+    conid BIGINT PRIMARY KEY,
+    symbol VARCHAR(32) NOT NULL,
+    sec_type VARCHAR(8) NOT NULL,
+    exchange VARCHAR(32) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    multiplier VARCHAR(16) NOT NULL DEFAULT '',
+    trading_class VARCHAR(32) NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    refreshed_at TIMESTAMP NOT NULL DEFAULT NOW(),  -- last time the contract was confirmed by the Gateway
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_instruments_symbol_sec_type ON instruments(symbol, sec_type);
+
+-- +goose Down
+DROP TABLE instruments;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE bars (
+    conid BIGINT NOT NULL,
+    bar_size VARCHAR(8) NOT NULL,
+    outside_rth BOOLEAN NOT NULL DEFAULT FALSE,
+    bar_time TIMESTAMP NOT NULL,  -- start of the bar, in UTC
+    open DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL,
+    volume BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (conid, bar_size, outside_rth, bar_time)
+);
+
+-- Time ranges whose finalized bars are all in the bars table, including ranges without
+-- any bars such as weekends, so that they are not requested from the Gateway again.
+CREATE TABLE bar_coverage (
+    id BIGSERIAL PRIMARY KEY,
+    conid BIGINT NOT NULL,
+    bar_size VARCHAR(8) NOT NULL,
+    outside_rth BOOLEAN NOT NULL DEFAULT FALSE,
+    start_time TIMESTAMP NOT NULL,  -- inclusive, in UTC
+    end_time TIMESTAMP NOT NULL,    -- exclusive, in UTC
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_bar_coverage_conid_bar_size ON bar_coverage(conid, bar_size, outside_rth, start_time);
+
+-- +goose Down
+DROP TABLE bar_coverage;
+DROP TABLE bars;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE order_replies (
+    reply_id VARCHAR(255) PRIMARY KEY,
+    account_id VARCHAR(255) NOT NULL,
+    order_types TEXT[] NOT NULL DEFAULT '{}',  -- Gateway types of the orders awaiting the reply, in request order
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_replies_expires_at ON order_replies(expires_at);
+
+-- +goose Down
+DROP TABLE order_replies;
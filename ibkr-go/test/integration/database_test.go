@@ -36,7 +36,7 @@ func TestIntegration_Database_SessionQueries(t *testing.T) {
 	queries := testCtx.DB.Queries
 
 	// Create a test session directly via queries
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 	tokenHash := "test_hash_12345"
 	encryptedToken := []byte("encrypted_test_token")
 	expiresAt := pgtype.Timestamp{
@@ -85,6 +85,111 @@ func TestIntegration_Database_SessionQueries(t *testing.T) {
 	}
 }
 
+func TestIntegration_Database_BarQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	queries := testCtx.DB.Queries
+
+	const conID = 999000001
+
+	defer func() {
+		_ = queries.DeleteBarCoverageByConid(ctx, conID)
+		_, _ = queries.DeleteBarsByConid(ctx, conID)
+	}()
+
+	start := time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC)
+	at := func(d time.Duration) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: start.Add(d), Valid: true}
+	}
+
+	// Upsert two bars, then one of them again with a new close.
+	err := queries.UpsertBars(ctx, db.UpsertBarsParams{
+		Conid:    conID,
+		BarSize:  "1h",
+		BarTimes: []pgtype.Timestamp{at(0), at(time.Hour)},
+		Opens:    []float64{100, 101},
+		Highs:    []float64{102, 103},
+		Lows:     []float64{99, 100},
+		Closes:   []float64{101, 102},
+		Volumes:  []int64{1000, 2000},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert bars: %v", err)
+	}
+
+	err = queries.UpsertBars(ctx, db.UpsertBarsParams{
+		Conid:    conID,
+		BarSize:  "1h",
+		BarTimes: []pgtype.Timestamp{at(time.Hour)},
+		Opens:    []float64{101},
+		Highs:    []float64{103},
+		Lows:     []float64{100},
+		Closes:   []float64{102.5},
+		Volumes:  []int64{2100},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert bars: %v", err)
+	}
+
+	bars, err := queries.ListBars(ctx, db.ListBarsParams{
+		Conid:     conID,
+		BarSize:   "1h",
+		StartTime: at(0),
+		EndTime:   at(2 * time.Hour),
+		MaxBars:   10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list bars: %v", err)
+	}
+
+	// Most recent first.
+	if len(bars) != 2 || !bars[0].BarTime.Time.Equal(start.Add(time.Hour)) || bars[0].Close != 102.5 {
+		t.Fatalf("Unexpected bars: %+v", bars)
+	}
+
+	// Record and merge coverage.
+	err = queries.InsertBarCoverage(ctx, db.InsertBarCoverageParams{
+		Conid:     conID,
+		BarSize:   "1h",
+		StartTime: at(0),
+		EndTime:   at(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert bar coverage: %v", err)
+	}
+
+	// A range touching the covered one is returned, so that it can be merged.
+	coverage, err := queries.ListBarCoverage(ctx, db.ListBarCoverageParams{
+		Conid:     conID,
+		BarSize:   "1h",
+		StartTime: at(2 * time.Hour),
+		EndTime:   at(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to list bar coverage: %v", err)
+	}
+
+	if len(coverage) != 1 {
+		t.Fatalf("Expected the touching range, got %+v", coverage)
+	}
+
+	if err := queries.DeleteBarCoverage(ctx, []int64{coverage[0].ID}); err != nil {
+		t.Fatalf("Failed to delete bar coverage: %v", err)
+	}
+
+	deleted, err := queries.DeleteBarsByConid(ctx, conID)
+	if err != nil {
+		t.Fatalf("Failed to delete bars: %v", err)
+	}
+
+	if deleted != 2 {
+		t.Errorf("Expected 2 deleted bars, got %d", deleted)
+	}
+}
+
 func TestIntegration_Database_HealthCheck(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -110,7 +215,7 @@ func TestIntegration_Database_TransactionIsolation(t *testing.T) {
 	queries := testCtx.DB.Queries
 
 	// Create two sessions with different tokens
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	token1Hash := "test_hash_1"
 	encryptedToken1 := []byte("encrypted_test_token_1")
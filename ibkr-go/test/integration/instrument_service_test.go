@@ -0,0 +1,102 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/api"
+	instrumentv1 "github.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/instrument/v1"
+)
+
+func TestIntegration_InstrumentService_SearchInstruments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create instrument service handler
+	handler := api.NewInstrumentServiceHandler(testCtx.IBKRClient)
+
+	// Search instruments
+	resp, err := handler.SearchInstruments(ctx, connect.NewRequest(&instrumentv1.SearchInstrumentsRequest{
+		Query: "AAPL",
+	}))
+	if err != nil {
+		t.Fatalf("SearchInstruments failed: %v", err)
+	}
+
+	if len(resp.Msg.Instruments) == 0 {
+		t.Fatal("Expected at least one instrument")
+	}
+
+	if resp.Msg.Instruments[0].Conid == 0 {
+		t.Error("Expected the instrument conid to be set")
+	}
+
+	t.Logf("Instruments found: %d", len(resp.Msg.Instruments))
+}
+
+func TestIntegration_InstrumentService_GetContractDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create instrument service handler
+	handler := api.NewInstrumentServiceHandler(testCtx.IBKRClient)
+
+	// Get contract details with trading rules
+	resp, err := handler.GetContractDetails(ctx, connect.NewRequest(&instrumentv1.GetContractDetailsRequest{
+		Conid:        265598,
+		IncludeRules: true,
+	}))
+	if err != nil {
+		t.Fatalf("GetContractDetails failed: %v", err)
+	}
+
+	contract := resp.Msg.Contract
+	if contract.Conid != 265598 || contract.Symbol != "AAPL" {
+		t.Errorf("Expected AAPL (265598), got %s (%d)", contract.Symbol, contract.Conid)
+	}
+
+	if contract.Rules == nil {
+		t.Fatal("Expected trading rules to be set")
+	}
+
+	t.Logf("Contract details: %s %s on %s", contract.Symbol, contract.SecType, contract.Exchange)
+}
+
+func TestIntegration_InstrumentService_GetTradingRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create instrument service handler
+	handler := api.NewInstrumentServiceHandler(testCtx.IBKRClient)
+
+	// Get trading rules
+	resp, err := handler.GetTradingRules(ctx, connect.NewRequest(&instrumentv1.GetTradingRulesRequest{
+		Conid: 265598,
+	}))
+	if err != nil {
+		t.Fatalf("GetTradingRules failed: %v", err)
+	}
+
+	rules := resp.Msg.Rules
+	if rules.PriceIncrement <= 0 {
+		t.Errorf("Expected a positive price increment, got %v", rules.PriceIncrement)
+	}
+
+	if len(rules.OrderTypes) == 0 || len(rules.TimeInForce) == 0 {
+		t.Errorf("Expected order types and times in force, got %v and %v", rules.OrderTypes, rules.TimeInForce)
+	}
+
+	t.Logf("Trading rules: increment=%v, order types=%v", rules.PriceIncrement, rules.OrderTypes)
+}
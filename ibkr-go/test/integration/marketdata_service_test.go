@@ -20,18 +20,18 @@ func TestIntegration_MarketDataService_GetQuote(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create market data service handler
-	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient)
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
 
 	// Create get quote request
 	req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
-		Symbol: "AAPL",
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "AAPL"},
 	})
 
 	// Get quote
@@ -63,10 +63,98 @@ func TestIntegration_MarketDataService_GetQuote(t *testing.T) {
 		t.Error("Expected ask price to be set")
 	}
 
+	if quote.UpdatedAt == nil {
+		t.Error("Expected timestamp to be set")
+	}
+
 	t.Logf("Quote retrieved: Symbol=%s, Last=%.2f, Bid=%.2f, Ask=%.2f",
 		quote.Symbol, quote.Last, quote.Bid, quote.Ask)
 }
 
+func TestIntegration_MarketDataService_GetQuote_Conid(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	// Create market data service handler
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
+
+	// Get quote by contract ID, without a symbol search
+	resp, err := handler.GetQuote(ctx, connect.NewRequest(&marketdatav1.GetQuoteRequest{
+		Instrument: &marketdatav1.GetQuoteRequest_Conid{Conid: 265598},
+	}))
+	if err != nil {
+		t.Fatalf("GetQuote failed: %v", err)
+	}
+
+	quote := resp.Msg.Quote
+
+	if quote.Conid != 265598 {
+		t.Errorf("Expected conid 265598, got %d", quote.Conid)
+	}
+
+	if quote.Last == 0 {
+		t.Error("Expected last price to be set")
+	}
+
+	t.Logf("Quote retrieved: Conid=%d, Symbol=%s, Last=%.2f", quote.Conid, quote.Symbol, quote.Last)
+}
+
+func TestIntegration_MarketDataService_GetQuotes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	// Create market data service handler
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
+
+	// Get quotes
+	resp, err := handler.GetQuotes(ctx, connect.NewRequest(&marketdatav1.GetQuotesRequest{
+		Instruments: []*marketdatav1.QuoteInstrument{
+			{Instrument: &marketdatav1.QuoteInstrument_Symbol{Symbol: "AAPL"}},
+			{Instrument: &marketdatav1.QuoteInstrument_Symbol{Symbol: "MSFT"}},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("GetQuotes failed: %v", err)
+	}
+
+	// Verify response
+	if len(resp.Msg.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Msg.Results))
+	}
+
+	for _, result := range resp.Msg.Results {
+		if result.GetError() != nil {
+			t.Errorf("Unexpected error for %s: %s", result.Symbol, result.GetError().GetMessage())
+
+			continue
+		}
+
+		if result.GetQuote().GetLast() == 0 {
+			t.Errorf("Expected last price to be set for %s", result.Symbol)
+		}
+	}
+}
+
 func TestIntegration_MarketDataService_GetHistoricalData(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -75,20 +163,20 @@ func TestIntegration_MarketDataService_GetHistoricalData(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create market data service handler
-	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient)
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
 
 	// Create get historical data request
 	req := connect.NewRequest(&marketdatav1.GetHistoricalDataRequest{
-		Symbol:  "AAPL",
-		Period:  "1d",
-		BarSize: "5min",
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1d",
+		BarSize:    "5min",
 	})
 
 	// Get historical data
@@ -106,7 +194,7 @@ func TestIntegration_MarketDataService_GetHistoricalData(t *testing.T) {
 
 	// Verify bar structure if any exist
 	for i, bar := range resp.Msg.Bars {
-		if bar.Timestamp == "" {
+		if bar.OpenedAt == nil || bar.OpenedAt.AsTime().Unix() == 0 {
 			t.Errorf("Bar %d: expected timestamp to be set", i)
 		}
 		if bar.Open == 0 {
@@ -124,6 +212,104 @@ func TestIntegration_MarketDataService_GetHistoricalData(t *testing.T) {
 	}
 }
 
+func TestIntegration_MarketDataService_GetHistoricalData_Pages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
+
+	limit := int32(1)
+	msg := &marketdatav1.GetHistoricalDataRequest{
+		Instrument: &marketdatav1.GetHistoricalDataRequest_Symbol{Symbol: "AAPL"},
+		Period:     "1y",
+		BarSize:    "1d",
+		Limit:      &limit,
+	}
+
+	first, err := handler.GetHistoricalData(ctx, connect.NewRequest(msg))
+	if err != nil {
+		t.Fatalf("GetHistoricalData failed: %v", err)
+	}
+
+	if len(first.Msg.Bars) != 1 || first.Msg.NextPageToken == "" {
+		t.Fatalf("Expected one bar and a next page, got %v", first.Msg)
+	}
+
+	msg.PageToken = &first.Msg.NextPageToken
+
+	second, err := handler.GetHistoricalData(ctx, connect.NewRequest(msg))
+	if err != nil {
+		t.Fatalf("GetHistoricalData failed: %v", err)
+	}
+
+	if len(second.Msg.Bars) != 1 {
+		t.Fatalf("Expected one bar, got %d", len(second.Msg.Bars))
+	}
+
+	// Pages walk back in time.
+	if older, newer := second.Msg.Bars[0].OpenedAt.AsTime(), first.Msg.Bars[0].OpenedAt.AsTime(); !older.Before(newer) {
+		t.Errorf("Expected an older bar on the second page, got %s after %s", older, newer)
+	}
+}
+
+func TestIntegration_MarketDataService_GetIndicators(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
+
+	// 2h bars are resampled from 1h bars.
+	resp, err := handler.GetIndicators(ctx, connect.NewRequest(&marketdatav1.GetIndicatorsRequest{
+		Instrument: &marketdatav1.GetIndicatorsRequest_Symbol{Symbol: "AAPL"},
+		Period:     "2d",
+		BarSize:    "2h",
+		Indicators: []*marketdatav1.IndicatorSpec{
+			{Type: marketdatav1.IndicatorType_INDICATOR_TYPE_SMA},
+			{Type: marketdatav1.IndicatorType_INDICATOR_TYPE_VWAP},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("GetIndicators failed: %v", err)
+	}
+
+	if len(resp.Msg.Bars) == 0 {
+		t.Fatal("Expected bars")
+	}
+
+	if len(resp.Msg.Indicators) != 2 {
+		t.Fatalf("Expected 2 indicators, got %d", len(resp.Msg.Indicators))
+	}
+
+	for _, indicator := range resp.Msg.Indicators {
+		for _, series := range indicator.Series {
+			if len(series.Values) != len(resp.Msg.Bars) {
+				t.Errorf("Expected %s values aligned with %d bars, got %d",
+					series.Name, len(resp.Msg.Bars), len(series.Values))
+			}
+		}
+	}
+}
+
 func TestIntegration_MarketDataService_InvalidSymbol(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -132,18 +318,18 @@ func TestIntegration_MarketDataService_InvalidSymbol(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create market data service handler
-	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient)
+	handler := api.NewMarketDataServiceHandler(testCtx.IBKRClient, testCtx.Contracts)
 
 	// Create get quote request with invalid symbol
 	req := connect.NewRequest(&marketdatav1.GetQuoteRequest{
-		Symbol: "INVALID_SYMBOL_12345",
+		Instrument: &marketdatav1.GetQuoteRequest_Symbol{Symbol: "INVALID_SYMBOL_12345"},
 	})
 
 	// Get quote (should fail or handle gracefully)
@@ -20,18 +20,18 @@ func TestIntegration_OrderService_PlaceOrder(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context (simulating session middleware)
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create order service handler
-	handler := api.NewOrderServiceHandler(testCtx.IBKRClient)
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
 
 	// Create place order request
 	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
-		Symbol:      "AAPL",
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
 		Quantity:    100,
 		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
 		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
@@ -56,6 +56,93 @@ func TestIntegration_OrderService_PlaceOrder(t *testing.T) {
 	t.Logf("Order placed successfully: ID=%s, Status=%s", resp.Msg.OrderId, resp.Msg.Status)
 }
 
+func TestIntegration_OrderService_PlaceBracketOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	// Create order service handler
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
+
+	// Place a limit entry with a take profit and a stop loss attached
+	resp, err := handler.PlaceBracketOrder(ctx, connect.NewRequest(&orderv1.PlaceBracketOrderRequest{
+		Entry: &orderv1.PlaceOrderRequest{
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
+			Quantity:    100,
+			Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+			Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
+			LimitPrice:  &[]float64{150.00}[0],
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+		},
+		TakeProfitPrice: 160.00,
+		StopLossPrice:   145.00,
+		ExitTimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_GTC,
+	}))
+	if err != nil {
+		t.Fatalf("PlaceBracketOrder failed: %v", err)
+	}
+
+	// Verify every leg was placed
+	if len(resp.Msg.OrderIds) != 3 {
+		t.Fatalf("Expected 3 order IDs, got %v", resp.Msg.OrderIds)
+	}
+
+	t.Logf("Bracket order placed: IDs=%v, Status=%s", resp.Msg.OrderIds, resp.Msg.Status)
+}
+
+func TestIntegration_OrderService_PlaceOrderGroup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Create test session
+	token := CreateTestSession(t, testCtx.AccountID)
+	defer DeleteTestSession(t, token)
+
+	// Add account ID to context
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
+
+	// Create order service handler
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
+
+	// Place two limit buys of which only one may fill
+	order := func(symbol string, price float64) *orderv1.PlaceOrderRequest {
+		return &orderv1.PlaceOrderRequest{
+			AccountId:   testCtx.AccountID,
+			Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: symbol},
+			Quantity:    10,
+			Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
+			Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
+			LimitPrice:  &price,
+			TimeInForce: orderv1.TimeInForce_TIME_IN_FORCE_DAY,
+		}
+	}
+
+	resp, err := handler.PlaceOrderGroup(ctx, connect.NewRequest(&orderv1.PlaceOrderGroupRequest{
+		Orders: []*orderv1.PlaceOrderRequest{order("AAPL", 150.00), order("MSFT", 400.00)},
+	}))
+	if err != nil {
+		t.Fatalf("PlaceOrderGroup failed: %v", err)
+	}
+
+	if len(resp.Msg.OrderIds) != 2 || resp.Msg.OcaGroup == "" {
+		t.Errorf("Expected 2 order IDs and a group name, got %v and %q", resp.Msg.OrderIds, resp.Msg.OcaGroup)
+	}
+
+	t.Logf("Order group %s placed: IDs=%v", resp.Msg.OcaGroup, resp.Msg.OrderIds)
+}
+
 func TestIntegration_OrderService_ListOrders(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -64,14 +151,14 @@ func TestIntegration_OrderService_ListOrders(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create order service handler
-	handler := api.NewOrderServiceHandler(testCtx.IBKRClient)
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
 
 	// Create list orders request
 	req := connect.NewRequest(&orderv1.ListOrdersRequest{})
@@ -98,18 +185,18 @@ func TestIntegration_OrderService_CancelOrder(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create order service handler
-	handler := api.NewOrderServiceHandler(testCtx.IBKRClient)
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
 
 	// First, place an order
 	placeReq := connect.NewRequest(&orderv1.PlaceOrderRequest{
-		Symbol:      "AAPL",
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "AAPL"},
 		Quantity:    100,
 		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
 		Type:        orderv1.OrderType_ORDER_TYPE_LIMIT,
@@ -150,18 +237,18 @@ func TestIntegration_OrderService_InvalidSymbol(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create order service handler
-	handler := api.NewOrderServiceHandler(testCtx.IBKRClient)
+	handler := api.NewOrderServiceHandler(testCtx.IBKRClient, testCtx.Accounts, testCtx.Contracts)
 
 	// Create place order request with invalid symbol
 	req := connect.NewRequest(&orderv1.PlaceOrderRequest{
-		Symbol:      "INVALID_SYMBOL_12345",
+		Instrument:  &orderv1.PlaceOrderRequest_Symbol{Symbol: "INVALID_SYMBOL_12345"},
 		Quantity:    100,
 		Side:        orderv1.OrderSide_ORDER_SIDE_BUY,
 		Type:        orderv1.OrderType_ORDER_TYPE_MARKET,
@@ -20,14 +20,14 @@ func TestIntegration_PortfolioService_GetPortfolio(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create portfolio service handler
-	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient)
+	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient, testCtx.Accounts)
 
 	// Create get portfolio request
 	req := connect.NewRequest(&portfoliov1.GetPortfolioRequest{})
@@ -45,8 +45,8 @@ func TestIntegration_PortfolioService_GetPortfolio(t *testing.T) {
 
 	portfolio := resp.Msg.Portfolio
 
-	if portfolio.AccountId != testCtx.Config.IBKRAccountID {
-		t.Errorf("Expected account ID %s, got %s", testCtx.Config.IBKRAccountID, portfolio.AccountId)
+	if portfolio.AccountId != testCtx.AccountID {
+		t.Errorf("Expected account ID %s, got %s", testCtx.AccountID, portfolio.AccountId)
 	}
 
 	if portfolio.TotalValue == nil {
@@ -69,14 +69,14 @@ func TestIntegration_PortfolioService_GetPositions(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create portfolio service handler
-	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient)
+	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient, testCtx.Accounts)
 
 	// Create get positions request
 	req := connect.NewRequest(&portfoliov1.GetPositionsRequest{})
@@ -116,14 +116,14 @@ func TestIntegration_PortfolioService_GetAccountSummary(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create portfolio service handler
-	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient)
+	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient, testCtx.Accounts)
 
 	// Create get account summary request
 	req := connect.NewRequest(&portfoliov1.GetAccountSummaryRequest{})
@@ -141,8 +141,8 @@ func TestIntegration_PortfolioService_GetAccountSummary(t *testing.T) {
 
 	summary := resp.Msg.AccountSummary
 
-	if summary.AccountId != testCtx.Config.IBKRAccountID {
-		t.Errorf("Expected account ID %s, got %s", testCtx.Config.IBKRAccountID, summary.AccountId)
+	if summary.AccountId != testCtx.AccountID {
+		t.Errorf("Expected account ID %s, got %s", testCtx.AccountID, summary.AccountId)
 	}
 
 	if summary.NetLiquidation == nil {
@@ -164,14 +164,14 @@ func TestIntegration_PortfolioService_MoneyConversion(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test session
-	token := CreateTestSession(t, testCtx.Config.IBKRAccountID)
+	token := CreateTestSession(t, testCtx.AccountID)
 	defer DeleteTestSession(t, token)
 
 	// Add account ID to context
-	ctx = middleware.SetAccountIDInContext(ctx, testCtx.Config.IBKRAccountID)
+	ctx = middleware.SetAccountIDInContext(ctx, testCtx.AccountID)
 
 	// Create portfolio service handler
-	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient)
+	handler := api.NewPortfolioServiceHandler(testCtx.IBKRClient, testCtx.Accounts)
 
 	// Get portfolio to test money conversion
 	req := connect.NewRequest(&portfoliov1.GetPortfolioRequest{})
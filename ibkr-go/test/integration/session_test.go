@@ -14,7 +14,7 @@ func TestIntegration_Session_CreateAndValidate(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	// Create session
 	token, err := testCtx.SessionService.Create(ctx, accountID)
@@ -67,7 +67,7 @@ func TestIntegration_Session_DeleteAndValidate(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	// Create session
 	token, err := testCtx.SessionService.Create(ctx, accountID)
@@ -96,7 +96,7 @@ func TestIntegration_Session_ExpiredCleanup(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	// Create session
 	token, err := testCtx.SessionService.Create(ctx, accountID)
@@ -124,7 +124,7 @@ func TestIntegration_Session_MultipleTokens(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	// Create multiple sessions
 	tokens := make([]string, 3)
@@ -162,7 +162,7 @@ func TestIntegration_Session_TokenUniqueness(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	accountID := testCtx.Config.IBKRAccountID
+	accountID := testCtx.AccountID
 
 	// Create two sessions
 	token1, err := testCtx.SessionService.Create(ctx, accountID)
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/api"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/config"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/database"
 	"github.com/majidmvulle/ibkr-client/ibkr-go/internal/ibkr"
@@ -19,8 +20,11 @@ import (
 type TestContext struct {
 	DB             *database.DB
 	IBKRClient     *ibkr.Client
+	Accounts       *api.AccountResolver
+	Contracts      *ibkr.Resolver
 	SessionService *session.Service
 	Config         *config.Config
+	AccountID      string // Gateway account the tests act as, from IBKR_ACCOUNT_ID.
 }
 
 var testCtx *TestContext
@@ -45,7 +49,7 @@ func TestMain(m *testing.M) {
 	defer db.Close()
 
 	// Initialize IBKR client
-	ibkrClient := ibkr.NewClient(cfg.IBKRGatewayURL, cfg.IBKRAccountID)
+	ibkrClient := ibkr.NewClient(cfg.IBKRGatewayURL)
 
 	// Initialize session service
 	sessionService := session.NewService(db.Queries, cfg.EncryptionKey, 24*time.Hour)
@@ -54,8 +58,11 @@ func TestMain(m *testing.M) {
 	testCtx = &TestContext{
 		DB:             db,
 		IBKRClient:     ibkrClient,
+		Accounts:       api.NewAccountResolver(ibkrClient, 5*time.Minute),
+		Contracts:      ibkr.NewResolver(ibkrClient),
 		SessionService: sessionService,
 		Config:         cfg,
+		AccountID:      os.Getenv("IBKR_ACCOUNT_ID"),
 	}
 
 	// Run tests
@@ -10,6 +10,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,10 +23,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// IndicatorType is a technical indicator.
+type IndicatorType int32
+
+const (
+	IndicatorType_INDICATOR_TYPE_UNSPECIFIED IndicatorType = 0
+	// Simple moving average of the closes over period bars, 20 by default. Series "sma".
+	IndicatorType_INDICATOR_TYPE_SMA IndicatorType = 1
+	// Exponential moving average of the closes over period bars, 20 by default. Series "ema".
+	IndicatorType_INDICATOR_TYPE_EMA IndicatorType = 2
+	// Relative strength index over period bars, 14 by default, with Wilder's smoothing.
+	// Series "rsi".
+	IndicatorType_INDICATOR_TYPE_RSI IndicatorType = 3
+	// Moving average convergence divergence, 12, 26 and 9 by default.
+	// Series "macd", "signal" and "histogram".
+	IndicatorType_INDICATOR_TYPE_MACD IndicatorType = 4
+	// Average true range over period bars, 14 by default, with Wilder's smoothing.
+	// Series "atr".
+	IndicatorType_INDICATOR_TYPE_ATR IndicatorType = 5
+	// Bollinger bands over period bars, 20 by default, std_dev standard deviations wide,
+	// 2 by default. Series "middle", "upper" and "lower".
+	IndicatorType_INDICATOR_TYPE_BOLLINGER IndicatorType = 6
+	// Volume-weighted average price, restarting every day for intraday bars.
+	// Series "vwap".
+	IndicatorType_INDICATOR_TYPE_VWAP IndicatorType = 7
+)
+
+// Enum value maps for IndicatorType.
+var (
+	IndicatorType_name = map[int32]string{
+		0: "INDICATOR_TYPE_UNSPECIFIED",
+		1: "INDICATOR_TYPE_SMA",
+		2: "INDICATOR_TYPE_EMA",
+		3: "INDICATOR_TYPE_RSI",
+		4: "INDICATOR_TYPE_MACD",
+		5: "INDICATOR_TYPE_ATR",
+		6: "INDICATOR_TYPE_BOLLINGER",
+		7: "INDICATOR_TYPE_VWAP",
+	}
+	IndicatorType_value = map[string]int32{
+		"INDICATOR_TYPE_UNSPECIFIED": 0,
+		"INDICATOR_TYPE_SMA":         1,
+		"INDICATOR_TYPE_EMA":         2,
+		"INDICATOR_TYPE_RSI":         3,
+		"INDICATOR_TYPE_MACD":        4,
+		"INDICATOR_TYPE_ATR":         5,
+		"INDICATOR_TYPE_BOLLINGER":   6,
+		"INDICATOR_TYPE_VWAP":        7,
+	}
+)
+
+func (x IndicatorType) Enum() *IndicatorType {
+	p := new(IndicatorType)
+	*p = x
+	return p
+}
+
+func (x IndicatorType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndicatorType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_ibkr_marketdata_v1_market_data_proto_enumTypes[0].Descriptor()
+}
+
+func (IndicatorType) Type() protoreflect.EnumType {
+	return &file_api_ibkr_marketdata_v1_market_data_proto_enumTypes[0]
+}
+
+func (x IndicatorType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndicatorType.Descriptor instead.
+func (IndicatorType) EnumDescriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{0}
+}
+
 // GetQuoteRequest contains parameters for retrieving a quote.
 type GetQuoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*GetQuoteRequest_Symbol
+	//	*GetQuoteRequest_Conid
+	Instrument isGetQuoteRequest_Instrument `protobuf_oneof:"instrument"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,2,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,3,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,4,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry *string `protobuf:"bytes,5,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	// Additional snapshot fields to return in Quote.fields, by name, e.g. "bid_size",
+	// "change_percent" or "market_data_availability".
+	Fields        []string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -60,13 +155,87 @@ func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GetQuoteRequest) GetInstrument() isGetQuoteRequest_Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
 func (x *GetQuoteRequest) GetSymbol() string {
 	if x != nil {
-		return x.Symbol
+		if x, ok := x.Instrument.(*GetQuoteRequest_Symbol); ok {
+			return x.Symbol
+		}
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*GetQuoteRequest_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
+func (x *GetQuoteRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
 	}
 	return ""
 }
 
+func (x *GetQuoteRequest) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type isGetQuoteRequest_Instrument interface {
+	isGetQuoteRequest_Instrument()
+}
+
+type GetQuoteRequest_Symbol struct {
+	// Symbol of the instrument: upper case letters and digits, in parts joined by a single
+	// ".", "-" or space, e.g. "AAPL", "BRK.B", "RDS-A" or "7203". The listing fields below
+	// pick among the listings found for it.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3,oneof"`
+}
+
+type GetQuoteRequest_Conid struct {
+	// Contract ID of the instrument. The contract is used as is, without a search, and
+	// the listing fields below are ignored.
+	Conid int64 `protobuf:"varint,7,opt,name=conid,proto3,oneof"`
+}
+
+func (*GetQuoteRequest_Symbol) isGetQuoteRequest_Instrument() {}
+
+func (*GetQuoteRequest_Conid) isGetQuoteRequest_Instrument() {}
+
 // GetQuoteResponse contains a market quote.
 type GetQuoteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -114,17 +283,22 @@ func (x *GetQuoteResponse) GetQuote() *Quote {
 
 // Quote represents a market quote.
 type Quote struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Bid           float64                `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`
-	Ask           float64                `protobuf:"fixed64,3,opt,name=ask,proto3" json:"ask,omitempty"`
-	Last          float64                `protobuf:"fixed64,4,opt,name=last,proto3" json:"last,omitempty"`
-	Volume        int64                  `protobuf:"varint,5,opt,name=volume,proto3" json:"volume,omitempty"`
-	High          float64                `protobuf:"fixed64,6,opt,name=high,proto3" json:"high,omitempty"`
-	Low           float64                `protobuf:"fixed64,7,opt,name=low,proto3" json:"low,omitempty"`
-	Open          float64                `protobuf:"fixed64,8,opt,name=open,proto3" json:"open,omitempty"`
-	Close         float64                `protobuf:"fixed64,9,opt,name=close,proto3" json:"close,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Bid    float64                `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask    float64                `protobuf:"fixed64,3,opt,name=ask,proto3" json:"ask,omitempty"`
+	Last   float64                `protobuf:"fixed64,4,opt,name=last,proto3" json:"last,omitempty"`
+	Volume int64                  `protobuf:"varint,5,opt,name=volume,proto3" json:"volume,omitempty"`
+	High   float64                `protobuf:"fixed64,6,opt,name=high,proto3" json:"high,omitempty"`
+	Low    float64                `protobuf:"fixed64,7,opt,name=low,proto3" json:"low,omitempty"`
+	Open   float64                `protobuf:"fixed64,8,opt,name=open,proto3" json:"open,omitempty"`
+	Close  float64                `protobuf:"fixed64,9,opt,name=close,proto3" json:"close,omitempty"`
+	// Time of the last update of the quote by the Gateway.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Raw values of the requested additional fields returned by the Gateway, keyed by field name.
+	Fields map[string]string `protobuf:"bytes,11,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Contract ID of the instrument.
+	Conid         int64 `protobuf:"varint,13,opt,name=conid,proto3" json:"conid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -222,38 +396,52 @@ func (x *Quote) GetClose() float64 {
 	return 0
 }
 
-func (x *Quote) GetTimestamp() string {
+func (x *Quote) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Timestamp
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
 }
 
-// GetHistoricalDataRequest contains parameters for historical data.
-type GetHistoricalDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Period        string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                  // e.g., "1d", "1w", "1m"
-	BarSize       string                 `protobuf:"bytes,3,opt,name=bar_size,json=barSize,proto3" json:"bar_size,omitempty"` // e.g., "1min", "5min", "1hour", "1day"
-	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+func (x *Quote) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Quote) GetConid() int64 {
+	if x != nil {
+		return x.Conid
+	}
+	return 0
+}
+
+// GetQuotesRequest contains parameters for retrieving quotes of many instruments.
+type GetQuotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Instruments to quote. Results are returned in the same order.
+	Instruments []*QuoteInstrument `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	// Additional snapshot fields to return in Quote.fields, by name, as in GetQuoteRequest.
+	Fields        []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoricalDataRequest) Reset() {
-	*x = GetHistoricalDataRequest{}
+func (x *GetQuotesRequest) Reset() {
+	*x = GetQuotesRequest{}
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoricalDataRequest) String() string {
+func (x *GetQuotesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoricalDataRequest) ProtoMessage() {}
+func (*GetQuotesRequest) ProtoMessage() {}
 
-func (x *GetHistoricalDataRequest) ProtoReflect() protoreflect.Message {
+func (x *GetQuotesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -265,61 +453,61 @@ func (x *GetHistoricalDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoricalDataRequest.ProtoReflect.Descriptor instead.
-func (*GetHistoricalDataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetQuotesRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotesRequest) Descriptor() ([]byte, []int) {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetHistoricalDataRequest) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *GetHistoricalDataRequest) GetPeriod() string {
+func (x *GetQuotesRequest) GetInstruments() []*QuoteInstrument {
 	if x != nil {
-		return x.Period
+		return x.Instruments
 	}
-	return ""
+	return nil
 }
 
-func (x *GetHistoricalDataRequest) GetBarSize() string {
+func (x *GetQuotesRequest) GetFields() []string {
 	if x != nil {
-		return x.BarSize
-	}
-	return ""
-}
-
-func (x *GetHistoricalDataRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+		return x.Fields
 	}
-	return 0
+	return nil
 }
 
-// GetHistoricalDataResponse contains historical data bars.
-type GetHistoricalDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bars          []*Bar                 `protobuf:"bytes,1,rep,name=bars,proto3" json:"bars,omitempty"`
+// QuoteInstrument identifies an instrument to quote, as in GetQuoteRequest.
+type QuoteInstrument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*QuoteInstrument_Symbol
+	//	*QuoteInstrument_Conid
+	Instrument isQuoteInstrument_Instrument `protobuf_oneof:"instrument"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,2,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,3,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,4,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry        *string `protobuf:"bytes,5,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoricalDataResponse) Reset() {
-	*x = GetHistoricalDataResponse{}
+func (x *QuoteInstrument) Reset() {
+	*x = QuoteInstrument{}
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoricalDataResponse) String() string {
+func (x *QuoteInstrument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoricalDataResponse) ProtoMessage() {}
+func (*QuoteInstrument) ProtoMessage() {}
 
-func (x *GetHistoricalDataResponse) ProtoReflect() protoreflect.Message {
+func (x *QuoteInstrument) ProtoReflect() protoreflect.Message {
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -331,45 +519,106 @@ func (x *GetHistoricalDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoricalDataResponse.ProtoReflect.Descriptor instead.
-func (*GetHistoricalDataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteInstrument.ProtoReflect.Descriptor instead.
+func (*QuoteInstrument) Descriptor() ([]byte, []int) {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetHistoricalDataResponse) GetBars() []*Bar {
+func (x *QuoteInstrument) GetInstrument() isQuoteInstrument_Instrument {
 	if x != nil {
-		return x.Bars
+		return x.Instrument
 	}
 	return nil
 }
 
-// Bar represents a historical price bar.
-type Bar struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     string                 `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
-	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
-	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
-	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
-	Volume        int64                  `protobuf:"varint,6,opt,name=volume,proto3" json:"volume,omitempty"`
+func (x *QuoteInstrument) GetSymbol() string {
+	if x != nil {
+		if x, ok := x.Instrument.(*QuoteInstrument_Symbol); ok {
+			return x.Symbol
+		}
+	}
+	return ""
+}
+
+func (x *QuoteInstrument) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*QuoteInstrument_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
+func (x *QuoteInstrument) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *QuoteInstrument) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteInstrument) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *QuoteInstrument) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+type isQuoteInstrument_Instrument interface {
+	isQuoteInstrument_Instrument()
+}
+
+type QuoteInstrument_Symbol struct {
+	// Symbol of the instrument, as in GetQuoteRequest.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3,oneof"`
+}
+
+type QuoteInstrument_Conid struct {
+	// Contract ID of the instrument. The contract is used as is, without a search, and
+	// the listing fields below are ignored.
+	Conid int64 `protobuf:"varint,6,opt,name=conid,proto3,oneof"`
+}
+
+func (*QuoteInstrument_Symbol) isQuoteInstrument_Instrument() {}
+
+func (*QuoteInstrument_Conid) isQuoteInstrument_Instrument() {}
+
+// GetQuotesResponse contains the quotes of the requested instruments.
+type GetQuotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested instrument, in request order.
+	Results       []*QuoteResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Bar) Reset() {
-	*x = Bar{}
+func (x *GetQuotesResponse) Reset() {
+	*x = GetQuotesResponse{}
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Bar) String() string {
+func (x *GetQuotesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Bar) ProtoMessage() {}
+func (*GetQuotesResponse) ProtoMessage() {}
 
-func (x *Bar) ProtoReflect() protoreflect.Message {
+func (x *GetQuotesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,75 +630,48 @@ func (x *Bar) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Bar.ProtoReflect.Descriptor instead.
-func (*Bar) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetQuotesResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotesResponse) Descriptor() ([]byte, []int) {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Bar) GetTimestamp() string {
-	if x != nil {
-		return x.Timestamp
-	}
-	return ""
-}
-
-func (x *Bar) GetOpen() float64 {
-	if x != nil {
-		return x.Open
-	}
-	return 0
-}
-
-func (x *Bar) GetHigh() float64 {
-	if x != nil {
-		return x.High
-	}
-	return 0
-}
-
-func (x *Bar) GetLow() float64 {
-	if x != nil {
-		return x.Low
-	}
-	return 0
-}
-
-func (x *Bar) GetClose() float64 {
-	if x != nil {
-		return x.Close
-	}
-	return 0
-}
-
-func (x *Bar) GetVolume() int64 {
+func (x *GetQuotesResponse) GetResults() []*QuoteResult {
 	if x != nil {
-		return x.Volume
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-// StreamQuotesRequest contains parameters for streaming quotes.
-type StreamQuotesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+// QuoteResult is the quote of an instrument, or the error that prevented quoting it.
+type QuoteResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Symbol of the requested instrument, empty when it was requested by conid.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Contract ID of the requested instrument, when it was requested by conid.
+	Conid int64 `protobuf:"varint,4,opt,name=conid,proto3" json:"conid,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*QuoteResult_Quote
+	//	*QuoteResult_Error
+	Result        isQuoteResult_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamQuotesRequest) Reset() {
-	*x = StreamQuotesRequest{}
+func (x *QuoteResult) Reset() {
+	*x = QuoteResult{}
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamQuotesRequest) String() string {
+func (x *QuoteResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamQuotesRequest) ProtoMessage() {}
+func (*QuoteResult) ProtoMessage() {}
 
-func (x *StreamQuotesRequest) ProtoReflect() protoreflect.Message {
+func (x *QuoteResult) ProtoReflect() protoreflect.Message {
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -461,40 +683,90 @@ func (x *StreamQuotesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamQuotesRequest.ProtoReflect.Descriptor instead.
-func (*StreamQuotesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteResult.ProtoReflect.Descriptor instead.
+func (*QuoteResult) Descriptor() ([]byte, []int) {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *StreamQuotesRequest) GetSymbol() string {
+func (x *QuoteResult) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-// StreamQuotesResponse contains a streaming quote.
-type StreamQuotesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Quote         *Quote                 `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+func (x *QuoteResult) GetConid() int64 {
+	if x != nil {
+		return x.Conid
+	}
+	return 0
+}
+
+func (x *QuoteResult) GetResult() isQuoteResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *QuoteResult) GetQuote() *Quote {
+	if x != nil {
+		if x, ok := x.Result.(*QuoteResult_Quote); ok {
+			return x.Quote
+		}
+	}
+	return nil
+}
+
+func (x *QuoteResult) GetError() *QuoteError {
+	if x != nil {
+		if x, ok := x.Result.(*QuoteResult_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isQuoteResult_Result interface {
+	isQuoteResult_Result()
+}
+
+type QuoteResult_Quote struct {
+	Quote *Quote `protobuf:"bytes,2,opt,name=quote,proto3,oneof"`
+}
+
+type QuoteResult_Error struct {
+	Error *QuoteError `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*QuoteResult_Quote) isQuoteResult_Result() {}
+
+func (*QuoteResult_Error) isQuoteResult_Result() {}
+
+// QuoteError describes why an instrument could not be quoted.
+type QuoteError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Connect error code, e.g. "not_found" or "invalid_argument".
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamQuotesResponse) Reset() {
-	*x = StreamQuotesResponse{}
+func (x *QuoteError) Reset() {
+	*x = QuoteError{}
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamQuotesResponse) String() string {
+func (x *QuoteError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamQuotesResponse) ProtoMessage() {}
+func (*QuoteError) ProtoMessage() {}
 
-func (x *StreamQuotesResponse) ProtoReflect() protoreflect.Message {
+func (x *QuoteError) ProtoReflect() protoreflect.Message {
 	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -506,27 +778,1501 @@ func (x *StreamQuotesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use QuoteError.ProtoReflect.Descriptor instead.
+func (*QuoteError) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QuoteError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *QuoteError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetHistoricalDataRequest contains parameters for historical data.
+type GetHistoricalDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*GetHistoricalDataRequest_Symbol
+	//	*GetHistoricalDataRequest_Conid
+	Instrument isGetHistoricalDataRequest_Instrument `protobuf_oneof:"instrument"`
+	Period     string                                `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                  // e.g., "1d", "1w", "1m"
+	BarSize    string                                `protobuf:"bytes,3,opt,name=bar_size,json=barSize,proto3" json:"bar_size,omitempty"` // e.g., "1min", "5min", "1hour", "1day"
+	// Page size: the most bars returned, the most recent of the period first.
+	// Defaults to 10000.
+	Limit *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,5,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,6,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,7,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry *string `protobuf:"bytes,8,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	// Token of the page to return, from next_page_token of the previous response.
+	// The other fields must match the first request.
+	PageToken *string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	// Include bars outside regular trading hours.
+	OutsideRth    bool `protobuf:"varint,10,opt,name=outside_rth,json=outsideRth,proto3" json:"outside_rth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoricalDataRequest) Reset() {
+	*x = GetHistoricalDataRequest{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoricalDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoricalDataRequest) ProtoMessage() {}
+
+func (x *GetHistoricalDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoricalDataRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoricalDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetHistoricalDataRequest) GetInstrument() isGetHistoricalDataRequest_Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+func (x *GetHistoricalDataRequest) GetSymbol() string {
+	if x != nil {
+		if x, ok := x.Instrument.(*GetHistoricalDataRequest_Symbol); ok {
+			return x.Symbol
+		}
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*GetHistoricalDataRequest_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
+func (x *GetHistoricalDataRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetBarSize() string {
+	if x != nil {
+		return x.BarSize
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetHistoricalDataRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataRequest) GetOutsideRth() bool {
+	if x != nil {
+		return x.OutsideRth
+	}
+	return false
+}
+
+type isGetHistoricalDataRequest_Instrument interface {
+	isGetHistoricalDataRequest_Instrument()
+}
+
+type GetHistoricalDataRequest_Symbol struct {
+	// Symbol of the instrument, as in GetQuoteRequest.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3,oneof"`
+}
+
+type GetHistoricalDataRequest_Conid struct {
+	// Contract ID of the instrument. The contract is used as is, without a search, and
+	// the listing fields below are ignored.
+	Conid int64 `protobuf:"varint,11,opt,name=conid,proto3,oneof"`
+}
+
+func (*GetHistoricalDataRequest_Symbol) isGetHistoricalDataRequest_Instrument() {}
+
+func (*GetHistoricalDataRequest_Conid) isGetHistoricalDataRequest_Instrument() {}
+
+// GetHistoricalDataResponse contains a page of historical data bars, oldest first.
+type GetHistoricalDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Bars  []*Bar                 `protobuf:"bytes,1,rep,name=bars,proto3" json:"bars,omitempty"`
+	// Token of the page of older bars, empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Whether the Gateway served delayed rather than real-time data, e.g. without a market
+	// data subscription.
+	Delayed bool `protobuf:"varint,3,opt,name=delayed,proto3" json:"delayed,omitempty"`
+	// Whether the bars include trading outside regular trading hours.
+	OutsideRth    bool `protobuf:"varint,4,opt,name=outside_rth,json=outsideRth,proto3" json:"outside_rth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoricalDataResponse) Reset() {
+	*x = GetHistoricalDataResponse{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoricalDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoricalDataResponse) ProtoMessage() {}
+
+func (x *GetHistoricalDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoricalDataResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoricalDataResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetHistoricalDataResponse) GetBars() []*Bar {
+	if x != nil {
+		return x.Bars
+	}
+	return nil
+}
+
+func (x *GetHistoricalDataResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetHistoricalDataResponse) GetDelayed() bool {
+	if x != nil {
+		return x.Delayed
+	}
+	return false
+}
+
+func (x *GetHistoricalDataResponse) GetOutsideRth() bool {
+	if x != nil {
+		return x.OutsideRth
+	}
+	return false
+}
+
+// Bar represents a historical price bar. Prices are in the currency of the instrument and
+// volumes in units traded, already scaled by the price and volume factors of the Gateway.
+type Bar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the bar.
+	OpenedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        int64                  `protobuf:"varint,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bar) Reset() {
+	*x = Bar{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bar) ProtoMessage() {}
+
+func (x *Bar) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bar.ProtoReflect.Descriptor instead.
+func (*Bar) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Bar) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *Bar) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Bar) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Bar) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Bar) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Bar) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+// GetIndicatorsRequest contains parameters for computing technical indicators.
+type GetIndicatorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*GetIndicatorsRequest_Symbol
+	//	*GetIndicatorsRequest_Conid
+	Instrument isGetIndicatorsRequest_Instrument `protobuf_oneof:"instrument"`
+	// Lookback of the bars, e.g. "6m". The first values of each indicator are its warm-up,
+	// so the period should cover the longest indicator period.
+	Period string `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	// Size of the bars, e.g. "5min", "1h", "2h" or "3d". Sizes the Gateway does not serve
+	// are resampled from the largest smaller size that divides them, in buckets aligned to
+	// the Unix epoch in UTC.
+	BarSize string `protobuf:"bytes,3,opt,name=bar_size,json=barSize,proto3" json:"bar_size,omitempty"`
+	// Indicators to compute, each returned in the same order.
+	Indicators []*IndicatorSpec `protobuf:"bytes,4,rep,name=indicators,proto3" json:"indicators,omitempty"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,5,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,6,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,7,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry *string `protobuf:"bytes,8,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	// Include bars outside regular trading hours.
+	OutsideRth    bool `protobuf:"varint,9,opt,name=outside_rth,json=outsideRth,proto3" json:"outside_rth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIndicatorsRequest) Reset() {
+	*x = GetIndicatorsRequest{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndicatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndicatorsRequest) ProtoMessage() {}
+
+func (x *GetIndicatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndicatorsRequest.ProtoReflect.Descriptor instead.
+func (*GetIndicatorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetIndicatorsRequest) GetInstrument() isGetIndicatorsRequest_Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+func (x *GetIndicatorsRequest) GetSymbol() string {
+	if x != nil {
+		if x, ok := x.Instrument.(*GetIndicatorsRequest_Symbol); ok {
+			return x.Symbol
+		}
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*GetIndicatorsRequest_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
+func (x *GetIndicatorsRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetBarSize() string {
+	if x != nil {
+		return x.BarSize
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetIndicators() []*IndicatorSpec {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+func (x *GetIndicatorsRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+func (x *GetIndicatorsRequest) GetOutsideRth() bool {
+	if x != nil {
+		return x.OutsideRth
+	}
+	return false
+}
+
+type isGetIndicatorsRequest_Instrument interface {
+	isGetIndicatorsRequest_Instrument()
+}
+
+type GetIndicatorsRequest_Symbol struct {
+	// Symbol of the instrument, as in GetQuoteRequest.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3,oneof"`
+}
+
+type GetIndicatorsRequest_Conid struct {
+	// Contract ID of the instrument. The contract is used as is, without a search, and
+	// the listing fields below are ignored.
+	Conid int64 `protobuf:"varint,10,opt,name=conid,proto3,oneof"`
+}
+
+func (*GetIndicatorsRequest_Symbol) isGetIndicatorsRequest_Instrument() {}
+
+func (*GetIndicatorsRequest_Conid) isGetIndicatorsRequest_Instrument() {}
+
+// IndicatorSpec is an indicator and its parameters. Parameters an indicator does not
+// take are ignored.
+type IndicatorSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  IndicatorType          `protobuf:"varint,1,opt,name=type,proto3,enum=api.ibkr.marketdata.v1.IndicatorType" json:"type,omitempty"`
+	// Number of bars of SMA, EMA, RSI, ATR and Bollinger.
+	Period *int32 `protobuf:"varint,2,opt,name=period,proto3,oneof" json:"period,omitempty"`
+	// Period of the fast EMA of MACD.
+	FastPeriod *int32 `protobuf:"varint,3,opt,name=fast_period,json=fastPeriod,proto3,oneof" json:"fast_period,omitempty"`
+	// Period of the slow EMA of MACD.
+	SlowPeriod *int32 `protobuf:"varint,4,opt,name=slow_period,json=slowPeriod,proto3,oneof" json:"slow_period,omitempty"`
+	// Period of the signal EMA of MACD.
+	SignalPeriod *int32 `protobuf:"varint,5,opt,name=signal_period,json=signalPeriod,proto3,oneof" json:"signal_period,omitempty"`
+	// Width of the Bollinger bands in standard deviations.
+	StdDev        *float64 `protobuf:"fixed64,6,opt,name=std_dev,json=stdDev,proto3,oneof" json:"std_dev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndicatorSpec) Reset() {
+	*x = IndicatorSpec{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndicatorSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicatorSpec) ProtoMessage() {}
+
+func (x *IndicatorSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicatorSpec.ProtoReflect.Descriptor instead.
+func (*IndicatorSpec) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IndicatorSpec) GetType() IndicatorType {
+	if x != nil {
+		return x.Type
+	}
+	return IndicatorType_INDICATOR_TYPE_UNSPECIFIED
+}
+
+func (x *IndicatorSpec) GetPeriod() int32 {
+	if x != nil && x.Period != nil {
+		return *x.Period
+	}
+	return 0
+}
+
+func (x *IndicatorSpec) GetFastPeriod() int32 {
+	if x != nil && x.FastPeriod != nil {
+		return *x.FastPeriod
+	}
+	return 0
+}
+
+func (x *IndicatorSpec) GetSlowPeriod() int32 {
+	if x != nil && x.SlowPeriod != nil {
+		return *x.SlowPeriod
+	}
+	return 0
+}
+
+func (x *IndicatorSpec) GetSignalPeriod() int32 {
+	if x != nil && x.SignalPeriod != nil {
+		return *x.SignalPeriod
+	}
+	return 0
+}
+
+func (x *IndicatorSpec) GetStdDev() float64 {
+	if x != nil && x.StdDev != nil {
+		return *x.StdDev
+	}
+	return 0
+}
+
+// GetIndicatorsResponse contains the bars and the indicators computed over them.
+type GetIndicatorsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bars the indicators were computed over, resampled to the requested size, oldest first.
+	Bars []*Bar `protobuf:"bytes,1,rep,name=bars,proto3" json:"bars,omitempty"`
+	// Indicators in the order requested.
+	Indicators []*Indicator `protobuf:"bytes,2,rep,name=indicators,proto3" json:"indicators,omitempty"`
+	// Whether the Gateway served delayed rather than real-time data.
+	Delayed       bool `protobuf:"varint,3,opt,name=delayed,proto3" json:"delayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIndicatorsResponse) Reset() {
+	*x = GetIndicatorsResponse{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndicatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndicatorsResponse) ProtoMessage() {}
+
+func (x *GetIndicatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndicatorsResponse.ProtoReflect.Descriptor instead.
+func (*GetIndicatorsResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetIndicatorsResponse) GetBars() []*Bar {
+	if x != nil {
+		return x.Bars
+	}
+	return nil
+}
+
+func (x *GetIndicatorsResponse) GetIndicators() []*Indicator {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+func (x *GetIndicatorsResponse) GetDelayed() bool {
+	if x != nil {
+		return x.Delayed
+	}
+	return false
+}
+
+// Indicator holds the series of an indicator.
+type Indicator struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Indicator and its parameters, with the defaults applied.
+	Spec *IndicatorSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	// Series of the indicator, e.g. a single "rsi" series or "macd", "signal" and "histogram".
+	Series        []*IndicatorSeries `protobuf:"bytes,2,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Indicator) Reset() {
+	*x = Indicator{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Indicator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Indicator) ProtoMessage() {}
+
+func (x *Indicator) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Indicator.ProtoReflect.Descriptor instead.
+func (*Indicator) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Indicator) GetSpec() *IndicatorSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Indicator) GetSeries() []*IndicatorSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// IndicatorSeries is a series of indicator values aligned with the bars: values[i] is the
+// value as of bars[i]. Values during the warm-up of the indicator are NaN.
+type IndicatorSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []float64              `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndicatorSeries) Reset() {
+	*x = IndicatorSeries{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndicatorSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicatorSeries) ProtoMessage() {}
+
+func (x *IndicatorSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicatorSeries.ProtoReflect.Descriptor instead.
+func (*IndicatorSeries) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IndicatorSeries) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndicatorSeries) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// StreamQuotesRequest contains parameters for streaming quotes. The instrument is
+// given either by symbol and the fields next to it, by conid, or as a list in instruments.
+type StreamQuotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*StreamQuotesRequest_Symbol
+	//	*StreamQuotesRequest_Conid
+	Instrument isStreamQuotesRequest_Instrument `protobuf_oneof:"instrument"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,2,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,3,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,4,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry *string `protobuf:"bytes,5,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	// Instruments to stream, instead of symbol or conid.
+	Instruments []*QuoteInstrument `protobuf:"bytes,6,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	// Minimum interval between two quotes of an instrument, in milliseconds. It is clamped
+	// to the server bounds; the server default is used when omitted.
+	IntervalMs *int32 `protobuf:"varint,7,opt,name=interval_ms,json=intervalMs,proto3,oneof" json:"interval_ms,omitempty"`
+	// Send only the changed fields of a quote, with a full quote periodically.
+	Delta         bool `protobuf:"varint,8,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamQuotesRequest) Reset() {
+	*x = StreamQuotesRequest{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQuotesRequest) ProtoMessage() {}
+
+func (x *StreamQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamQuotesRequest.ProtoReflect.Descriptor instead.
+func (*StreamQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamQuotesRequest) GetInstrument() isStreamQuotesRequest_Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+func (x *StreamQuotesRequest) GetSymbol() string {
+	if x != nil {
+		if x, ok := x.Instrument.(*StreamQuotesRequest_Symbol); ok {
+			return x.Symbol
+		}
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*StreamQuotesRequest_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
+func (x *StreamQuotesRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+func (x *StreamQuotesRequest) GetInstruments() []*QuoteInstrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+func (x *StreamQuotesRequest) GetIntervalMs() int32 {
+	if x != nil && x.IntervalMs != nil {
+		return *x.IntervalMs
+	}
+	return 0
+}
+
+func (x *StreamQuotesRequest) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+type isStreamQuotesRequest_Instrument interface {
+	isStreamQuotesRequest_Instrument()
+}
+
+type StreamQuotesRequest_Symbol struct {
+	// Symbol of the instrument, as in GetQuoteRequest.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3,oneof"`
+}
+
+type StreamQuotesRequest_Conid struct {
+	// Contract ID of the instrument, as in GetQuoteRequest.
+	Conid int64 `protobuf:"varint,9,opt,name=conid,proto3,oneof"`
+}
+
+func (*StreamQuotesRequest_Symbol) isStreamQuotesRequest_Instrument() {}
+
+func (*StreamQuotesRequest_Conid) isStreamQuotesRequest_Instrument() {}
+
+// StreamQuotesResponse contains a streaming quote, or a heartbeat.
+type StreamQuotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Quote of an instrument. In delta mode, partial quotes only set the symbol and the
+	// fields listed in changed_fields.
+	Quote *Quote `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Names of the quote fields set in a partial quote, e.g. "bid" or "last".
+	ChangedFields []string `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// Whether quote is a full quote. Always true outside delta mode.
+	Full bool `protobuf:"varint,3,opt,name=full,proto3" json:"full,omitempty"`
+	// Heartbeat sent when no quote was sent for the heartbeat interval, so that a quiet
+	// market can be told apart from a dead stream. Quote is unset.
+	Heartbeat     bool `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamQuotesResponse) Reset() {
+	*x = StreamQuotesResponse{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamQuotesResponse) ProtoMessage() {}
+
+func (x *StreamQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
 // Deprecated: Use StreamQuotesResponse.ProtoReflect.Descriptor instead.
 func (*StreamQuotesResponse) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{7}
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StreamQuotesResponse) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *StreamQuotesResponse) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *StreamQuotesResponse) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *StreamQuotesResponse) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+// GetOptionChainRequest contains parameters for retrieving an option chain.
+type GetOptionChainRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Symbol of the underlying, as in GetQuoteRequest. Option months are listed by the
+	// symbol search, so the underlying cannot be given by conid.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Listing exchange used to pick among listings of the underlying, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,2,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the underlying, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,3,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the underlying. Defaults to "STK".
+	SecType *string `protobuf:"bytes,4,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month in the Gateway format, e.g. "JAN24". Defaults to the nearest month.
+	Month *string `protobuf:"bytes,5,opt,name=month,proto3,oneof" json:"month,omitempty"`
+	// Expiration date (YYYYMMDD) used to pick one expiration within the month.
+	Expiration *string `protobuf:"bytes,6,opt,name=expiration,proto3,oneof" json:"expiration,omitempty"`
+	// Option right, "C" for calls or "P" for puts. Both are returned when omitted.
+	Right *string `protobuf:"bytes,7,opt,name=right,proto3,oneof" json:"right,omitempty"`
+	// Lowest strike to return.
+	MinStrike *float64 `protobuf:"fixed64,8,opt,name=min_strike,json=minStrike,proto3,oneof" json:"min_strike,omitempty"`
+	// Highest strike to return.
+	MaxStrike     *float64 `protobuf:"fixed64,9,opt,name=max_strike,json=maxStrike,proto3,oneof" json:"max_strike,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOptionChainRequest) Reset() {
+	*x = GetOptionChainRequest{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOptionChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOptionChainRequest) ProtoMessage() {}
+
+func (x *GetOptionChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOptionChainRequest.ProtoReflect.Descriptor instead.
+func (*GetOptionChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOptionChainRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
 }
 
-func (x *StreamQuotesResponse) GetQuote() *Quote {
+func (x *GetOptionChainRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetMonth() string {
+	if x != nil && x.Month != nil {
+		return *x.Month
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetExpiration() string {
+	if x != nil && x.Expiration != nil {
+		return *x.Expiration
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetRight() string {
+	if x != nil && x.Right != nil {
+		return *x.Right
+	}
+	return ""
+}
+
+func (x *GetOptionChainRequest) GetMinStrike() float64 {
+	if x != nil && x.MinStrike != nil {
+		return *x.MinStrike
+	}
+	return 0
+}
+
+func (x *GetOptionChainRequest) GetMaxStrike() float64 {
+	if x != nil && x.MaxStrike != nil {
+		return *x.MaxStrike
+	}
+	return 0
+}
+
+// GetOptionChainResponse contains an option chain.
+type GetOptionChainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Symbol of the underlying.
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Contract ID of the underlying.
+	UnderlyingConid int64 `protobuf:"varint,2,opt,name=underlying_conid,json=underlyingConid,proto3" json:"underlying_conid,omitempty"`
+	// Expiration months listed for the underlying, e.g. "JAN24".
+	Months []string `protobuf:"bytes,3,rep,name=months,proto3" json:"months,omitempty"`
+	// Expiration month of the returned contracts.
+	Month string `protobuf:"bytes,4,opt,name=month,proto3" json:"month,omitempty"`
+	// Expiration dates (YYYYMMDD) of the returned contracts.
+	Expirations []string `protobuf:"bytes,5,rep,name=expirations,proto3" json:"expirations,omitempty"`
+	// Strikes of the returned contracts, in ascending order.
+	Strikes []float64 `protobuf:"fixed64,6,rep,packed,name=strikes,proto3" json:"strikes,omitempty"`
+	// Option contracts ordered by expiration, strike and right.
+	Contracts     []*OptionContract `protobuf:"bytes,7,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOptionChainResponse) Reset() {
+	*x = GetOptionChainResponse{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOptionChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOptionChainResponse) ProtoMessage() {}
+
+func (x *GetOptionChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[19]
 	if x != nil {
-		return x.Quote
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOptionChainResponse.ProtoReflect.Descriptor instead.
+func (*GetOptionChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetOptionChainResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetOptionChainResponse) GetUnderlyingConid() int64 {
+	if x != nil {
+		return x.UnderlyingConid
+	}
+	return 0
+}
+
+func (x *GetOptionChainResponse) GetMonths() []string {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+func (x *GetOptionChainResponse) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *GetOptionChainResponse) GetExpirations() []string {
+	if x != nil {
+		return x.Expirations
+	}
+	return nil
+}
+
+func (x *GetOptionChainResponse) GetStrikes() []float64 {
+	if x != nil {
+		return x.Strikes
 	}
 	return nil
 }
 
+func (x *GetOptionChainResponse) GetContracts() []*OptionContract {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+// OptionContract represents an option contract of a chain.
+// Market data fields are omitted when the Gateway did not return them.
+type OptionContract struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Conid  int64                  `protobuf:"varint,1,opt,name=conid,proto3" json:"conid,omitempty"`
+	Symbol string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// "C" for a call, "P" for a put.
+	Right  string  `protobuf:"bytes,3,opt,name=right,proto3" json:"right,omitempty"`
+	Strike float64 `protobuf:"fixed64,4,opt,name=strike,proto3" json:"strike,omitempty"`
+	// Expiration date (YYYYMMDD).
+	Expiration   string   `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Multiplier   string   `protobuf:"bytes,6,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	TradingClass string   `protobuf:"bytes,7,opt,name=trading_class,json=tradingClass,proto3" json:"trading_class,omitempty"`
+	Bid          *float64 `protobuf:"fixed64,8,opt,name=bid,proto3,oneof" json:"bid,omitempty"`
+	Ask          *float64 `protobuf:"fixed64,9,opt,name=ask,proto3,oneof" json:"ask,omitempty"`
+	Last         *float64 `protobuf:"fixed64,10,opt,name=last,proto3,oneof" json:"last,omitempty"`
+	// Implied volatility, in percent.
+	ImpliedVolatility *float64 `protobuf:"fixed64,11,opt,name=implied_volatility,json=impliedVolatility,proto3,oneof" json:"implied_volatility,omitempty"`
+	Delta             *float64 `protobuf:"fixed64,12,opt,name=delta,proto3,oneof" json:"delta,omitempty"`
+	Gamma             *float64 `protobuf:"fixed64,13,opt,name=gamma,proto3,oneof" json:"gamma,omitempty"`
+	Theta             *float64 `protobuf:"fixed64,14,opt,name=theta,proto3,oneof" json:"theta,omitempty"`
+	Vega              *float64 `protobuf:"fixed64,15,opt,name=vega,proto3,oneof" json:"vega,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OptionContract) Reset() {
+	*x = OptionContract{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionContract) ProtoMessage() {}
+
+func (x *OptionContract) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionContract.ProtoReflect.Descriptor instead.
+func (*OptionContract) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OptionContract) GetConid() int64 {
+	if x != nil {
+		return x.Conid
+	}
+	return 0
+}
+
+func (x *OptionContract) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OptionContract) GetRight() string {
+	if x != nil {
+		return x.Right
+	}
+	return ""
+}
+
+func (x *OptionContract) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+func (x *OptionContract) GetExpiration() string {
+	if x != nil {
+		return x.Expiration
+	}
+	return ""
+}
+
+func (x *OptionContract) GetMultiplier() string {
+	if x != nil {
+		return x.Multiplier
+	}
+	return ""
+}
+
+func (x *OptionContract) GetTradingClass() string {
+	if x != nil {
+		return x.TradingClass
+	}
+	return ""
+}
+
+func (x *OptionContract) GetBid() float64 {
+	if x != nil && x.Bid != nil {
+		return *x.Bid
+	}
+	return 0
+}
+
+func (x *OptionContract) GetAsk() float64 {
+	if x != nil && x.Ask != nil {
+		return *x.Ask
+	}
+	return 0
+}
+
+func (x *OptionContract) GetLast() float64 {
+	if x != nil && x.Last != nil {
+		return *x.Last
+	}
+	return 0
+}
+
+func (x *OptionContract) GetImpliedVolatility() float64 {
+	if x != nil && x.ImpliedVolatility != nil {
+		return *x.ImpliedVolatility
+	}
+	return 0
+}
+
+func (x *OptionContract) GetDelta() float64 {
+	if x != nil && x.Delta != nil {
+		return *x.Delta
+	}
+	return 0
+}
+
+func (x *OptionContract) GetGamma() float64 {
+	if x != nil && x.Gamma != nil {
+		return *x.Gamma
+	}
+	return 0
+}
+
+func (x *OptionContract) GetTheta() float64 {
+	if x != nil && x.Theta != nil {
+		return *x.Theta
+	}
+	return 0
+}
+
+func (x *OptionContract) GetVega() float64 {
+	if x != nil && x.Vega != nil {
+		return *x.Vega
+	}
+	return 0
+}
+
+// InvalidateHistoricalCacheRequest identifies the contract whose cached bars are removed.
+type InvalidateHistoricalCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conid         int64                  `protobuf:"varint,1,opt,name=conid,proto3" json:"conid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateHistoricalCacheRequest) Reset() {
+	*x = InvalidateHistoricalCacheRequest{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateHistoricalCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateHistoricalCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateHistoricalCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateHistoricalCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateHistoricalCacheRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InvalidateHistoricalCacheRequest) GetConid() int64 {
+	if x != nil {
+		return x.Conid
+	}
+	return 0
+}
+
+// InvalidateHistoricalCacheResponse reports how many cached bars were removed.
+type InvalidateHistoricalCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedBars   int64                  `protobuf:"varint,1,opt,name=deleted_bars,json=deletedBars,proto3" json:"deleted_bars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateHistoricalCacheResponse) Reset() {
+	*x = InvalidateHistoricalCacheResponse{}
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateHistoricalCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateHistoricalCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateHistoricalCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateHistoricalCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateHistoricalCacheResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *InvalidateHistoricalCacheResponse) GetDeletedBars() int64 {
+	if x != nil {
+		return x.DeletedBars
+	}
+	return 0
+}
+
 var File_api_ibkr_marketdata_v1_market_data_proto protoreflect.FileDescriptor
 
 const file_api_ibkr_marketdata_v1_market_data_proto_rawDesc = "" +
 	"\n" +
-	"(api/ibkr/marketdata/v1/market_data.proto\x12\x16api.ibkr.marketdata.v1\x1a\x1bbuf/validate/validate.proto\"A\n" +
-	"\x0fGetQuoteRequest\x12.\n" +
-	"\x06symbol\x18\x01 \x01(\tB\x16\xbaH\x13r\x11\x10\x01\x18\x142\v^[A-Z0-9]+$R\x06symbol\"G\n" +
+	"(api/ibkr/marketdata/v1/market_data.proto\x12\x16api.ibkr.marketdata.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x03\n" +
+	"\x0fGetQuoteRequest\x12A\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\a \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x128\n" +
+	"\bexchange\x18\x02 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x01R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x03 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x02R\bcurrency\x88\x01\x01\x12:\n" +
+	"\bsec_type\x18\x04 \x01(\tB\x1a\xbaH\x17r\x15R\x03STKR\x03INDR\x04BONDR\x03FUTH\x03R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\x05 \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x04R\x06expiry\x88\x01\x01\x124\n" +
+	"\x06fields\x18\x06 \x03(\tB\x1c\xbaH\x19\x92\x01\x16\x102\x18\x01\"\x10r\x0e2\f^[a-z0-9_]+$R\x06fieldsB\x13\n" +
+	"\n" +
+	"instrument\x12\x05\xbaH\x02\b\x01B\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiry\"G\n" +
 	"\x10GetQuoteResponse\x123\n" +
-	"\x05quote\x18\x01 \x01(\v2\x1d.api.ibkr.marketdata.v1.QuoteR\x05quote\"\xdd\x01\n" +
+	"\x05quote\x18\x01 \x01(\v2\x1d.api.ibkr.marketdata.v1.QuoteR\x05quote\"\x9f\x03\n" +
 	"\x05Quote\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x10\n" +
 	"\x03bid\x18\x02 \x01(\x01R\x03bid\x12\x10\n" +
@@ -536,35 +2282,249 @@ const file_api_ibkr_marketdata_v1_market_data_proto_rawDesc = "" +
 	"\x04high\x18\x06 \x01(\x01R\x04high\x12\x10\n" +
 	"\x03low\x18\a \x01(\x01R\x03low\x12\x12\n" +
 	"\x04open\x18\b \x01(\x01R\x04open\x12\x14\n" +
-	"\x05close\x18\t \x01(\x01R\x05close\x12\x1c\n" +
-	"\ttimestamp\x18\n" +
-	" \x01(\tR\ttimestamp\"\xc4\x01\n" +
-	"\x18GetHistoricalDataRequest\x12.\n" +
-	"\x06symbol\x18\x01 \x01(\tB\x16\xbaH\x13r\x11\x10\x01\x18\x142\v^[A-Z0-9]+$R\x06symbol\x12!\n" +
+	"\x05close\x18\t \x01(\x01R\x05close\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12A\n" +
+	"\x06fields\x18\v \x03(\v2).api.ibkr.marketdata.v1.Quote.FieldsEntryR\x06fields\x12\x14\n" +
+	"\x05conid\x18\r \x01(\x03R\x05conid\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\n" +
+	"\x10\vR\ttimestamp\"\xa0\x01\n" +
+	"\x10GetQuotesRequest\x12V\n" +
+	"\vinstruments\x18\x01 \x03(\v2'.api.ibkr.marketdata.v1.QuoteInstrumentB\v\xbaH\b\x92\x01\x05\b\x01\x10\xf4\x03R\vinstruments\x124\n" +
+	"\x06fields\x18\x02 \x03(\tB\x1c\xbaH\x19\x92\x01\x16\x102\x18\x01\"\x10r\x0e2\f^[a-z0-9_]+$R\x06fields\"\xa1\x03\n" +
+	"\x0fQuoteInstrument\x12A\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\x06 \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x128\n" +
+	"\bexchange\x18\x02 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x01R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x03 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x02R\bcurrency\x88\x01\x01\x12:\n" +
+	"\bsec_type\x18\x04 \x01(\tB\x1a\xbaH\x17r\x15R\x03STKR\x03INDR\x04BONDR\x03FUTH\x03R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\x05 \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x04R\x06expiry\x88\x01\x01B\x13\n" +
+	"\n" +
+	"instrument\x12\x05\xbaH\x02\b\x01B\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiry\"R\n" +
+	"\x11GetQuotesResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.api.ibkr.marketdata.v1.QuoteResultR\aresults\"\xb8\x01\n" +
+	"\vQuoteResult\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05conid\x18\x04 \x01(\x03R\x05conid\x125\n" +
+	"\x05quote\x18\x02 \x01(\v2\x1d.api.ibkr.marketdata.v1.QuoteH\x00R\x05quote\x12:\n" +
+	"\x05error\x18\x03 \x01(\v2\".api.ibkr.marketdata.v1.QuoteErrorH\x00R\x05errorB\b\n" +
+	"\x06result\":\n" +
+	"\n" +
+	"QuoteError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x82\x05\n" +
+	"\x18GetHistoricalDataRequest\x12A\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\v \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x12!\n" +
 	"\x06period\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18\n" +
 	"R\x06period\x12$\n" +
 	"\bbar_size\x18\x03 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18\n" +
 	"R\abarSize\x12%\n" +
 	"\x05limit\x18\x04 \x01(\x05B\n" +
-	"\xbaH\a\x1a\x05\x18\x90N(\x01H\x00R\x05limit\x88\x01\x01B\b\n" +
-	"\x06_limit\"L\n" +
+	"\xbaH\a\x1a\x05\x18\x90N(\x01H\x01R\x05limit\x88\x01\x01\x128\n" +
+	"\bexchange\x18\x05 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x02R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x06 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x03R\bcurrency\x88\x01\x01\x12:\n" +
+	"\bsec_type\x18\a \x01(\tB\x1a\xbaH\x17r\x15R\x03STKR\x03INDR\x04BONDR\x03FUTH\x04R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\b \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x05R\x06expiry\x88\x01\x01\x12,\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tB\b\xbaH\x05r\x03\x18\xc8\x01H\x06R\tpageToken\x88\x01\x01\x12\x1f\n" +
+	"\voutside_rth\x18\n" +
+	" \x01(\bR\n" +
+	"outsideRthB\x13\n" +
+	"\n" +
+	"instrument\x12\x05\xbaH\x02\b\x01B\b\n" +
+	"\x06_limitB\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiryB\r\n" +
+	"\v_page_token\"\xaf\x01\n" +
 	"\x19GetHistoricalDataResponse\x12/\n" +
-	"\x04bars\x18\x01 \x03(\v2\x1b.api.ibkr.marketdata.v1.BarR\x04bars\"\x8b\x01\n" +
-	"\x03Bar\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x12\n" +
+	"\x04bars\x18\x01 \x03(\v2\x1b.api.ibkr.marketdata.v1.BarR\x04bars\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\adelayed\x18\x03 \x01(\bR\adelayed\x12\x1f\n" +
+	"\voutside_rth\x18\x04 \x01(\bR\n" +
+	"outsideRth\"\xb7\x01\n" +
+	"\x03Bar\x127\n" +
+	"\topened_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\x12\x12\n" +
 	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
 	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
 	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
 	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x16\n" +
-	"\x06volume\x18\x06 \x01(\x03R\x06volume\"E\n" +
-	"\x13StreamQuotesRequest\x12.\n" +
-	"\x06symbol\x18\x01 \x01(\tB\x16\xbaH\x13r\x11\x10\x01\x18\x142\v^[A-Z0-9]+$R\x06symbol\"K\n" +
+	"\x06volume\x18\x06 \x01(\x03R\x06volumeJ\x04\b\x01\x10\x02R\ttimestamp\"\xfd\x04\n" +
+	"\x14GetIndicatorsRequest\x12A\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\n" +
+	" \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x12!\n" +
+	"\x06period\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18\n" +
+	"R\x06period\x12>\n" +
+	"\bbar_size\x18\x03 \x01(\tB#\xbaH r\x1e2\x1c^[1-9][0-9]{0,3}(min|h|d|w)$R\abarSize\x12Q\n" +
+	"\n" +
+	"indicators\x18\x04 \x03(\v2%.api.ibkr.marketdata.v1.IndicatorSpecB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10\x14R\n" +
+	"indicators\x128\n" +
+	"\bexchange\x18\x05 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x01R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x06 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x02R\bcurrency\x88\x01\x01\x12:\n" +
+	"\bsec_type\x18\a \x01(\tB\x1a\xbaH\x17r\x15R\x03STKR\x03INDR\x04BONDR\x03FUTH\x03R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\b \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x04R\x06expiry\x88\x01\x01\x12\x1f\n" +
+	"\voutside_rth\x18\t \x01(\bR\n" +
+	"outsideRthB\x13\n" +
+	"\n" +
+	"instrument\x12\x05\xbaH\x02\b\x01B\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiry\"\xc4\x04\n" +
+	"\rIndicatorSpec\x12E\n" +
+	"\x04type\x18\x01 \x01(\x0e2%.api.ibkr.marketdata.v1.IndicatorTypeB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04type\x12'\n" +
+	"\x06period\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01H\x00R\x06period\x88\x01\x01\x120\n" +
+	"\vfast_period\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01H\x01R\n" +
+	"fastPeriod\x88\x01\x01\x120\n" +
+	"\vslow_period\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01H\x02R\n" +
+	"slowPeriod\x88\x01\x01\x124\n" +
+	"\rsignal_period\x18\x05 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01H\x03R\fsignalPeriod\x88\x01\x01\x125\n" +
+	"\astd_dev\x18\x06 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00$@!\x00\x00\x00\x00\x00\x00\x00\x00H\x04R\x06stdDev\x88\x01\x01:\xa8\x01\xbaH\xa4\x01\x1a\xa1\x01\n" +
+	"\x1bindicator_spec.macd_periods\x12)fast_period must be less than slow_period\x1aW!has(this.fast_period) || !has(this.slow_period) || this.fast_period < this.slow_periodB\t\n" +
+	"\a_periodB\x0e\n" +
+	"\f_fast_periodB\x0e\n" +
+	"\f_slow_periodB\x10\n" +
+	"\x0e_signal_periodB\n" +
+	"\n" +
+	"\b_std_dev\"\xa5\x01\n" +
+	"\x15GetIndicatorsResponse\x12/\n" +
+	"\x04bars\x18\x01 \x03(\v2\x1b.api.ibkr.marketdata.v1.BarR\x04bars\x12A\n" +
+	"\n" +
+	"indicators\x18\x02 \x03(\v2!.api.ibkr.marketdata.v1.IndicatorR\n" +
+	"indicators\x12\x18\n" +
+	"\adelayed\x18\x03 \x01(\bR\adelayed\"\x87\x01\n" +
+	"\tIndicator\x129\n" +
+	"\x04spec\x18\x01 \x01(\v2%.api.ibkr.marketdata.v1.IndicatorSpecR\x04spec\x12?\n" +
+	"\x06series\x18\x02 \x03(\v2'.api.ibkr.marketdata.v1.IndicatorSeriesR\x06series\"=\n" +
+	"\x0fIndicatorSeries\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\"\xec\x05\n" +
+	"\x13StreamQuotesRequest\x12A\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\t \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x128\n" +
+	"\bexchange\x18\x02 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x01R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x03 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x02R\bcurrency\x88\x01\x01\x12:\n" +
+	"\bsec_type\x18\x04 \x01(\tB\x1a\xbaH\x17r\x15R\x03STKR\x03INDR\x04BONDR\x03FUTH\x03R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\x05 \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x04R\x06expiry\x88\x01\x01\x12S\n" +
+	"\vinstruments\x18\x06 \x03(\v2'.api.ibkr.marketdata.v1.QuoteInstrumentB\b\xbaH\x05\x92\x01\x02\x10dR\vinstruments\x12-\n" +
+	"\vinterval_ms\x18\a \x01(\x05B\a\xbaH\x04\x1a\x02 \x00H\x05R\n" +
+	"intervalMs\x88\x01\x01\x12\x14\n" +
+	"\x05delta\x18\b \x01(\bR\x05delta:\xa1\x01\xbaH\x9d\x01\x1a\x9a\x01\n" +
+	"\x18stream_quotes.instrument\x127exactly one of symbol, conid or instruments must be set\x1aE(has(this.symbol) || has(this.conid)) != (size(this.instruments) > 0)B\f\n" +
+	"\n" +
+	"instrumentB\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiryB\x0e\n" +
+	"\f_interval_ms\"\xa4\x01\n" +
 	"\x14StreamQuotesResponse\x123\n" +
-	"\x05quote\x18\x01 \x01(\v2\x1d.api.ibkr.marketdata.v1.QuoteR\x05quote2\xd9\x02\n" +
+	"\x05quote\x18\x01 \x01(\v2\x1d.api.ibkr.marketdata.v1.QuoteR\x05quote\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\x12\x12\n" +
+	"\x04full\x18\x03 \x01(\bR\x04full\x12\x1c\n" +
+	"\theartbeat\x18\x04 \x01(\bR\theartbeat\"\xdd\x04\n" +
+	"\x15GetOptionChainRequest\x12?\n" +
+	"\x06symbol\x18\x01 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$R\x06symbol\x128\n" +
+	"\bexchange\x18\x02 \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x00R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\x03 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x01R\bcurrency\x88\x01\x01\x12/\n" +
+	"\bsec_type\x18\x04 \x01(\tB\x0f\xbaH\fr\n" +
+	"R\x03STKR\x03INDH\x02R\asecType\x88\x01\x01\x124\n" +
+	"\x05month\x18\x05 \x01(\tB\x19\xbaH\x16r\x142\x12^[A-Z]{3}[0-9]{2}$H\x03R\x05month\x88\x01\x01\x126\n" +
+	"\n" +
+	"expiration\x18\x06 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[0-9]{8}$H\x04R\n" +
+	"expiration\x88\x01\x01\x12&\n" +
+	"\x05right\x18\a \x01(\tB\v\xbaH\br\x06R\x01CR\x01PH\x05R\x05right\x88\x01\x01\x122\n" +
+	"\n" +
+	"min_strike\x18\b \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00H\x06R\tminStrike\x88\x01\x01\x122\n" +
+	"\n" +
+	"max_strike\x18\t \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\aR\tmaxStrike\x88\x01\x01B\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\b\n" +
+	"\x06_monthB\r\n" +
+	"\v_expirationB\b\n" +
+	"\x06_rightB\r\n" +
+	"\v_min_strikeB\r\n" +
+	"\v_max_strike\"\x8b\x02\n" +
+	"\x16GetOptionChainResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12)\n" +
+	"\x10underlying_conid\x18\x02 \x01(\x03R\x0funderlyingConid\x12\x16\n" +
+	"\x06months\x18\x03 \x03(\tR\x06months\x12\x14\n" +
+	"\x05month\x18\x04 \x01(\tR\x05month\x12 \n" +
+	"\vexpirations\x18\x05 \x03(\tR\vexpirations\x12\x18\n" +
+	"\astrikes\x18\x06 \x03(\x01R\astrikes\x12D\n" +
+	"\tcontracts\x18\a \x03(\v2&.api.ibkr.marketdata.v1.OptionContractR\tcontracts\"\x8d\x04\n" +
+	"\x0eOptionContract\x12\x14\n" +
+	"\x05conid\x18\x01 \x01(\x03R\x05conid\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05right\x18\x03 \x01(\tR\x05right\x12\x16\n" +
+	"\x06strike\x18\x04 \x01(\x01R\x06strike\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\x05 \x01(\tR\n" +
+	"expiration\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x06 \x01(\tR\n" +
+	"multiplier\x12#\n" +
+	"\rtrading_class\x18\a \x01(\tR\ftradingClass\x12\x15\n" +
+	"\x03bid\x18\b \x01(\x01H\x00R\x03bid\x88\x01\x01\x12\x15\n" +
+	"\x03ask\x18\t \x01(\x01H\x01R\x03ask\x88\x01\x01\x12\x17\n" +
+	"\x04last\x18\n" +
+	" \x01(\x01H\x02R\x04last\x88\x01\x01\x122\n" +
+	"\x12implied_volatility\x18\v \x01(\x01H\x03R\x11impliedVolatility\x88\x01\x01\x12\x19\n" +
+	"\x05delta\x18\f \x01(\x01H\x04R\x05delta\x88\x01\x01\x12\x19\n" +
+	"\x05gamma\x18\r \x01(\x01H\x05R\x05gamma\x88\x01\x01\x12\x19\n" +
+	"\x05theta\x18\x0e \x01(\x01H\x06R\x05theta\x88\x01\x01\x12\x17\n" +
+	"\x04vega\x18\x0f \x01(\x01H\aR\x04vega\x88\x01\x01B\x06\n" +
+	"\x04_bidB\x06\n" +
+	"\x04_askB\a\n" +
+	"\x05_lastB\x15\n" +
+	"\x13_implied_volatilityB\b\n" +
+	"\x06_deltaB\b\n" +
+	"\x06_gammaB\b\n" +
+	"\x06_thetaB\a\n" +
+	"\x05_vega\"A\n" +
+	" InvalidateHistoricalCacheRequest\x12\x1d\n" +
+	"\x05conid\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x05conid\"F\n" +
+	"!InvalidateHistoricalCacheResponse\x12!\n" +
+	"\fdeleted_bars\x18\x01 \x01(\x03R\vdeletedBars*\xdf\x01\n" +
+	"\rIndicatorType\x12\x1e\n" +
+	"\x1aINDICATOR_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12INDICATOR_TYPE_SMA\x10\x01\x12\x16\n" +
+	"\x12INDICATOR_TYPE_EMA\x10\x02\x12\x16\n" +
+	"\x12INDICATOR_TYPE_RSI\x10\x03\x12\x17\n" +
+	"\x13INDICATOR_TYPE_MACD\x10\x04\x12\x16\n" +
+	"\x12INDICATOR_TYPE_ATR\x10\x05\x12\x1c\n" +
+	"\x18INDICATOR_TYPE_BOLLINGER\x10\x06\x12\x17\n" +
+	"\x13INDICATOR_TYPE_VWAP\x10\a2\xad\x06\n" +
 	"\x11MarketDataService\x12]\n" +
-	"\bGetQuote\x12'.api.ibkr.marketdata.v1.GetQuoteRequest\x1a(.api.ibkr.marketdata.v1.GetQuoteResponse\x12x\n" +
-	"\x11GetHistoricalData\x120.api.ibkr.marketdata.v1.GetHistoricalDataRequest\x1a1.api.ibkr.marketdata.v1.GetHistoricalDataResponse\x12k\n" +
-	"\fStreamQuotes\x12+.api.ibkr.marketdata.v1.StreamQuotesRequest\x1a,.api.ibkr.marketdata.v1.StreamQuotesResponse0\x01B\xfd\x01\n" +
+	"\bGetQuote\x12'.api.ibkr.marketdata.v1.GetQuoteRequest\x1a(.api.ibkr.marketdata.v1.GetQuoteResponse\x12`\n" +
+	"\tGetQuotes\x12(.api.ibkr.marketdata.v1.GetQuotesRequest\x1a).api.ibkr.marketdata.v1.GetQuotesResponse\x12x\n" +
+	"\x11GetHistoricalData\x120.api.ibkr.marketdata.v1.GetHistoricalDataRequest\x1a1.api.ibkr.marketdata.v1.GetHistoricalDataResponse\x12l\n" +
+	"\rGetIndicators\x12,.api.ibkr.marketdata.v1.GetIndicatorsRequest\x1a-.api.ibkr.marketdata.v1.GetIndicatorsResponse\x12k\n" +
+	"\fStreamQuotes\x12+.api.ibkr.marketdata.v1.StreamQuotesRequest\x1a,.api.ibkr.marketdata.v1.StreamQuotesResponse0\x01\x12o\n" +
+	"\x0eGetOptionChain\x12-.api.ibkr.marketdata.v1.GetOptionChainRequest\x1a..api.ibkr.marketdata.v1.GetOptionChainResponse\x12\x90\x01\n" +
+	"\x19InvalidateHistoricalCache\x128.api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest\x1a9.api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponseB\xfd\x01\n" +
 	"\x1acom.api.ibkr.marketdata.v1B\x0fMarketDataProtoP\x01ZSgithub.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/marketdata/v1;marketdatav1\xa2\x02\x03AIM\xaa\x02\x16Api.Ibkr.Marketdata.V1\xca\x02\x16Api\\Ibkr\\Marketdata\\V1\xe2\x02\"Api\\Ibkr\\Marketdata\\V1\\GPBMetadata\xea\x02\x19Api::Ibkr::Marketdata::V1b\x06proto3"
 
 var (
@@ -579,32 +2539,74 @@ func file_api_ibkr_marketdata_v1_market_data_proto_rawDescGZIP() []byte {
 	return file_api_ibkr_marketdata_v1_market_data_proto_rawDescData
 }
 
-var file_api_ibkr_marketdata_v1_market_data_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_ibkr_marketdata_v1_market_data_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_ibkr_marketdata_v1_market_data_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_api_ibkr_marketdata_v1_market_data_proto_goTypes = []any{
-	(*GetQuoteRequest)(nil),           // 0: api.ibkr.marketdata.v1.GetQuoteRequest
-	(*GetQuoteResponse)(nil),          // 1: api.ibkr.marketdata.v1.GetQuoteResponse
-	(*Quote)(nil),                     // 2: api.ibkr.marketdata.v1.Quote
-	(*GetHistoricalDataRequest)(nil),  // 3: api.ibkr.marketdata.v1.GetHistoricalDataRequest
-	(*GetHistoricalDataResponse)(nil), // 4: api.ibkr.marketdata.v1.GetHistoricalDataResponse
-	(*Bar)(nil),                       // 5: api.ibkr.marketdata.v1.Bar
-	(*StreamQuotesRequest)(nil),       // 6: api.ibkr.marketdata.v1.StreamQuotesRequest
-	(*StreamQuotesResponse)(nil),      // 7: api.ibkr.marketdata.v1.StreamQuotesResponse
+	(IndicatorType)(0),                        // 0: api.ibkr.marketdata.v1.IndicatorType
+	(*GetQuoteRequest)(nil),                   // 1: api.ibkr.marketdata.v1.GetQuoteRequest
+	(*GetQuoteResponse)(nil),                  // 2: api.ibkr.marketdata.v1.GetQuoteResponse
+	(*Quote)(nil),                             // 3: api.ibkr.marketdata.v1.Quote
+	(*GetQuotesRequest)(nil),                  // 4: api.ibkr.marketdata.v1.GetQuotesRequest
+	(*QuoteInstrument)(nil),                   // 5: api.ibkr.marketdata.v1.QuoteInstrument
+	(*GetQuotesResponse)(nil),                 // 6: api.ibkr.marketdata.v1.GetQuotesResponse
+	(*QuoteResult)(nil),                       // 7: api.ibkr.marketdata.v1.QuoteResult
+	(*QuoteError)(nil),                        // 8: api.ibkr.marketdata.v1.QuoteError
+	(*GetHistoricalDataRequest)(nil),          // 9: api.ibkr.marketdata.v1.GetHistoricalDataRequest
+	(*GetHistoricalDataResponse)(nil),         // 10: api.ibkr.marketdata.v1.GetHistoricalDataResponse
+	(*Bar)(nil),                               // 11: api.ibkr.marketdata.v1.Bar
+	(*GetIndicatorsRequest)(nil),              // 12: api.ibkr.marketdata.v1.GetIndicatorsRequest
+	(*IndicatorSpec)(nil),                     // 13: api.ibkr.marketdata.v1.IndicatorSpec
+	(*GetIndicatorsResponse)(nil),             // 14: api.ibkr.marketdata.v1.GetIndicatorsResponse
+	(*Indicator)(nil),                         // 15: api.ibkr.marketdata.v1.Indicator
+	(*IndicatorSeries)(nil),                   // 16: api.ibkr.marketdata.v1.IndicatorSeries
+	(*StreamQuotesRequest)(nil),               // 17: api.ibkr.marketdata.v1.StreamQuotesRequest
+	(*StreamQuotesResponse)(nil),              // 18: api.ibkr.marketdata.v1.StreamQuotesResponse
+	(*GetOptionChainRequest)(nil),             // 19: api.ibkr.marketdata.v1.GetOptionChainRequest
+	(*GetOptionChainResponse)(nil),            // 20: api.ibkr.marketdata.v1.GetOptionChainResponse
+	(*OptionContract)(nil),                    // 21: api.ibkr.marketdata.v1.OptionContract
+	(*InvalidateHistoricalCacheRequest)(nil),  // 22: api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest
+	(*InvalidateHistoricalCacheResponse)(nil), // 23: api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponse
+	nil,                           // 24: api.ibkr.marketdata.v1.Quote.FieldsEntry
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
 }
 var file_api_ibkr_marketdata_v1_market_data_proto_depIdxs = []int32{
-	2, // 0: api.ibkr.marketdata.v1.GetQuoteResponse.quote:type_name -> api.ibkr.marketdata.v1.Quote
-	5, // 1: api.ibkr.marketdata.v1.GetHistoricalDataResponse.bars:type_name -> api.ibkr.marketdata.v1.Bar
-	2, // 2: api.ibkr.marketdata.v1.StreamQuotesResponse.quote:type_name -> api.ibkr.marketdata.v1.Quote
-	0, // 3: api.ibkr.marketdata.v1.MarketDataService.GetQuote:input_type -> api.ibkr.marketdata.v1.GetQuoteRequest
-	3, // 4: api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData:input_type -> api.ibkr.marketdata.v1.GetHistoricalDataRequest
-	6, // 5: api.ibkr.marketdata.v1.MarketDataService.StreamQuotes:input_type -> api.ibkr.marketdata.v1.StreamQuotesRequest
-	1, // 6: api.ibkr.marketdata.v1.MarketDataService.GetQuote:output_type -> api.ibkr.marketdata.v1.GetQuoteResponse
-	4, // 7: api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData:output_type -> api.ibkr.marketdata.v1.GetHistoricalDataResponse
-	7, // 8: api.ibkr.marketdata.v1.MarketDataService.StreamQuotes:output_type -> api.ibkr.marketdata.v1.StreamQuotesResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3,  // 0: api.ibkr.marketdata.v1.GetQuoteResponse.quote:type_name -> api.ibkr.marketdata.v1.Quote
+	25, // 1: api.ibkr.marketdata.v1.Quote.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 2: api.ibkr.marketdata.v1.Quote.fields:type_name -> api.ibkr.marketdata.v1.Quote.FieldsEntry
+	5,  // 3: api.ibkr.marketdata.v1.GetQuotesRequest.instruments:type_name -> api.ibkr.marketdata.v1.QuoteInstrument
+	7,  // 4: api.ibkr.marketdata.v1.GetQuotesResponse.results:type_name -> api.ibkr.marketdata.v1.QuoteResult
+	3,  // 5: api.ibkr.marketdata.v1.QuoteResult.quote:type_name -> api.ibkr.marketdata.v1.Quote
+	8,  // 6: api.ibkr.marketdata.v1.QuoteResult.error:type_name -> api.ibkr.marketdata.v1.QuoteError
+	11, // 7: api.ibkr.marketdata.v1.GetHistoricalDataResponse.bars:type_name -> api.ibkr.marketdata.v1.Bar
+	25, // 8: api.ibkr.marketdata.v1.Bar.opened_at:type_name -> google.protobuf.Timestamp
+	13, // 9: api.ibkr.marketdata.v1.GetIndicatorsRequest.indicators:type_name -> api.ibkr.marketdata.v1.IndicatorSpec
+	0,  // 10: api.ibkr.marketdata.v1.IndicatorSpec.type:type_name -> api.ibkr.marketdata.v1.IndicatorType
+	11, // 11: api.ibkr.marketdata.v1.GetIndicatorsResponse.bars:type_name -> api.ibkr.marketdata.v1.Bar
+	15, // 12: api.ibkr.marketdata.v1.GetIndicatorsResponse.indicators:type_name -> api.ibkr.marketdata.v1.Indicator
+	13, // 13: api.ibkr.marketdata.v1.Indicator.spec:type_name -> api.ibkr.marketdata.v1.IndicatorSpec
+	16, // 14: api.ibkr.marketdata.v1.Indicator.series:type_name -> api.ibkr.marketdata.v1.IndicatorSeries
+	5,  // 15: api.ibkr.marketdata.v1.StreamQuotesRequest.instruments:type_name -> api.ibkr.marketdata.v1.QuoteInstrument
+	3,  // 16: api.ibkr.marketdata.v1.StreamQuotesResponse.quote:type_name -> api.ibkr.marketdata.v1.Quote
+	21, // 17: api.ibkr.marketdata.v1.GetOptionChainResponse.contracts:type_name -> api.ibkr.marketdata.v1.OptionContract
+	1,  // 18: api.ibkr.marketdata.v1.MarketDataService.GetQuote:input_type -> api.ibkr.marketdata.v1.GetQuoteRequest
+	4,  // 19: api.ibkr.marketdata.v1.MarketDataService.GetQuotes:input_type -> api.ibkr.marketdata.v1.GetQuotesRequest
+	9,  // 20: api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData:input_type -> api.ibkr.marketdata.v1.GetHistoricalDataRequest
+	12, // 21: api.ibkr.marketdata.v1.MarketDataService.GetIndicators:input_type -> api.ibkr.marketdata.v1.GetIndicatorsRequest
+	17, // 22: api.ibkr.marketdata.v1.MarketDataService.StreamQuotes:input_type -> api.ibkr.marketdata.v1.StreamQuotesRequest
+	19, // 23: api.ibkr.marketdata.v1.MarketDataService.GetOptionChain:input_type -> api.ibkr.marketdata.v1.GetOptionChainRequest
+	22, // 24: api.ibkr.marketdata.v1.MarketDataService.InvalidateHistoricalCache:input_type -> api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest
+	2,  // 25: api.ibkr.marketdata.v1.MarketDataService.GetQuote:output_type -> api.ibkr.marketdata.v1.GetQuoteResponse
+	6,  // 26: api.ibkr.marketdata.v1.MarketDataService.GetQuotes:output_type -> api.ibkr.marketdata.v1.GetQuotesResponse
+	10, // 27: api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData:output_type -> api.ibkr.marketdata.v1.GetHistoricalDataResponse
+	14, // 28: api.ibkr.marketdata.v1.MarketDataService.GetIndicators:output_type -> api.ibkr.marketdata.v1.GetIndicatorsResponse
+	18, // 29: api.ibkr.marketdata.v1.MarketDataService.StreamQuotes:output_type -> api.ibkr.marketdata.v1.StreamQuotesResponse
+	20, // 30: api.ibkr.marketdata.v1.MarketDataService.GetOptionChain:output_type -> api.ibkr.marketdata.v1.GetOptionChainResponse
+	23, // 31: api.ibkr.marketdata.v1.MarketDataService.InvalidateHistoricalCache:output_type -> api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponse
+	25, // [25:32] is the sub-list for method output_type
+	18, // [18:25] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_api_ibkr_marketdata_v1_market_data_proto_init() }
@@ -612,19 +2614,46 @@ func file_api_ibkr_marketdata_v1_market_data_proto_init() {
 	if File_api_ibkr_marketdata_v1_market_data_proto != nil {
 		return
 	}
-	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[0].OneofWrappers = []any{
+		(*GetQuoteRequest_Symbol)(nil),
+		(*GetQuoteRequest_Conid)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[4].OneofWrappers = []any{
+		(*QuoteInstrument_Symbol)(nil),
+		(*QuoteInstrument_Conid)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[6].OneofWrappers = []any{
+		(*QuoteResult_Quote)(nil),
+		(*QuoteResult_Error)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[8].OneofWrappers = []any{
+		(*GetHistoricalDataRequest_Symbol)(nil),
+		(*GetHistoricalDataRequest_Conid)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[11].OneofWrappers = []any{
+		(*GetIndicatorsRequest_Symbol)(nil),
+		(*GetIndicatorsRequest_Conid)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[12].OneofWrappers = []any{}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[16].OneofWrappers = []any{
+		(*StreamQuotesRequest_Symbol)(nil),
+		(*StreamQuotesRequest_Conid)(nil),
+	}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[18].OneofWrappers = []any{}
+	file_api_ibkr_marketdata_v1_market_data_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_ibkr_marketdata_v1_market_data_proto_rawDesc), len(file_api_ibkr_marketdata_v1_market_data_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_ibkr_marketdata_v1_market_data_proto_goTypes,
 		DependencyIndexes: file_api_ibkr_marketdata_v1_market_data_proto_depIdxs,
+		EnumInfos:         file_api_ibkr_marketdata_v1_market_data_proto_enumTypes,
 		MessageInfos:      file_api_ibkr_marketdata_v1_market_data_proto_msgTypes,
 	}.Build()
 	File_api_ibkr_marketdata_v1_market_data_proto = out.File
@@ -36,22 +36,48 @@ const (
 	// MarketDataServiceGetQuoteProcedure is the fully-qualified name of the MarketDataService's
 	// GetQuote RPC.
 	MarketDataServiceGetQuoteProcedure = "/api.ibkr.marketdata.v1.MarketDataService/GetQuote"
+	// MarketDataServiceGetQuotesProcedure is the fully-qualified name of the MarketDataService's
+	// GetQuotes RPC.
+	MarketDataServiceGetQuotesProcedure = "/api.ibkr.marketdata.v1.MarketDataService/GetQuotes"
 	// MarketDataServiceGetHistoricalDataProcedure is the fully-qualified name of the
 	// MarketDataService's GetHistoricalData RPC.
 	MarketDataServiceGetHistoricalDataProcedure = "/api.ibkr.marketdata.v1.MarketDataService/GetHistoricalData"
+	// MarketDataServiceGetIndicatorsProcedure is the fully-qualified name of the MarketDataService's
+	// GetIndicators RPC.
+	MarketDataServiceGetIndicatorsProcedure = "/api.ibkr.marketdata.v1.MarketDataService/GetIndicators"
 	// MarketDataServiceStreamQuotesProcedure is the fully-qualified name of the MarketDataService's
 	// StreamQuotes RPC.
 	MarketDataServiceStreamQuotesProcedure = "/api.ibkr.marketdata.v1.MarketDataService/StreamQuotes"
+	// MarketDataServiceGetOptionChainProcedure is the fully-qualified name of the MarketDataService's
+	// GetOptionChain RPC.
+	MarketDataServiceGetOptionChainProcedure = "/api.ibkr.marketdata.v1.MarketDataService/GetOptionChain"
+	// MarketDataServiceInvalidateHistoricalCacheProcedure is the fully-qualified name of the
+	// MarketDataService's InvalidateHistoricalCache RPC.
+	MarketDataServiceInvalidateHistoricalCacheProcedure = "/api.ibkr.marketdata.v1.MarketDataService/InvalidateHistoricalCache"
 )
 
 // MarketDataServiceClient is a client for the api.ibkr.marketdata.v1.MarketDataService service.
 type MarketDataServiceClient interface {
 	// GetQuote retrieves a real-time quote for a symbol.
 	GetQuote(context.Context, *connect.Request[v1.GetQuoteRequest]) (*connect.Response[v1.GetQuoteResponse], error)
+	// GetQuotes retrieves real-time quotes for many instruments with batched snapshot requests.
+	// Instruments that cannot be resolved or quoted get an error result instead of failing the call.
+	GetQuotes(context.Context, *connect.Request[v1.GetQuotesRequest]) (*connect.Response[v1.GetQuotesResponse], error)
 	// GetHistoricalData retrieves historical market data.
 	GetHistoricalData(context.Context, *connect.Request[v1.GetHistoricalDataRequest]) (*connect.Response[v1.GetHistoricalDataResponse], error)
-	// StreamQuotes streams real-time quotes for a symbol.
+	// GetIndicators computes technical indicators over the historical bars of a symbol,
+	// resampling them to bar sizes the Gateway does not serve, such as 2h or 3d.
+	GetIndicators(context.Context, *connect.Request[v1.GetIndicatorsRequest]) (*connect.Response[v1.GetIndicatorsResponse], error)
+	// StreamQuotes streams real-time quotes for one or more instruments, either as full
+	// quotes or as changed fields with periodic full quotes, with heartbeats while quiet.
 	StreamQuotes(context.Context, *connect.Request[v1.StreamQuotesRequest]) (*connect.ServerStreamForClient[v1.StreamQuotesResponse], error)
+	// GetOptionChain retrieves the option contracts of an underlying for an expiration month,
+	// with quotes, implied volatility and greeks where the Gateway provides them.
+	GetOptionChain(context.Context, *connect.Request[v1.GetOptionChainRequest]) (*connect.Response[v1.GetOptionChainResponse], error)
+	// InvalidateHistoricalCache removes the cached historical bars of a contract, e.g. after
+	// a split or a data correction, so that they are fetched from the Gateway again.
+	// Only admin accounts may call it.
+	InvalidateHistoricalCache(context.Context, *connect.Request[v1.InvalidateHistoricalCacheRequest]) (*connect.Response[v1.InvalidateHistoricalCacheResponse], error)
 }
 
 // NewMarketDataServiceClient constructs a client for the api.ibkr.marketdata.v1.MarketDataService
@@ -71,26 +97,54 @@ func NewMarketDataServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(marketDataServiceMethods.ByName("GetQuote")),
 			connect.WithClientOptions(opts...),
 		),
+		getQuotes: connect.NewClient[v1.GetQuotesRequest, v1.GetQuotesResponse](
+			httpClient,
+			baseURL+MarketDataServiceGetQuotesProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetQuotes")),
+			connect.WithClientOptions(opts...),
+		),
 		getHistoricalData: connect.NewClient[v1.GetHistoricalDataRequest, v1.GetHistoricalDataResponse](
 			httpClient,
 			baseURL+MarketDataServiceGetHistoricalDataProcedure,
 			connect.WithSchema(marketDataServiceMethods.ByName("GetHistoricalData")),
 			connect.WithClientOptions(opts...),
 		),
+		getIndicators: connect.NewClient[v1.GetIndicatorsRequest, v1.GetIndicatorsResponse](
+			httpClient,
+			baseURL+MarketDataServiceGetIndicatorsProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetIndicators")),
+			connect.WithClientOptions(opts...),
+		),
 		streamQuotes: connect.NewClient[v1.StreamQuotesRequest, v1.StreamQuotesResponse](
 			httpClient,
 			baseURL+MarketDataServiceStreamQuotesProcedure,
 			connect.WithSchema(marketDataServiceMethods.ByName("StreamQuotes")),
 			connect.WithClientOptions(opts...),
 		),
+		getOptionChain: connect.NewClient[v1.GetOptionChainRequest, v1.GetOptionChainResponse](
+			httpClient,
+			baseURL+MarketDataServiceGetOptionChainProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("GetOptionChain")),
+			connect.WithClientOptions(opts...),
+		),
+		invalidateHistoricalCache: connect.NewClient[v1.InvalidateHistoricalCacheRequest, v1.InvalidateHistoricalCacheResponse](
+			httpClient,
+			baseURL+MarketDataServiceInvalidateHistoricalCacheProcedure,
+			connect.WithSchema(marketDataServiceMethods.ByName("InvalidateHistoricalCache")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // marketDataServiceClient implements MarketDataServiceClient.
 type marketDataServiceClient struct {
-	getQuote          *connect.Client[v1.GetQuoteRequest, v1.GetQuoteResponse]
-	getHistoricalData *connect.Client[v1.GetHistoricalDataRequest, v1.GetHistoricalDataResponse]
-	streamQuotes      *connect.Client[v1.StreamQuotesRequest, v1.StreamQuotesResponse]
+	getQuote                  *connect.Client[v1.GetQuoteRequest, v1.GetQuoteResponse]
+	getQuotes                 *connect.Client[v1.GetQuotesRequest, v1.GetQuotesResponse]
+	getHistoricalData         *connect.Client[v1.GetHistoricalDataRequest, v1.GetHistoricalDataResponse]
+	getIndicators             *connect.Client[v1.GetIndicatorsRequest, v1.GetIndicatorsResponse]
+	streamQuotes              *connect.Client[v1.StreamQuotesRequest, v1.StreamQuotesResponse]
+	getOptionChain            *connect.Client[v1.GetOptionChainRequest, v1.GetOptionChainResponse]
+	invalidateHistoricalCache *connect.Client[v1.InvalidateHistoricalCacheRequest, v1.InvalidateHistoricalCacheResponse]
 }
 
 // GetQuote calls api.ibkr.marketdata.v1.MarketDataService.GetQuote.
@@ -98,25 +152,60 @@ func (c *marketDataServiceClient) GetQuote(ctx context.Context, req *connect.Req
 	return c.getQuote.CallUnary(ctx, req)
 }
 
+// GetQuotes calls api.ibkr.marketdata.v1.MarketDataService.GetQuotes.
+func (c *marketDataServiceClient) GetQuotes(ctx context.Context, req *connect.Request[v1.GetQuotesRequest]) (*connect.Response[v1.GetQuotesResponse], error) {
+	return c.getQuotes.CallUnary(ctx, req)
+}
+
 // GetHistoricalData calls api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData.
 func (c *marketDataServiceClient) GetHistoricalData(ctx context.Context, req *connect.Request[v1.GetHistoricalDataRequest]) (*connect.Response[v1.GetHistoricalDataResponse], error) {
 	return c.getHistoricalData.CallUnary(ctx, req)
 }
 
+// GetIndicators calls api.ibkr.marketdata.v1.MarketDataService.GetIndicators.
+func (c *marketDataServiceClient) GetIndicators(ctx context.Context, req *connect.Request[v1.GetIndicatorsRequest]) (*connect.Response[v1.GetIndicatorsResponse], error) {
+	return c.getIndicators.CallUnary(ctx, req)
+}
+
 // StreamQuotes calls api.ibkr.marketdata.v1.MarketDataService.StreamQuotes.
 func (c *marketDataServiceClient) StreamQuotes(ctx context.Context, req *connect.Request[v1.StreamQuotesRequest]) (*connect.ServerStreamForClient[v1.StreamQuotesResponse], error) {
 	return c.streamQuotes.CallServerStream(ctx, req)
 }
 
+// GetOptionChain calls api.ibkr.marketdata.v1.MarketDataService.GetOptionChain.
+func (c *marketDataServiceClient) GetOptionChain(ctx context.Context, req *connect.Request[v1.GetOptionChainRequest]) (*connect.Response[v1.GetOptionChainResponse], error) {
+	return c.getOptionChain.CallUnary(ctx, req)
+}
+
+// InvalidateHistoricalCache calls
+// api.ibkr.marketdata.v1.MarketDataService.InvalidateHistoricalCache.
+func (c *marketDataServiceClient) InvalidateHistoricalCache(ctx context.Context, req *connect.Request[v1.InvalidateHistoricalCacheRequest]) (*connect.Response[v1.InvalidateHistoricalCacheResponse], error) {
+	return c.invalidateHistoricalCache.CallUnary(ctx, req)
+}
+
 // MarketDataServiceHandler is an implementation of the api.ibkr.marketdata.v1.MarketDataService
 // service.
 type MarketDataServiceHandler interface {
 	// GetQuote retrieves a real-time quote for a symbol.
 	GetQuote(context.Context, *connect.Request[v1.GetQuoteRequest]) (*connect.Response[v1.GetQuoteResponse], error)
+	// GetQuotes retrieves real-time quotes for many instruments with batched snapshot requests.
+	// Instruments that cannot be resolved or quoted get an error result instead of failing the call.
+	GetQuotes(context.Context, *connect.Request[v1.GetQuotesRequest]) (*connect.Response[v1.GetQuotesResponse], error)
 	// GetHistoricalData retrieves historical market data.
 	GetHistoricalData(context.Context, *connect.Request[v1.GetHistoricalDataRequest]) (*connect.Response[v1.GetHistoricalDataResponse], error)
-	// StreamQuotes streams real-time quotes for a symbol.
+	// GetIndicators computes technical indicators over the historical bars of a symbol,
+	// resampling them to bar sizes the Gateway does not serve, such as 2h or 3d.
+	GetIndicators(context.Context, *connect.Request[v1.GetIndicatorsRequest]) (*connect.Response[v1.GetIndicatorsResponse], error)
+	// StreamQuotes streams real-time quotes for one or more instruments, either as full
+	// quotes or as changed fields with periodic full quotes, with heartbeats while quiet.
 	StreamQuotes(context.Context, *connect.Request[v1.StreamQuotesRequest], *connect.ServerStream[v1.StreamQuotesResponse]) error
+	// GetOptionChain retrieves the option contracts of an underlying for an expiration month,
+	// with quotes, implied volatility and greeks where the Gateway provides them.
+	GetOptionChain(context.Context, *connect.Request[v1.GetOptionChainRequest]) (*connect.Response[v1.GetOptionChainResponse], error)
+	// InvalidateHistoricalCache removes the cached historical bars of a contract, e.g. after
+	// a split or a data correction, so that they are fetched from the Gateway again.
+	// Only admin accounts may call it.
+	InvalidateHistoricalCache(context.Context, *connect.Request[v1.InvalidateHistoricalCacheRequest]) (*connect.Response[v1.InvalidateHistoricalCacheResponse], error)
 }
 
 // NewMarketDataServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -132,26 +221,58 @@ func NewMarketDataServiceHandler(svc MarketDataServiceHandler, opts ...connect.H
 		connect.WithSchema(marketDataServiceMethods.ByName("GetQuote")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceGetQuotesHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetQuotesProcedure,
+		svc.GetQuotes,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetQuotes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	marketDataServiceGetHistoricalDataHandler := connect.NewUnaryHandler(
 		MarketDataServiceGetHistoricalDataProcedure,
 		svc.GetHistoricalData,
 		connect.WithSchema(marketDataServiceMethods.ByName("GetHistoricalData")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceGetIndicatorsHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetIndicatorsProcedure,
+		svc.GetIndicators,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetIndicators")),
+		connect.WithHandlerOptions(opts...),
+	)
 	marketDataServiceStreamQuotesHandler := connect.NewServerStreamHandler(
 		MarketDataServiceStreamQuotesProcedure,
 		svc.StreamQuotes,
 		connect.WithSchema(marketDataServiceMethods.ByName("StreamQuotes")),
 		connect.WithHandlerOptions(opts...),
 	)
+	marketDataServiceGetOptionChainHandler := connect.NewUnaryHandler(
+		MarketDataServiceGetOptionChainProcedure,
+		svc.GetOptionChain,
+		connect.WithSchema(marketDataServiceMethods.ByName("GetOptionChain")),
+		connect.WithHandlerOptions(opts...),
+	)
+	marketDataServiceInvalidateHistoricalCacheHandler := connect.NewUnaryHandler(
+		MarketDataServiceInvalidateHistoricalCacheProcedure,
+		svc.InvalidateHistoricalCache,
+		connect.WithSchema(marketDataServiceMethods.ByName("InvalidateHistoricalCache")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.ibkr.marketdata.v1.MarketDataService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MarketDataServiceGetQuoteProcedure:
 			marketDataServiceGetQuoteHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetQuotesProcedure:
+			marketDataServiceGetQuotesHandler.ServeHTTP(w, r)
 		case MarketDataServiceGetHistoricalDataProcedure:
 			marketDataServiceGetHistoricalDataHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetIndicatorsProcedure:
+			marketDataServiceGetIndicatorsHandler.ServeHTTP(w, r)
 		case MarketDataServiceStreamQuotesProcedure:
 			marketDataServiceStreamQuotesHandler.ServeHTTP(w, r)
+		case MarketDataServiceGetOptionChainProcedure:
+			marketDataServiceGetOptionChainHandler.ServeHTTP(w, r)
+		case MarketDataServiceInvalidateHistoricalCacheProcedure:
+			marketDataServiceInvalidateHistoricalCacheHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -165,10 +286,26 @@ func (UnimplementedMarketDataServiceHandler) GetQuote(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.GetQuote is not implemented"))
 }
 
+func (UnimplementedMarketDataServiceHandler) GetQuotes(context.Context, *connect.Request[v1.GetQuotesRequest]) (*connect.Response[v1.GetQuotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.GetQuotes is not implemented"))
+}
+
 func (UnimplementedMarketDataServiceHandler) GetHistoricalData(context.Context, *connect.Request[v1.GetHistoricalDataRequest]) (*connect.Response[v1.GetHistoricalDataResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData is not implemented"))
 }
 
+func (UnimplementedMarketDataServiceHandler) GetIndicators(context.Context, *connect.Request[v1.GetIndicatorsRequest]) (*connect.Response[v1.GetIndicatorsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.GetIndicators is not implemented"))
+}
+
 func (UnimplementedMarketDataServiceHandler) StreamQuotes(context.Context, *connect.Request[v1.StreamQuotesRequest], *connect.ServerStream[v1.StreamQuotesResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.StreamQuotes is not implemented"))
 }
+
+func (UnimplementedMarketDataServiceHandler) GetOptionChain(context.Context, *connect.Request[v1.GetOptionChainRequest]) (*connect.Response[v1.GetOptionChainResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.GetOptionChain is not implemented"))
+}
+
+func (UnimplementedMarketDataServiceHandler) InvalidateHistoricalCache(context.Context, *connect.Request[v1.InvalidateHistoricalCacheRequest]) (*connect.Response[v1.InvalidateHistoricalCacheResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.marketdata.v1.MarketDataService.InvalidateHistoricalCache is not implemented"))
+}
@@ -78,19 +78,48 @@ type OrderType int32
 const (
 	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
 	OrderType_ORDER_TYPE_MARKET      OrderType = 1
-	OrderType_ORDER_TYPE_LIMIT       OrderType = 2
-	OrderType_ORDER_TYPE_STOP        OrderType = 3
-	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 4
+	// Limit order at limit_price.
+	OrderType_ORDER_TYPE_LIMIT OrderType = 2
+	// Stop order triggered at stop_price.
+	OrderType_ORDER_TYPE_STOP OrderType = 3
+	// Stop limit order at limit_price, triggered at stop_price.
+	OrderType_ORDER_TYPE_STOP_LIMIT OrderType = 4
+	// Trailing stop order, its stop price trailing_amount away from the market. stop_price
+	// optionally sets the initial stop price.
+	OrderType_ORDER_TYPE_TRAIL OrderType = 5
+	// Trailing stop limit order at limit_price, its stop price trailing_amount away from
+	// the market. stop_price optionally sets the initial stop price.
+	OrderType_ORDER_TYPE_TRAIL_LIMIT OrderType = 6
+	// Market on close order.
+	OrderType_ORDER_TYPE_MOC OrderType = 7
+	// Limit on close order at limit_price.
+	OrderType_ORDER_TYPE_LOC OrderType = 8
+	// Market if touched order, triggered at stop_price.
+	OrderType_ORDER_TYPE_MIT OrderType = 9
+	// Limit if touched order at limit_price, triggered at stop_price.
+	OrderType_ORDER_TYPE_LIT OrderType = 10
+	// Relative order pegged offset away from the best bid or ask, capped at limit_price if set.
+	OrderType_ORDER_TYPE_REL OrderType = 11
+	// Midprice order pegged to the midpoint of the best bid and ask, capped at limit_price if set.
+	OrderType_ORDER_TYPE_MIDPRICE OrderType = 12
 )
 
 // Enum value maps for OrderType.
 var (
 	OrderType_name = map[int32]string{
-		0: "ORDER_TYPE_UNSPECIFIED",
-		1: "ORDER_TYPE_MARKET",
-		2: "ORDER_TYPE_LIMIT",
-		3: "ORDER_TYPE_STOP",
-		4: "ORDER_TYPE_STOP_LIMIT",
+		0:  "ORDER_TYPE_UNSPECIFIED",
+		1:  "ORDER_TYPE_MARKET",
+		2:  "ORDER_TYPE_LIMIT",
+		3:  "ORDER_TYPE_STOP",
+		4:  "ORDER_TYPE_STOP_LIMIT",
+		5:  "ORDER_TYPE_TRAIL",
+		6:  "ORDER_TYPE_TRAIL_LIMIT",
+		7:  "ORDER_TYPE_MOC",
+		8:  "ORDER_TYPE_LOC",
+		9:  "ORDER_TYPE_MIT",
+		10: "ORDER_TYPE_LIT",
+		11: "ORDER_TYPE_REL",
+		12: "ORDER_TYPE_MIDPRICE",
 	}
 	OrderType_value = map[string]int32{
 		"ORDER_TYPE_UNSPECIFIED": 0,
@@ -98,6 +127,14 @@ var (
 		"ORDER_TYPE_LIMIT":       2,
 		"ORDER_TYPE_STOP":        3,
 		"ORDER_TYPE_STOP_LIMIT":  4,
+		"ORDER_TYPE_TRAIL":       5,
+		"ORDER_TYPE_TRAIL_LIMIT": 6,
+		"ORDER_TYPE_MOC":         7,
+		"ORDER_TYPE_LOC":         8,
+		"ORDER_TYPE_MIT":         9,
+		"ORDER_TYPE_LIT":         10,
+		"ORDER_TYPE_REL":         11,
+		"ORDER_TYPE_MIDPRICE":    12,
 	}
 )
 
@@ -128,17 +165,70 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{1}
 }
 
+// TrailingType represents the unit of the trailing amount of a trailing order.
+type TrailingType int32
+
+const (
+	TrailingType_TRAILING_TYPE_UNSPECIFIED TrailingType = 0
+	// Trailing amount in price units.
+	TrailingType_TRAILING_TYPE_AMOUNT TrailingType = 1
+	// Trailing amount in percent of the price.
+	TrailingType_TRAILING_TYPE_PERCENT TrailingType = 2
+)
+
+// Enum value maps for TrailingType.
+var (
+	TrailingType_name = map[int32]string{
+		0: "TRAILING_TYPE_UNSPECIFIED",
+		1: "TRAILING_TYPE_AMOUNT",
+		2: "TRAILING_TYPE_PERCENT",
+	}
+	TrailingType_value = map[string]int32{
+		"TRAILING_TYPE_UNSPECIFIED": 0,
+		"TRAILING_TYPE_AMOUNT":      1,
+		"TRAILING_TYPE_PERCENT":     2,
+	}
+)
+
+func (x TrailingType) Enum() *TrailingType {
+	p := new(TrailingType)
+	*p = x
+	return p
+}
+
+func (x TrailingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrailingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_ibkr_order_v1_order_proto_enumTypes[2].Descriptor()
+}
+
+func (TrailingType) Type() protoreflect.EnumType {
+	return &file_api_ibkr_order_v1_order_proto_enumTypes[2]
+}
+
+func (x TrailingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrailingType.Descriptor instead.
+func (TrailingType) EnumDescriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{2}
+}
+
 // OrderStatus represents the status of an order.
 type OrderStatus int32
 
 const (
-	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
-	OrderStatus_ORDER_STATUS_PENDING          OrderStatus = 1
-	OrderStatus_ORDER_STATUS_SUBMITTED        OrderStatus = 2
-	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 3
-	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 4
-	OrderStatus_ORDER_STATUS_CANCELLED        OrderStatus = 5
-	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 6
+	OrderStatus_ORDER_STATUS_UNSPECIFIED           OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING               OrderStatus = 1
+	OrderStatus_ORDER_STATUS_SUBMITTED             OrderStatus = 2
+	OrderStatus_ORDER_STATUS_FILLED                OrderStatus = 3
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED      OrderStatus = 4
+	OrderStatus_ORDER_STATUS_CANCELLED             OrderStatus = 5
+	OrderStatus_ORDER_STATUS_REJECTED              OrderStatus = 6
+	OrderStatus_ORDER_STATUS_CONFIRMATION_REQUIRED OrderStatus = 7
 )
 
 // Enum value maps for OrderStatus.
@@ -151,15 +241,17 @@ var (
 		4: "ORDER_STATUS_PARTIALLY_FILLED",
 		5: "ORDER_STATUS_CANCELLED",
 		6: "ORDER_STATUS_REJECTED",
+		7: "ORDER_STATUS_CONFIRMATION_REQUIRED",
 	}
 	OrderStatus_value = map[string]int32{
-		"ORDER_STATUS_UNSPECIFIED":      0,
-		"ORDER_STATUS_PENDING":          1,
-		"ORDER_STATUS_SUBMITTED":        2,
-		"ORDER_STATUS_FILLED":           3,
-		"ORDER_STATUS_PARTIALLY_FILLED": 4,
-		"ORDER_STATUS_CANCELLED":        5,
-		"ORDER_STATUS_REJECTED":         6,
+		"ORDER_STATUS_UNSPECIFIED":           0,
+		"ORDER_STATUS_PENDING":               1,
+		"ORDER_STATUS_SUBMITTED":             2,
+		"ORDER_STATUS_FILLED":                3,
+		"ORDER_STATUS_PARTIALLY_FILLED":      4,
+		"ORDER_STATUS_CANCELLED":             5,
+		"ORDER_STATUS_REJECTED":              6,
+		"ORDER_STATUS_CONFIRMATION_REQUIRED": 7,
 	}
 )
 
@@ -174,11 +266,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_ibkr_order_v1_order_proto_enumTypes[2].Descriptor()
+	return file_api_ibkr_order_v1_order_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_api_ibkr_order_v1_order_proto_enumTypes[2]
+	return &file_api_ibkr_order_v1_order_proto_enumTypes[3]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -187,7 +279,7 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{2}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{3}
 }
 
 // TimeInForce represents how long an order remains active.
@@ -230,11 +322,11 @@ func (x TimeInForce) String() string {
 }
 
 func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_ibkr_order_v1_order_proto_enumTypes[3].Descriptor()
+	return file_api_ibkr_order_v1_order_proto_enumTypes[4].Descriptor()
 }
 
 func (TimeInForce) Type() protoreflect.EnumType {
-	return &file_api_ibkr_order_v1_order_proto_enumTypes[3]
+	return &file_api_ibkr_order_v1_order_proto_enumTypes[4]
 }
 
 func (x TimeInForce) Number() protoreflect.EnumNumber {
@@ -243,20 +335,41 @@ func (x TimeInForce) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TimeInForce.Descriptor instead.
 func (TimeInForce) EnumDescriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{3}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{4}
 }
 
-// PlaceOrderRequest contains parameters for placing an order.
+// PlaceOrderRequest contains parameters for placing an order. The prices an order needs
+// depend on its type, see OrderType; the rules below refer to OrderType by number.
 type PlaceOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Side          OrderSide              `protobuf:"varint,3,opt,name=side,proto3,enum=api.ibkr.order.v1.OrderSide" json:"side,omitempty"`
-	Type          OrderType              `protobuf:"varint,4,opt,name=type,proto3,enum=api.ibkr.order.v1.OrderType" json:"type,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	LimitPrice    *float64               `protobuf:"fixed64,6,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`
-	StopPrice     *float64               `protobuf:"fixed64,7,opt,name=stop_price,json=stopPrice,proto3,oneof" json:"stop_price,omitempty"`
-	TimeInForce   TimeInForce            `protobuf:"varint,8,opt,name=time_in_force,json=timeInForce,proto3,enum=api.ibkr.order.v1.TimeInForce" json:"time_in_force,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Types that are valid to be assigned to Instrument:
+	//
+	//	*PlaceOrderRequest_Symbol
+	//	*PlaceOrderRequest_Conid
+	Instrument  isPlaceOrderRequest_Instrument `protobuf_oneof:"instrument"`
+	Side        OrderSide                      `protobuf:"varint,3,opt,name=side,proto3,enum=api.ibkr.order.v1.OrderSide" json:"side,omitempty"`
+	Type        OrderType                      `protobuf:"varint,4,opt,name=type,proto3,enum=api.ibkr.order.v1.OrderType" json:"type,omitempty"`
+	Quantity    float64                        `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPrice  *float64                       `protobuf:"fixed64,6,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`
+	StopPrice   *float64                       `protobuf:"fixed64,7,opt,name=stop_price,json=stopPrice,proto3,oneof" json:"stop_price,omitempty"`
+	TimeInForce TimeInForce                    `protobuf:"varint,8,opt,name=time_in_force,json=timeInForce,proto3,enum=api.ibkr.order.v1.TimeInForce" json:"time_in_force,omitempty"`
+	// Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+	Exchange *string `protobuf:"bytes,9,opt,name=exchange,proto3,oneof" json:"exchange,omitempty"`
+	// Trading currency used to pick among listings of the symbol, e.g. "USD".
+	// USD listings are preferred when omitted.
+	Currency *string `protobuf:"bytes,10,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	// Security type of the instrument. Defaults to "STK".
+	SecType *string `protobuf:"bytes,11,opt,name=sec_type,json=secType,proto3,oneof" json:"sec_type,omitempty"`
+	// Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+	// The front month is used when omitted.
+	Expiry *string `protobuf:"bytes,12,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	// Distance of the stop price of a trailing order from the market, in trailing_type units.
+	TrailingAmount *float64 `protobuf:"fixed64,14,opt,name=trailing_amount,json=trailingAmount,proto3,oneof" json:"trailing_amount,omitempty"`
+	// Unit of trailing_amount.
+	TrailingType TrailingType `protobuf:"varint,15,opt,name=trailing_type,json=trailingType,proto3,enum=api.ibkr.order.v1.TrailingType" json:"trailing_type,omitempty"`
+	// Offset of a relative order from the best bid of buys, or the best ask of sells.
+	Offset        *float64 `protobuf:"fixed64,16,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -298,13 +411,31 @@ func (x *PlaceOrderRequest) GetAccountId() string {
 	return ""
 }
 
+func (x *PlaceOrderRequest) GetInstrument() isPlaceOrderRequest_Instrument {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
 func (x *PlaceOrderRequest) GetSymbol() string {
 	if x != nil {
-		return x.Symbol
+		if x, ok := x.Instrument.(*PlaceOrderRequest_Symbol); ok {
+			return x.Symbol
+		}
 	}
 	return ""
 }
 
+func (x *PlaceOrderRequest) GetConid() int64 {
+	if x != nil {
+		if x, ok := x.Instrument.(*PlaceOrderRequest_Conid); ok {
+			return x.Conid
+		}
+	}
+	return 0
+}
+
 func (x *PlaceOrderRequest) GetSide() OrderSide {
 	if x != nil {
 		return x.Side
@@ -347,12 +478,84 @@ func (x *PlaceOrderRequest) GetTimeInForce() TimeInForce {
 	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
 }
 
+func (x *PlaceOrderRequest) GetExchange() string {
+	if x != nil && x.Exchange != nil {
+		return *x.Exchange
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSecType() string {
+	if x != nil && x.SecType != nil {
+		return *x.SecType
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetExpiry() string {
+	if x != nil && x.Expiry != nil {
+		return *x.Expiry
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetTrailingAmount() float64 {
+	if x != nil && x.TrailingAmount != nil {
+		return *x.TrailingAmount
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetTrailingType() TrailingType {
+	if x != nil {
+		return x.TrailingType
+	}
+	return TrailingType_TRAILING_TYPE_UNSPECIFIED
+}
+
+func (x *PlaceOrderRequest) GetOffset() float64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+type isPlaceOrderRequest_Instrument interface {
+	isPlaceOrderRequest_Instrument()
+}
+
+type PlaceOrderRequest_Symbol struct {
+	// Symbol of the instrument: upper case letters and digits, in parts joined by a single
+	// ".", "-" or space, e.g. "AAPL", "BRK.B", "RDS-A" or "7203". The listing fields below
+	// pick among the listings found for it.
+	Symbol string `protobuf:"bytes,2,opt,name=symbol,proto3,oneof"`
+}
+
+type PlaceOrderRequest_Conid struct {
+	// Contract ID of the instrument. The contract is used as is, without a search, and
+	// the listing fields below are ignored.
+	Conid int64 `protobuf:"varint,13,opt,name=conid,proto3,oneof"`
+}
+
+func (*PlaceOrderRequest_Symbol) isPlaceOrderRequest_Instrument() {}
+
+func (*PlaceOrderRequest_Conid) isPlaceOrderRequest_Instrument() {}
+
 // PlaceOrderResponse contains the result of placing an order.
 type PlaceOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+	Confirmation  *OrderConfirmation `protobuf:"bytes,4,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -408,21 +611,387 @@ func (x *PlaceOrderResponse) GetMessage() string {
 	return ""
 }
 
-// ModifyOrderRequest contains parameters for modifying an order.
+func (x *PlaceOrderResponse) GetConfirmation() *OrderConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return nil
+}
+
+// OrderConfirmation describes warnings the gateway requires the caller to confirm
+// before it submits an order.
+type OrderConfirmation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier to pass to ConfirmOrder.
+	ReplyId       string   `protobuf:"bytes,1,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	Messages      []string `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	MessageIds    []string `protobuf:"bytes,3,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderConfirmation) Reset() {
+	*x = OrderConfirmation{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderConfirmation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderConfirmation) ProtoMessage() {}
+
+func (x *OrderConfirmation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderConfirmation.ProtoReflect.Descriptor instead.
+func (*OrderConfirmation) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderConfirmation) GetReplyId() string {
+	if x != nil {
+		return x.ReplyId
+	}
+	return ""
+}
+
+func (x *OrderConfirmation) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *OrderConfirmation) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+// PlaceBracketOrderRequest contains parameters for placing a bracket order. The take
+// profit and stop loss orders close the position the entry order opens: they are only
+// submitted once the entry order fills, and once either of them fills the other is
+// canceled.
+type PlaceBracketOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entry order. The take profit and stop loss orders are for the same account, instrument
+	// and quantity, on the opposite side.
+	Entry *PlaceOrderRequest `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Limit price of the take profit order.
+	TakeProfitPrice float64 `protobuf:"fixed64,2,opt,name=take_profit_price,json=takeProfitPrice,proto3" json:"take_profit_price,omitempty"`
+	// Stop price of the stop loss order.
+	StopLossPrice float64 `protobuf:"fixed64,3,opt,name=stop_loss_price,json=stopLossPrice,proto3" json:"stop_loss_price,omitempty"`
+	// Time in force of the take profit and stop loss orders. Defaults to the time in force
+	// of the entry order.
+	ExitTimeInForce TimeInForce `protobuf:"varint,4,opt,name=exit_time_in_force,json=exitTimeInForce,proto3,enum=api.ibkr.order.v1.TimeInForce" json:"exit_time_in_force,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlaceBracketOrderRequest) Reset() {
+	*x = PlaceBracketOrderRequest{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBracketOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBracketOrderRequest) ProtoMessage() {}
+
+func (x *PlaceBracketOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBracketOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceBracketOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlaceBracketOrderRequest) GetEntry() *PlaceOrderRequest {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *PlaceBracketOrderRequest) GetTakeProfitPrice() float64 {
+	if x != nil {
+		return x.TakeProfitPrice
+	}
+	return 0
+}
+
+func (x *PlaceBracketOrderRequest) GetStopLossPrice() float64 {
+	if x != nil {
+		return x.StopLossPrice
+	}
+	return 0
+}
+
+func (x *PlaceBracketOrderRequest) GetExitTimeInForce() TimeInForce {
+	if x != nil {
+		return x.ExitTimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+// PlaceBracketOrderResponse contains the result of placing a bracket order.
+type PlaceBracketOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs of the entry, take profit and stop loss orders, in that order. Empty when the
+	// status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+	OrderIds []string `protobuf:"bytes,1,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	// Status of the entry order.
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
+	Message string      `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+	Confirmation  *OrderConfirmation `protobuf:"bytes,4,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceBracketOrderResponse) Reset() {
+	*x = PlaceBracketOrderResponse{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBracketOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBracketOrderResponse) ProtoMessage() {}
+
+func (x *PlaceBracketOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBracketOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceBracketOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlaceBracketOrderResponse) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *PlaceBracketOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *PlaceBracketOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PlaceBracketOrderResponse) GetConfirmation() *OrderConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return nil
+}
+
+// PlaceOrderGroupRequest contains parameters for placing a one-cancels-all group of
+// orders: once an order of the group fills, the others are canceled.
+type PlaceOrderGroupRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*PlaceOrderRequest   `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Name of the group, unique among the groups of the account. Generated when omitted.
+	OcaGroup      *string `protobuf:"bytes,2,opt,name=oca_group,json=ocaGroup,proto3,oneof" json:"oca_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderGroupRequest) Reset() {
+	*x = PlaceOrderGroupRequest{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderGroupRequest) ProtoMessage() {}
+
+func (x *PlaceOrderGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderGroupRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderGroupRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlaceOrderGroupRequest) GetOrders() []*PlaceOrderRequest {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *PlaceOrderGroupRequest) GetOcaGroup() string {
+	if x != nil && x.OcaGroup != nil {
+		return *x.OcaGroup
+	}
+	return ""
+}
+
+// PlaceOrderGroupResponse contains the result of placing a group of orders.
+type PlaceOrderGroupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs of the orders, in the order of the request. Empty when the status is
+	// ORDER_STATUS_CONFIRMATION_REQUIRED.
+	OrderIds []string `protobuf:"bytes,1,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	// Status of the first order.
+	Status  OrderStatus `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
+	Message string      `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+	Confirmation *OrderConfirmation `protobuf:"bytes,4,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
+	// Name of the group.
+	OcaGroup      string `protobuf:"bytes,5,opt,name=oca_group,json=ocaGroup,proto3" json:"oca_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderGroupResponse) Reset() {
+	*x = PlaceOrderGroupResponse{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderGroupResponse) ProtoMessage() {}
+
+func (x *PlaceOrderGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderGroupResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderGroupResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlaceOrderGroupResponse) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *PlaceOrderGroupResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *PlaceOrderGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PlaceOrderGroupResponse) GetConfirmation() *OrderConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return nil
+}
+
+func (x *PlaceOrderGroupResponse) GetOcaGroup() string {
+	if x != nil {
+		return x.OcaGroup
+	}
+	return ""
+}
+
+// ModifyOrderRequest contains parameters for modifying an order. The prices must be ones
+// the type of the order takes, see OrderType.
 type ModifyOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Quantity      *float64               `protobuf:"fixed64,3,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
-	LimitPrice    *float64               `protobuf:"fixed64,4,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`
-	StopPrice     *float64               `protobuf:"fixed64,5,opt,name=stop_price,json=stopPrice,proto3,oneof" json:"stop_price,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AccountId  string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	OrderId    string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity   *float64               `protobuf:"fixed64,3,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	LimitPrice *float64               `protobuf:"fixed64,4,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`
+	StopPrice  *float64               `protobuf:"fixed64,5,opt,name=stop_price,json=stopPrice,proto3,oneof" json:"stop_price,omitempty"`
+	// New distance of the stop price of a TRAIL or TRAIL_LIMIT order from the market, in
+	// trailing_type units.
+	TrailingAmount *float64 `protobuf:"fixed64,6,opt,name=trailing_amount,json=trailingAmount,proto3,oneof" json:"trailing_amount,omitempty"`
+	// Unit of trailing_amount.
+	TrailingType TrailingType `protobuf:"varint,7,opt,name=trailing_type,json=trailingType,proto3,enum=api.ibkr.order.v1.TrailingType" json:"trailing_type,omitempty"`
+	// New offset of a REL order from the best bid of buys, or the best ask of sells.
+	Offset        *float64 `protobuf:"fixed64,8,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ModifyOrderRequest) Reset() {
 	*x = ModifyOrderRequest{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[2]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -434,7 +1003,7 @@ func (x *ModifyOrderRequest) String() string {
 func (*ModifyOrderRequest) ProtoMessage() {}
 
 func (x *ModifyOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[2]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,7 +1016,7 @@ func (x *ModifyOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModifyOrderRequest.ProtoReflect.Descriptor instead.
 func (*ModifyOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{2}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ModifyOrderRequest) GetAccountId() string {
@@ -485,19 +1054,42 @@ func (x *ModifyOrderRequest) GetStopPrice() float64 {
 	return 0
 }
 
+func (x *ModifyOrderRequest) GetTrailingAmount() float64 {
+	if x != nil && x.TrailingAmount != nil {
+		return *x.TrailingAmount
+	}
+	return 0
+}
+
+func (x *ModifyOrderRequest) GetTrailingType() TrailingType {
+	if x != nil {
+		return x.TrailingType
+	}
+	return TrailingType_TRAILING_TYPE_UNSPECIFIED
+}
+
+func (x *ModifyOrderRequest) GetOffset() float64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
 // ModifyOrderResponse contains the result of modifying an order.
 type ModifyOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+	Confirmation  *OrderConfirmation `protobuf:"bytes,4,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ModifyOrderResponse) Reset() {
 	*x = ModifyOrderResponse{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[3]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -509,7 +1101,7 @@ func (x *ModifyOrderResponse) String() string {
 func (*ModifyOrderResponse) ProtoMessage() {}
 
 func (x *ModifyOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[3]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,7 +1114,7 @@ func (x *ModifyOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModifyOrderResponse.ProtoReflect.Descriptor instead.
 func (*ModifyOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{3}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ModifyOrderResponse) GetOrderId() string {
@@ -546,6 +1138,13 @@ func (x *ModifyOrderResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ModifyOrderResponse) GetConfirmation() *OrderConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return nil
+}
+
 // CancelOrderRequest contains parameters for canceling an order.
 type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -557,7 +1156,7 @@ type CancelOrderRequest struct {
 
 func (x *CancelOrderRequest) Reset() {
 	*x = CancelOrderRequest{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[4]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +1168,7 @@ func (x *CancelOrderRequest) String() string {
 func (*CancelOrderRequest) ProtoMessage() {}
 
 func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[4]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +1181,7 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
 func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{4}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CancelOrderRequest) GetAccountId() string {
@@ -611,7 +1210,7 @@ type CancelOrderResponse struct {
 
 func (x *CancelOrderResponse) Reset() {
 	*x = CancelOrderResponse{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[5]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +1222,7 @@ func (x *CancelOrderResponse) String() string {
 func (*CancelOrderResponse) ProtoMessage() {}
 
 func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[5]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +1235,7 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
 func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{5}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CancelOrderResponse) GetOrderId() string {
@@ -671,7 +1270,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[6]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -683,7 +1282,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[6]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,7 +1295,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{6}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetOrderRequest) GetAccountId() string {
@@ -723,7 +1322,7 @@ type GetOrderResponse struct {
 
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[7]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +1334,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[7]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1347,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{7}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetOrderResponse) GetOrder() *Order {
@@ -770,7 +1369,7 @@ type ListOrdersRequest struct {
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[8]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -782,7 +1381,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[8]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +1394,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{8}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListOrdersRequest) GetAccountId() string {
@@ -829,7 +1428,7 @@ type ListOrdersResponse struct {
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[9]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +1440,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[9]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +1453,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{9}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -864,6 +1463,148 @@ func (x *ListOrdersResponse) GetOrders() []*Order {
 	return nil
 }
 
+// ConfirmOrderRequest contains the answer to an order confirmation question.
+type ConfirmOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ReplyId   string                 `protobuf:"bytes,2,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	// Whether to confirm the warnings and submit the order.
+	Confirmed     bool `protobuf:"varint,3,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderRequest) Reset() {
+	*x = ConfirmOrderRequest{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderRequest) ProtoMessage() {}
+
+func (x *ConfirmOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConfirmOrderRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ConfirmOrderRequest) GetReplyId() string {
+	if x != nil {
+		return x.ReplyId
+	}
+	return ""
+}
+
+func (x *ConfirmOrderRequest) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+// ConfirmOrderResponse contains the result of answering a confirmation question.
+type ConfirmOrderResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.ibkr.order.v1.OrderStatus" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Set when the gateway asks a further question.
+	Confirmation *OrderConfirmation `protobuf:"bytes,4,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
+	// IDs of every order submitted, in the order of the request, when the question was
+	// asked for a bracket or a group of orders. order_id is the first of them.
+	OrderIds      []string `protobuf:"bytes,5,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOrderResponse) Reset() {
+	*x = ConfirmOrderResponse{}
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOrderResponse) ProtoMessage() {}
+
+func (x *ConfirmOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOrderResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmOrderResponse) Descriptor() ([]byte, []int) {
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConfirmOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ConfirmOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ConfirmOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmOrderResponse) GetConfirmation() *OrderConfirmation {
+	if x != nil {
+		return x.Confirmation
+	}
+	return nil
+}
+
+func (x *ConfirmOrderResponse) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
 // Order represents an order.
 type Order struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -886,7 +1627,7 @@ type Order struct {
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[10]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -898,7 +1639,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[10]
+	mi := &file_api_ibkr_order_v1_order_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -911,7 +1652,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{10}
+	return file_api_ibkr_order_v1_order_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Order) GetOrderId() string {
@@ -1009,28 +1750,86 @@ var File_api_ibkr_order_v1_order_proto protoreflect.FileDescriptor
 
 const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\n" +
-	"\x1dapi/ibkr/order/v1/order.proto\x12\x11api.ibkr.order.v1\x1a\x1bbuf/validate/validate.proto\"\xec\x03\n" +
+	"\x1dapi/ibkr/order/v1/order.proto\x12\x11api.ibkr.order.v1\x1a\x1bbuf/validate/validate.proto\"\xee\x10\n" +
 	"\x11PlaceOrderRequest\x12&\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\taccountId\x12.\n" +
-	"\x06symbol\x18\x02 \x01(\tB\x16\xbaH\x13r\x11\x10\x01\x18\x142\v^[A-Z0-9]+$R\x06symbol\x12<\n" +
+	"account_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\taccountId\x12A\n" +
+	"\x06symbol\x18\x02 \x01(\tB'\xbaH$r\"\x10\x01\x18\x142\x1c^[A-Z0-9]+([-. ][A-Z0-9]+)*$H\x00R\x06symbol\x12\x1f\n" +
+	"\x05conid\x18\r \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\x05conid\x12<\n" +
 	"\x04side\x18\x03 \x01(\x0e2\x1c.api.ibkr.order.v1.OrderSideB\n" +
 	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04side\x12<\n" +
 	"\x04type\x18\x04 \x01(\x0e2\x1c.api.ibkr.order.v1.OrderTypeB\n" +
 	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04type\x12*\n" +
 	"\bquantity\x18\x05 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\bquantity\x124\n" +
-	"\vlimit_price\x18\x06 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x00R\n" +
+	"\vlimit_price\x18\x06 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x01R\n" +
 	"limitPrice\x88\x01\x01\x122\n" +
 	"\n" +
-	"stop_price\x18\a \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x01R\tstopPrice\x88\x01\x01\x12N\n" +
+	"stop_price\x18\a \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x02R\tstopPrice\x88\x01\x01\x12N\n" +
 	"\rtime_in_force\x18\b \x01(\x0e2\x1e.api.ibkr.order.v1.TimeInForceB\n" +
-	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\vtimeInForceB\x0e\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\vtimeInForce\x128\n" +
+	"\bexchange\x18\t \x01(\tB\x17\xbaH\x14r\x12\x10\x01\x18\x142\f^[A-Z0-9.]+$H\x03R\bexchange\x88\x01\x01\x122\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[A-Z]{3}$H\x04R\bcurrency\x88\x01\x01\x12/\n" +
+	"\bsec_type\x18\v \x01(\tB\x0f\xbaH\fr\n" +
+	"R\x03STKR\x03FUTH\x05R\asecType\x88\x01\x01\x129\n" +
+	"\x06expiry\x18\f \x01(\tB\x1c\xbaH\x19r\x172\x15^[0-9]{6}([0-9]{2})?$H\x06R\x06expiry\x88\x01\x01\x12<\n" +
+	"\x0ftrailing_amount\x18\x0e \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\aR\x0etrailingAmount\x88\x01\x01\x12N\n" +
+	"\rtrailing_type\x18\x0f \x01(\x0e2\x1f.api.ibkr.order.v1.TrailingTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\ftrailingType\x12+\n" +
+	"\x06offset\x18\x10 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\bR\x06offset\x88\x01\x01:\xd0\b\xbaH\xcc\b\x1a\x99\x01\n" +
+	"\x17place_order.limit_price\x12CLIMIT, STOP_LIMIT, TRAIL_LIMIT, LOC and LIT orders need limit_price\x1a9!(this.type in [2, 4, 6, 8, 10]) || has(this.limit_price)\x1a\x9c\x01\n" +
+	"\x1eplace_order.limit_price_unused\x12?MARKET, STOP, TRAIL, MOC and MIT orders do not take limit_price\x1a9!(this.type in [1, 3, 5, 7, 9]) || !has(this.limit_price)\x1a\x85\x01\n" +
+	"\x16place_order.stop_price\x124STOP, STOP_LIMIT, MIT and LIT orders need stop_price\x1a5!(this.type in [3, 4, 9, 10]) || has(this.stop_price)\x1a\xa9\x01\n" +
+	"\x1dplace_order.stop_price_unused\x12Monly STOP, STOP_LIMIT, TRAIL, TRAIL_LIMIT, MIT and LIT orders take stop_price\x1a9this.type in [3, 4, 5, 6, 9, 10] || !has(this.stop_price)\x1a\xad\x01\n" +
+	"\x14place_order.trailing\x12CTRAIL and TRAIL_LIMIT orders need trailing_amount and trailing_type\x1aP!(this.type in [5, 6]) || (has(this.trailing_amount) && this.trailing_type != 0)\x1a\xb5\x01\n" +
+	"\x19place_order.trailing_only\x12Honly TRAIL and TRAIL_LIMIT orders take trailing_amount and trailing_type\x1aNthis.type in [5, 6] || (!has(this.trailing_amount) && this.trailing_type == 0)\x1as\n" +
+	"\x12place_order.offset\x126REL orders need offset, which other orders do not take\x1a%(this.type == 11) == has(this.offset)B\x13\n" +
+	"\n" +
+	"instrument\x12\x05\xbaH\x02\b\x01B\x0e\n" +
 	"\f_limit_priceB\r\n" +
-	"\v_stop_price\"\x81\x01\n" +
+	"\v_stop_priceB\v\n" +
+	"\t_exchangeB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_sec_typeB\t\n" +
+	"\a_expiryB\x12\n" +
+	"\x10_trailing_amountB\t\n" +
+	"\a_offset\"\xcb\x01\n" +
 	"\x12PlaceOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x126\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1e.api.ibkr.order.v1.OrderStatusR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xa7\x02\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12H\n" +
+	"\fconfirmation\x18\x04 \x01(\v2$.api.ibkr.order.v1.OrderConfirmationR\fconfirmation\"k\n" +
+	"\x11OrderConfirmation\x12\x19\n" +
+	"\breply_id\x18\x01 \x01(\tR\areplyId\x12\x1a\n" +
+	"\bmessages\x18\x02 \x03(\tR\bmessages\x12\x1f\n" +
+	"\vmessage_ids\x18\x03 \x03(\tR\n" +
+	"messageIds\"\xdc\a\n" +
+	"\x18PlaceBracketOrderRequest\x12B\n" +
+	"\x05entry\x18\x01 \x01(\v2$.api.ibkr.order.v1.PlaceOrderRequestB\x06\xbaH\x03\xc8\x01\x01R\x05entry\x12:\n" +
+	"\x11take_profit_price\x18\x02 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\x0ftakeProfitPrice\x126\n" +
+	"\x0fstop_loss_price\x18\x03 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\rstopLossPrice\x12U\n" +
+	"\x12exit_time_in_force\x18\x04 \x01(\x0e2\x1e.api.ibkr.order.v1.TimeInForceB\b\xbaH\x05\x82\x01\x02\x10\x01R\x0fexitTimeInForce:\xb0\x05\xbaH\xac\x05\x1a\xd2\x02\n" +
+	"\x1eplace_bracket_order.buy_prices\x12^a buy bracket needs take_profit_price above the entry limit price and stop_loss_price below it\x1a\xcf\x01this.entry.side != 1 || (this.take_profit_price > this.stop_loss_price && (!has(this.entry.limit_price) || (this.take_profit_price > this.entry.limit_price && this.entry.limit_price > this.stop_loss_price)))\x1a\xd4\x02\n" +
+	"\x1fplace_bracket_order.sell_prices\x12_a sell bracket needs take_profit_price below the entry limit price and stop_loss_price above it\x1a\xcf\x01this.entry.side != 2 || (this.take_profit_price < this.stop_loss_price && (!has(this.entry.limit_price) || (this.take_profit_price < this.entry.limit_price && this.entry.limit_price < this.stop_loss_price)))\"\xd4\x01\n" +
+	"\x19PlaceBracketOrderResponse\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\tR\borderIds\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.api.ibkr.order.v1.OrderStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12H\n" +
+	"\fconfirmation\x18\x04 \x01(\v2$.api.ibkr.order.v1.OrderConfirmationR\fconfirmation\"\xc0\x02\n" +
+	"\x16PlaceOrderGroupRequest\x12H\n" +
+	"\x06orders\x18\x01 \x03(\v2$.api.ibkr.order.v1.PlaceOrderRequestB\n" +
+	"\xbaH\a\x92\x01\x04\b\x02\x10\n" +
+	"R\x06orders\x12+\n" +
+	"\toca_group\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18@H\x00R\bocaGroup\x88\x01\x01:\xa0\x01\xbaH\x9c\x01\x1a\x99\x01\n" +
+	"\x1cplace_order_group.account_id\x122the orders of a group must be for the same account\x1aEthis.orders.all(order, order.account_id == this.orders[0].account_id)B\f\n" +
+	"\n" +
+	"_oca_group\"\xef\x01\n" +
+	"\x17PlaceOrderGroupResponse\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\tR\borderIds\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.api.ibkr.order.v1.OrderStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12H\n" +
+	"\fconfirmation\x18\x04 \x01(\v2$.api.ibkr.order.v1.OrderConfirmationR\fconfirmation\x12\x1b\n" +
+	"\toca_group\x18\x05 \x01(\tR\bocaGroup\"\x8e\x05\n" +
 	"\x12ModifyOrderRequest\x12&\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\taccountId\x12\"\n" +
@@ -1039,14 +1838,21 @@ const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\vlimit_price\x18\x04 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x01R\n" +
 	"limitPrice\x88\x01\x01\x122\n" +
 	"\n" +
-	"stop_price\x18\x05 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x02R\tstopPrice\x88\x01\x01B\v\n" +
+	"stop_price\x18\x05 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x02R\tstopPrice\x88\x01\x01\x12<\n" +
+	"\x0ftrailing_amount\x18\x06 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x03R\x0etrailingAmount\x88\x01\x01\x12N\n" +
+	"\rtrailing_type\x18\a \x01(\x0e2\x1f.api.ibkr.order.v1.TrailingTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\ftrailingType\x12+\n" +
+	"\x06offset\x18\b \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00H\x04R\x06offset\x88\x01\x01:\x8a\x01\xbaH\x86\x01\x1a\x83\x01\n" +
+	"\x15modify_order.trailing\x122trailing_amount and trailing_type are set together\x1a6has(this.trailing_amount) == (this.trailing_type != 0)B\v\n" +
 	"\t_quantityB\x0e\n" +
 	"\f_limit_priceB\r\n" +
-	"\v_stop_price\"\x82\x01\n" +
+	"\v_stop_priceB\x12\n" +
+	"\x10_trailing_amountB\t\n" +
+	"\a_offset\"\xcc\x01\n" +
 	"\x13ModifyOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x126\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1e.api.ibkr.order.v1.OrderStatusR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"`\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12H\n" +
+	"\fconfirmation\x18\x04 \x01(\v2$.api.ibkr.order.v1.OrderConfirmationR\fconfirmation\"`\n" +
 	"\x12CancelOrderRequest\x12&\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\taccountId\x12\"\n" +
@@ -1070,7 +1876,18 @@ const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\x0e_status_filterB\b\n" +
 	"\x06_limit\"F\n" +
 	"\x12ListOrdersResponse\x120\n" +
-	"\x06orders\x18\x01 \x03(\v2\x18.api.ibkr.order.v1.OrderR\x06orders\"\xb9\x04\n" +
+	"\x06orders\x18\x01 \x03(\v2\x18.api.ibkr.order.v1.OrderR\x06orders\"\x7f\n" +
+	"\x13ConfirmOrderRequest\x12&\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\taccountId\x12\"\n" +
+	"\breply_id\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\areplyId\x12\x1c\n" +
+	"\tconfirmed\x18\x03 \x01(\bR\tconfirmed\"\xea\x01\n" +
+	"\x14ConfirmOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.api.ibkr.order.v1.OrderStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12H\n" +
+	"\fconfirmation\x18\x04 \x01(\v2$.api.ibkr.order.v1.OrderConfirmationR\fconfirmation\x12\x1b\n" +
+	"\torder_ids\x18\x05 \x03(\tR\borderIds\"\xb9\x04\n" +
 	"\x05Order\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
 	"\n" +
@@ -1097,13 +1914,26 @@ const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\tOrderSide\x12\x1a\n" +
 	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eORDER_SIDE_BUY\x10\x01\x12\x13\n" +
-	"\x0fORDER_SIDE_SELL\x10\x02*\x84\x01\n" +
+	"\x0fORDER_SIDE_SELL\x10\x02*\xb3\x02\n" +
 	"\tOrderType\x12\x1a\n" +
 	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11ORDER_TYPE_MARKET\x10\x01\x12\x14\n" +
 	"\x10ORDER_TYPE_LIMIT\x10\x02\x12\x13\n" +
 	"\x0fORDER_TYPE_STOP\x10\x03\x12\x19\n" +
-	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04*\xd4\x01\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x04\x12\x14\n" +
+	"\x10ORDER_TYPE_TRAIL\x10\x05\x12\x1a\n" +
+	"\x16ORDER_TYPE_TRAIL_LIMIT\x10\x06\x12\x12\n" +
+	"\x0eORDER_TYPE_MOC\x10\a\x12\x12\n" +
+	"\x0eORDER_TYPE_LOC\x10\b\x12\x12\n" +
+	"\x0eORDER_TYPE_MIT\x10\t\x12\x12\n" +
+	"\x0eORDER_TYPE_LIT\x10\n" +
+	"\x12\x12\n" +
+	"\x0eORDER_TYPE_REL\x10\v\x12\x17\n" +
+	"\x13ORDER_TYPE_MIDPRICE\x10\f*b\n" +
+	"\fTrailingType\x12\x1d\n" +
+	"\x19TRAILING_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TRAILING_TYPE_AMOUNT\x10\x01\x12\x19\n" +
+	"\x15TRAILING_TYPE_PERCENT\x10\x02*\xfc\x01\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
@@ -1111,13 +1941,14 @@ const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\x13ORDER_STATUS_FILLED\x10\x03\x12!\n" +
 	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x04\x12\x1a\n" +
 	"\x16ORDER_STATUS_CANCELLED\x10\x05\x12\x19\n" +
-	"\x15ORDER_STATUS_REJECTED\x10\x06*\x88\x01\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x06\x12&\n" +
+	"\"ORDER_STATUS_CONFIRMATION_REQUIRED\x10\a*\x88\x01\n" +
 	"\vTimeInForce\x12\x1d\n" +
 	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11TIME_IN_FORCE_DAY\x10\x01\x12\x15\n" +
 	"\x11TIME_IN_FORCE_GTC\x10\x02\x12\x15\n" +
 	"\x11TIME_IN_FORCE_IOC\x10\x03\x12\x15\n" +
-	"\x11TIME_IN_FORCE_FOK\x10\x042\xd5\x03\n" +
+	"\x11TIME_IN_FORCE_FOK\x10\x042\x90\x06\n" +
 	"\fOrderService\x12Y\n" +
 	"\n" +
 	"PlaceOrder\x12$.api.ibkr.order.v1.PlaceOrderRequest\x1a%.api.ibkr.order.v1.PlaceOrderResponse\x12\\\n" +
@@ -1125,7 +1956,10 @@ const file_api_ibkr_order_v1_order_proto_rawDesc = "" +
 	"\vCancelOrder\x12%.api.ibkr.order.v1.CancelOrderRequest\x1a&.api.ibkr.order.v1.CancelOrderResponse\x12S\n" +
 	"\bGetOrder\x12\".api.ibkr.order.v1.GetOrderRequest\x1a#.api.ibkr.order.v1.GetOrderResponse\x12Y\n" +
 	"\n" +
-	"ListOrders\x12$.api.ibkr.order.v1.ListOrdersRequest\x1a%.api.ibkr.order.v1.ListOrdersResponseB\xd5\x01\n" +
+	"ListOrders\x12$.api.ibkr.order.v1.ListOrdersRequest\x1a%.api.ibkr.order.v1.ListOrdersResponse\x12_\n" +
+	"\fConfirmOrder\x12&.api.ibkr.order.v1.ConfirmOrderRequest\x1a'.api.ibkr.order.v1.ConfirmOrderResponse\x12n\n" +
+	"\x11PlaceBracketOrder\x12+.api.ibkr.order.v1.PlaceBracketOrderRequest\x1a,.api.ibkr.order.v1.PlaceBracketOrderResponse\x12h\n" +
+	"\x0fPlaceOrderGroup\x12).api.ibkr.order.v1.PlaceOrderGroupRequest\x1a*.api.ibkr.order.v1.PlaceOrderGroupResponseB\xd5\x01\n" +
 	"\x15com.api.ibkr.order.v1B\n" +
 	"OrderProtoP\x01ZIgithub.com/majidmvulle/ibkr-client/proto/gen/go/api/ibkr/order/v1;orderv1\xa2\x02\x03AIO\xaa\x02\x11Api.Ibkr.Order.V1\xca\x02\x11Api\\Ibkr\\Order\\V1\xe2\x02\x1dApi\\Ibkr\\Order\\V1\\GPBMetadata\xea\x02\x14Api::Ibkr::Order::V1b\x06proto3"
 
@@ -1141,54 +1975,81 @@ func file_api_ibkr_order_v1_order_proto_rawDescGZIP() []byte {
 	return file_api_ibkr_order_v1_order_proto_rawDescData
 }
 
-var file_api_ibkr_order_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_api_ibkr_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_ibkr_order_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_api_ibkr_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_api_ibkr_order_v1_order_proto_goTypes = []any{
-	(OrderSide)(0),              // 0: api.ibkr.order.v1.OrderSide
-	(OrderType)(0),              // 1: api.ibkr.order.v1.OrderType
-	(OrderStatus)(0),            // 2: api.ibkr.order.v1.OrderStatus
-	(TimeInForce)(0),            // 3: api.ibkr.order.v1.TimeInForce
-	(*PlaceOrderRequest)(nil),   // 4: api.ibkr.order.v1.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),  // 5: api.ibkr.order.v1.PlaceOrderResponse
-	(*ModifyOrderRequest)(nil),  // 6: api.ibkr.order.v1.ModifyOrderRequest
-	(*ModifyOrderResponse)(nil), // 7: api.ibkr.order.v1.ModifyOrderResponse
-	(*CancelOrderRequest)(nil),  // 8: api.ibkr.order.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil), // 9: api.ibkr.order.v1.CancelOrderResponse
-	(*GetOrderRequest)(nil),     // 10: api.ibkr.order.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),    // 11: api.ibkr.order.v1.GetOrderResponse
-	(*ListOrdersRequest)(nil),   // 12: api.ibkr.order.v1.ListOrdersRequest
-	(*ListOrdersResponse)(nil),  // 13: api.ibkr.order.v1.ListOrdersResponse
-	(*Order)(nil),               // 14: api.ibkr.order.v1.Order
+	(OrderSide)(0),                    // 0: api.ibkr.order.v1.OrderSide
+	(OrderType)(0),                    // 1: api.ibkr.order.v1.OrderType
+	(TrailingType)(0),                 // 2: api.ibkr.order.v1.TrailingType
+	(OrderStatus)(0),                  // 3: api.ibkr.order.v1.OrderStatus
+	(TimeInForce)(0),                  // 4: api.ibkr.order.v1.TimeInForce
+	(*PlaceOrderRequest)(nil),         // 5: api.ibkr.order.v1.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),        // 6: api.ibkr.order.v1.PlaceOrderResponse
+	(*OrderConfirmation)(nil),         // 7: api.ibkr.order.v1.OrderConfirmation
+	(*PlaceBracketOrderRequest)(nil),  // 8: api.ibkr.order.v1.PlaceBracketOrderRequest
+	(*PlaceBracketOrderResponse)(nil), // 9: api.ibkr.order.v1.PlaceBracketOrderResponse
+	(*PlaceOrderGroupRequest)(nil),    // 10: api.ibkr.order.v1.PlaceOrderGroupRequest
+	(*PlaceOrderGroupResponse)(nil),   // 11: api.ibkr.order.v1.PlaceOrderGroupResponse
+	(*ModifyOrderRequest)(nil),        // 12: api.ibkr.order.v1.ModifyOrderRequest
+	(*ModifyOrderResponse)(nil),       // 13: api.ibkr.order.v1.ModifyOrderResponse
+	(*CancelOrderRequest)(nil),        // 14: api.ibkr.order.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),       // 15: api.ibkr.order.v1.CancelOrderResponse
+	(*GetOrderRequest)(nil),           // 16: api.ibkr.order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),          // 17: api.ibkr.order.v1.GetOrderResponse
+	(*ListOrdersRequest)(nil),         // 18: api.ibkr.order.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),        // 19: api.ibkr.order.v1.ListOrdersResponse
+	(*ConfirmOrderRequest)(nil),       // 20: api.ibkr.order.v1.ConfirmOrderRequest
+	(*ConfirmOrderResponse)(nil),      // 21: api.ibkr.order.v1.ConfirmOrderResponse
+	(*Order)(nil),                     // 22: api.ibkr.order.v1.Order
 }
 var file_api_ibkr_order_v1_order_proto_depIdxs = []int32{
 	0,  // 0: api.ibkr.order.v1.PlaceOrderRequest.side:type_name -> api.ibkr.order.v1.OrderSide
 	1,  // 1: api.ibkr.order.v1.PlaceOrderRequest.type:type_name -> api.ibkr.order.v1.OrderType
-	3,  // 2: api.ibkr.order.v1.PlaceOrderRequest.time_in_force:type_name -> api.ibkr.order.v1.TimeInForce
-	2,  // 3: api.ibkr.order.v1.PlaceOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
-	2,  // 4: api.ibkr.order.v1.ModifyOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
-	2,  // 5: api.ibkr.order.v1.CancelOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
-	14, // 6: api.ibkr.order.v1.GetOrderResponse.order:type_name -> api.ibkr.order.v1.Order
-	2,  // 7: api.ibkr.order.v1.ListOrdersRequest.status_filter:type_name -> api.ibkr.order.v1.OrderStatus
-	14, // 8: api.ibkr.order.v1.ListOrdersResponse.orders:type_name -> api.ibkr.order.v1.Order
-	0,  // 9: api.ibkr.order.v1.Order.side:type_name -> api.ibkr.order.v1.OrderSide
-	1,  // 10: api.ibkr.order.v1.Order.type:type_name -> api.ibkr.order.v1.OrderType
-	3,  // 11: api.ibkr.order.v1.Order.time_in_force:type_name -> api.ibkr.order.v1.TimeInForce
-	2,  // 12: api.ibkr.order.v1.Order.status:type_name -> api.ibkr.order.v1.OrderStatus
-	4,  // 13: api.ibkr.order.v1.OrderService.PlaceOrder:input_type -> api.ibkr.order.v1.PlaceOrderRequest
-	6,  // 14: api.ibkr.order.v1.OrderService.ModifyOrder:input_type -> api.ibkr.order.v1.ModifyOrderRequest
-	8,  // 15: api.ibkr.order.v1.OrderService.CancelOrder:input_type -> api.ibkr.order.v1.CancelOrderRequest
-	10, // 16: api.ibkr.order.v1.OrderService.GetOrder:input_type -> api.ibkr.order.v1.GetOrderRequest
-	12, // 17: api.ibkr.order.v1.OrderService.ListOrders:input_type -> api.ibkr.order.v1.ListOrdersRequest
-	5,  // 18: api.ibkr.order.v1.OrderService.PlaceOrder:output_type -> api.ibkr.order.v1.PlaceOrderResponse
-	7,  // 19: api.ibkr.order.v1.OrderService.ModifyOrder:output_type -> api.ibkr.order.v1.ModifyOrderResponse
-	9,  // 20: api.ibkr.order.v1.OrderService.CancelOrder:output_type -> api.ibkr.order.v1.CancelOrderResponse
-	11, // 21: api.ibkr.order.v1.OrderService.GetOrder:output_type -> api.ibkr.order.v1.GetOrderResponse
-	13, // 22: api.ibkr.order.v1.OrderService.ListOrders:output_type -> api.ibkr.order.v1.ListOrdersResponse
-	18, // [18:23] is the sub-list for method output_type
-	13, // [13:18] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	4,  // 2: api.ibkr.order.v1.PlaceOrderRequest.time_in_force:type_name -> api.ibkr.order.v1.TimeInForce
+	2,  // 3: api.ibkr.order.v1.PlaceOrderRequest.trailing_type:type_name -> api.ibkr.order.v1.TrailingType
+	3,  // 4: api.ibkr.order.v1.PlaceOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	7,  // 5: api.ibkr.order.v1.PlaceOrderResponse.confirmation:type_name -> api.ibkr.order.v1.OrderConfirmation
+	5,  // 6: api.ibkr.order.v1.PlaceBracketOrderRequest.entry:type_name -> api.ibkr.order.v1.PlaceOrderRequest
+	4,  // 7: api.ibkr.order.v1.PlaceBracketOrderRequest.exit_time_in_force:type_name -> api.ibkr.order.v1.TimeInForce
+	3,  // 8: api.ibkr.order.v1.PlaceBracketOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	7,  // 9: api.ibkr.order.v1.PlaceBracketOrderResponse.confirmation:type_name -> api.ibkr.order.v1.OrderConfirmation
+	5,  // 10: api.ibkr.order.v1.PlaceOrderGroupRequest.orders:type_name -> api.ibkr.order.v1.PlaceOrderRequest
+	3,  // 11: api.ibkr.order.v1.PlaceOrderGroupResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	7,  // 12: api.ibkr.order.v1.PlaceOrderGroupResponse.confirmation:type_name -> api.ibkr.order.v1.OrderConfirmation
+	2,  // 13: api.ibkr.order.v1.ModifyOrderRequest.trailing_type:type_name -> api.ibkr.order.v1.TrailingType
+	3,  // 14: api.ibkr.order.v1.ModifyOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	7,  // 15: api.ibkr.order.v1.ModifyOrderResponse.confirmation:type_name -> api.ibkr.order.v1.OrderConfirmation
+	3,  // 16: api.ibkr.order.v1.CancelOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	22, // 17: api.ibkr.order.v1.GetOrderResponse.order:type_name -> api.ibkr.order.v1.Order
+	3,  // 18: api.ibkr.order.v1.ListOrdersRequest.status_filter:type_name -> api.ibkr.order.v1.OrderStatus
+	22, // 19: api.ibkr.order.v1.ListOrdersResponse.orders:type_name -> api.ibkr.order.v1.Order
+	3,  // 20: api.ibkr.order.v1.ConfirmOrderResponse.status:type_name -> api.ibkr.order.v1.OrderStatus
+	7,  // 21: api.ibkr.order.v1.ConfirmOrderResponse.confirmation:type_name -> api.ibkr.order.v1.OrderConfirmation
+	0,  // 22: api.ibkr.order.v1.Order.side:type_name -> api.ibkr.order.v1.OrderSide
+	1,  // 23: api.ibkr.order.v1.Order.type:type_name -> api.ibkr.order.v1.OrderType
+	4,  // 24: api.ibkr.order.v1.Order.time_in_force:type_name -> api.ibkr.order.v1.TimeInForce
+	3,  // 25: api.ibkr.order.v1.Order.status:type_name -> api.ibkr.order.v1.OrderStatus
+	5,  // 26: api.ibkr.order.v1.OrderService.PlaceOrder:input_type -> api.ibkr.order.v1.PlaceOrderRequest
+	12, // 27: api.ibkr.order.v1.OrderService.ModifyOrder:input_type -> api.ibkr.order.v1.ModifyOrderRequest
+	14, // 28: api.ibkr.order.v1.OrderService.CancelOrder:input_type -> api.ibkr.order.v1.CancelOrderRequest
+	16, // 29: api.ibkr.order.v1.OrderService.GetOrder:input_type -> api.ibkr.order.v1.GetOrderRequest
+	18, // 30: api.ibkr.order.v1.OrderService.ListOrders:input_type -> api.ibkr.order.v1.ListOrdersRequest
+	20, // 31: api.ibkr.order.v1.OrderService.ConfirmOrder:input_type -> api.ibkr.order.v1.ConfirmOrderRequest
+	8,  // 32: api.ibkr.order.v1.OrderService.PlaceBracketOrder:input_type -> api.ibkr.order.v1.PlaceBracketOrderRequest
+	10, // 33: api.ibkr.order.v1.OrderService.PlaceOrderGroup:input_type -> api.ibkr.order.v1.PlaceOrderGroupRequest
+	6,  // 34: api.ibkr.order.v1.OrderService.PlaceOrder:output_type -> api.ibkr.order.v1.PlaceOrderResponse
+	13, // 35: api.ibkr.order.v1.OrderService.ModifyOrder:output_type -> api.ibkr.order.v1.ModifyOrderResponse
+	15, // 36: api.ibkr.order.v1.OrderService.CancelOrder:output_type -> api.ibkr.order.v1.CancelOrderResponse
+	17, // 37: api.ibkr.order.v1.OrderService.GetOrder:output_type -> api.ibkr.order.v1.GetOrderResponse
+	19, // 38: api.ibkr.order.v1.OrderService.ListOrders:output_type -> api.ibkr.order.v1.ListOrdersResponse
+	21, // 39: api.ibkr.order.v1.OrderService.ConfirmOrder:output_type -> api.ibkr.order.v1.ConfirmOrderResponse
+	9,  // 40: api.ibkr.order.v1.OrderService.PlaceBracketOrder:output_type -> api.ibkr.order.v1.PlaceBracketOrderResponse
+	11, // 41: api.ibkr.order.v1.OrderService.PlaceOrderGroup:output_type -> api.ibkr.order.v1.PlaceOrderGroupResponse
+	34, // [34:42] is the sub-list for method output_type
+	26, // [26:34] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_api_ibkr_order_v1_order_proto_init() }
@@ -1196,17 +2057,21 @@ func file_api_ibkr_order_v1_order_proto_init() {
 	if File_api_ibkr_order_v1_order_proto != nil {
 		return
 	}
-	file_api_ibkr_order_v1_order_proto_msgTypes[0].OneofWrappers = []any{}
-	file_api_ibkr_order_v1_order_proto_msgTypes[2].OneofWrappers = []any{}
-	file_api_ibkr_order_v1_order_proto_msgTypes[8].OneofWrappers = []any{}
-	file_api_ibkr_order_v1_order_proto_msgTypes[10].OneofWrappers = []any{}
+	file_api_ibkr_order_v1_order_proto_msgTypes[0].OneofWrappers = []any{
+		(*PlaceOrderRequest_Symbol)(nil),
+		(*PlaceOrderRequest_Conid)(nil),
+	}
+	file_api_ibkr_order_v1_order_proto_msgTypes[5].OneofWrappers = []any{}
+	file_api_ibkr_order_v1_order_proto_msgTypes[7].OneofWrappers = []any{}
+	file_api_ibkr_order_v1_order_proto_msgTypes[13].OneofWrappers = []any{}
+	file_api_ibkr_order_v1_order_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_ibkr_order_v1_order_proto_rawDesc), len(file_api_ibkr_order_v1_order_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   11,
+			NumEnums:      5,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
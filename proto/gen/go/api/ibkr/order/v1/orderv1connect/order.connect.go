@@ -45,6 +45,15 @@ const (
 	OrderServiceGetOrderProcedure = "/api.ibkr.order.v1.OrderService/GetOrder"
 	// OrderServiceListOrdersProcedure is the fully-qualified name of the OrderService's ListOrders RPC.
 	OrderServiceListOrdersProcedure = "/api.ibkr.order.v1.OrderService/ListOrders"
+	// OrderServiceConfirmOrderProcedure is the fully-qualified name of the OrderService's ConfirmOrder
+	// RPC.
+	OrderServiceConfirmOrderProcedure = "/api.ibkr.order.v1.OrderService/ConfirmOrder"
+	// OrderServicePlaceBracketOrderProcedure is the fully-qualified name of the OrderService's
+	// PlaceBracketOrder RPC.
+	OrderServicePlaceBracketOrderProcedure = "/api.ibkr.order.v1.OrderService/PlaceBracketOrder"
+	// OrderServicePlaceOrderGroupProcedure is the fully-qualified name of the OrderService's
+	// PlaceOrderGroup RPC.
+	OrderServicePlaceOrderGroupProcedure = "/api.ibkr.order.v1.OrderService/PlaceOrderGroup"
 )
 
 // OrderServiceClient is a client for the api.ibkr.order.v1.OrderService service.
@@ -59,6 +68,14 @@ type OrderServiceClient interface {
 	GetOrder(context.Context, *connect.Request[v1.GetOrderRequest]) (*connect.Response[v1.GetOrderResponse], error)
 	// ListOrders lists orders for an account.
 	ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error)
+	// ConfirmOrder answers a confirmation question returned by PlaceOrder, ModifyOrder,
+	// PlaceBracketOrder or PlaceOrderGroup.
+	ConfirmOrder(context.Context, *connect.Request[v1.ConfirmOrderRequest]) (*connect.Response[v1.ConfirmOrderResponse], error)
+	// PlaceBracketOrder places an entry order with a take profit and a stop loss order
+	// attached, in one request.
+	PlaceBracketOrder(context.Context, *connect.Request[v1.PlaceBracketOrderRequest]) (*connect.Response[v1.PlaceBracketOrderResponse], error)
+	// PlaceOrderGroup places a one-cancels-all group of orders, in one request.
+	PlaceOrderGroup(context.Context, *connect.Request[v1.PlaceOrderGroupRequest]) (*connect.Response[v1.PlaceOrderGroupResponse], error)
 }
 
 // NewOrderServiceClient constructs a client for the api.ibkr.order.v1.OrderService service. By
@@ -102,16 +119,37 @@ func NewOrderServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(orderServiceMethods.ByName("ListOrders")),
 			connect.WithClientOptions(opts...),
 		),
+		confirmOrder: connect.NewClient[v1.ConfirmOrderRequest, v1.ConfirmOrderResponse](
+			httpClient,
+			baseURL+OrderServiceConfirmOrderProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("ConfirmOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		placeBracketOrder: connect.NewClient[v1.PlaceBracketOrderRequest, v1.PlaceBracketOrderResponse](
+			httpClient,
+			baseURL+OrderServicePlaceBracketOrderProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("PlaceBracketOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		placeOrderGroup: connect.NewClient[v1.PlaceOrderGroupRequest, v1.PlaceOrderGroupResponse](
+			httpClient,
+			baseURL+OrderServicePlaceOrderGroupProcedure,
+			connect.WithSchema(orderServiceMethods.ByName("PlaceOrderGroup")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // orderServiceClient implements OrderServiceClient.
 type orderServiceClient struct {
-	placeOrder  *connect.Client[v1.PlaceOrderRequest, v1.PlaceOrderResponse]
-	modifyOrder *connect.Client[v1.ModifyOrderRequest, v1.ModifyOrderResponse]
-	cancelOrder *connect.Client[v1.CancelOrderRequest, v1.CancelOrderResponse]
-	getOrder    *connect.Client[v1.GetOrderRequest, v1.GetOrderResponse]
-	listOrders  *connect.Client[v1.ListOrdersRequest, v1.ListOrdersResponse]
+	placeOrder        *connect.Client[v1.PlaceOrderRequest, v1.PlaceOrderResponse]
+	modifyOrder       *connect.Client[v1.ModifyOrderRequest, v1.ModifyOrderResponse]
+	cancelOrder       *connect.Client[v1.CancelOrderRequest, v1.CancelOrderResponse]
+	getOrder          *connect.Client[v1.GetOrderRequest, v1.GetOrderResponse]
+	listOrders        *connect.Client[v1.ListOrdersRequest, v1.ListOrdersResponse]
+	confirmOrder      *connect.Client[v1.ConfirmOrderRequest, v1.ConfirmOrderResponse]
+	placeBracketOrder *connect.Client[v1.PlaceBracketOrderRequest, v1.PlaceBracketOrderResponse]
+	placeOrderGroup   *connect.Client[v1.PlaceOrderGroupRequest, v1.PlaceOrderGroupResponse]
 }
 
 // PlaceOrder calls api.ibkr.order.v1.OrderService.PlaceOrder.
@@ -139,6 +177,21 @@ func (c *orderServiceClient) ListOrders(ctx context.Context, req *connect.Reques
 	return c.listOrders.CallUnary(ctx, req)
 }
 
+// ConfirmOrder calls api.ibkr.order.v1.OrderService.ConfirmOrder.
+func (c *orderServiceClient) ConfirmOrder(ctx context.Context, req *connect.Request[v1.ConfirmOrderRequest]) (*connect.Response[v1.ConfirmOrderResponse], error) {
+	return c.confirmOrder.CallUnary(ctx, req)
+}
+
+// PlaceBracketOrder calls api.ibkr.order.v1.OrderService.PlaceBracketOrder.
+func (c *orderServiceClient) PlaceBracketOrder(ctx context.Context, req *connect.Request[v1.PlaceBracketOrderRequest]) (*connect.Response[v1.PlaceBracketOrderResponse], error) {
+	return c.placeBracketOrder.CallUnary(ctx, req)
+}
+
+// PlaceOrderGroup calls api.ibkr.order.v1.OrderService.PlaceOrderGroup.
+func (c *orderServiceClient) PlaceOrderGroup(ctx context.Context, req *connect.Request[v1.PlaceOrderGroupRequest]) (*connect.Response[v1.PlaceOrderGroupResponse], error) {
+	return c.placeOrderGroup.CallUnary(ctx, req)
+}
+
 // OrderServiceHandler is an implementation of the api.ibkr.order.v1.OrderService service.
 type OrderServiceHandler interface {
 	// PlaceOrder places a new order.
@@ -151,6 +204,14 @@ type OrderServiceHandler interface {
 	GetOrder(context.Context, *connect.Request[v1.GetOrderRequest]) (*connect.Response[v1.GetOrderResponse], error)
 	// ListOrders lists orders for an account.
 	ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error)
+	// ConfirmOrder answers a confirmation question returned by PlaceOrder, ModifyOrder,
+	// PlaceBracketOrder or PlaceOrderGroup.
+	ConfirmOrder(context.Context, *connect.Request[v1.ConfirmOrderRequest]) (*connect.Response[v1.ConfirmOrderResponse], error)
+	// PlaceBracketOrder places an entry order with a take profit and a stop loss order
+	// attached, in one request.
+	PlaceBracketOrder(context.Context, *connect.Request[v1.PlaceBracketOrderRequest]) (*connect.Response[v1.PlaceBracketOrderResponse], error)
+	// PlaceOrderGroup places a one-cancels-all group of orders, in one request.
+	PlaceOrderGroup(context.Context, *connect.Request[v1.PlaceOrderGroupRequest]) (*connect.Response[v1.PlaceOrderGroupResponse], error)
 }
 
 // NewOrderServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -190,6 +251,24 @@ func NewOrderServiceHandler(svc OrderServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(orderServiceMethods.ByName("ListOrders")),
 		connect.WithHandlerOptions(opts...),
 	)
+	orderServiceConfirmOrderHandler := connect.NewUnaryHandler(
+		OrderServiceConfirmOrderProcedure,
+		svc.ConfirmOrder,
+		connect.WithSchema(orderServiceMethods.ByName("ConfirmOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	orderServicePlaceBracketOrderHandler := connect.NewUnaryHandler(
+		OrderServicePlaceBracketOrderProcedure,
+		svc.PlaceBracketOrder,
+		connect.WithSchema(orderServiceMethods.ByName("PlaceBracketOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	orderServicePlaceOrderGroupHandler := connect.NewUnaryHandler(
+		OrderServicePlaceOrderGroupProcedure,
+		svc.PlaceOrderGroup,
+		connect.WithSchema(orderServiceMethods.ByName("PlaceOrderGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.ibkr.order.v1.OrderService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case OrderServicePlaceOrderProcedure:
@@ -202,6 +281,12 @@ func NewOrderServiceHandler(svc OrderServiceHandler, opts ...connect.HandlerOpti
 			orderServiceGetOrderHandler.ServeHTTP(w, r)
 		case OrderServiceListOrdersProcedure:
 			orderServiceListOrdersHandler.ServeHTTP(w, r)
+		case OrderServiceConfirmOrderProcedure:
+			orderServiceConfirmOrderHandler.ServeHTTP(w, r)
+		case OrderServicePlaceBracketOrderProcedure:
+			orderServicePlaceBracketOrderHandler.ServeHTTP(w, r)
+		case OrderServicePlaceOrderGroupProcedure:
+			orderServicePlaceOrderGroupHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -230,3 +315,15 @@ func (UnimplementedOrderServiceHandler) GetOrder(context.Context, *connect.Reque
 func (UnimplementedOrderServiceHandler) ListOrders(context.Context, *connect.Request[v1.ListOrdersRequest]) (*connect.Response[v1.ListOrdersResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.order.v1.OrderService.ListOrders is not implemented"))
 }
+
+func (UnimplementedOrderServiceHandler) ConfirmOrder(context.Context, *connect.Request[v1.ConfirmOrderRequest]) (*connect.Response[v1.ConfirmOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.order.v1.OrderService.ConfirmOrder is not implemented"))
+}
+
+func (UnimplementedOrderServiceHandler) PlaceBracketOrder(context.Context, *connect.Request[v1.PlaceBracketOrderRequest]) (*connect.Response[v1.PlaceBracketOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.order.v1.OrderService.PlaceBracketOrder is not implemented"))
+}
+
+func (UnimplementedOrderServiceHandler) PlaceOrderGroup(context.Context, *connect.Request[v1.PlaceOrderGroupRequest]) (*connect.Response[v1.PlaceOrderGroupResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.ibkr.order.v1.OrderService.PlaceOrderGroup is not implemented"))
+}
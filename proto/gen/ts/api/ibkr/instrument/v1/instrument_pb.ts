@@ -0,0 +1,488 @@
+// @generated by protoc-gen-es v2.10.1 with parameter "target=ts"
+// @generated from file api/ibkr/instrument/v1/instrument.proto (package api.ibkr.instrument.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../../buf/validate/validate_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/ibkr/instrument/v1/instrument.proto.
+ */
+export const file_api_ibkr_instrument_v1_instrument: GenFile = /*@__PURE__*/
+  fileDesc("CidhcGkvaWJrci9pbnN0cnVtZW50L3YxL2luc3RydW1lbnQucHJvdG8SFmFwaS5pYmtyLmluc3RydW1lbnQudjEiogEKGFNlYXJjaEluc3RydW1lbnRzUmVxdWVzdBIYCgVxdWVyeRgBIAEoCUIJukgGcgQQARgyEgwKBG5hbWUYAiABKAgSUQoIc2VjX3R5cGUYAyABKAlCOrpIN3I1UgNTVEtSA0lORFIEQk9ORFIDRlVUUgNPUFRSA0ZPUFIDV0FSUgRDQVNIUgNDRkRSBEZVTkRIAIgBAUILCglfc2VjX3R5cGUiVAoZU2VhcmNoSW5zdHJ1bWVudHNSZXNwb25zZRI3CgtpbnN0cnVtZW50cxgBIAMoCzIiLmFwaS5pYmtyLmluc3RydW1lbnQudjEuSW5zdHJ1bWVudCKTAQoKSW5zdHJ1bWVudBINCgVjb25pZBgBIAEoAxIOCgZzeW1ib2wYAiABKAkSFAoMY29tcGFueV9uYW1lGAMgASgJEhMKC2Rlc2NyaXB0aW9uGAQgASgJEjsKCHNlY3Rpb25zGAUgAygLMikuYXBpLmlia3IuaW5zdHJ1bWVudC52MS5JbnN0cnVtZW50U2VjdGlvbiJHChFJbnN0cnVtZW50U2VjdGlvbhIQCghzZWNfdHlwZRgBIAEoCRIQCghleGNoYW5nZRgCIAEoCRIOCgZtb250aHMYAyADKAkiSgoZR2V0Q29udHJhY3REZXRhaWxzUmVxdWVzdBIWCgVjb25pZBgBIAEoA0IHukgEIgIgABIVCg1pbmNsdWRlX3J1bGVzGAIgASgIIlcKGkdldENvbnRyYWN0RGV0YWlsc1Jlc3BvbnNlEjkKCGNvbnRyYWN0GAEgASgLMicuYXBpLmlia3IuaW5zdHJ1bWVudC52MS5Db250cmFjdERldGFpbHMilgMKD0NvbnRyYWN0RGV0YWlscxINCgVjb25pZBgBIAEoAxIOCgZzeW1ib2wYAiABKAkSFAoMbG9jYWxfc3ltYm9sGAMgASgJEhQKDGNvbXBhbnlfbmFtZRgEIAEoCRIQCghzZWNfdHlwZRgFIAEoCRIQCghleGNoYW5nZRgGIAEoCRIXCg92YWxpZF9leGNoYW5nZXMYByADKAkSEAoIY3VycmVuY3kYCCABKAkSFQoNdHJhZGluZ19jbGFzcxgJIAEoCRISCgptdWx0aXBsaWVyGAogASgJEhAKCGluZHVzdHJ5GAsgASgJEhAKCGNhdGVnb3J5GAwgASgJEg0KBWN1c2lwGA0gASgJEhUKDW1hdHVyaXR5X2RhdGUYDiABKAkSFgoOY29udHJhY3RfbW9udGgYDyABKAkSGAoQdW5kZXJseWluZ19jb25pZBgQIAEoAxI4CgVydWxlcxgRIAEoCzIkLmFwaS5pYmtyLmluc3RydW1lbnQudjEuVHJhZGluZ1J1bGVzSACIAQFCCAoGX3J1bGVzIj4KFkdldFRyYWRpbmdSdWxlc1JlcXVlc3QSFgoFY29uaWQYASABKANCB7pIBCICIAASDAoEc2VsbBgCIAEoCCJOChdHZXRUcmFkaW5nUnVsZXNSZXNwb25zZRIzCgVydWxlcxgBIAEoCzIkLmFwaS5pYmtyLmluc3RydW1lbnQudjEuVHJhZGluZ1J1bGVzIp0CCgxUcmFkaW5nUnVsZXMSFwoPcHJpY2VfaW5jcmVtZW50GAEgASgBEkAKEHByaWNlX2luY3JlbWVudHMYAiADKAsyJi5hcGkuaWJrci5pbnN0cnVtZW50LnYxLlByaWNlSW5jcmVtZW50EhYKDnByaWNlX2RlY2ltYWxzGAMgASgFEhYKDnNpemVfaW5jcmVtZW50GAQgASgBEhAKCG1pbl9zaXplGAUgASgBEhQKDGRlZmF1bHRfc2l6ZRgGIAEoARITCgtvcmRlcl90eXBlcxgHIAMoCRIfChdvcmRlcl90eXBlc19vdXRzaWRlX3J0aBgIIAMoCRIVCg10aW1lX2luX2ZvcmNlGAkgAygJEg0KBWVycm9yGAogASgJIjcKDlByaWNlSW5jcmVtZW50EhIKCmxvd2VyX2VkZ2UYASABKAESEQoJaW5jcmVtZW50GAIgASgBMv4CChFJbnN0cnVtZW50U2VydmljZRJ4ChFTZWFyY2hJbnN0cnVtZW50cxIwLmFwaS5pYmtyLmluc3RydW1lbnQudjEuU2VhcmNoSW5zdHJ1bWVudHNSZXF1ZXN0GjEuYXBpLmlia3IuaW5zdHJ1bWVudC52MS5TZWFyY2hJbnN0cnVtZW50c1Jlc3BvbnNlEnsKEkdldENvbnRyYWN0RGV0YWlscxIxLmFwaS5pYmtyLmluc3RydW1lbnQudjEuR2V0Q29udHJhY3REZXRhaWxzUmVxdWVzdBoyLmFwaS5pYmtyLmluc3RydW1lbnQudjEuR2V0Q29udHJhY3REZXRhaWxzUmVzcG9uc2UScgoPR2V0VHJhZGluZ1J1bGVzEi4uYXBpLmlia3IuaW5zdHJ1bWVudC52MS5HZXRUcmFkaW5nUnVsZXNSZXF1ZXN0Gi8uYXBpLmlia3IuaW5zdHJ1bWVudC52MS5HZXRUcmFkaW5nUnVsZXNSZXNwb25zZUL9AQoaY29tLmFwaS5pYmtyLmluc3RydW1lbnQudjFCD0luc3RydW1lbnRQcm90b1ABWlNnaXRodWIuY29tL21hamlkbXZ1bGxlL2lia3ItY2xpZW50L3Byb3RvL2dlbi9nby9hcGkvaWJrci9pbnN0cnVtZW50L3YxO2luc3RydW1lbnR2MaICA0FJSaoCFkFwaS5JYmtyLkluc3RydW1lbnQuVjHKAhZBcGlcSWJrclxJbnN0cnVtZW50XFYx4gIiQXBpXElia3JcSW5zdHJ1bWVudFxWMVxHUEJNZXRhZGF0YeoCGUFwaTo6SWJrcjo6SW5zdHJ1bWVudDo6VjFiBnByb3RvMw", [file_buf_validate_validate]);
+
+/**
+ * SearchInstrumentsRequest contains parameters for searching instruments.
+ *
+ * @generated from message api.ibkr.instrument.v1.SearchInstrumentsRequest
+ */
+export type SearchInstrumentsRequest = Message<"api.ibkr.instrument.v1.SearchInstrumentsRequest"> & {
+  /**
+   * Symbol, or company name when name is set, e.g. "AAPL" or "Apple".
+   *
+   * @generated from field: string query = 1;
+   */
+  query: string;
+
+  /**
+   * Search by company name rather than by symbol.
+   *
+   * @generated from field: bool name = 2;
+   */
+  name: boolean;
+
+  /**
+   * Security type to restrict the results to, e.g. "STK".
+   *
+   * @generated from field: optional string sec_type = 3;
+   */
+  secType?: string;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.SearchInstrumentsRequest.
+ * Use `create(SearchInstrumentsRequestSchema)` to create a new message.
+ */
+export const SearchInstrumentsRequestSchema: GenMessage<SearchInstrumentsRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 0);
+
+/**
+ * SearchInstrumentsResponse contains the instruments found.
+ *
+ * @generated from message api.ibkr.instrument.v1.SearchInstrumentsResponse
+ */
+export type SearchInstrumentsResponse = Message<"api.ibkr.instrument.v1.SearchInstrumentsResponse"> & {
+  /**
+   * @generated from field: repeated api.ibkr.instrument.v1.Instrument instruments = 1;
+   */
+  instruments: Instrument[];
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.SearchInstrumentsResponse.
+ * Use `create(SearchInstrumentsResponseSchema)` to create a new message.
+ */
+export const SearchInstrumentsResponseSchema: GenMessage<SearchInstrumentsResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 1);
+
+/**
+ * Instrument represents an instrument found by a search.
+ *
+ * @generated from message api.ibkr.instrument.v1.Instrument
+ */
+export type Instrument = Message<"api.ibkr.instrument.v1.Instrument"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+
+  /**
+   * @generated from field: string symbol = 2;
+   */
+  symbol: string;
+
+  /**
+   * @generated from field: string company_name = 3;
+   */
+  companyName: string;
+
+  /**
+   * Listing description, usually the primary exchange, e.g. "NASDAQ".
+   *
+   * @generated from field: string description = 4;
+   */
+  description: string;
+
+  /**
+   * @generated from field: repeated api.ibkr.instrument.v1.InstrumentSection sections = 5;
+   */
+  sections: InstrumentSection[];
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.Instrument.
+ * Use `create(InstrumentSchema)` to create a new message.
+ */
+export const InstrumentSchema: GenMessage<Instrument> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 2);
+
+/**
+ * InstrumentSection represents a security type the instrument is traded as, e.g. its
+ * stock or its options.
+ *
+ * @generated from message api.ibkr.instrument.v1.InstrumentSection
+ */
+export type InstrumentSection = Message<"api.ibkr.instrument.v1.InstrumentSection"> & {
+  /**
+   * @generated from field: string sec_type = 1;
+   */
+  secType: string;
+
+  /**
+   * @generated from field: string exchange = 2;
+   */
+  exchange: string;
+
+  /**
+   * Expiration months of derivatives, in the Gateway format, e.g. "JAN24".
+   *
+   * @generated from field: repeated string months = 3;
+   */
+  months: string[];
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.InstrumentSection.
+ * Use `create(InstrumentSectionSchema)` to create a new message.
+ */
+export const InstrumentSectionSchema: GenMessage<InstrumentSection> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 3);
+
+/**
+ * GetContractDetailsRequest contains parameters for retrieving contract details.
+ *
+ * @generated from message api.ibkr.instrument.v1.GetContractDetailsRequest
+ */
+export type GetContractDetailsRequest = Message<"api.ibkr.instrument.v1.GetContractDetailsRequest"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+
+  /**
+   * Include the trading rules of the contract, for buy orders.
+   *
+   * @generated from field: bool include_rules = 2;
+   */
+  includeRules: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.GetContractDetailsRequest.
+ * Use `create(GetContractDetailsRequestSchema)` to create a new message.
+ */
+export const GetContractDetailsRequestSchema: GenMessage<GetContractDetailsRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 4);
+
+/**
+ * GetContractDetailsResponse contains the details of a contract.
+ *
+ * @generated from message api.ibkr.instrument.v1.GetContractDetailsResponse
+ */
+export type GetContractDetailsResponse = Message<"api.ibkr.instrument.v1.GetContractDetailsResponse"> & {
+  /**
+   * @generated from field: api.ibkr.instrument.v1.ContractDetails contract = 1;
+   */
+  contract?: ContractDetails;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.GetContractDetailsResponse.
+ * Use `create(GetContractDetailsResponseSchema)` to create a new message.
+ */
+export const GetContractDetailsResponseSchema: GenMessage<GetContractDetailsResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 5);
+
+/**
+ * ContractDetails represents the details of a contract.
+ *
+ * @generated from message api.ibkr.instrument.v1.ContractDetails
+ */
+export type ContractDetails = Message<"api.ibkr.instrument.v1.ContractDetails"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+
+  /**
+   * @generated from field: string symbol = 2;
+   */
+  symbol: string;
+
+  /**
+   * @generated from field: string local_symbol = 3;
+   */
+  localSymbol: string;
+
+  /**
+   * @generated from field: string company_name = 4;
+   */
+  companyName: string;
+
+  /**
+   * @generated from field: string sec_type = 5;
+   */
+  secType: string;
+
+  /**
+   * Primary exchange of the contract.
+   *
+   * @generated from field: string exchange = 6;
+   */
+  exchange: string;
+
+  /**
+   * Exchanges the contract can be routed to.
+   *
+   * @generated from field: repeated string valid_exchanges = 7;
+   */
+  validExchanges: string[];
+
+  /**
+   * @generated from field: string currency = 8;
+   */
+  currency: string;
+
+  /**
+   * @generated from field: string trading_class = 9;
+   */
+  tradingClass: string;
+
+  /**
+   * @generated from field: string multiplier = 10;
+   */
+  multiplier: string;
+
+  /**
+   * @generated from field: string industry = 11;
+   */
+  industry: string;
+
+  /**
+   * @generated from field: string category = 12;
+   */
+  category: string;
+
+  /**
+   * @generated from field: string cusip = 13;
+   */
+  cusip: string;
+
+  /**
+   * Expiration date of a derivative (YYYYMMDD).
+   *
+   * @generated from field: string maturity_date = 14;
+   */
+  maturityDate: string;
+
+  /**
+   * Contract month of a derivative (YYYYMM).
+   *
+   * @generated from field: string contract_month = 15;
+   */
+  contractMonth: string;
+
+  /**
+   * @generated from field: int64 underlying_conid = 16;
+   */
+  underlyingConid: bigint;
+
+  /**
+   * Set when include_rules was requested.
+   *
+   * @generated from field: optional api.ibkr.instrument.v1.TradingRules rules = 17;
+   */
+  rules?: TradingRules;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.ContractDetails.
+ * Use `create(ContractDetailsSchema)` to create a new message.
+ */
+export const ContractDetailsSchema: GenMessage<ContractDetails> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 6);
+
+/**
+ * GetTradingRulesRequest contains parameters for retrieving trading rules.
+ *
+ * @generated from message api.ibkr.instrument.v1.GetTradingRulesRequest
+ */
+export type GetTradingRulesRequest = Message<"api.ibkr.instrument.v1.GetTradingRulesRequest"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+
+  /**
+   * Retrieve the rules of sell orders rather than of buy orders.
+   *
+   * @generated from field: bool sell = 2;
+   */
+  sell: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.GetTradingRulesRequest.
+ * Use `create(GetTradingRulesRequestSchema)` to create a new message.
+ */
+export const GetTradingRulesRequestSchema: GenMessage<GetTradingRulesRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 7);
+
+/**
+ * GetTradingRulesResponse contains the trading rules of a contract.
+ *
+ * @generated from message api.ibkr.instrument.v1.GetTradingRulesResponse
+ */
+export type GetTradingRulesResponse = Message<"api.ibkr.instrument.v1.GetTradingRulesResponse"> & {
+  /**
+   * @generated from field: api.ibkr.instrument.v1.TradingRules rules = 1;
+   */
+  rules?: TradingRules;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.GetTradingRulesResponse.
+ * Use `create(GetTradingRulesResponseSchema)` to create a new message.
+ */
+export const GetTradingRulesResponseSchema: GenMessage<GetTradingRulesResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 8);
+
+/**
+ * TradingRules represents the rules orders of a contract must follow.
+ *
+ * @generated from message api.ibkr.instrument.v1.TradingRules
+ */
+export type TradingRules = Message<"api.ibkr.instrument.v1.TradingRules"> & {
+  /**
+   * Price increment, the tick size, of the lowest price band.
+   *
+   * @generated from field: double price_increment = 1;
+   */
+  priceIncrement: number;
+
+  /**
+   * Price increments by price band, when they vary with the price.
+   *
+   * @generated from field: repeated api.ibkr.instrument.v1.PriceIncrement price_increments = 2;
+   */
+  priceIncrements: PriceIncrement[];
+
+  /**
+   * Decimals of prices at the price increment.
+   *
+   * @generated from field: int32 price_decimals = 3;
+   */
+  priceDecimals: number;
+
+  /**
+   * Quantities must be multiples of the size increment.
+   *
+   * @generated from field: double size_increment = 4;
+   */
+  sizeIncrement: number;
+
+  /**
+   * Smallest quantity of an order, the size increment.
+   *
+   * @generated from field: double min_size = 5;
+   */
+  minSize: number;
+
+  /**
+   * @generated from field: double default_size = 6;
+   */
+  defaultSize: number;
+
+  /**
+   * Order types allowed, in the Gateway format, e.g. "limit" or "stop_limit".
+   *
+   * @generated from field: repeated string order_types = 7;
+   */
+  orderTypes: string[];
+
+  /**
+   * Order types allowed outside regular trading hours.
+   *
+   * @generated from field: repeated string order_types_outside_rth = 8;
+   */
+  orderTypesOutsideRth: string[];
+
+  /**
+   * Times in force allowed, e.g. "DAY" or "GTC".
+   *
+   * @generated from field: repeated string time_in_force = 9;
+   */
+  timeInForce: string[];
+
+  /**
+   * Why the contract cannot be traded, when it cannot.
+   *
+   * @generated from field: string error = 10;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.TradingRules.
+ * Use `create(TradingRulesSchema)` to create a new message.
+ */
+export const TradingRulesSchema: GenMessage<TradingRules> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 9);
+
+/**
+ * PriceIncrement is the price increment of the prices from lower_edge up to the lower edge
+ * of the next band.
+ *
+ * @generated from message api.ibkr.instrument.v1.PriceIncrement
+ */
+export type PriceIncrement = Message<"api.ibkr.instrument.v1.PriceIncrement"> & {
+  /**
+   * @generated from field: double lower_edge = 1;
+   */
+  lowerEdge: number;
+
+  /**
+   * @generated from field: double increment = 2;
+   */
+  increment: number;
+};
+
+/**
+ * Describes the message api.ibkr.instrument.v1.PriceIncrement.
+ * Use `create(PriceIncrementSchema)` to create a new message.
+ */
+export const PriceIncrementSchema: GenMessage<PriceIncrement> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_instrument_v1_instrument, 10);
+
+/**
+ * InstrumentService handles instrument search and contract lookups.
+ *
+ * @generated from service api.ibkr.instrument.v1.InstrumentService
+ */
+export const InstrumentService: GenService<{
+  /**
+   * SearchInstruments searches for instruments by symbol or company name.
+   *
+   * @generated from rpc api.ibkr.instrument.v1.InstrumentService.SearchInstruments
+   */
+  searchInstruments: {
+    methodKind: "unary";
+    input: typeof SearchInstrumentsRequestSchema;
+    output: typeof SearchInstrumentsResponseSchema;
+  },
+  /**
+   * GetContractDetails retrieves the details of a contract, optionally with its trading rules.
+   *
+   * @generated from rpc api.ibkr.instrument.v1.InstrumentService.GetContractDetails
+   */
+  getContractDetails: {
+    methodKind: "unary";
+    input: typeof GetContractDetailsRequestSchema;
+    output: typeof GetContractDetailsResponseSchema;
+  },
+  /**
+   * GetTradingRules retrieves the trading rules of a contract: its price increments,
+   * size increment and the order types and times in force it accepts.
+   *
+   * @generated from rpc api.ibkr.instrument.v1.InstrumentService.GetTradingRules
+   */
+  getTradingRules: {
+    methodKind: "unary";
+    input: typeof GetTradingRulesRequestSchema;
+    output: typeof GetTradingRulesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_ibkr_instrument_v1_instrument, 0);
+
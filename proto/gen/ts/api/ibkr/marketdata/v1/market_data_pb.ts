@@ -2,16 +2,18 @@
 // @generated from file api/ibkr/marketdata/v1/market_data.proto (package api.ibkr.marketdata.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import { file_buf_validate_validate } from "../../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file api/ibkr/marketdata/v1/market_data.proto.
  */
 export const file_api_ibkr_marketdata_v1_market_data: GenFile = /*@__PURE__*/
-  fileDesc("CihhcGkvaWJrci9tYXJrZXRkYXRhL3YxL21hcmtldF9kYXRhLnByb3RvEhZhcGkuaWJrci5tYXJrZXRkYXRhLnYxIjkKD0dldFF1b3RlUmVxdWVzdBImCgZzeW1ib2wYASABKAlCFrpIE3IREAEYFDILXltBLVowLTldKyQiQAoQR2V0UXVvdGVSZXNwb25zZRIsCgVxdW90ZRgBIAEoCzIdLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuUXVvdGUimgEKBVF1b3RlEg4KBnN5bWJvbBgBIAEoCRILCgNiaWQYAiABKAESCwoDYXNrGAMgASgBEgwKBGxhc3QYBCABKAESDgoGdm9sdW1lGAUgASgDEgwKBGhpZ2gYBiABKAESCwoDbG93GAcgASgBEgwKBG9wZW4YCCABKAESDQoFY2xvc2UYCSABKAESEQoJdGltZXN0YW1wGAogASgJIqQBChhHZXRIaXN0b3JpY2FsRGF0YVJlcXVlc3QSJgoGc3ltYm9sGAEgASgJQha6SBNyERABGBQyC15bQS1aMC05XSskEhkKBnBlcmlvZBgCIAEoCUIJukgGcgQQARgKEhsKCGJhcl9zaXplGAMgASgJQgm6SAZyBBABGAoSHgoFbGltaXQYBCABKAVCCrpIBxoFGJBOKAFIAIgBAUIICgZfbGltaXQiRgoZR2V0SGlzdG9yaWNhbERhdGFSZXNwb25zZRIpCgRiYXJzGAEgAygLMhsuYXBpLmlia3IubWFya2V0ZGF0YS52MS5CYXIiYAoDQmFyEhEKCXRpbWVzdGFtcBgBIAEoCRIMCgRvcGVuGAIgASgBEgwKBGhpZ2gYAyABKAESCwoDbG93GAQgASgBEg0KBWNsb3NlGAUgASgBEg4KBnZvbHVtZRgGIAEoAyI9ChNTdHJlYW1RdW90ZXNSZXF1ZXN0EiYKBnN5bWJvbBgBIAEoCUIWukgTchEQARgUMgteW0EtWjAtOV0rJCJEChRTdHJlYW1RdW90ZXNSZXNwb25zZRIsCgVxdW90ZRgBIAEoCzIdLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuUXVvdGUy2QIKEU1hcmtldERhdGFTZXJ2aWNlEl0KCEdldFF1b3RlEicuYXBpLmlia3IubWFya2V0ZGF0YS52MS5HZXRRdW90ZVJlcXVlc3QaKC5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldFF1b3RlUmVzcG9uc2USeAoRR2V0SGlzdG9yaWNhbERhdGESMC5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldEhpc3RvcmljYWxEYXRhUmVxdWVzdBoxLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuR2V0SGlzdG9yaWNhbERhdGFSZXNwb25zZRJrCgxTdHJlYW1RdW90ZXMSKy5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlN0cmVhbVF1b3Rlc1JlcXVlc3QaLC5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlN0cmVhbVF1b3Rlc1Jlc3BvbnNlMAFC/QEKGmNvbS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxQg9NYXJrZXREYXRhUHJvdG9QAVpTZ2l0aHViLmNvbS9tYWppZG12dWxsZS9pYmtyLWNsaWVudC9wcm90by9nZW4vZ28vYXBpL2lia3IvbWFya2V0ZGF0YS92MTttYXJrZXRkYXRhdjGiAgNBSU2qAhZBcGkuSWJrci5NYXJrZXRkYXRhLlYxygIWQXBpXElia3JcTWFya2V0ZGF0YVxWMeICIkFwaVxJYmtyXE1hcmtldGRhdGFcVjFcR1BCTWV0YWRhdGHqAhlBcGk6Oklia3I6Ok1hcmtldGRhdGE6OlYxYgZwcm90bzM", [file_buf_validate_validate]);
+  fileDesc("CihhcGkvaWJrci9tYXJrZXRkYXRhL3YxL21hcmtldF9kYXRhLnByb3RvEhZhcGkuaWJrci5tYXJrZXRkYXRhLnYxIpsDCg9HZXRRdW90ZVJlcXVlc3QSOQoGc3ltYm9sGAEgASgJQie6SCRyIhABGBQyHF5bQS1aMC05XSsoWy0uIF1bQS1aMC05XSspKiRIABIYCgVjb25pZBgHIAEoA0IHukgEIgIgAEgAEi4KCGV4Y2hhbmdlGAIgASgJQhe6SBRyEhABGBQyDF5bQS1aMC05Ll0rJEgBiAEBEigKCGN1cnJlbmN5GAMgASgJQhG6SA5yDDIKXltBLVpdezN9JEgCiAEBEjEKCHNlY190eXBlGAQgASgJQhq6SBdyFVIDU1RLUgNJTkRSBEJPTkRSA0ZVVEgDiAEBEjEKBmV4cGlyeRgFIAEoCUIcukgZchcyFV5bMC05XXs2fShbMC05XXsyfSk/JEgEiAEBEiwKBmZpZWxkcxgGIAMoCUIcukgZkgEWEDIYASIQcg4yDF5bYS16MC05X10rJEITCgppbnN0cnVtZW50EgW6SAIIAUILCglfZXhjaGFuZ2VCCwoJX2N1cnJlbmN5QgsKCV9zZWNfdHlwZUIJCgdfZXhwaXJ5IkAKEEdldFF1b3RlUmVzcG9uc2USLAoFcXVvdGUYASABKAsyHS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlF1b3RlIsECCgVRdW90ZRIOCgZzeW1ib2wYASABKAkSCwoDYmlkGAIgASgBEgsKA2FzaxgDIAEoARIMCgRsYXN0GAQgASgBEg4KBnZvbHVtZRgFIAEoAxIMCgRoaWdoGAYgASgBEgsKA2xvdxgHIAEoARIMCgRvcGVuGAggASgBEg0KBWNsb3NlGAkgASgBEi4KCnVwZGF0ZWRfYXQYDCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjkKBmZpZWxkcxgLIAMoCzIpLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuUXVvdGUuRmllbGRzRW50cnkSDQoFY29uaWQYDSABKAMaLQoLRmllbGRzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUoECAoQC1IJdGltZXN0YW1wIosBChBHZXRRdW90ZXNSZXF1ZXN0EkkKC2luc3RydW1lbnRzGAEgAygLMicuYXBpLmlia3IubWFya2V0ZGF0YS52MS5RdW90ZUluc3RydW1lbnRCC7pICJIBBQgBEPQDEiwKBmZpZWxkcxgCIAMoCUIcukgZkgEWEDIYASIQcg4yDF5bYS16MC05X10rJCLtAgoPUXVvdGVJbnN0cnVtZW50EjkKBnN5bWJvbBgBIAEoCUInukgkciIQARgUMhxeW0EtWjAtOV0rKFstLiBdW0EtWjAtOV0rKSokSAASGAoFY29uaWQYBiABKANCB7pIBCICIABIABIuCghleGNoYW5nZRgCIAEoCUIXukgUchIQARgUMgxeW0EtWjAtOS5dKyRIAYgBARIoCghjdXJyZW5jeRgDIAEoCUIRukgOcgwyCl5bQS1aXXszfSRIAogBARIxCghzZWNfdHlwZRgEIAEoCUIaukgXchVSA1NUS1IDSU5EUgRCT05EUgNGVVRIA4gBARIxCgZleHBpcnkYBSABKAlCHLpIGXIXMhVeWzAtOV17Nn0oWzAtOV17Mn0pPyRIBIgBAUITCgppbnN0cnVtZW50EgW6SAIIAUILCglfZXhjaGFuZ2VCCwoJX2N1cnJlbmN5QgsKCV9zZWNfdHlwZUIJCgdfZXhwaXJ5IkkKEUdldFF1b3Rlc1Jlc3BvbnNlEjQKB3Jlc3VsdHMYASADKAsyIy5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlF1b3RlUmVzdWx0IpsBCgtRdW90ZVJlc3VsdBIOCgZzeW1ib2wYASABKAkSDQoFY29uaWQYBCABKAMSLgoFcXVvdGUYAiABKAsyHS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlF1b3RlSAASMwoFZXJyb3IYAyABKAsyIi5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlF1b3RlRXJyb3JIAEIICgZyZXN1bHQiKwoKUXVvdGVFcnJvchIMCgRjb2RlGAEgASgJEg8KB21lc3NhZ2UYAiABKAkinwQKGEdldEhpc3RvcmljYWxEYXRhUmVxdWVzdBI5CgZzeW1ib2wYASABKAlCJ7pIJHIiEAEYFDIcXltBLVowLTldKyhbLS4gXVtBLVowLTldKykqJEgAEhgKBWNvbmlkGAsgASgDQge6SAQiAiAASAASGQoGcGVyaW9kGAIgASgJQgm6SAZyBBABGAoSGwoIYmFyX3NpemUYAyABKAlCCbpIBnIEEAEYChIeCgVsaW1pdBgEIAEoBUIKukgHGgUYkE4oAUgBiAEBEi4KCGV4Y2hhbmdlGAUgASgJQhe6SBRyEhABGBQyDF5bQS1aMC05Ll0rJEgCiAEBEigKCGN1cnJlbmN5GAYgASgJQhG6SA5yDDIKXltBLVpdezN9JEgDiAEBEjEKCHNlY190eXBlGAcgASgJQhq6SBdyFVIDU1RLUgNJTkRSBEJPTkRSA0ZVVEgEiAEBEjEKBmV4cGlyeRgIIAEoCUIcukgZchcyFV5bMC05XXs2fShbMC05XXsyfSk/JEgFiAEBEiEKCnBhZ2VfdG9rZW4YCSABKAlCCLpIBXIDGMgBSAaIAQESEwoLb3V0c2lkZV9ydGgYCiABKAhCEwoKaW5zdHJ1bWVudBIFukgCCAFCCAoGX2xpbWl0QgsKCV9leGNoYW5nZUILCglfY3VycmVuY3lCCwoJX3NlY190eXBlQgkKB19leHBpcnlCDQoLX3BhZ2VfdG9rZW4ihQEKGUdldEhpc3RvcmljYWxEYXRhUmVzcG9uc2USKQoEYmFycxgBIAMoCzIbLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuQmFyEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCRIPCgdkZWxheWVkGAMgASgIEhMKC291dHNpZGVfcnRoGAQgASgIIo0BCgNCYXISLQoJb3BlbmVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIMCgRvcGVuGAIgASgBEgwKBGhpZ2gYAyABKAESCwoDbG93GAQgASgBEg0KBWNsb3NlGAUgASgBEg4KBnZvbHVtZRgGIAEoA0oECAEQAlIJdGltZXN0YW1wIqAEChRHZXRJbmRpY2F0b3JzUmVxdWVzdBI5CgZzeW1ib2wYASABKAlCJ7pIJHIiEAEYFDIcXltBLVowLTldKyhbLS4gXVtBLVowLTldKykqJEgAEhgKBWNvbmlkGAogASgDQge6SAQiAiAASAASGQoGcGVyaW9kGAIgASgJQgm6SAZyBBABGAoSNQoIYmFyX3NpemUYAyABKAlCI7pIIHIeMhxeWzEtOV1bMC05XXswLDN9KG1pbnxofGR8dykkEkUKCmluZGljYXRvcnMYBCADKAsyJS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkluZGljYXRvclNwZWNCCrpIB5IBBAgBEBQSLgoIZXhjaGFuZ2UYBSABKAlCF7pIFHISEAEYFDIMXltBLVowLTkuXSskSAGIAQESKAoIY3VycmVuY3kYBiABKAlCEbpIDnIMMgpeW0EtWl17M30kSAKIAQESMQoIc2VjX3R5cGUYByABKAlCGrpIF3IVUgNTVEtSA0lORFIEQk9ORFIDRlVUSAOIAQESMQoGZXhwaXJ5GAggASgJQhy6SBlyFzIVXlswLTldezZ9KFswLTldezJ9KT8kSASIAQESEwoLb3V0c2lkZV9ydGgYCSABKAhCEwoKaW5zdHJ1bWVudBIFukgCCAFCCwoJX2V4Y2hhbmdlQgsKCV9jdXJyZW5jeUILCglfc2VjX3R5cGVCCQoHX2V4cGlyeSKIBAoNSW5kaWNhdG9yU3BlYxI/CgR0eXBlGAEgASgOMiUuYXBpLmlia3IubWFya2V0ZGF0YS52MS5JbmRpY2F0b3JUeXBlQgq6SAeCAQQQASAAEh8KBnBlcmlvZBgCIAEoBUIKukgHGgUY6AcoAUgAiAEBEiQKC2Zhc3RfcGVyaW9kGAMgASgFQgq6SAcaBRjoBygBSAGIAQESJAoLc2xvd19wZXJpb2QYBCABKAVCCrpIBxoFGOgHKAFIAogBARImCg1zaWduYWxfcGVyaW9kGAUgASgFQgq6SAcaBRjoBygBSAOIAQESLQoHc3RkX2RldhgGIAEoAUIXukgUEhIZAAAAAAAAJEAhAAAAAAAAAABIBIgBATqoAbpIpAEaoQEKG2luZGljYXRvcl9zcGVjLm1hY2RfcGVyaW9kcxIpZmFzdF9wZXJpb2QgbXVzdCBiZSBsZXNzIHRoYW4gc2xvd19wZXJpb2QaVyFoYXModGhpcy5mYXN0X3BlcmlvZCkgfHwgIWhhcyh0aGlzLnNsb3dfcGVyaW9kKSB8fCB0aGlzLmZhc3RfcGVyaW9kIDwgdGhpcy5zbG93X3BlcmlvZEIJCgdfcGVyaW9kQg4KDF9mYXN0X3BlcmlvZEIOCgxfc2xvd19wZXJpb2RCEAoOX3NpZ25hbF9wZXJpb2RCCgoIX3N0ZF9kZXYiigEKFUdldEluZGljYXRvcnNSZXNwb25zZRIpCgRiYXJzGAEgAygLMhsuYXBpLmlia3IubWFya2V0ZGF0YS52MS5CYXISNQoKaW5kaWNhdG9ycxgCIAMoCzIhLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuSW5kaWNhdG9yEg8KB2RlbGF5ZWQYAyABKAgieQoJSW5kaWNhdG9yEjMKBHNwZWMYASABKAsyJS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkluZGljYXRvclNwZWMSNwoGc2VyaWVzGAIgAygLMicuYXBpLmlia3IubWFya2V0ZGF0YS52MS5JbmRpY2F0b3JTZXJpZXMiLwoPSW5kaWNhdG9yU2VyaWVzEgwKBG5hbWUYASABKAkSDgoGdmFsdWVzGAIgAygBIpgFChNTdHJlYW1RdW90ZXNSZXF1ZXN0EjkKBnN5bWJvbBgBIAEoCUInukgkciIQARgUMhxeW0EtWjAtOV0rKFstLiBdW0EtWjAtOV0rKSokSAASGAoFY29uaWQYCSABKANCB7pIBCICIABIABIuCghleGNoYW5nZRgCIAEoCUIXukgUchIQARgUMgxeW0EtWjAtOS5dKyRIAYgBARIoCghjdXJyZW5jeRgDIAEoCUIRukgOcgwyCl5bQS1aXXszfSRIAogBARIxCghzZWNfdHlwZRgEIAEoCUIaukgXchVSA1NUS1IDSU5EUgRCT05EUgNGVVRIA4gBARIxCgZleHBpcnkYBSABKAlCHLpIGXIXMhVeWzAtOV17Nn0oWzAtOV17Mn0pPyRIBIgBARJGCgtpbnN0cnVtZW50cxgGIAMoCzInLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuUXVvdGVJbnN0cnVtZW50Qgi6SAWSAQIQZBIhCgtpbnRlcnZhbF9tcxgHIAEoBUIHukgEGgIgAEgFiAEBEg0KBWRlbHRhGAggASgIOqEBukidARqaAQoYc3RyZWFtX3F1b3Rlcy5pbnN0cnVtZW50EjdleGFjdGx5IG9uZSBvZiBzeW1ib2wsIGNvbmlkIG9yIGluc3RydW1lbnRzIG11c3QgYmUgc2V0GkUoaGFzKHRoaXMuc3ltYm9sKSB8fCBoYXModGhpcy5jb25pZCkpICE9IChzaXplKHRoaXMuaW5zdHJ1bWVudHMpID4gMClCDAoKaW5zdHJ1bWVudEILCglfZXhjaGFuZ2VCCwoJX2N1cnJlbmN5QgsKCV9zZWNfdHlwZUIJCgdfZXhwaXJ5Qg4KDF9pbnRlcnZhbF9tcyJ9ChRTdHJlYW1RdW90ZXNSZXNwb25zZRIsCgVxdW90ZRgBIAEoCzIdLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuUXVvdGUSFgoOY2hhbmdlZF9maWVsZHMYAiADKAkSDAoEZnVsbBgDIAEoCBIRCgloZWFydGJlYXQYBCABKAgiiAQKFUdldE9wdGlvbkNoYWluUmVxdWVzdBI3CgZzeW1ib2wYASABKAlCJ7pIJHIiEAEYFDIcXltBLVowLTldKyhbLS4gXVtBLVowLTldKykqJBIuCghleGNoYW5nZRgCIAEoCUIXukgUchIQARgUMgxeW0EtWjAtOS5dKyRIAIgBARIoCghjdXJyZW5jeRgDIAEoCUIRukgOcgwyCl5bQS1aXXszfSRIAYgBARImCghzZWNfdHlwZRgEIAEoCUIPukgMcgpSA1NUS1IDSU5ESAKIAQESLQoFbW9udGgYBSABKAlCGbpIFnIUMhJeW0EtWl17M31bMC05XXsyfSRIA4gBARIqCgpleHBpcmF0aW9uGAYgASgJQhG6SA5yDDIKXlswLTldezh9JEgEiAEBEh8KBXJpZ2h0GAcgASgJQgu6SAhyBlIBQ1IBUEgFiAEBEicKCm1pbl9zdHJpa2UYCCABKAFCDrpICxIJKQAAAAAAAAAASAaIAQESJwoKbWF4X3N0cmlrZRgJIAEoAUIOukgLEgkhAAAAAAAAAABIB4gBAUILCglfZXhjaGFuZ2VCCwoJX2N1cnJlbmN5QgsKCV9zZWNfdHlwZUIICgZfbW9udGhCDQoLX2V4cGlyYXRpb25CCAoGX3JpZ2h0Qg0KC19taW5fc3RyaWtlQg0KC19tYXhfc3RyaWtlIsIBChZHZXRPcHRpb25DaGFpblJlc3BvbnNlEg4KBnN5bWJvbBgBIAEoCRIYChB1bmRlcmx5aW5nX2NvbmlkGAIgASgDEg4KBm1vbnRocxgDIAMoCRINCgVtb250aBgEIAEoCRITCgtleHBpcmF0aW9ucxgFIAMoCRIPCgdzdHJpa2VzGAYgAygBEjkKCWNvbnRyYWN0cxgHIAMoCzImLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuT3B0aW9uQ29udHJhY3QiiwMKDk9wdGlvbkNvbnRyYWN0Eg0KBWNvbmlkGAEgASgDEg4KBnN5bWJvbBgCIAEoCRINCgVyaWdodBgDIAEoCRIOCgZzdHJpa2UYBCABKAESEgoKZXhwaXJhdGlvbhgFIAEoCRISCgptdWx0aXBsaWVyGAYgASgJEhUKDXRyYWRpbmdfY2xhc3MYByABKAkSEAoDYmlkGAggASgBSACIAQESEAoDYXNrGAkgASgBSAGIAQESEQoEbGFzdBgKIAEoAUgCiAEBEh8KEmltcGxpZWRfdm9sYXRpbGl0eRgLIAEoAUgDiAEBEhIKBWRlbHRhGAwgASgBSASIAQESEgoFZ2FtbWEYDSABKAFIBYgBARISCgV0aGV0YRgOIAEoAUgGiAEBEhEKBHZlZ2EYDyABKAFIB4gBAUIGCgRfYmlkQgYKBF9hc2tCBwoFX2xhc3RCFQoTX2ltcGxpZWRfdm9sYXRpbGl0eUIICgZfZGVsdGFCCAoGX2dhbW1hQggKBl90aGV0YUIHCgVfdmVnYSI6CiBJbnZhbGlkYXRlSGlzdG9yaWNhbENhY2hlUmVxdWVzdBIWCgVjb25pZBgBIAEoA0IHukgEIgIgACI5CiFJbnZhbGlkYXRlSGlzdG9yaWNhbENhY2hlUmVzcG9uc2USFAoMZGVsZXRlZF9iYXJzGAEgASgDKt8BCg1JbmRpY2F0b3JUeXBlEh4KGklORElDQVRPUl9UWVBFX1VOU1BFQ0lGSUVEEAASFgoSSU5ESUNBVE9SX1RZUEVfU01BEAESFgoSSU5ESUNBVE9SX1RZUEVfRU1BEAISFgoSSU5ESUNBVE9SX1RZUEVfUlNJEAMSFwoTSU5ESUNBVE9SX1RZUEVfTUFDRBAEEhYKEklORElDQVRPUl9UWVBFX0FUUhAFEhwKGElORElDQVRPUl9UWVBFX0JPTExJTkdFUhAGEhcKE0lORElDQVRPUl9UWVBFX1ZXQVAQBzKtBgoRTWFya2V0RGF0YVNlcnZpY2USXQoIR2V0UXVvdGUSJy5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldFF1b3RlUmVxdWVzdBooLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuR2V0UXVvdGVSZXNwb25zZRJgCglHZXRRdW90ZXMSKC5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldFF1b3Rlc1JlcXVlc3QaKS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldFF1b3Rlc1Jlc3BvbnNlEngKEUdldEhpc3RvcmljYWxEYXRhEjAuYXBpLmlia3IubWFya2V0ZGF0YS52MS5HZXRIaXN0b3JpY2FsRGF0YVJlcXVlc3QaMS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldEhpc3RvcmljYWxEYXRhUmVzcG9uc2USbAoNR2V0SW5kaWNhdG9ycxIsLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuR2V0SW5kaWNhdG9yc1JlcXVlc3QaLS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldEluZGljYXRvcnNSZXNwb25zZRJrCgxTdHJlYW1RdW90ZXMSKy5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlN0cmVhbVF1b3Rlc1JlcXVlc3QaLC5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLlN0cmVhbVF1b3Rlc1Jlc3BvbnNlMAESbwoOR2V0T3B0aW9uQ2hhaW4SLS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkdldE9wdGlvbkNoYWluUmVxdWVzdBouLmFwaS5pYmtyLm1hcmtldGRhdGEudjEuR2V0T3B0aW9uQ2hhaW5SZXNwb25zZRKQAQoZSW52YWxpZGF0ZUhpc3RvcmljYWxDYWNoZRI4LmFwaS5pYmtyLm1hcmtldGRhdGEudjEuSW52YWxpZGF0ZUhpc3RvcmljYWxDYWNoZVJlcXVlc3QaOS5hcGkuaWJrci5tYXJrZXRkYXRhLnYxLkludmFsaWRhdGVIaXN0b3JpY2FsQ2FjaGVSZXNwb25zZUL9AQoaY29tLmFwaS5pYmtyLm1hcmtldGRhdGEudjFCD01hcmtldERhdGFQcm90b1ABWlNnaXRodWIuY29tL21hamlkbXZ1bGxlL2lia3ItY2xpZW50L3Byb3RvL2dlbi9nby9hcGkvaWJrci9tYXJrZXRkYXRhL3YxO21hcmtldGRhdGF2MaICA0FJTaoCFkFwaS5JYmtyLk1hcmtldGRhdGEuVjHKAhZBcGlcSWJrclxNYXJrZXRkYXRhXFYx4gIiQXBpXElia3JcTWFya2V0ZGF0YVxWMVxHUEJNZXRhZGF0YeoCGUFwaTo6SWJrcjo6TWFya2V0ZGF0YTo6VjFiBnByb3RvMw", [file_buf_validate_validate, file_google_protobuf_timestamp]);
 
 /**
  * GetQuoteRequest contains parameters for retrieving a quote.
@@ -20,9 +22,66 @@ export const file_api_ibkr_marketdata_v1_market_data: GenFile = /*@__PURE__*/
  */
 export type GetQuoteRequest = Message<"api.ibkr.marketdata.v1.GetQuoteRequest"> & {
   /**
-   * @generated from field: string symbol = 1;
+   * @generated from oneof api.ibkr.marketdata.v1.GetQuoteRequest.instrument
    */
-  symbol: string;
+  instrument: {
+    /**
+     * Symbol of the instrument: upper case letters and digits, in parts joined by a single
+     * ".", "-" or space, e.g. "AAPL", "BRK.B", "RDS-A" or "7203". The listing fields below
+     * pick among the listings found for it.
+     *
+     * @generated from field: string symbol = 1;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument. The contract is used as is, without a search, and
+     * the listing fields below are ignored.
+     *
+     * @generated from field: int64 conid = 7;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 2;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 3;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 4;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 5;
+   */
+  expiry?: string;
+
+  /**
+   * Additional snapshot fields to return in Quote.fields, by name, e.g. "bid_size",
+   * "change_percent" or "market_data_availability".
+   *
+   * @generated from field: repeated string fields = 6;
+   */
+  fields: string[];
 };
 
 /**
@@ -103,9 +162,25 @@ export type Quote = Message<"api.ibkr.marketdata.v1.Quote"> & {
   close: number;
 
   /**
-   * @generated from field: string timestamp = 10;
+   * Time of the last update of the quote by the Gateway.
+   *
+   * @generated from field: google.protobuf.Timestamp updated_at = 12;
+   */
+  updatedAt?: Timestamp;
+
+  /**
+   * Raw values of the requested additional fields returned by the Gateway, keyed by field name.
+   *
+   * @generated from field: map<string, string> fields = 11;
+   */
+  fields: { [key: string]: string };
+
+  /**
+   * Contract ID of the instrument.
+   *
+   * @generated from field: int64 conid = 13;
    */
-  timestamp: string;
+  conid: bigint;
 };
 
 /**
@@ -115,6 +190,214 @@ export type Quote = Message<"api.ibkr.marketdata.v1.Quote"> & {
 export const QuoteSchema: GenMessage<Quote> = /*@__PURE__*/
   messageDesc(file_api_ibkr_marketdata_v1_market_data, 2);
 
+/**
+ * @generated from message api.ibkr.marketdata.v1.Quote.FieldsEntry
+ */
+export type Quote_FieldsEntry = Message<"api.ibkr.marketdata.v1.Quote.FieldsEntry"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: string value = 2;
+   */
+  value: string;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.Quote.FieldsEntry.
+ * Use `create(Quote_FieldsEntrySchema)` to create a new message.
+ */
+export const Quote_FieldsEntrySchema: GenMessage<Quote_FieldsEntry> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 2, 0);
+
+/**
+ * GetQuotesRequest contains parameters for retrieving quotes of many instruments.
+ *
+ * @generated from message api.ibkr.marketdata.v1.GetQuotesRequest
+ */
+export type GetQuotesRequest = Message<"api.ibkr.marketdata.v1.GetQuotesRequest"> & {
+  /**
+   * Instruments to quote. Results are returned in the same order.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.QuoteInstrument instruments = 1;
+   */
+  instruments: QuoteInstrument[];
+
+  /**
+   * Additional snapshot fields to return in Quote.fields, by name, as in GetQuoteRequest.
+   *
+   * @generated from field: repeated string fields = 2;
+   */
+  fields: string[];
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.GetQuotesRequest.
+ * Use `create(GetQuotesRequestSchema)` to create a new message.
+ */
+export const GetQuotesRequestSchema: GenMessage<GetQuotesRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 3);
+
+/**
+ * QuoteInstrument identifies an instrument to quote, as in GetQuoteRequest.
+ *
+ * @generated from message api.ibkr.marketdata.v1.QuoteInstrument
+ */
+export type QuoteInstrument = Message<"api.ibkr.marketdata.v1.QuoteInstrument"> & {
+  /**
+   * @generated from oneof api.ibkr.marketdata.v1.QuoteInstrument.instrument
+   */
+  instrument: {
+    /**
+     * Symbol of the instrument, as in GetQuoteRequest.
+     *
+     * @generated from field: string symbol = 1;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument. The contract is used as is, without a search, and
+     * the listing fields below are ignored.
+     *
+     * @generated from field: int64 conid = 6;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 2;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 3;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 4;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 5;
+   */
+  expiry?: string;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.QuoteInstrument.
+ * Use `create(QuoteInstrumentSchema)` to create a new message.
+ */
+export const QuoteInstrumentSchema: GenMessage<QuoteInstrument> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 4);
+
+/**
+ * GetQuotesResponse contains the quotes of the requested instruments.
+ *
+ * @generated from message api.ibkr.marketdata.v1.GetQuotesResponse
+ */
+export type GetQuotesResponse = Message<"api.ibkr.marketdata.v1.GetQuotesResponse"> & {
+  /**
+   * One result per requested instrument, in request order.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.QuoteResult results = 1;
+   */
+  results: QuoteResult[];
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.GetQuotesResponse.
+ * Use `create(GetQuotesResponseSchema)` to create a new message.
+ */
+export const GetQuotesResponseSchema: GenMessage<GetQuotesResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 5);
+
+/**
+ * QuoteResult is the quote of an instrument, or the error that prevented quoting it.
+ *
+ * @generated from message api.ibkr.marketdata.v1.QuoteResult
+ */
+export type QuoteResult = Message<"api.ibkr.marketdata.v1.QuoteResult"> & {
+  /**
+   * Symbol of the requested instrument, empty when it was requested by conid.
+   *
+   * @generated from field: string symbol = 1;
+   */
+  symbol: string;
+
+  /**
+   * Contract ID of the requested instrument, when it was requested by conid.
+   *
+   * @generated from field: int64 conid = 4;
+   */
+  conid: bigint;
+
+  /**
+   * @generated from oneof api.ibkr.marketdata.v1.QuoteResult.result
+   */
+  result: {
+    /**
+     * @generated from field: api.ibkr.marketdata.v1.Quote quote = 2;
+     */
+    value: Quote;
+    case: "quote";
+  } | {
+    /**
+     * @generated from field: api.ibkr.marketdata.v1.QuoteError error = 3;
+     */
+    value: QuoteError;
+    case: "error";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.QuoteResult.
+ * Use `create(QuoteResultSchema)` to create a new message.
+ */
+export const QuoteResultSchema: GenMessage<QuoteResult> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 6);
+
+/**
+ * QuoteError describes why an instrument could not be quoted.
+ *
+ * @generated from message api.ibkr.marketdata.v1.QuoteError
+ */
+export type QuoteError = Message<"api.ibkr.marketdata.v1.QuoteError"> & {
+  /**
+   * Connect error code, e.g. "not_found" or "invalid_argument".
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.QuoteError.
+ * Use `create(QuoteErrorSchema)` to create a new message.
+ */
+export const QuoteErrorSchema: GenMessage<QuoteError> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 7);
+
 /**
  * GetHistoricalDataRequest contains parameters for historical data.
  *
@@ -122,9 +405,26 @@ export const QuoteSchema: GenMessage<Quote> = /*@__PURE__*/
  */
 export type GetHistoricalDataRequest = Message<"api.ibkr.marketdata.v1.GetHistoricalDataRequest"> & {
   /**
-   * @generated from field: string symbol = 1;
+   * @generated from oneof api.ibkr.marketdata.v1.GetHistoricalDataRequest.instrument
    */
-  symbol: string;
+  instrument: {
+    /**
+     * Symbol of the instrument, as in GetQuoteRequest.
+     *
+     * @generated from field: string symbol = 1;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument. The contract is used as is, without a search, and
+     * the listing fields below are ignored.
+     *
+     * @generated from field: int64 conid = 11;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
 
   /**
    * e.g., "1d", "1w", "1m"
@@ -141,9 +441,57 @@ export type GetHistoricalDataRequest = Message<"api.ibkr.marketdata.v1.GetHistor
   barSize: string;
 
   /**
+   * Page size: the most bars returned, the most recent of the period first.
+   * Defaults to 10000.
+   *
    * @generated from field: optional int32 limit = 4;
    */
   limit?: number;
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 5;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 6;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 7;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 8;
+   */
+  expiry?: string;
+
+  /**
+   * Token of the page to return, from next_page_token of the previous response.
+   * The other fields must match the first request.
+   *
+   * @generated from field: optional string page_token = 9;
+   */
+  pageToken?: string;
+
+  /**
+   * Include bars outside regular trading hours.
+   *
+   * @generated from field: bool outside_rth = 10;
+   */
+  outsideRth: boolean;
 };
 
 /**
@@ -151,10 +499,10 @@ export type GetHistoricalDataRequest = Message<"api.ibkr.marketdata.v1.GetHistor
  * Use `create(GetHistoricalDataRequestSchema)` to create a new message.
  */
 export const GetHistoricalDataRequestSchema: GenMessage<GetHistoricalDataRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_marketdata_v1_market_data, 3);
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 8);
 
 /**
- * GetHistoricalDataResponse contains historical data bars.
+ * GetHistoricalDataResponse contains a page of historical data bars, oldest first.
  *
  * @generated from message api.ibkr.marketdata.v1.GetHistoricalDataResponse
  */
@@ -163,6 +511,28 @@ export type GetHistoricalDataResponse = Message<"api.ibkr.marketdata.v1.GetHisto
    * @generated from field: repeated api.ibkr.marketdata.v1.Bar bars = 1;
    */
   bars: Bar[];
+
+  /**
+   * Token of the page of older bars, empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * Whether the Gateway served delayed rather than real-time data, e.g. without a market
+   * data subscription.
+   *
+   * @generated from field: bool delayed = 3;
+   */
+  delayed: boolean;
+
+  /**
+   * Whether the bars include trading outside regular trading hours.
+   *
+   * @generated from field: bool outside_rth = 4;
+   */
+  outsideRth: boolean;
 };
 
 /**
@@ -170,18 +540,21 @@ export type GetHistoricalDataResponse = Message<"api.ibkr.marketdata.v1.GetHisto
  * Use `create(GetHistoricalDataResponseSchema)` to create a new message.
  */
 export const GetHistoricalDataResponseSchema: GenMessage<GetHistoricalDataResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_marketdata_v1_market_data, 4);
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 9);
 
 /**
- * Bar represents a historical price bar.
+ * Bar represents a historical price bar. Prices are in the currency of the instrument and
+ * volumes in units traded, already scaled by the price and volume factors of the Gateway.
  *
  * @generated from message api.ibkr.marketdata.v1.Bar
  */
 export type Bar = Message<"api.ibkr.marketdata.v1.Bar"> & {
   /**
-   * @generated from field: string timestamp = 1;
+   * Start of the bar.
+   *
+   * @generated from field: google.protobuf.Timestamp opened_at = 7;
    */
-  timestamp: string;
+  openedAt?: Timestamp;
 
   /**
    * @generated from field: double open = 2;
@@ -214,82 +587,810 @@ export type Bar = Message<"api.ibkr.marketdata.v1.Bar"> & {
  * Use `create(BarSchema)` to create a new message.
  */
 export const BarSchema: GenMessage<Bar> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_marketdata_v1_market_data, 5);
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 10);
 
 /**
- * StreamQuotesRequest contains parameters for streaming quotes.
+ * GetIndicatorsRequest contains parameters for computing technical indicators.
  *
- * @generated from message api.ibkr.marketdata.v1.StreamQuotesRequest
+ * @generated from message api.ibkr.marketdata.v1.GetIndicatorsRequest
  */
-export type StreamQuotesRequest = Message<"api.ibkr.marketdata.v1.StreamQuotesRequest"> & {
+export type GetIndicatorsRequest = Message<"api.ibkr.marketdata.v1.GetIndicatorsRequest"> & {
   /**
-   * @generated from field: string symbol = 1;
+   * @generated from oneof api.ibkr.marketdata.v1.GetIndicatorsRequest.instrument
    */
-  symbol: string;
-};
+  instrument: {
+    /**
+     * Symbol of the instrument, as in GetQuoteRequest.
+     *
+     * @generated from field: string symbol = 1;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument. The contract is used as is, without a search, and
+     * the listing fields below are ignored.
+     *
+     * @generated from field: int64 conid = 10;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
 
-/**
- * Describes the message api.ibkr.marketdata.v1.StreamQuotesRequest.
- * Use `create(StreamQuotesRequestSchema)` to create a new message.
- */
-export const StreamQuotesRequestSchema: GenMessage<StreamQuotesRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_marketdata_v1_market_data, 6);
+  /**
+   * Lookback of the bars, e.g. "6m". The first values of each indicator are its warm-up,
+   * so the period should cover the longest indicator period.
+   *
+   * @generated from field: string period = 2;
+   */
+  period: string;
 
-/**
- * StreamQuotesResponse contains a streaming quote.
- *
- * @generated from message api.ibkr.marketdata.v1.StreamQuotesResponse
- */
-export type StreamQuotesResponse = Message<"api.ibkr.marketdata.v1.StreamQuotesResponse"> & {
   /**
-   * @generated from field: api.ibkr.marketdata.v1.Quote quote = 1;
+   * Size of the bars, e.g. "5min", "1h", "2h" or "3d". Sizes the Gateway does not serve
+   * are resampled from the largest smaller size that divides them, in buckets aligned to
+   * the Unix epoch in UTC.
+   *
+   * @generated from field: string bar_size = 3;
    */
-  quote?: Quote;
+  barSize: string;
+
+  /**
+   * Indicators to compute, each returned in the same order.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.IndicatorSpec indicators = 4;
+   */
+  indicators: IndicatorSpec[];
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 5;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 6;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 7;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 8;
+   */
+  expiry?: string;
+
+  /**
+   * Include bars outside regular trading hours.
+   *
+   * @generated from field: bool outside_rth = 9;
+   */
+  outsideRth: boolean;
 };
 
 /**
- * Describes the message api.ibkr.marketdata.v1.StreamQuotesResponse.
- * Use `create(StreamQuotesResponseSchema)` to create a new message.
+ * Describes the message api.ibkr.marketdata.v1.GetIndicatorsRequest.
+ * Use `create(GetIndicatorsRequestSchema)` to create a new message.
  */
-export const StreamQuotesResponseSchema: GenMessage<StreamQuotesResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_marketdata_v1_market_data, 7);
+export const GetIndicatorsRequestSchema: GenMessage<GetIndicatorsRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 11);
 
 /**
- * MarketDataService handles market data requests.
+ * IndicatorSpec is an indicator and its parameters. Parameters an indicator does not
+ * take are ignored.
  *
- * @generated from service api.ibkr.marketdata.v1.MarketDataService
+ * @generated from message api.ibkr.marketdata.v1.IndicatorSpec
  */
-export const MarketDataService: GenService<{
+export type IndicatorSpec = Message<"api.ibkr.marketdata.v1.IndicatorSpec"> & {
   /**
-   * GetQuote retrieves a real-time quote for a symbol.
+   * @generated from field: api.ibkr.marketdata.v1.IndicatorType type = 1;
+   */
+  type: IndicatorType;
+
+  /**
+   * Number of bars of SMA, EMA, RSI, ATR and Bollinger.
    *
-   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetQuote
+   * @generated from field: optional int32 period = 2;
    */
-  getQuote: {
-    methodKind: "unary";
-    input: typeof GetQuoteRequestSchema;
-    output: typeof GetQuoteResponseSchema;
-  },
+  period?: number;
+
   /**
-   * GetHistoricalData retrieves historical market data.
+   * Period of the fast EMA of MACD.
    *
-   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData
+   * @generated from field: optional int32 fast_period = 3;
    */
-  getHistoricalData: {
-    methodKind: "unary";
-    input: typeof GetHistoricalDataRequestSchema;
-    output: typeof GetHistoricalDataResponseSchema;
-  },
+  fastPeriod?: number;
+
   /**
-   * StreamQuotes streams real-time quotes for a symbol.
+   * Period of the slow EMA of MACD.
    *
-   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.StreamQuotes
+   * @generated from field: optional int32 slow_period = 4;
    */
-  streamQuotes: {
-    methodKind: "server_streaming";
+  slowPeriod?: number;
+
+  /**
+   * Period of the signal EMA of MACD.
+   *
+   * @generated from field: optional int32 signal_period = 5;
+   */
+  signalPeriod?: number;
+
+  /**
+   * Width of the Bollinger bands in standard deviations.
+   *
+   * @generated from field: optional double std_dev = 6;
+   */
+  stdDev?: number;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.IndicatorSpec.
+ * Use `create(IndicatorSpecSchema)` to create a new message.
+ */
+export const IndicatorSpecSchema: GenMessage<IndicatorSpec> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 12);
+
+/**
+ * GetIndicatorsResponse contains the bars and the indicators computed over them.
+ *
+ * @generated from message api.ibkr.marketdata.v1.GetIndicatorsResponse
+ */
+export type GetIndicatorsResponse = Message<"api.ibkr.marketdata.v1.GetIndicatorsResponse"> & {
+  /**
+   * Bars the indicators were computed over, resampled to the requested size, oldest first.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.Bar bars = 1;
+   */
+  bars: Bar[];
+
+  /**
+   * Indicators in the order requested.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.Indicator indicators = 2;
+   */
+  indicators: Indicator[];
+
+  /**
+   * Whether the Gateway served delayed rather than real-time data.
+   *
+   * @generated from field: bool delayed = 3;
+   */
+  delayed: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.GetIndicatorsResponse.
+ * Use `create(GetIndicatorsResponseSchema)` to create a new message.
+ */
+export const GetIndicatorsResponseSchema: GenMessage<GetIndicatorsResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 13);
+
+/**
+ * Indicator holds the series of an indicator.
+ *
+ * @generated from message api.ibkr.marketdata.v1.Indicator
+ */
+export type Indicator = Message<"api.ibkr.marketdata.v1.Indicator"> & {
+  /**
+   * Indicator and its parameters, with the defaults applied.
+   *
+   * @generated from field: api.ibkr.marketdata.v1.IndicatorSpec spec = 1;
+   */
+  spec?: IndicatorSpec;
+
+  /**
+   * Series of the indicator, e.g. a single "rsi" series or "macd", "signal" and "histogram".
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.IndicatorSeries series = 2;
+   */
+  series: IndicatorSeries[];
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.Indicator.
+ * Use `create(IndicatorSchema)` to create a new message.
+ */
+export const IndicatorSchema: GenMessage<Indicator> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 14);
+
+/**
+ * IndicatorSeries is a series of indicator values aligned with the bars: values[i] is the
+ * value as of bars[i]. Values during the warm-up of the indicator are NaN.
+ *
+ * @generated from message api.ibkr.marketdata.v1.IndicatorSeries
+ */
+export type IndicatorSeries = Message<"api.ibkr.marketdata.v1.IndicatorSeries"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: repeated double values = 2;
+   */
+  values: number[];
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.IndicatorSeries.
+ * Use `create(IndicatorSeriesSchema)` to create a new message.
+ */
+export const IndicatorSeriesSchema: GenMessage<IndicatorSeries> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 15);
+
+/**
+ * StreamQuotesRequest contains parameters for streaming quotes. The instrument is
+ * given either by symbol and the fields next to it, by conid, or as a list in instruments.
+ *
+ * @generated from message api.ibkr.marketdata.v1.StreamQuotesRequest
+ */
+export type StreamQuotesRequest = Message<"api.ibkr.marketdata.v1.StreamQuotesRequest"> & {
+  /**
+   * @generated from oneof api.ibkr.marketdata.v1.StreamQuotesRequest.instrument
+   */
+  instrument: {
+    /**
+     * Symbol of the instrument, as in GetQuoteRequest.
+     *
+     * @generated from field: string symbol = 1;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument, as in GetQuoteRequest.
+     *
+     * @generated from field: int64 conid = 9;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 2;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 3;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 4;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 5;
+   */
+  expiry?: string;
+
+  /**
+   * Instruments to stream, instead of symbol or conid.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.QuoteInstrument instruments = 6;
+   */
+  instruments: QuoteInstrument[];
+
+  /**
+   * Minimum interval between two quotes of an instrument, in milliseconds. It is clamped
+   * to the server bounds; the server default is used when omitted.
+   *
+   * @generated from field: optional int32 interval_ms = 7;
+   */
+  intervalMs?: number;
+
+  /**
+   * Send only the changed fields of a quote, with a full quote periodically.
+   *
+   * @generated from field: bool delta = 8;
+   */
+  delta: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.StreamQuotesRequest.
+ * Use `create(StreamQuotesRequestSchema)` to create a new message.
+ */
+export const StreamQuotesRequestSchema: GenMessage<StreamQuotesRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 16);
+
+/**
+ * StreamQuotesResponse contains a streaming quote, or a heartbeat.
+ *
+ * @generated from message api.ibkr.marketdata.v1.StreamQuotesResponse
+ */
+export type StreamQuotesResponse = Message<"api.ibkr.marketdata.v1.StreamQuotesResponse"> & {
+  /**
+   * Quote of an instrument. In delta mode, partial quotes only set the symbol and the
+   * fields listed in changed_fields.
+   *
+   * @generated from field: api.ibkr.marketdata.v1.Quote quote = 1;
+   */
+  quote?: Quote;
+
+  /**
+   * Names of the quote fields set in a partial quote, e.g. "bid" or "last".
+   *
+   * @generated from field: repeated string changed_fields = 2;
+   */
+  changedFields: string[];
+
+  /**
+   * Whether quote is a full quote. Always true outside delta mode.
+   *
+   * @generated from field: bool full = 3;
+   */
+  full: boolean;
+
+  /**
+   * Heartbeat sent when no quote was sent for the heartbeat interval, so that a quiet
+   * market can be told apart from a dead stream. Quote is unset.
+   *
+   * @generated from field: bool heartbeat = 4;
+   */
+  heartbeat: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.StreamQuotesResponse.
+ * Use `create(StreamQuotesResponseSchema)` to create a new message.
+ */
+export const StreamQuotesResponseSchema: GenMessage<StreamQuotesResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 17);
+
+/**
+ * GetOptionChainRequest contains parameters for retrieving an option chain.
+ *
+ * @generated from message api.ibkr.marketdata.v1.GetOptionChainRequest
+ */
+export type GetOptionChainRequest = Message<"api.ibkr.marketdata.v1.GetOptionChainRequest"> & {
+  /**
+   * Symbol of the underlying, as in GetQuoteRequest. Option months are listed by the
+   * symbol search, so the underlying cannot be given by conid.
+   *
+   * @generated from field: string symbol = 1;
+   */
+  symbol: string;
+
+  /**
+   * Listing exchange used to pick among listings of the underlying, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 2;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the underlying, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 3;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the underlying. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 4;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month in the Gateway format, e.g. "JAN24". Defaults to the nearest month.
+   *
+   * @generated from field: optional string month = 5;
+   */
+  month?: string;
+
+  /**
+   * Expiration date (YYYYMMDD) used to pick one expiration within the month.
+   *
+   * @generated from field: optional string expiration = 6;
+   */
+  expiration?: string;
+
+  /**
+   * Option right, "C" for calls or "P" for puts. Both are returned when omitted.
+   *
+   * @generated from field: optional string right = 7;
+   */
+  right?: string;
+
+  /**
+   * Lowest strike to return.
+   *
+   * @generated from field: optional double min_strike = 8;
+   */
+  minStrike?: number;
+
+  /**
+   * Highest strike to return.
+   *
+   * @generated from field: optional double max_strike = 9;
+   */
+  maxStrike?: number;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.GetOptionChainRequest.
+ * Use `create(GetOptionChainRequestSchema)` to create a new message.
+ */
+export const GetOptionChainRequestSchema: GenMessage<GetOptionChainRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 18);
+
+/**
+ * GetOptionChainResponse contains an option chain.
+ *
+ * @generated from message api.ibkr.marketdata.v1.GetOptionChainResponse
+ */
+export type GetOptionChainResponse = Message<"api.ibkr.marketdata.v1.GetOptionChainResponse"> & {
+  /**
+   * Symbol of the underlying.
+   *
+   * @generated from field: string symbol = 1;
+   */
+  symbol: string;
+
+  /**
+   * Contract ID of the underlying.
+   *
+   * @generated from field: int64 underlying_conid = 2;
+   */
+  underlyingConid: bigint;
+
+  /**
+   * Expiration months listed for the underlying, e.g. "JAN24".
+   *
+   * @generated from field: repeated string months = 3;
+   */
+  months: string[];
+
+  /**
+   * Expiration month of the returned contracts.
+   *
+   * @generated from field: string month = 4;
+   */
+  month: string;
+
+  /**
+   * Expiration dates (YYYYMMDD) of the returned contracts.
+   *
+   * @generated from field: repeated string expirations = 5;
+   */
+  expirations: string[];
+
+  /**
+   * Strikes of the returned contracts, in ascending order.
+   *
+   * @generated from field: repeated double strikes = 6;
+   */
+  strikes: number[];
+
+  /**
+   * Option contracts ordered by expiration, strike and right.
+   *
+   * @generated from field: repeated api.ibkr.marketdata.v1.OptionContract contracts = 7;
+   */
+  contracts: OptionContract[];
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.GetOptionChainResponse.
+ * Use `create(GetOptionChainResponseSchema)` to create a new message.
+ */
+export const GetOptionChainResponseSchema: GenMessage<GetOptionChainResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 19);
+
+/**
+ * OptionContract represents an option contract of a chain.
+ * Market data fields are omitted when the Gateway did not return them.
+ *
+ * @generated from message api.ibkr.marketdata.v1.OptionContract
+ */
+export type OptionContract = Message<"api.ibkr.marketdata.v1.OptionContract"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+
+  /**
+   * @generated from field: string symbol = 2;
+   */
+  symbol: string;
+
+  /**
+   * "C" for a call, "P" for a put.
+   *
+   * @generated from field: string right = 3;
+   */
+  right: string;
+
+  /**
+   * @generated from field: double strike = 4;
+   */
+  strike: number;
+
+  /**
+   * Expiration date (YYYYMMDD).
+   *
+   * @generated from field: string expiration = 5;
+   */
+  expiration: string;
+
+  /**
+   * @generated from field: string multiplier = 6;
+   */
+  multiplier: string;
+
+  /**
+   * @generated from field: string trading_class = 7;
+   */
+  tradingClass: string;
+
+  /**
+   * @generated from field: optional double bid = 8;
+   */
+  bid?: number;
+
+  /**
+   * @generated from field: optional double ask = 9;
+   */
+  ask?: number;
+
+  /**
+   * @generated from field: optional double last = 10;
+   */
+  last?: number;
+
+  /**
+   * Implied volatility, in percent.
+   *
+   * @generated from field: optional double implied_volatility = 11;
+   */
+  impliedVolatility?: number;
+
+  /**
+   * @generated from field: optional double delta = 12;
+   */
+  delta?: number;
+
+  /**
+   * @generated from field: optional double gamma = 13;
+   */
+  gamma?: number;
+
+  /**
+   * @generated from field: optional double theta = 14;
+   */
+  theta?: number;
+
+  /**
+   * @generated from field: optional double vega = 15;
+   */
+  vega?: number;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.OptionContract.
+ * Use `create(OptionContractSchema)` to create a new message.
+ */
+export const OptionContractSchema: GenMessage<OptionContract> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 20);
+
+/**
+ * InvalidateHistoricalCacheRequest identifies the contract whose cached bars are removed.
+ *
+ * @generated from message api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest
+ */
+export type InvalidateHistoricalCacheRequest = Message<"api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest"> & {
+  /**
+   * @generated from field: int64 conid = 1;
+   */
+  conid: bigint;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.InvalidateHistoricalCacheRequest.
+ * Use `create(InvalidateHistoricalCacheRequestSchema)` to create a new message.
+ */
+export const InvalidateHistoricalCacheRequestSchema: GenMessage<InvalidateHistoricalCacheRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 21);
+
+/**
+ * InvalidateHistoricalCacheResponse reports how many cached bars were removed.
+ *
+ * @generated from message api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponse
+ */
+export type InvalidateHistoricalCacheResponse = Message<"api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponse"> & {
+  /**
+   * @generated from field: int64 deleted_bars = 1;
+   */
+  deletedBars: bigint;
+};
+
+/**
+ * Describes the message api.ibkr.marketdata.v1.InvalidateHistoricalCacheResponse.
+ * Use `create(InvalidateHistoricalCacheResponseSchema)` to create a new message.
+ */
+export const InvalidateHistoricalCacheResponseSchema: GenMessage<InvalidateHistoricalCacheResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_marketdata_v1_market_data, 22);
+
+/**
+ * IndicatorType is a technical indicator.
+ *
+ * @generated from enum api.ibkr.marketdata.v1.IndicatorType
+ */
+export enum IndicatorType {
+  /**
+   * @generated from enum value: INDICATOR_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Simple moving average of the closes over period bars, 20 by default. Series "sma".
+   *
+   * @generated from enum value: INDICATOR_TYPE_SMA = 1;
+   */
+  SMA = 1,
+
+  /**
+   * Exponential moving average of the closes over period bars, 20 by default. Series "ema".
+   *
+   * @generated from enum value: INDICATOR_TYPE_EMA = 2;
+   */
+  EMA = 2,
+
+  /**
+   * Relative strength index over period bars, 14 by default, with Wilder's smoothing.
+   * Series "rsi".
+   *
+   * @generated from enum value: INDICATOR_TYPE_RSI = 3;
+   */
+  RSI = 3,
+
+  /**
+   * Moving average convergence divergence, 12, 26 and 9 by default.
+   * Series "macd", "signal" and "histogram".
+   *
+   * @generated from enum value: INDICATOR_TYPE_MACD = 4;
+   */
+  MACD = 4,
+
+  /**
+   * Average true range over period bars, 14 by default, with Wilder's smoothing.
+   * Series "atr".
+   *
+   * @generated from enum value: INDICATOR_TYPE_ATR = 5;
+   */
+  ATR = 5,
+
+  /**
+   * Bollinger bands over period bars, 20 by default, std_dev standard deviations wide,
+   * 2 by default. Series "middle", "upper" and "lower".
+   *
+   * @generated from enum value: INDICATOR_TYPE_BOLLINGER = 6;
+   */
+  BOLLINGER = 6,
+
+  /**
+   * Volume-weighted average price, restarting every day for intraday bars.
+   * Series "vwap".
+   *
+   * @generated from enum value: INDICATOR_TYPE_VWAP = 7;
+   */
+  VWAP = 7,
+}
+
+/**
+ * Describes the enum api.ibkr.marketdata.v1.IndicatorType.
+ */
+export const IndicatorTypeSchema: GenEnum<IndicatorType> = /*@__PURE__*/
+  enumDesc(file_api_ibkr_marketdata_v1_market_data, 0);
+
+/**
+ * MarketDataService handles market data requests.
+ *
+ * @generated from service api.ibkr.marketdata.v1.MarketDataService
+ */
+export const MarketDataService: GenService<{
+  /**
+   * GetQuote retrieves a real-time quote for a symbol.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetQuote
+   */
+  getQuote: {
+    methodKind: "unary";
+    input: typeof GetQuoteRequestSchema;
+    output: typeof GetQuoteResponseSchema;
+  },
+  /**
+   * GetQuotes retrieves real-time quotes for many instruments with batched snapshot requests.
+   * Instruments that cannot be resolved or quoted get an error result instead of failing the call.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetQuotes
+   */
+  getQuotes: {
+    methodKind: "unary";
+    input: typeof GetQuotesRequestSchema;
+    output: typeof GetQuotesResponseSchema;
+  },
+  /**
+   * GetHistoricalData retrieves historical market data.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetHistoricalData
+   */
+  getHistoricalData: {
+    methodKind: "unary";
+    input: typeof GetHistoricalDataRequestSchema;
+    output: typeof GetHistoricalDataResponseSchema;
+  },
+  /**
+   * GetIndicators computes technical indicators over the historical bars of a symbol,
+   * resampling them to bar sizes the Gateway does not serve, such as 2h or 3d.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetIndicators
+   */
+  getIndicators: {
+    methodKind: "unary";
+    input: typeof GetIndicatorsRequestSchema;
+    output: typeof GetIndicatorsResponseSchema;
+  },
+  /**
+   * StreamQuotes streams real-time quotes for one or more instruments, either as full
+   * quotes or as changed fields with periodic full quotes, with heartbeats while quiet.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.StreamQuotes
+   */
+  streamQuotes: {
+    methodKind: "server_streaming";
     input: typeof StreamQuotesRequestSchema;
     output: typeof StreamQuotesResponseSchema;
   },
+  /**
+   * GetOptionChain retrieves the option contracts of an underlying for an expiration month,
+   * with quotes, implied volatility and greeks where the Gateway provides them.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.GetOptionChain
+   */
+  getOptionChain: {
+    methodKind: "unary";
+    input: typeof GetOptionChainRequestSchema;
+    output: typeof GetOptionChainResponseSchema;
+  },
+  /**
+   * InvalidateHistoricalCache removes the cached historical bars of a contract, e.g. after
+   * a split or a data correction, so that they are fetched from the Gateway again.
+   * Only admin accounts may call it.
+   *
+   * @generated from rpc api.ibkr.marketdata.v1.MarketDataService.InvalidateHistoricalCache
+   */
+  invalidateHistoricalCache: {
+    methodKind: "unary";
+    input: typeof InvalidateHistoricalCacheRequestSchema;
+    output: typeof InvalidateHistoricalCacheResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_ibkr_marketdata_v1_market_data, 0);
 
@@ -11,10 +11,11 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file api/ibkr/order/v1/order.proto.
  */
 export const file_api_ibkr_order_v1_order: GenFile = /*@__PURE__*/
-  fileDesc("Ch1hcGkvaWJrci9vcmRlci92MS9vcmRlci5wcm90bxIRYXBpLmlia3Iub3JkZXIudjEinwMKEVBsYWNlT3JkZXJSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESJgoGc3ltYm9sGAIgASgJQha6SBNyERABGBQyC15bQS1aMC05XSskEjYKBHNpZGUYAyABKA4yHC5hcGkuaWJrci5vcmRlci52MS5PcmRlclNpZGVCCrpIB4IBBBABIAASNgoEdHlwZRgEIAEoDjIcLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyVHlwZUIKukgHggEEEAEgABIgCghxdWFudGl0eRgFIAEoAUIOukgLEgkhAAAAAAAAAAASKAoLbGltaXRfcHJpY2UYBiABKAFCDrpICxIJIQAAAAAAAAAASACIAQESJwoKc3RvcF9wcmljZRgHIAEoAUIOukgLEgkhAAAAAAAAAABIAYgBARJBCg10aW1lX2luX2ZvcmNlGAggASgOMh4uYXBpLmlia3Iub3JkZXIudjEuVGltZUluRm9yY2VCCrpIB4IBBBABIABCDgoMX2xpbWl0X3ByaWNlQg0KC19zdG9wX3ByaWNlImcKElBsYWNlT3JkZXJSZXNwb25zZRIQCghvcmRlcl9pZBgBIAEoCRIuCgZzdGF0dXMYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxIPCgdtZXNzYWdlGAMgASgJIvIBChJNb2RpZnlPcmRlclJlcXVlc3QSGwoKYWNjb3VudF9pZBgBIAEoCUIHukgEcgIQARIZCghvcmRlcl9pZBgCIAEoCUIHukgEcgIQARIlCghxdWFudGl0eRgDIAEoAUIOukgLEgkhAAAAAAAAAABIAIgBARIoCgtsaW1pdF9wcmljZRgEIAEoAUIOukgLEgkhAAAAAAAAAABIAYgBARInCgpzdG9wX3ByaWNlGAUgASgBQg66SAsSCSEAAAAAAAAAAEgCiAEBQgsKCV9xdWFudGl0eUIOCgxfbGltaXRfcHJpY2VCDQoLX3N0b3BfcHJpY2UiaAoTTW9kaWZ5T3JkZXJSZXNwb25zZRIQCghvcmRlcl9pZBgBIAEoCRIuCgZzdGF0dXMYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxIPCgdtZXNzYWdlGAMgASgJIkwKEkNhbmNlbE9yZGVyUmVxdWVzdBIbCgphY2NvdW50X2lkGAEgASgJQge6SARyAhABEhkKCG9yZGVyX2lkGAIgASgJQge6SARyAhABImgKE0NhbmNlbE9yZGVyUmVzcG9uc2USEAoIb3JkZXJfaWQYASABKAkSLgoGc3RhdHVzGAIgASgOMh4uYXBpLmlia3Iub3JkZXIudjEuT3JkZXJTdGF0dXMSDwoHbWVzc2FnZRgDIAEoCSJJCg9HZXRPcmRlclJlcXVlc3QSGwoKYWNjb3VudF9pZBgBIAEoCUIHukgEcgIQARIZCghvcmRlcl9pZBgCIAEoCUIHukgEcgIQASI7ChBHZXRPcmRlclJlc3BvbnNlEicKBW9yZGVyGAEgASgLMhguYXBpLmlia3Iub3JkZXIudjEuT3JkZXIiqAEKEUxpc3RPcmRlcnNSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESOgoNc3RhdHVzX2ZpbHRlchgCIAEoDjIeLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyU3RhdHVzSACIAQESHgoFbGltaXQYAyABKAVCCrpIBxoFGOgHKAFIAYgBAUIQCg5fc3RhdHVzX2ZpbHRlckIICgZfbGltaXQiPgoSTGlzdE9yZGVyc1Jlc3BvbnNlEigKBm9yZGVycxgBIAMoCzIYLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyIrUDCgVPcmRlchIQCghvcmRlcl9pZBgBIAEoCRISCgphY2NvdW50X2lkGAIgASgJEg4KBnN5bWJvbBgDIAEoCRIqCgRzaWRlGAQgASgOMhwuYXBpLmlia3Iub3JkZXIudjEuT3JkZXJTaWRlEioKBHR5cGUYBSABKA4yHC5hcGkuaWJrci5vcmRlci52MS5PcmRlclR5cGUSEAoIcXVhbnRpdHkYBiABKAESFwoPZmlsbGVkX3F1YW50aXR5GAcgASgBEhgKC2xpbWl0X3ByaWNlGAggASgBSACIAQESFwoKc3RvcF9wcmljZRgJIAEoAUgBiAEBEjUKDXRpbWVfaW5fZm9yY2UYCiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5UaW1lSW5Gb3JjZRIuCgZzdGF0dXMYCyABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxISCgpjcmVhdGVkX2F0GAwgASgJEhcKCnVwZGF0ZWRfYXQYDSABKAlIAogBAUIOCgxfbGltaXRfcHJpY2VCDQoLX3N0b3BfcHJpY2VCDQoLX3VwZGF0ZWRfYXQqUAoJT3JkZXJTaWRlEhoKFk9SREVSX1NJREVfVU5TUEVDSUZJRUQQABISCg5PUkRFUl9TSURFX0JVWRABEhMKD09SREVSX1NJREVfU0VMTBACKoQBCglPcmRlclR5cGUSGgoWT1JERVJfVFlQRV9VTlNQRUNJRklFRBAAEhUKEU9SREVSX1RZUEVfTUFSS0VUEAESFAoQT1JERVJfVFlQRV9MSU1JVBACEhMKD09SREVSX1RZUEVfU1RPUBADEhkKFU9SREVSX1RZUEVfU1RPUF9MSU1JVBAEKtQBCgtPcmRlclN0YXR1cxIcChhPUkRFUl9TVEFUVVNfVU5TUEVDSUZJRUQQABIYChRPUkRFUl9TVEFUVVNfUEVORElORxABEhoKFk9SREVSX1NUQVRVU19TVUJNSVRURUQQAhIXChNPUkRFUl9TVEFUVVNfRklMTEVEEAMSIQodT1JERVJfU1RBVFVTX1BBUlRJQUxMWV9GSUxMRUQQBBIaChZPUkRFUl9TVEFUVVNfQ0FOQ0VMTEVEEAUSGQoVT1JERVJfU1RBVFVTX1JFSkVDVEVEEAYqiAEKC1RpbWVJbkZvcmNlEh0KGVRJTUVfSU5fRk9SQ0VfVU5TUEVDSUZJRUQQABIVChFUSU1FX0lOX0ZPUkNFX0RBWRABEhUKEVRJTUVfSU5fRk9SQ0VfR1RDEAISFQoRVElNRV9JTl9GT1JDRV9JT0MQAxIVChFUSU1FX0lOX0ZPUkNFX0ZPSxAEMtUDCgxPcmRlclNlcnZpY2USWQoKUGxhY2VPcmRlchIkLmFwaS5pYmtyLm9yZGVyLnYxLlBsYWNlT3JkZXJSZXF1ZXN0GiUuYXBpLmlia3Iub3JkZXIudjEuUGxhY2VPcmRlclJlc3BvbnNlElwKC01vZGlmeU9yZGVyEiUuYXBpLmlia3Iub3JkZXIudjEuTW9kaWZ5T3JkZXJSZXF1ZXN0GiYuYXBpLmlia3Iub3JkZXIudjEuTW9kaWZ5T3JkZXJSZXNwb25zZRJcCgtDYW5jZWxPcmRlchIlLmFwaS5pYmtyLm9yZGVyLnYxLkNhbmNlbE9yZGVyUmVxdWVzdBomLmFwaS5pYmtyLm9yZGVyLnYxLkNhbmNlbE9yZGVyUmVzcG9uc2USUwoIR2V0T3JkZXISIi5hcGkuaWJrci5vcmRlci52MS5HZXRPcmRlclJlcXVlc3QaIy5hcGkuaWJrci5vcmRlci52MS5HZXRPcmRlclJlc3BvbnNlElkKCkxpc3RPcmRlcnMSJC5hcGkuaWJrci5vcmRlci52MS5MaXN0T3JkZXJzUmVxdWVzdBolLmFwaS5pYmtyLm9yZGVyLnYxLkxpc3RPcmRlcnNSZXNwb25zZULVAQoVY29tLmFwaS5pYmtyLm9yZGVyLnYxQgpPcmRlclByb3RvUAFaSWdpdGh1Yi5jb20vbWFqaWRtdnVsbGUvaWJrci1jbGllbnQvcHJvdG8vZ2VuL2dvL2FwaS9pYmtyL29yZGVyL3YxO29yZGVydjGiAgNBSU+qAhFBcGkuSWJrci5PcmRlci5WMcoCEUFwaVxJYmtyXE9yZGVyXFYx4gIdQXBpXElia3JcT3JkZXJcVjFcR1BCTWV0YWRhdGHqAhRBcGk6Oklia3I6Ok9yZGVyOjpWMWIGcHJvdG8z", [file_buf_validate_validate]);
+  fileDesc("Ch1hcGkvaWJrci9vcmRlci92MS9vcmRlci5wcm90bxIRYXBpLmlia3Iub3JkZXIudjEizw8KEVBsYWNlT3JkZXJSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESOQoGc3ltYm9sGAIgASgJQie6SCRyIhABGBQyHF5bQS1aMC05XSsoWy0uIF1bQS1aMC05XSspKiRIABIYCgVjb25pZBgNIAEoA0IHukgEIgIgAEgAEjYKBHNpZGUYAyABKA4yHC5hcGkuaWJrci5vcmRlci52MS5PcmRlclNpZGVCCrpIB4IBBBABIAASNgoEdHlwZRgEIAEoDjIcLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyVHlwZUIKukgHggEEEAEgABIgCghxdWFudGl0eRgFIAEoAUIOukgLEgkhAAAAAAAAAAASKAoLbGltaXRfcHJpY2UYBiABKAFCDrpICxIJIQAAAAAAAAAASAGIAQESJwoKc3RvcF9wcmljZRgHIAEoAUIOukgLEgkhAAAAAAAAAABIAogBARJBCg10aW1lX2luX2ZvcmNlGAggASgOMh4uYXBpLmlia3Iub3JkZXIudjEuVGltZUluRm9yY2VCCrpIB4IBBBABIAASLgoIZXhjaGFuZ2UYCSABKAlCF7pIFHISEAEYFDIMXltBLVowLTkuXSskSAOIAQESKAoIY3VycmVuY3kYCiABKAlCEbpIDnIMMgpeW0EtWl17M30kSASIAQESJgoIc2VjX3R5cGUYCyABKAlCD7pIDHIKUgNTVEtSA0ZVVEgFiAEBEjEKBmV4cGlyeRgMIAEoCUIcukgZchcyFV5bMC05XXs2fShbMC05XXsyfSk/JEgGiAEBEiwKD3RyYWlsaW5nX2Ftb3VudBgOIAEoAUIOukgLEgkhAAAAAAAAAABIB4gBARJACg10cmFpbGluZ190eXBlGA8gASgOMh8uYXBpLmlia3Iub3JkZXIudjEuVHJhaWxpbmdUeXBlQgi6SAWCAQIQARIjCgZvZmZzZXQYECABKAFCDrpICxIJIQAAAAAAAAAASAiIAQE60Ai6SMwIGpkBChdwbGFjZV9vcmRlci5saW1pdF9wcmljZRJDTElNSVQsIFNUT1BfTElNSVQsIFRSQUlMX0xJTUlULCBMT0MgYW5kIExJVCBvcmRlcnMgbmVlZCBsaW1pdF9wcmljZRo5ISh0aGlzLnR5cGUgaW4gWzIsIDQsIDYsIDgsIDEwXSkgfHwgaGFzKHRoaXMubGltaXRfcHJpY2UpGpwBCh5wbGFjZV9vcmRlci5saW1pdF9wcmljZV91bnVzZWQSP01BUktFVCwgU1RPUCwgVFJBSUwsIE1PQyBhbmQgTUlUIG9yZGVycyBkbyBub3QgdGFrZSBsaW1pdF9wcmljZRo5ISh0aGlzLnR5cGUgaW4gWzEsIDMsIDUsIDcsIDldKSB8fCAhaGFzKHRoaXMubGltaXRfcHJpY2UpGoUBChZwbGFjZV9vcmRlci5zdG9wX3ByaWNlEjRTVE9QLCBTVE9QX0xJTUlULCBNSVQgYW5kIExJVCBvcmRlcnMgbmVlZCBzdG9wX3ByaWNlGjUhKHRoaXMudHlwZSBpbiBbMywgNCwgOSwgMTBdKSB8fCBoYXModGhpcy5zdG9wX3ByaWNlKRqpAQodcGxhY2Vfb3JkZXIuc3RvcF9wcmljZV91bnVzZWQSTW9ubHkgU1RPUCwgU1RPUF9MSU1JVCwgVFJBSUwsIFRSQUlMX0xJTUlULCBNSVQgYW5kIExJVCBvcmRlcnMgdGFrZSBzdG9wX3ByaWNlGjl0aGlzLnR5cGUgaW4gWzMsIDQsIDUsIDYsIDksIDEwXSB8fCAhaGFzKHRoaXMuc3RvcF9wcmljZSkarQEKFHBsYWNlX29yZGVyLnRyYWlsaW5nEkNUUkFJTCBhbmQgVFJBSUxfTElNSVQgb3JkZXJzIG5lZWQgdHJhaWxpbmdfYW1vdW50IGFuZCB0cmFpbGluZ190eXBlGlAhKHRoaXMudHlwZSBpbiBbNSwgNl0pIHx8IChoYXModGhpcy50cmFpbGluZ19hbW91bnQpICYmIHRoaXMudHJhaWxpbmdfdHlwZSAhPSAwKRq1AQoZcGxhY2Vfb3JkZXIudHJhaWxpbmdfb25seRJIb25seSBUUkFJTCBhbmQgVFJBSUxfTElNSVQgb3JkZXJzIHRha2UgdHJhaWxpbmdfYW1vdW50IGFuZCB0cmFpbGluZ190eXBlGk50aGlzLnR5cGUgaW4gWzUsIDZdIHx8ICghaGFzKHRoaXMudHJhaWxpbmdfYW1vdW50KSAmJiB0aGlzLnRyYWlsaW5nX3R5cGUgPT0gMCkacwoScGxhY2Vfb3JkZXIub2Zmc2V0EjZSRUwgb3JkZXJzIG5lZWQgb2Zmc2V0LCB3aGljaCBvdGhlciBvcmRlcnMgZG8gbm90IHRha2UaJSh0aGlzLnR5cGUgPT0gMTEpID09IGhhcyh0aGlzLm9mZnNldClCEwoKaW5zdHJ1bWVudBIFukgCCAFCDgoMX2xpbWl0X3ByaWNlQg0KC19zdG9wX3ByaWNlQgsKCV9leGNoYW5nZUILCglfY3VycmVuY3lCCwoJX3NlY190eXBlQgkKB19leHBpcnlCEgoQX3RyYWlsaW5nX2Ftb3VudEIJCgdfb2Zmc2V0IqMBChJQbGFjZU9yZGVyUmVzcG9uc2USEAoIb3JkZXJfaWQYASABKAkSLgoGc3RhdHVzGAIgASgOMh4uYXBpLmlia3Iub3JkZXIudjEuT3JkZXJTdGF0dXMSDwoHbWVzc2FnZRgDIAEoCRI6Cgxjb25maXJtYXRpb24YBCABKAsyJC5hcGkuaWJrci5vcmRlci52MS5PcmRlckNvbmZpcm1hdGlvbiJMChFPcmRlckNvbmZpcm1hdGlvbhIQCghyZXBseV9pZBgBIAEoCRIQCghtZXNzYWdlcxgCIAMoCRITCgttZXNzYWdlX2lkcxgDIAMoCSKkBwoYUGxhY2VCcmFja2V0T3JkZXJSZXF1ZXN0EjsKBWVudHJ5GAEgASgLMiQuYXBpLmlia3Iub3JkZXIudjEuUGxhY2VPcmRlclJlcXVlc3RCBrpIA8gBARIpChF0YWtlX3Byb2ZpdF9wcmljZRgCIAEoAUIOukgLEgkhAAAAAAAAAAASJwoPc3RvcF9sb3NzX3ByaWNlGAMgASgBQg66SAsSCSEAAAAAAAAAABJEChJleGl0X3RpbWVfaW5fZm9yY2UYBCABKA4yHi5hcGkuaWJrci5vcmRlci52MS5UaW1lSW5Gb3JjZUIIukgFggECEAE6sAW6SKwFGtICCh5wbGFjZV9icmFja2V0X29yZGVyLmJ1eV9wcmljZXMSXmEgYnV5IGJyYWNrZXQgbmVlZHMgdGFrZV9wcm9maXRfcHJpY2UgYWJvdmUgdGhlIGVudHJ5IGxpbWl0IHByaWNlIGFuZCBzdG9wX2xvc3NfcHJpY2UgYmVsb3cgaXQazwF0aGlzLmVudHJ5LnNpZGUgIT0gMSB8fCAodGhpcy50YWtlX3Byb2ZpdF9wcmljZSA+IHRoaXMuc3RvcF9sb3NzX3ByaWNlICYmICghaGFzKHRoaXMuZW50cnkubGltaXRfcHJpY2UpIHx8ICh0aGlzLnRha2VfcHJvZml0X3ByaWNlID4gdGhpcy5lbnRyeS5saW1pdF9wcmljZSAmJiB0aGlzLmVudHJ5LmxpbWl0X3ByaWNlID4gdGhpcy5zdG9wX2xvc3NfcHJpY2UpKSka1AIKH3BsYWNlX2JyYWNrZXRfb3JkZXIuc2VsbF9wcmljZXMSX2Egc2VsbCBicmFja2V0IG5lZWRzIHRha2VfcHJvZml0X3ByaWNlIGJlbG93IHRoZSBlbnRyeSBsaW1pdCBwcmljZSBhbmQgc3RvcF9sb3NzX3ByaWNlIGFib3ZlIGl0Gs8BdGhpcy5lbnRyeS5zaWRlICE9IDIgfHwgKHRoaXMudGFrZV9wcm9maXRfcHJpY2UgPCB0aGlzLnN0b3BfbG9zc19wcmljZSAmJiAoIWhhcyh0aGlzLmVudHJ5LmxpbWl0X3ByaWNlKSB8fCAodGhpcy50YWtlX3Byb2ZpdF9wcmljZSA8IHRoaXMuZW50cnkubGltaXRfcHJpY2UgJiYgdGhpcy5lbnRyeS5saW1pdF9wcmljZSA8IHRoaXMuc3RvcF9sb3NzX3ByaWNlKSkpIqsBChlQbGFjZUJyYWNrZXRPcmRlclJlc3BvbnNlEhEKCW9yZGVyX2lkcxgBIAMoCRIuCgZzdGF0dXMYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxIPCgdtZXNzYWdlGAMgASgJEjoKDGNvbmZpcm1hdGlvbhgEIAEoCzIkLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyQ29uZmlybWF0aW9uIq4CChZQbGFjZU9yZGVyR3JvdXBSZXF1ZXN0EkAKBm9yZGVycxgBIAMoCzIkLmFwaS5pYmtyLm9yZGVyLnYxLlBsYWNlT3JkZXJSZXF1ZXN0Qgq6SAeSAQQIAhAKEiEKCW9jYV9ncm91cBgCIAEoCUIJukgGcgQQARhASACIAQE6oAG6SJwBGpkBChxwbGFjZV9vcmRlcl9ncm91cC5hY2NvdW50X2lkEjJ0aGUgb3JkZXJzIG9mIGEgZ3JvdXAgbXVzdCBiZSBmb3IgdGhlIHNhbWUgYWNjb3VudBpFdGhpcy5vcmRlcnMuYWxsKG9yZGVyLCBvcmRlci5hY2NvdW50X2lkID09IHRoaXMub3JkZXJzWzBdLmFjY291bnRfaWQpQgwKCl9vY2FfZ3JvdXAivAEKF1BsYWNlT3JkZXJHcm91cFJlc3BvbnNlEhEKCW9yZGVyX2lkcxgBIAMoCRIuCgZzdGF0dXMYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxIPCgdtZXNzYWdlGAMgASgJEjoKDGNvbmZpcm1hdGlvbhgEIAEoCzIkLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyQ29uZmlybWF0aW9uEhEKCW9jYV9ncm91cBgFIAEoCSLZBAoSTW9kaWZ5T3JkZXJSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESGQoIb3JkZXJfaWQYAiABKAlCB7pIBHICEAESJQoIcXVhbnRpdHkYAyABKAFCDrpICxIJIQAAAAAAAAAASACIAQESKAoLbGltaXRfcHJpY2UYBCABKAFCDrpICxIJIQAAAAAAAAAASAGIAQESJwoKc3RvcF9wcmljZRgFIAEoAUIOukgLEgkhAAAAAAAAAABIAogBARI8Cg90cmFpbGluZ19hbW91bnQYBiABKAFCDrpICxIJIQAAAAAAAAAASANSDnRyYWlsaW5nQW1vdW50iAEBEk4KDXRyYWlsaW5nX3R5cGUYByABKA4yHy5hcGkuaWJrci5vcmRlci52MS5UcmFpbGluZ1R5cGVCCLpIBYIBAhABUgx0cmFpbGluZ1R5cGUSKwoGb2Zmc2V0GAggASgBQg66SAsSCSEAAAAAAAAAAEgEUgZvZmZzZXSIAQE6igG6SIYBGoMBChVtb2RpZnlfb3JkZXIudHJhaWxpbmcSMnRyYWlsaW5nX2Ftb3VudCBhbmQgdHJhaWxpbmdfdHlwZSBhcmUgc2V0IHRvZ2V0aGVyGjZoYXModGhpcy50cmFpbGluZ19hbW91bnQpID09ICh0aGlzLnRyYWlsaW5nX3R5cGUgIT0gMClCCwoJX3F1YW50aXR5Qg4KDF9saW1pdF9wcmljZUINCgtfc3RvcF9wcmljZUISChBfdHJhaWxpbmdfYW1vdW50QgkKB19vZmZzZXQipAEKE01vZGlmeU9yZGVyUmVzcG9uc2USEAoIb3JkZXJfaWQYASABKAkSLgoGc3RhdHVzGAIgASgOMh4uYXBpLmlia3Iub3JkZXIudjEuT3JkZXJTdGF0dXMSDwoHbWVzc2FnZRgDIAEoCRI6Cgxjb25maXJtYXRpb24YBCABKAsyJC5hcGkuaWJrci5vcmRlci52MS5PcmRlckNvbmZpcm1hdGlvbiJMChJDYW5jZWxPcmRlclJlcXVlc3QSGwoKYWNjb3VudF9pZBgBIAEoCUIHukgEcgIQARIZCghvcmRlcl9pZBgCIAEoCUIHukgEcgIQASJoChNDYW5jZWxPcmRlclJlc3BvbnNlEhAKCG9yZGVyX2lkGAEgASgJEi4KBnN0YXR1cxgCIAEoDjIeLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyU3RhdHVzEg8KB21lc3NhZ2UYAyABKAkiSQoPR2V0T3JkZXJSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESGQoIb3JkZXJfaWQYAiABKAlCB7pIBHICEAEiOwoQR2V0T3JkZXJSZXNwb25zZRInCgVvcmRlchgBIAEoCzIYLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyIqgBChFMaXN0T3JkZXJzUmVxdWVzdBIbCgphY2NvdW50X2lkGAEgASgJQge6SARyAhABEjoKDXN0YXR1c19maWx0ZXIYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1c0gAiAEBEh4KBWxpbWl0GAMgASgFQgq6SAcaBRjoBygBSAGIAQFCEAoOX3N0YXR1c19maWx0ZXJCCAoGX2xpbWl0Ij4KEkxpc3RPcmRlcnNSZXNwb25zZRIoCgZvcmRlcnMYASADKAsyGC5hcGkuaWJrci5vcmRlci52MS5PcmRlciJgChNDb25maXJtT3JkZXJSZXF1ZXN0EhsKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAESGQoIcmVwbHlfaWQYAiABKAlCB7pIBHICEAESEQoJY29uZmlybWVkGAMgASgIIrgBChRDb25maXJtT3JkZXJSZXNwb25zZRIQCghvcmRlcl9pZBgBIAEoCRIuCgZzdGF0dXMYAiABKA4yHi5hcGkuaWJrci5vcmRlci52MS5PcmRlclN0YXR1cxIPCgdtZXNzYWdlGAMgASgJEjoKDGNvbmZpcm1hdGlvbhgEIAEoCzIkLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyQ29uZmlybWF0aW9uEhEKCW9yZGVyX2lkcxgFIAMoCSK1AwoFT3JkZXISEAoIb3JkZXJfaWQYASABKAkSEgoKYWNjb3VudF9pZBgCIAEoCRIOCgZzeW1ib2wYAyABKAkSKgoEc2lkZRgEIAEoDjIcLmFwaS5pYmtyLm9yZGVyLnYxLk9yZGVyU2lkZRIqCgR0eXBlGAUgASgOMhwuYXBpLmlia3Iub3JkZXIudjEuT3JkZXJUeXBlEhAKCHF1YW50aXR5GAYgASgBEhcKD2ZpbGxlZF9xdWFudGl0eRgHIAEoARIYCgtsaW1pdF9wcmljZRgIIAEoAUgAiAEBEhcKCnN0b3BfcHJpY2UYCSABKAFIAYgBARI1Cg10aW1lX2luX2ZvcmNlGAogASgOMh4uYXBpLmlia3Iub3JkZXIudjEuVGltZUluRm9yY2USLgoGc3RhdHVzGAsgASgOMh4uYXBpLmlia3Iub3JkZXIudjEuT3JkZXJTdGF0dXMSEgoKY3JlYXRlZF9hdBgMIAEoCRIXCgp1cGRhdGVkX2F0GA0gASgJSAKIAQFCDgoMX2xpbWl0X3ByaWNlQg0KC19zdG9wX3ByaWNlQg0KC191cGRhdGVkX2F0KlAKCU9yZGVyU2lkZRIaChZPUkRFUl9TSURFX1VOU1BFQ0lGSUVEEAASEgoOT1JERVJfU0lERV9CVVkQARITCg9PUkRFUl9TSURFX1NFTEwQAiqzAgoJT3JkZXJUeXBlEhoKFk9SREVSX1RZUEVfVU5TUEVDSUZJRUQQABIVChFPUkRFUl9UWVBFX01BUktFVBABEhQKEE9SREVSX1RZUEVfTElNSVQQAhITCg9PUkRFUl9UWVBFX1NUT1AQAxIZChVPUkRFUl9UWVBFX1NUT1BfTElNSVQQBBIUChBPUkRFUl9UWVBFX1RSQUlMEAUSGgoWT1JERVJfVFlQRV9UUkFJTF9MSU1JVBAGEhIKDk9SREVSX1RZUEVfTU9DEAcSEgoOT1JERVJfVFlQRV9MT0MQCBISCg5PUkRFUl9UWVBFX01JVBAJEhIKDk9SREVSX1RZUEVfTElUEAoSEgoOT1JERVJfVFlQRV9SRUwQCxIXChNPUkRFUl9UWVBFX01JRFBSSUNFEAwqYgoMVHJhaWxpbmdUeXBlEh0KGVRSQUlMSU5HX1RZUEVfVU5TUEVDSUZJRUQQABIYChRUUkFJTElOR19UWVBFX0FNT1VOVBABEhkKFVRSQUlMSU5HX1RZUEVfUEVSQ0VOVBACKvwBCgtPcmRlclN0YXR1cxIcChhPUkRFUl9TVEFUVVNfVU5TUEVDSUZJRUQQABIYChRPUkRFUl9TVEFUVVNfUEVORElORxABEhoKFk9SREVSX1NUQVRVU19TVUJNSVRURUQQAhIXChNPUkRFUl9TVEFUVVNfRklMTEVEEAMSIQodT1JERVJfU1RBVFVTX1BBUlRJQUxMWV9GSUxMRUQQBBIaChZPUkRFUl9TVEFUVVNfQ0FOQ0VMTEVEEAUSGQoVT1JERVJfU1RBVFVTX1JFSkVDVEVEEAYSJgoiT1JERVJfU1RBVFVTX0NPTkZJUk1BVElPTl9SRVFVSVJFRBAHKogBCgtUaW1lSW5Gb3JjZRIdChlUSU1FX0lOX0ZPUkNFX1VOU1BFQ0lGSUVEEAASFQoRVElNRV9JTl9GT1JDRV9EQVkQARIVChFUSU1FX0lOX0ZPUkNFX0dUQxACEhUKEVRJTUVfSU5fRk9SQ0VfSU9DEAMSFQoRVElNRV9JTl9GT1JDRV9GT0sQBDKQBgoMT3JkZXJTZXJ2aWNlElkKClBsYWNlT3JkZXISJC5hcGkuaWJrci5vcmRlci52MS5QbGFjZU9yZGVyUmVxdWVzdBolLmFwaS5pYmtyLm9yZGVyLnYxLlBsYWNlT3JkZXJSZXNwb25zZRJcCgtNb2RpZnlPcmRlchIlLmFwaS5pYmtyLm9yZGVyLnYxLk1vZGlmeU9yZGVyUmVxdWVzdBomLmFwaS5pYmtyLm9yZGVyLnYxLk1vZGlmeU9yZGVyUmVzcG9uc2USXAoLQ2FuY2VsT3JkZXISJS5hcGkuaWJrci5vcmRlci52MS5DYW5jZWxPcmRlclJlcXVlc3QaJi5hcGkuaWJrci5vcmRlci52MS5DYW5jZWxPcmRlclJlc3BvbnNlElMKCEdldE9yZGVyEiIuYXBpLmlia3Iub3JkZXIudjEuR2V0T3JkZXJSZXF1ZXN0GiMuYXBpLmlia3Iub3JkZXIudjEuR2V0T3JkZXJSZXNwb25zZRJZCgpMaXN0T3JkZXJzEiQuYXBpLmlia3Iub3JkZXIudjEuTGlzdE9yZGVyc1JlcXVlc3QaJS5hcGkuaWJrci5vcmRlci52MS5MaXN0T3JkZXJzUmVzcG9uc2USXwoMQ29uZmlybU9yZGVyEiYuYXBpLmlia3Iub3JkZXIudjEuQ29uZmlybU9yZGVyUmVxdWVzdBonLmFwaS5pYmtyLm9yZGVyLnYxLkNvbmZpcm1PcmRlclJlc3BvbnNlEm4KEVBsYWNlQnJhY2tldE9yZGVyEisuYXBpLmlia3Iub3JkZXIudjEuUGxhY2VCcmFja2V0T3JkZXJSZXF1ZXN0GiwuYXBpLmlia3Iub3JkZXIudjEuUGxhY2VCcmFja2V0T3JkZXJSZXNwb25zZRJoCg9QbGFjZU9yZGVyR3JvdXASKS5hcGkuaWJrci5vcmRlci52MS5QbGFjZU9yZGVyR3JvdXBSZXF1ZXN0GiouYXBpLmlia3Iub3JkZXIudjEuUGxhY2VPcmRlckdyb3VwUmVzcG9uc2VC1QEKFWNvbS5hcGkuaWJrci5vcmRlci52MUIKT3JkZXJQcm90b1ABWklnaXRodWIuY29tL21hamlkbXZ1bGxlL2lia3ItY2xpZW50L3Byb3RvL2dlbi9nby9hcGkvaWJrci9vcmRlci92MTtvcmRlcnYxogIDQUlPqgIRQXBpLklia3IuT3JkZXIuVjHKAhFBcGlcSWJrclxPcmRlclxWMeICHUFwaVxJYmtyXE9yZGVyXFYxXEdQQk1ldGFkYXRh6gIUQXBpOjpJYmtyOjpPcmRlcjo6VjFiBnByb3RvMw", [file_buf_validate_validate]);
 
 /**
- * PlaceOrderRequest contains parameters for placing an order.
+ * PlaceOrderRequest contains parameters for placing an order. The prices an order needs
+ * depend on its type, see OrderType; the rules below refer to OrderType by number.
  *
  * @generated from message api.ibkr.order.v1.PlaceOrderRequest
  */
@@ -25,9 +26,28 @@ export type PlaceOrderRequest = Message<"api.ibkr.order.v1.PlaceOrderRequest"> &
   accountId: string;
 
   /**
-   * @generated from field: string symbol = 2;
-   */
-  symbol: string;
+   * @generated from oneof api.ibkr.order.v1.PlaceOrderRequest.instrument
+   */
+  instrument: {
+    /**
+     * Symbol of the instrument: upper case letters and digits, in parts joined by a single
+     * ".", "-" or space, e.g. "AAPL", "BRK.B", "RDS-A" or "7203". The listing fields below
+     * pick among the listings found for it.
+     *
+     * @generated from field: string symbol = 2;
+     */
+    value: string;
+    case: "symbol";
+  } | {
+    /**
+     * Contract ID of the instrument. The contract is used as is, without a search, and
+     * the listing fields below are ignored.
+     *
+     * @generated from field: int64 conid = 13;
+     */
+    value: bigint;
+    case: "conid";
+  } | { case: undefined; value?: undefined };
 
   /**
    * @generated from field: api.ibkr.order.v1.OrderSide side = 3;
@@ -58,6 +78,57 @@ export type PlaceOrderRequest = Message<"api.ibkr.order.v1.PlaceOrderRequest"> &
    * @generated from field: api.ibkr.order.v1.TimeInForce time_in_force = 8;
    */
   timeInForce: TimeInForce;
+
+  /**
+   * Listing exchange used to pick among listings of the symbol, e.g. "NASDAQ".
+   *
+   * @generated from field: optional string exchange = 9;
+   */
+  exchange?: string;
+
+  /**
+   * Trading currency used to pick among listings of the symbol, e.g. "USD".
+   * USD listings are preferred when omitted.
+   *
+   * @generated from field: optional string currency = 10;
+   */
+  currency?: string;
+
+  /**
+   * Security type of the instrument. Defaults to "STK".
+   *
+   * @generated from field: optional string sec_type = 11;
+   */
+  secType?: string;
+
+  /**
+   * Expiration month (YYYYMM) or date (YYYYMMDD) of a futures contract.
+   * The front month is used when omitted.
+   *
+   * @generated from field: optional string expiry = 12;
+   */
+  expiry?: string;
+
+  /**
+   * Distance of the stop price of a trailing order from the market, in trailing_type units.
+   *
+   * @generated from field: optional double trailing_amount = 14;
+   */
+  trailingAmount?: number;
+
+  /**
+   * Unit of trailing_amount.
+   *
+   * @generated from field: api.ibkr.order.v1.TrailingType trailing_type = 15;
+   */
+  trailingType: TrailingType;
+
+  /**
+   * Offset of a relative order from the best bid of buys, or the best ask of sells.
+   *
+   * @generated from field: optional double offset = 16;
+   */
+  offset?: number;
 };
 
 /**
@@ -87,6 +158,13 @@ export type PlaceOrderResponse = Message<"api.ibkr.order.v1.PlaceOrderResponse">
    * @generated from field: string message = 3;
    */
   message: string;
+
+  /**
+   * Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderConfirmation confirmation = 4;
+   */
+  confirmation?: OrderConfirmation;
 };
 
 /**
@@ -97,7 +175,203 @@ export const PlaceOrderResponseSchema: GenMessage<PlaceOrderResponse> = /*@__PUR
   messageDesc(file_api_ibkr_order_v1_order, 1);
 
 /**
- * ModifyOrderRequest contains parameters for modifying an order.
+ * OrderConfirmation describes warnings the gateway requires the caller to confirm
+ * before it submits an order.
+ *
+ * @generated from message api.ibkr.order.v1.OrderConfirmation
+ */
+export type OrderConfirmation = Message<"api.ibkr.order.v1.OrderConfirmation"> & {
+  /**
+   * Identifier to pass to ConfirmOrder.
+   *
+   * @generated from field: string reply_id = 1;
+   */
+  replyId: string;
+
+  /**
+   * @generated from field: repeated string messages = 2;
+   */
+  messages: string[];
+
+  /**
+   * @generated from field: repeated string message_ids = 3;
+   */
+  messageIds: string[];
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.OrderConfirmation.
+ * Use `create(OrderConfirmationSchema)` to create a new message.
+ */
+export const OrderConfirmationSchema: GenMessage<OrderConfirmation> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 2);
+
+/**
+ * PlaceBracketOrderRequest contains parameters for placing a bracket order. The take
+ * profit and stop loss orders close the position the entry order opens: they are only
+ * submitted once the entry order fills, and once either of them fills the other is
+ * canceled.
+ *
+ * @generated from message api.ibkr.order.v1.PlaceBracketOrderRequest
+ */
+export type PlaceBracketOrderRequest = Message<"api.ibkr.order.v1.PlaceBracketOrderRequest"> & {
+  /**
+   * Entry order. The take profit and stop loss orders are for the same account, instrument
+   * and quantity, on the opposite side.
+   *
+   * @generated from field: api.ibkr.order.v1.PlaceOrderRequest entry = 1;
+   */
+  entry?: PlaceOrderRequest;
+
+  /**
+   * Limit price of the take profit order.
+   *
+   * @generated from field: double take_profit_price = 2;
+   */
+  takeProfitPrice: number;
+
+  /**
+   * Stop price of the stop loss order.
+   *
+   * @generated from field: double stop_loss_price = 3;
+   */
+  stopLossPrice: number;
+
+  /**
+   * Time in force of the take profit and stop loss orders. Defaults to the time in force
+   * of the entry order.
+   *
+   * @generated from field: api.ibkr.order.v1.TimeInForce exit_time_in_force = 4;
+   */
+  exitTimeInForce: TimeInForce;
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.PlaceBracketOrderRequest.
+ * Use `create(PlaceBracketOrderRequestSchema)` to create a new message.
+ */
+export const PlaceBracketOrderRequestSchema: GenMessage<PlaceBracketOrderRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 3);
+
+/**
+ * PlaceBracketOrderResponse contains the result of placing a bracket order.
+ *
+ * @generated from message api.ibkr.order.v1.PlaceBracketOrderResponse
+ */
+export type PlaceBracketOrderResponse = Message<"api.ibkr.order.v1.PlaceBracketOrderResponse"> & {
+  /**
+   * IDs of the entry, take profit and stop loss orders, in that order. Empty when the
+   * status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: repeated string order_ids = 1;
+   */
+  orderIds: string[];
+
+  /**
+   * Status of the entry order.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderStatus status = 2;
+   */
+  status: OrderStatus;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderConfirmation confirmation = 4;
+   */
+  confirmation?: OrderConfirmation;
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.PlaceBracketOrderResponse.
+ * Use `create(PlaceBracketOrderResponseSchema)` to create a new message.
+ */
+export const PlaceBracketOrderResponseSchema: GenMessage<PlaceBracketOrderResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 4);
+
+/**
+ * PlaceOrderGroupRequest contains parameters for placing a one-cancels-all group of
+ * orders: once an order of the group fills, the others are canceled.
+ *
+ * @generated from message api.ibkr.order.v1.PlaceOrderGroupRequest
+ */
+export type PlaceOrderGroupRequest = Message<"api.ibkr.order.v1.PlaceOrderGroupRequest"> & {
+  /**
+   * @generated from field: repeated api.ibkr.order.v1.PlaceOrderRequest orders = 1;
+   */
+  orders: PlaceOrderRequest[];
+
+  /**
+   * Name of the group, unique among the groups of the account. Generated when omitted.
+   *
+   * @generated from field: optional string oca_group = 2;
+   */
+  ocaGroup?: string;
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.PlaceOrderGroupRequest.
+ * Use `create(PlaceOrderGroupRequestSchema)` to create a new message.
+ */
+export const PlaceOrderGroupRequestSchema: GenMessage<PlaceOrderGroupRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 5);
+
+/**
+ * PlaceOrderGroupResponse contains the result of placing a group of orders.
+ *
+ * @generated from message api.ibkr.order.v1.PlaceOrderGroupResponse
+ */
+export type PlaceOrderGroupResponse = Message<"api.ibkr.order.v1.PlaceOrderGroupResponse"> & {
+  /**
+   * IDs of the orders, in the order of the request. Empty when the status is
+   * ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: repeated string order_ids = 1;
+   */
+  orderIds: string[];
+
+  /**
+   * Status of the first order.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderStatus status = 2;
+   */
+  status: OrderStatus;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderConfirmation confirmation = 4;
+   */
+  confirmation?: OrderConfirmation;
+
+  /**
+   * Name of the group.
+   *
+   * @generated from field: string oca_group = 5;
+   */
+  ocaGroup: string;
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.PlaceOrderGroupResponse.
+ * Use `create(PlaceOrderGroupResponseSchema)` to create a new message.
+ */
+export const PlaceOrderGroupResponseSchema: GenMessage<PlaceOrderGroupResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 6);
+
+/**
+ * ModifyOrderRequest contains parameters for modifying an order. The prices must be ones
+ * the type of the order takes, see OrderType.
  *
  * @generated from message api.ibkr.order.v1.ModifyOrderRequest
  */
@@ -126,6 +400,28 @@ export type ModifyOrderRequest = Message<"api.ibkr.order.v1.ModifyOrderRequest">
    * @generated from field: optional double stop_price = 5;
    */
   stopPrice?: number;
+
+  /**
+   * New distance of the stop price of a TRAIL or TRAIL_LIMIT order from the market, in
+   * trailing_type units.
+   *
+   * @generated from field: optional double trailing_amount = 6;
+   */
+  trailingAmount?: number;
+
+  /**
+   * Unit of trailing_amount.
+   *
+   * @generated from field: api.ibkr.order.v1.TrailingType trailing_type = 7;
+   */
+  trailingType: TrailingType;
+
+  /**
+   * New offset of a REL order from the best bid of buys, or the best ask of sells.
+   *
+   * @generated from field: optional double offset = 8;
+   */
+  offset?: number;
 };
 
 /**
@@ -133,7 +429,7 @@ export type ModifyOrderRequest = Message<"api.ibkr.order.v1.ModifyOrderRequest">
  * Use `create(ModifyOrderRequestSchema)` to create a new message.
  */
 export const ModifyOrderRequestSchema: GenMessage<ModifyOrderRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 2);
+  messageDesc(file_api_ibkr_order_v1_order, 7);
 
 /**
  * ModifyOrderResponse contains the result of modifying an order.
@@ -155,6 +451,13 @@ export type ModifyOrderResponse = Message<"api.ibkr.order.v1.ModifyOrderResponse
    * @generated from field: string message = 3;
    */
   message: string;
+
+  /**
+   * Set when the status is ORDER_STATUS_CONFIRMATION_REQUIRED.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderConfirmation confirmation = 4;
+   */
+  confirmation?: OrderConfirmation;
 };
 
 /**
@@ -162,7 +465,7 @@ export type ModifyOrderResponse = Message<"api.ibkr.order.v1.ModifyOrderResponse
  * Use `create(ModifyOrderResponseSchema)` to create a new message.
  */
 export const ModifyOrderResponseSchema: GenMessage<ModifyOrderResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 3);
+  messageDesc(file_api_ibkr_order_v1_order, 8);
 
 /**
  * CancelOrderRequest contains parameters for canceling an order.
@@ -186,7 +489,7 @@ export type CancelOrderRequest = Message<"api.ibkr.order.v1.CancelOrderRequest">
  * Use `create(CancelOrderRequestSchema)` to create a new message.
  */
 export const CancelOrderRequestSchema: GenMessage<CancelOrderRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 4);
+  messageDesc(file_api_ibkr_order_v1_order, 9);
 
 /**
  * CancelOrderResponse contains the result of canceling an order.
@@ -215,7 +518,7 @@ export type CancelOrderResponse = Message<"api.ibkr.order.v1.CancelOrderResponse
  * Use `create(CancelOrderResponseSchema)` to create a new message.
  */
 export const CancelOrderResponseSchema: GenMessage<CancelOrderResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 5);
+  messageDesc(file_api_ibkr_order_v1_order, 10);
 
 /**
  * GetOrderRequest contains parameters for retrieving an order.
@@ -239,7 +542,7 @@ export type GetOrderRequest = Message<"api.ibkr.order.v1.GetOrderRequest"> & {
  * Use `create(GetOrderRequestSchema)` to create a new message.
  */
 export const GetOrderRequestSchema: GenMessage<GetOrderRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 6);
+  messageDesc(file_api_ibkr_order_v1_order, 11);
 
 /**
  * GetOrderResponse contains order details.
@@ -258,7 +561,7 @@ export type GetOrderResponse = Message<"api.ibkr.order.v1.GetOrderResponse"> & {
  * Use `create(GetOrderResponseSchema)` to create a new message.
  */
 export const GetOrderResponseSchema: GenMessage<GetOrderResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 7);
+  messageDesc(file_api_ibkr_order_v1_order, 12);
 
 /**
  * ListOrdersRequest contains parameters for listing orders.
@@ -287,7 +590,7 @@ export type ListOrdersRequest = Message<"api.ibkr.order.v1.ListOrdersRequest"> &
  * Use `create(ListOrdersRequestSchema)` to create a new message.
  */
 export const ListOrdersRequestSchema: GenMessage<ListOrdersRequest> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 8);
+  messageDesc(file_api_ibkr_order_v1_order, 13);
 
 /**
  * ListOrdersResponse contains a list of orders.
@@ -306,7 +609,82 @@ export type ListOrdersResponse = Message<"api.ibkr.order.v1.ListOrdersResponse">
  * Use `create(ListOrdersResponseSchema)` to create a new message.
  */
 export const ListOrdersResponseSchema: GenMessage<ListOrdersResponse> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 9);
+  messageDesc(file_api_ibkr_order_v1_order, 14);
+
+/**
+ * ConfirmOrderRequest contains the answer to an order confirmation question.
+ *
+ * @generated from message api.ibkr.order.v1.ConfirmOrderRequest
+ */
+export type ConfirmOrderRequest = Message<"api.ibkr.order.v1.ConfirmOrderRequest"> & {
+  /**
+   * @generated from field: string account_id = 1;
+   */
+  accountId: string;
+
+  /**
+   * @generated from field: string reply_id = 2;
+   */
+  replyId: string;
+
+  /**
+   * Whether to confirm the warnings and submit the order.
+   *
+   * @generated from field: bool confirmed = 3;
+   */
+  confirmed: boolean;
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.ConfirmOrderRequest.
+ * Use `create(ConfirmOrderRequestSchema)` to create a new message.
+ */
+export const ConfirmOrderRequestSchema: GenMessage<ConfirmOrderRequest> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 15);
+
+/**
+ * ConfirmOrderResponse contains the result of answering a confirmation question.
+ *
+ * @generated from message api.ibkr.order.v1.ConfirmOrderResponse
+ */
+export type ConfirmOrderResponse = Message<"api.ibkr.order.v1.ConfirmOrderResponse"> & {
+  /**
+   * @generated from field: string order_id = 1;
+   */
+  orderId: string;
+
+  /**
+   * @generated from field: api.ibkr.order.v1.OrderStatus status = 2;
+   */
+  status: OrderStatus;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * Set when the gateway asks a further question.
+   *
+   * @generated from field: api.ibkr.order.v1.OrderConfirmation confirmation = 4;
+   */
+  confirmation?: OrderConfirmation;
+
+  /**
+   * IDs of every order submitted, in the order of the request, when the question was
+   * asked for a bracket or a group of orders. order_id is the first of them.
+   *
+   * @generated from field: repeated string order_ids = 5;
+   */
+  orderIds: string[];
+};
+
+/**
+ * Describes the message api.ibkr.order.v1.ConfirmOrderResponse.
+ * Use `create(ConfirmOrderResponseSchema)` to create a new message.
+ */
+export const ConfirmOrderResponseSchema: GenMessage<ConfirmOrderResponse> = /*@__PURE__*/
+  messageDesc(file_api_ibkr_order_v1_order, 16);
 
 /**
  * Order represents an order.
@@ -385,7 +763,7 @@ export type Order = Message<"api.ibkr.order.v1.Order"> & {
  * Use `create(OrderSchema)` to create a new message.
  */
 export const OrderSchema: GenMessage<Order> = /*@__PURE__*/
-  messageDesc(file_api_ibkr_order_v1_order, 10);
+  messageDesc(file_api_ibkr_order_v1_order, 17);
 
 /**
  * OrderSide represents the side of an order.
@@ -432,19 +810,83 @@ export enum OrderType {
   MARKET = 1,
 
   /**
+   * Limit order at limit_price.
+   *
    * @generated from enum value: ORDER_TYPE_LIMIT = 2;
    */
   LIMIT = 2,
 
   /**
+   * Stop order triggered at stop_price.
+   *
    * @generated from enum value: ORDER_TYPE_STOP = 3;
    */
   STOP = 3,
 
   /**
+   * Stop limit order at limit_price, triggered at stop_price.
+   *
    * @generated from enum value: ORDER_TYPE_STOP_LIMIT = 4;
    */
   STOP_LIMIT = 4,
+
+  /**
+   * Trailing stop order, its stop price trailing_amount away from the market. stop_price
+   * optionally sets the initial stop price.
+   *
+   * @generated from enum value: ORDER_TYPE_TRAIL = 5;
+   */
+  TRAIL = 5,
+
+  /**
+   * Trailing stop limit order at limit_price, its stop price trailing_amount away from
+   * the market. stop_price optionally sets the initial stop price.
+   *
+   * @generated from enum value: ORDER_TYPE_TRAIL_LIMIT = 6;
+   */
+  TRAIL_LIMIT = 6,
+
+  /**
+   * Market on close order.
+   *
+   * @generated from enum value: ORDER_TYPE_MOC = 7;
+   */
+  MOC = 7,
+
+  /**
+   * Limit on close order at limit_price.
+   *
+   * @generated from enum value: ORDER_TYPE_LOC = 8;
+   */
+  LOC = 8,
+
+  /**
+   * Market if touched order, triggered at stop_price.
+   *
+   * @generated from enum value: ORDER_TYPE_MIT = 9;
+   */
+  MIT = 9,
+
+  /**
+   * Limit if touched order at limit_price, triggered at stop_price.
+   *
+   * @generated from enum value: ORDER_TYPE_LIT = 10;
+   */
+  LIT = 10,
+
+  /**
+   * Relative order pegged offset away from the best bid or ask, capped at limit_price if set.
+   *
+   * @generated from enum value: ORDER_TYPE_REL = 11;
+   */
+  REL = 11,
+
+  /**
+   * Midprice order pegged to the midpoint of the best bid and ask, capped at limit_price if set.
+   *
+   * @generated from enum value: ORDER_TYPE_MIDPRICE = 12;
+   */
+  MIDPRICE = 12,
 }
 
 /**
@@ -453,6 +895,38 @@ export enum OrderType {
 export const OrderTypeSchema: GenEnum<OrderType> = /*@__PURE__*/
   enumDesc(file_api_ibkr_order_v1_order, 1);
 
+/**
+ * TrailingType represents the unit of the trailing amount of a trailing order.
+ *
+ * @generated from enum api.ibkr.order.v1.TrailingType
+ */
+export enum TrailingType {
+  /**
+   * @generated from enum value: TRAILING_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Trailing amount in price units.
+   *
+   * @generated from enum value: TRAILING_TYPE_AMOUNT = 1;
+   */
+  AMOUNT = 1,
+
+  /**
+   * Trailing amount in percent of the price.
+   *
+   * @generated from enum value: TRAILING_TYPE_PERCENT = 2;
+   */
+  PERCENT = 2,
+}
+
+/**
+ * Describes the enum api.ibkr.order.v1.TrailingType.
+ */
+export const TrailingTypeSchema: GenEnum<TrailingType> = /*@__PURE__*/
+  enumDesc(file_api_ibkr_order_v1_order, 2);
+
 /**
  * OrderStatus represents the status of an order.
  *
@@ -493,13 +967,18 @@ export enum OrderStatus {
    * @generated from enum value: ORDER_STATUS_REJECTED = 6;
    */
   REJECTED = 6,
+
+  /**
+   * @generated from enum value: ORDER_STATUS_CONFIRMATION_REQUIRED = 7;
+   */
+  CONFIRMATION_REQUIRED = 7,
 }
 
 /**
  * Describes the enum api.ibkr.order.v1.OrderStatus.
  */
 export const OrderStatusSchema: GenEnum<OrderStatus> = /*@__PURE__*/
-  enumDesc(file_api_ibkr_order_v1_order, 2);
+  enumDesc(file_api_ibkr_order_v1_order, 3);
 
 /**
  * TimeInForce represents how long an order remains active.
@@ -537,7 +1016,7 @@ export enum TimeInForce {
  * Describes the enum api.ibkr.order.v1.TimeInForce.
  */
 export const TimeInForceSchema: GenEnum<TimeInForce> = /*@__PURE__*/
-  enumDesc(file_api_ibkr_order_v1_order, 3);
+  enumDesc(file_api_ibkr_order_v1_order, 4);
 
 /**
  * OrderService handles order management operations.
@@ -595,6 +1074,38 @@ export const OrderService: GenService<{
     input: typeof ListOrdersRequestSchema;
     output: typeof ListOrdersResponseSchema;
   },
+  /**
+   * ConfirmOrder answers a confirmation question returned by PlaceOrder, ModifyOrder,
+   * PlaceBracketOrder or PlaceOrderGroup.
+   *
+   * @generated from rpc api.ibkr.order.v1.OrderService.ConfirmOrder
+   */
+  confirmOrder: {
+    methodKind: "unary";
+    input: typeof ConfirmOrderRequestSchema;
+    output: typeof ConfirmOrderResponseSchema;
+  },
+  /**
+   * PlaceBracketOrder places an entry order with a take profit and a stop loss order
+   * attached, in one request.
+   *
+   * @generated from rpc api.ibkr.order.v1.OrderService.PlaceBracketOrder
+   */
+  placeBracketOrder: {
+    methodKind: "unary";
+    input: typeof PlaceBracketOrderRequestSchema;
+    output: typeof PlaceBracketOrderResponseSchema;
+  },
+  /**
+   * PlaceOrderGroup places a one-cancels-all group of orders, in one request.
+   *
+   * @generated from rpc api.ibkr.order.v1.OrderService.PlaceOrderGroup
+   */
+  placeOrderGroup: {
+    methodKind: "unary";
+    input: typeof PlaceOrderGroupRequestSchema;
+    output: typeof PlaceOrderGroupResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_ibkr_order_v1_order, 0);
 
@@ -6,6 +6,7 @@ Provides mock responses for IBKR API endpoints
 
 from flask import Flask, jsonify, request
 import time
+from datetime import datetime, timezone
 
 app = Flask(__name__)
 
@@ -43,6 +44,18 @@ def get_accounts():
     """Get accounts"""
     return jsonify([MOCK_ACCOUNT_ID])
 
+@app.route('/v1/api/portfolio/accounts', methods=['GET'])
+def get_portfolio_accounts():
+    """Get portfolio accounts"""
+    return jsonify([{
+        "id": MOCK_ACCOUNT_ID,
+        "accountId": MOCK_ACCOUNT_ID,
+        "accountTitle": "Mock Account",
+        "currency": "USD",
+        "type": "INDIVIDUAL",
+        "parent": {"mmc": [], "accountId": "", "isMParent": False, "isMChild": False, "isMultiplex": False}
+    }])
+
 @app.route('/v1/api/iserver/account/<account_id>/orders', methods=['GET'])
 def get_orders(account_id):
     """Get orders"""
@@ -57,23 +70,39 @@ def get_orders(account_id):
 
 @app.route('/v1/api/iserver/account/<account_id>/orders', methods=['POST'])
 def place_order(account_id):
-    """Place order"""
-    order_data = request.json
-    order_id = f"ORDER{len(MOCK_ORDERS) + 1}"
+    """Place orders, e.g. the orders of a bracket, with one response per order"""
+    order_data = request.json or {}
+    responses = []
 
-    order = {
-        "order_id": order_id,
-        "order_status": "Submitted",
-        "encrypt_message": "1"
-    }
-    MOCK_ORDERS.append(order)
+    for _ in order_data.get("orders") or [{}]:
+        order_id = f"ORDER{len(MOCK_ORDERS) + 1}"
 
-    return jsonify({
-        "id": order_id,
-        "message": ["Order placed successfully"],
+        order = {
+            "order_id": order_id,
+            "order_status": "Submitted",
+            "encrypt_message": "1"
+        }
+        MOCK_ORDERS.append(order)
+
+        responses.append({
+            "id": order_id,
+            "message": ["Order placed successfully"],
+            "order_id": order_id,
+            "order_status": "Submitted"
+        })
+
+    return jsonify(responses)
+
+@app.route('/v1/api/iserver/reply/<reply_id>', methods=['POST'])
+def reply_order(reply_id):
+    """Answer an order confirmation question"""
+    order_id = f"ORDER{len(MOCK_ORDERS) + 1}"
+    MOCK_ORDERS.append({"order_id": order_id, "order_status": "Submitted"})
+
+    return jsonify([{
         "order_id": order_id,
         "order_status": "Submitted"
-    })
+    }])
 
 @app.route('/v1/api/iserver/account/<account_id>/order/<order_id>', methods=['POST'])
 def modify_order(account_id, order_id):
@@ -194,7 +223,9 @@ def get_market_data():
                 "87": 1000000,  # Volume
                 "70": 151.00,  # High
                 "71": 149.00,  # Low
-                "82": 150.00,  # Close
+                "82": 0.50,  # Change
+                "7296": 150.00,  # Close
+                "7762": 1000000,  # Volume (unformatted)
                 "7295": 150.00, # Open
                 "_updated": str(int(time.time() * 1000))
             })
@@ -203,7 +234,12 @@ def get_market_data():
 
 @app.route('/v1/api/iserver/marketdata/history', methods=['GET'])
 def get_historical_data():
-    """Get historical market data"""
+    """Get historical market data, ending at startTime when given"""
+    end = time.time()
+    start_time = request.args.get('startTime')
+    if start_time:
+        end = datetime.strptime(start_time, '%Y%m%d-%H:%M:%S').replace(tzinfo=timezone.utc).timestamp()
+
     return jsonify({
         "serverId": "1",
         "symbol": "AAPL",
@@ -224,8 +260,8 @@ def get_historical_data():
         "negativeCapable": False,
         "messageVersion": 2,
         "data": [
-            {"t": int(time.time() - 86400) * 1000, "o": 148.0, "c": 150.0, "h": 152.0, "l": 148.0, "v": 1000000},
-            {"t": int(time.time()) * 1000, "o": 150.0, "c": 150.5, "h": 151.0, "l": 149.5, "v": 800000}
+            {"t": int(end - 86400) * 1000, "o": 148.0, "c": 150.0, "h": 152.0, "l": 148.0, "v": 1000000},
+            {"t": int(end - 300) * 1000, "o": 150.0, "c": 150.5, "h": 151.0, "l": 149.5, "v": 800000}
         ],
         "points": 2,
         "travelTime": 10
@@ -258,6 +294,99 @@ def search_contracts():
         }
     ])
 
+@app.route('/v1/api/iserver/secdef/info', methods=['GET'])
+def contract_info():
+    """Get contract details"""
+    conid = int(request.args.get('conid', '0'))
+
+    return jsonify([
+        {
+            "conid": conid,
+            "symbol": "AAPL",
+            "secType": request.args.get('sectype', 'STK'),
+            "exchange": "SMART",
+            "listingExchange": "NASDAQ",
+            "currency": "USD",
+            "companyName": "APPLE INC",
+            "validExchanges": "SMART,NASDAQ,NYSE"
+        }
+    ])
+
+def contract_details(conid):
+    return {
+        "con_id": conid,
+        "symbol": "AAPL",
+        "local_symbol": "AAPL",
+        "company_name": "APPLE INC",
+        "instrument_type": "STK",
+        "exchange": "NASDAQ",
+        "valid_exchanges": "SMART,NASDAQ,NYSE",
+        "currency": "USD",
+        "trading_class": "NMS",
+        "multiplier": None,
+        "industry": "Technology",
+        "category": "Computers",
+        "cusip": None,
+        "maturity_date": None,
+        "contract_month": None,
+        "underlying_con_id": 0
+    }
+
+@app.route('/v1/api/iserver/contract/<int:conid>/info', methods=['GET'])
+def contract_details_info(conid):
+    """Get contract details by conid"""
+    return jsonify(contract_details(conid))
+
+@app.route('/v1/api/iserver/contract/<int:conid>/info-and-rules', methods=['GET'])
+def contract_rules(conid):
+    """Get contract details and trading rules by conid"""
+    details = contract_details(conid)
+    details["rules"] = {
+        "orderTypes": ["limit", "market", "stop", "stop_limit", "trailing_stop"],
+        "orderTypesOutside": ["limit", "stop_limit"],
+        "defaultSize": 100,
+        "sizeIncrement": 1,
+        "tifTypes": ["DAY/o,a", "GTC/o,a", "IOC/LMT,MKT", "OPG/LMT,MKT"],
+        "increment": 0.01,
+        "incrementDigits": 2,
+        "incrementRules": [{"lowerEdge": 0.0, "increment": 0.01}],
+        "negativeCapable": False,
+        "error": None
+    }
+
+    return jsonify(details)
+
+@app.route('/v1/api/iserver/secdef/strikes', methods=['GET'])
+def contract_strikes():
+    """Get option strikes for an expiration month"""
+    strikes = [145.0, 150.0, 155.0]
+
+    return jsonify({
+        "call": strikes,
+        "put": strikes
+    })
+
+@app.route('/v1/api/trsrv/futures', methods=['GET'])
+def get_futures():
+    """Get futures contracts by root symbol"""
+    symbols = [s for s in request.args.get('symbols', '').split(',') if s]
+    year = time.gmtime().tm_year + 1
+
+    futures = {}
+    for symbol in symbols:
+        futures[symbol] = [
+            {
+                "symbol": symbol,
+                "conid": 495512557 + i,
+                "underlyingConid": 11004968,
+                "expirationDate": int(f"{year}{month:02d}20"),
+                "ltd": int(f"{year}{month:02d}19")
+            }
+            for i, month in enumerate([3, 6, 9, 12])
+        ]
+
+    return jsonify(futures)
+
 if __name__ == '__main__':
     print("Starting Mock IBKR Gateway on port 5555...")
     app.run(host='0.0.0.0', port=5555, debug=False)